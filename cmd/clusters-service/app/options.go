@@ -62,6 +62,9 @@ type Options struct {
 	MonitoringOptions         monitoring.Options
 	ExplorerCleanerDisabled   bool
 	ExplorerEnabledFor        []string
+	ExplorerStoreBackend      string
+	ExplorerStoreURI          string
+	ExplorerAlertingConfig    string
 }
 
 type Option func(*Options)
@@ -300,6 +303,21 @@ func WithExplorerEnabledFor(enabledFor []string) Option {
 	}
 }
 
+// WithExplorerStore configures the storage backend for the explorer
+func WithExplorerStore(backend, uri string) Option {
+	return func(o *Options) {
+		o.ExplorerStoreBackend = backend
+		o.ExplorerStoreURI = uri
+	}
+}
+
+// WithExplorerAlertingConfig configures the file the explorer alerting rules are read from
+func WithExplorerAlertingConfig(path string) Option {
+	return func(o *Options) {
+		o.ExplorerAlertingConfig = path
+	}
+}
+
 func WithRoutePrefix(routePrefix string) Option {
 	return func(o *Options) {
 		o.RoutePrefix = routePrefix
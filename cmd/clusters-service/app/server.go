@@ -64,6 +64,7 @@ import (
 	"github.com/weaveworks/weave-gitops-enterprise/pkg/monitoring/metrics"
 	pipelines "github.com/weaveworks/weave-gitops-enterprise/pkg/pipelines/server"
 	"github.com/weaveworks/weave-gitops-enterprise/pkg/preview"
+	"github.com/weaveworks/weave-gitops-enterprise/pkg/query/alerting"
 	"github.com/weaveworks/weave-gitops-enterprise/pkg/query/configuration"
 	queryserver "github.com/weaveworks/weave-gitops-enterprise/pkg/query/server"
 	tfserver "github.com/weaveworks/weave-gitops-enterprise/pkg/terraform"
@@ -131,6 +132,8 @@ type Params struct {
 	OIDC                              OIDCAuthenticationOptions `mapstructure:",squash"`
 	GitProviderType                   string                    `mapstructure:"git-provider-type"`
 	GitProviderHostname               string                    `mapstructure:"git-provider-hostname"`
+	GitProviderPullRequestAPI         string                    `mapstructure:"git-provider-pull-request-api"`
+	GitProviderPrivateKeyFile         string                    `mapstructure:"git-provider-private-key-file"`
 	CAPIClustersNamespace             string                    `mapstructure:"capi-clusters-namespace"`
 	CAPITemplatesNamespace            string                    `mapstructure:"capi-templates-namespace"`
 	InjectPruneAnnotation             string                    `mapstructure:"inject-prune-annotation"`
@@ -166,6 +169,9 @@ type Params struct {
 	ExplorerCleanerDisabled           bool                      `mapstructure:"explorer-cleaner-disabled"`
 	NoAuthUser                        string                    `mapstructure:"insecure-no-authentication-user"`
 	ExplorerEnabledFor                []string                  `mapstructure:"explorer-enabled-for"`
+	ExplorerStoreBackend              string                    `mapstructure:"explorer-store-backend"`
+	ExplorerStoreURI                  string                    `mapstructure:"explorer-store-uri"`
+	ExplorerAlertingConfig            string                    `mapstructure:"explorer-alerting-config"`
 }
 
 type OIDCAuthenticationOptions struct {
@@ -223,6 +229,8 @@ func NewAPIServerCommand() *cobra.Command {
 	cmdFlags.String("html-root-path", "/html", "Where to serve static assets from")
 	cmdFlags.String("git-provider-type", "", "")
 	cmdFlags.String("git-provider-hostname", "", "")
+	cmdFlags.String("git-provider-pull-request-api", "", "the API the generic git provider opens pull requests with, none or gitea")
+	cmdFlags.String("git-provider-private-key-file", "", "the SSH private key the generic git provider pushes to repositories with")
 	cmdFlags.Bool("capi-enabled", true, "")
 	cmdFlags.String("capi-clusters-namespace", corev1.NamespaceAll, "where to look for GitOps cluster resources, defaults to looking in all namespaces")
 	cmdFlags.String("capi-templates-namespace", "", "where to look for CAPI template resources, required")
@@ -270,6 +278,9 @@ func NewAPIServerCommand() *cobra.Command {
 	cmdFlags.String("collector-serviceaccount-namespace", "", "namespace of the serviceaccount that collector impersonates to watch leaf clusters.")
 	cmdFlags.Bool("explorer-cleaner-disabled", false, "Enables the Explorer object cleaner that manages retaining objects")
 	cmdFlags.StringSlice("explorer-enabled-for", []string{}, "List of components that the Explorer is enabled for")
+	cmdFlags.String("explorer-store-backend", "sqlite", "Storage backend for the Explorer data, one of: sqlite, postgres")
+	cmdFlags.String("explorer-store-uri", "", "Connection string of the Explorer storage backend. Required for postgres.")
+	cmdFlags.String("explorer-alerting-config", "", "Path of the file declaring the Explorer alerting rules and sinks. Alerting is disabled when empty.")
 
 	// Monitoring
 	cmdFlags.Bool("monitoring-enabled", false, "creates monitoring server")
@@ -590,6 +601,8 @@ func StartServer(ctx context.Context, p Params, logOptions flux_logger.Options)
 		WithMonitoring(p.MonitoringEnabled, p.MonitoringBindAddress, p.MetricsEnabled, p.ProfilingEnabled, log),
 		WithExplorerCleanerDisabled(p.ExplorerCleanerDisabled),
 		WithExplorerEnabledFor(p.ExplorerEnabledFor),
+		WithExplorerStore(p.ExplorerStoreBackend, p.ExplorerStoreURI),
+		WithExplorerAlertingConfig(p.ExplorerAlertingConfig),
 		WithRoutePrefix(p.RoutePrefix),
 	)
 }
@@ -703,6 +716,15 @@ func RunInProcessGateway(ctx context.Context, addr string, setters ...Option) er
 	}
 
 	if featureflags.Get("WEAVE_GITOPS_FEATURE_EXPLORER") != "" {
+		var alertingConfig *alerting.Config
+		if args.ExplorerAlertingConfig != "" {
+			config, err := alerting.LoadConfig(args.ExplorerAlertingConfig)
+			if err != nil {
+				return fmt.Errorf("loading explorer alerting config: %w", err)
+			}
+			alertingConfig = &config
+		}
+
 		_, err := queryserver.Hydrate(ctx, grpcMux, queryserver.ServerOpts{
 			Logger:              args.Log,
 			DiscoveryClient:     args.DiscoveryClient,
@@ -712,6 +734,11 @@ func RunInProcessGateway(ctx context.Context, addr string, setters ...Option) er
 			ServiceAccount:      args.CollectorServiceAccount,
 			EnableObjectCleaner: !args.ExplorerCleanerDisabled,
 			EnabledFor:          args.ExplorerEnabledFor,
+			StoreType:           args.ExplorerStoreBackend,
+			StoreURI:            args.ExplorerStoreURI,
+			ManagementConfig:    args.CoreServerConfig.RestCfg,
+			UserPrefixes:        userPrefixes,
+			Alerting:            alertingConfig,
 		})
 		if err != nil {
 			return fmt.Errorf("hydrating query server: %w", err)
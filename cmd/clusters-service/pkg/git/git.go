@@ -3,6 +3,7 @@ package git
 import (
 	"context"
 	"fmt"
+	"os"
 	"path"
 	"strings"
 	"time"
@@ -29,6 +30,7 @@ type Provider interface {
 	GetRepository(ctx context.Context, gp GitProvider, url string) (*git.Repository, error)
 	GetTreeList(ctx context.Context, gp GitProvider, repoUrl string, sha string, path string, recursive bool) ([]*git.TreeEntry, error)
 	ListPullRequests(ctx context.Context, gp GitProvider, url string) ([]*git.PullRequest, error)
+	GetFiles(ctx context.Context, gp GitProvider, repoURL, branch string, paths []string) ([]git.CommitFile, error)
 }
 
 type GitProviderService struct {
@@ -139,6 +141,17 @@ func (s *GitProviderService) ListPullRequests(ctx context.Context, gp GitProvide
 	return provider.ListPullRequests(ctx, repoURL)
 }
 
+// GetFiles retrieves the content of the files at the paths in the branch,
+// leaving out those that don't exist.
+func (s *GitProviderService) GetFiles(ctx context.Context, gp GitProvider, repoURL, branch string, paths []string) ([]git.CommitFile, error) {
+	provider, err := getGitProviderClient(s.log, gp)
+	if err != nil {
+		return nil, fmt.Errorf("unable to get a git provider client for %q: %w", gp.Type, err)
+	}
+
+	return provider.GetFiles(ctx, repoURL, branch, paths)
+}
+
 type Commit struct {
 	CommitMessage string
 	Files         []gitprovider.CommitFile
@@ -172,6 +185,23 @@ func getGitProviderClient(log logr.Logger, gpi GitProvider) (git.Provider, error
 		providerOpts = append(providerOpts, git.WithConditionalRequests())
 	case git.AzureDevOpsProviderName:
 		providerOpts = append(providerOpts, git.WithToken(gpi.TokenType, gpi.Token))
+	case git.GenericProviderName:
+		providerOpts = append(providerOpts, git.WithToken(gpi.TokenType, gpi.Token))
+		providerOpts = append(providerOpts, git.WithPullRequestAPI(viper.GetString("git-provider-pull-request-api")))
+
+		// The key is read for each provider, so that rotating the secret it is mounted from
+		// doesn't require a restart.
+		if keyFile := viper.GetString("git-provider-private-key-file"); keyFile != "" {
+			key, err := os.ReadFile(keyFile)
+			if err != nil {
+				return nil, fmt.Errorf("failed to read the private key of the Git provider: %w", err)
+			}
+			providerOpts = append(providerOpts, git.WithPrivateKey(key))
+		}
+
+		if gpi.Hostname != "" {
+			providerOpts = append(providerOpts, git.WithDomain(hostname))
+		}
 	default:
 		return nil, fmt.Errorf("the Git provider %q is not supported", gpi.Type)
 	}
@@ -26,7 +26,9 @@ type FakeGitProvider struct {
 	err            error
 	CommittedFiles []git.CommitFile
 	OriginalFiles  []string
-	pullRequests   []*git.PullRequest
+	// OriginalContents is the content of the files in the repository, by path.
+	OriginalContents map[string]string
+	pullRequests     []*git.PullRequest
 }
 
 func (p *FakeGitProvider) WriteFilesToBranchAndCreatePullRequest(ctx context.Context, req csgit.WriteFilesToBranchAndCreatePullRequestRequest) (*csgit.WriteFilesToBranchAndCreatePullRequestResponse, error) {
@@ -86,6 +88,23 @@ func (p *FakeGitProvider) ListPullRequests(ctx context.Context, gp csgit.GitProv
 	return p.pullRequests, nil
 }
 
+func (p *FakeGitProvider) GetFiles(ctx context.Context, gp csgit.GitProvider, repoURL, branch string, paths []string) ([]git.CommitFile, error) {
+	if p.err != nil {
+		return nil, p.err
+	}
+
+	var files []git.CommitFile
+	for _, path := range paths {
+		if content, ok := p.OriginalContents[path]; ok {
+			files = append(files, git.CommitFile{
+				Path:    path,
+				Content: &content,
+			})
+		}
+	}
+	return files, nil
+}
+
 func NewPullRequest(id int, title string, description string, url string, merged bool, sourceBranch string) *git.PullRequest {
 	return &git.PullRequest{
 		Title:       title,
@@ -38,6 +38,12 @@ type ClustersServiceClient interface {
 	// In the request include one of the resources originally generated by the template.
 	// Then a pull request will be created to delete all resources generated by the template.
 	CreateDeletionPullRequest(ctx context.Context, in *CreateDeletionPullRequestRequest, opts ...grpc.CallOption) (*CreateDeletionPullRequestResponse, error)
+	// Create pull requests to upgrade the resources created from a template
+	//
+	// The resources created from other versions of the template are found in the
+	// repository by their create-request annotation, and rendered again with the
+	// values they were created with against the current version of the template.
+	CreateUpgradePullRequest(ctx context.Context, in *CreateUpgradePullRequestRequest, opts ...grpc.CallOption) (*CreateUpgradePullRequestResponse, error)
 	// Preview adding automations to a cluster
 	//
 	// Add kustomizations, helm releases, external secrets, policy configs, and sops secrets
@@ -106,6 +112,17 @@ type ClustersServiceClient interface {
 	ListPolicyConfigs(ctx context.Context, in *ListPolicyConfigsRequest, opts ...grpc.CallOption) (*ListPolicyConfigsResponse, error)
 	// Get policy config details
 	GetPolicyConfig(ctx context.Context, in *GetPolicyConfigRequest, opts ...grpc.CallOption) (*GetPolicyConfigResponse, error)
+	// Creates a pull request to add tenants to clusters
+	//
+	// Render the resources of the tenants declared by a tenancy file, and write the
+	// resources of each tenant to a file of its own in the directory of each cluster
+	CreateTenantsPullRequest(ctx context.Context, in *CreateTenantsPullRequestRequest, opts ...grpc.CallOption) (*CreateTenantsPullRequestResponse, error)
+	// Check tenants for drift
+	//
+	// Compare the resources of the tenants declared by a tenancy file with the
+	// tenant resources in the management cluster, or in the clusters that the
+	// tenancy file selects
+	CheckTenants(ctx context.Context, in *CheckTenantsRequest, opts ...grpc.CallOption) (*CheckTenantsResponse, error)
 }
 
 type clustersServiceClient struct {
@@ -179,6 +196,15 @@ func (c *clustersServiceClient) CreateDeletionPullRequest(ctx context.Context, i
 	return out, nil
 }
 
+func (c *clustersServiceClient) CreateUpgradePullRequest(ctx context.Context, in *CreateUpgradePullRequestRequest, opts ...grpc.CallOption) (*CreateUpgradePullRequestResponse, error) {
+	out := new(CreateUpgradePullRequestResponse)
+	err := c.cc.Invoke(ctx, "/cluster_services.v1.ClustersService/CreateUpgradePullRequest", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *clustersServiceClient) RenderAutomation(ctx context.Context, in *RenderAutomationRequest, opts ...grpc.CallOption) (*RenderAutomationResponse, error) {
 	out := new(RenderAutomationResponse)
 	err := c.cc.Invoke(ctx, "/cluster_services.v1.ClustersService/RenderAutomation", in, out, opts...)
@@ -413,6 +439,24 @@ func (c *clustersServiceClient) GetPolicyConfig(ctx context.Context, in *GetPoli
 	return out, nil
 }
 
+func (c *clustersServiceClient) CreateTenantsPullRequest(ctx context.Context, in *CreateTenantsPullRequestRequest, opts ...grpc.CallOption) (*CreateTenantsPullRequestResponse, error) {
+	out := new(CreateTenantsPullRequestResponse)
+	err := c.cc.Invoke(ctx, "/cluster_services.v1.ClustersService/CreateTenantsPullRequest", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *clustersServiceClient) CheckTenants(ctx context.Context, in *CheckTenantsRequest, opts ...grpc.CallOption) (*CheckTenantsResponse, error) {
+	out := new(CheckTenantsResponse)
+	err := c.cc.Invoke(ctx, "/cluster_services.v1.ClustersService/CheckTenants", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // ClustersServiceServer is the server API for ClustersService service.
 // All implementations must embed UnimplementedClustersServiceServer
 // for forward compatibility
@@ -436,6 +480,12 @@ type ClustersServiceServer interface {
 	// In the request include one of the resources originally generated by the template.
 	// Then a pull request will be created to delete all resources generated by the template.
 	CreateDeletionPullRequest(context.Context, *CreateDeletionPullRequestRequest) (*CreateDeletionPullRequestResponse, error)
+	// Create pull requests to upgrade the resources created from a template
+	//
+	// The resources created from other versions of the template are found in the
+	// repository by their create-request annotation, and rendered again with the
+	// values they were created with against the current version of the template.
+	CreateUpgradePullRequest(context.Context, *CreateUpgradePullRequestRequest) (*CreateUpgradePullRequestResponse, error)
 	// Preview adding automations to a cluster
 	//
 	// Add kustomizations, helm releases, external secrets, policy configs, and sops secrets
@@ -504,6 +554,17 @@ type ClustersServiceServer interface {
 	ListPolicyConfigs(context.Context, *ListPolicyConfigsRequest) (*ListPolicyConfigsResponse, error)
 	// Get policy config details
 	GetPolicyConfig(context.Context, *GetPolicyConfigRequest) (*GetPolicyConfigResponse, error)
+	// Creates a pull request to add tenants to clusters
+	//
+	// Render the resources of the tenants declared by a tenancy file, and write the
+	// resources of each tenant to a file of its own in the directory of each cluster
+	CreateTenantsPullRequest(context.Context, *CreateTenantsPullRequestRequest) (*CreateTenantsPullRequestResponse, error)
+	// Check tenants for drift
+	//
+	// Compare the resources of the tenants declared by a tenancy file with the
+	// tenant resources in the management cluster, or in the clusters that the
+	// tenancy file selects
+	CheckTenants(context.Context, *CheckTenantsRequest) (*CheckTenantsResponse, error)
 	mustEmbedUnimplementedClustersServiceServer()
 }
 
@@ -532,6 +593,9 @@ func (UnimplementedClustersServiceServer) CreatePullRequest(context.Context, *Cr
 func (UnimplementedClustersServiceServer) CreateDeletionPullRequest(context.Context, *CreateDeletionPullRequestRequest) (*CreateDeletionPullRequestResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method CreateDeletionPullRequest not implemented")
 }
+func (UnimplementedClustersServiceServer) CreateUpgradePullRequest(context.Context, *CreateUpgradePullRequestRequest) (*CreateUpgradePullRequestResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateUpgradePullRequest not implemented")
+}
 func (UnimplementedClustersServiceServer) RenderAutomation(context.Context, *RenderAutomationRequest) (*RenderAutomationResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method RenderAutomation not implemented")
 }
@@ -610,6 +674,12 @@ func (UnimplementedClustersServiceServer) ListPolicyConfigs(context.Context, *Li
 func (UnimplementedClustersServiceServer) GetPolicyConfig(context.Context, *GetPolicyConfigRequest) (*GetPolicyConfigResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetPolicyConfig not implemented")
 }
+func (UnimplementedClustersServiceServer) CreateTenantsPullRequest(context.Context, *CreateTenantsPullRequestRequest) (*CreateTenantsPullRequestResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateTenantsPullRequest not implemented")
+}
+func (UnimplementedClustersServiceServer) CheckTenants(context.Context, *CheckTenantsRequest) (*CheckTenantsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CheckTenants not implemented")
+}
 func (UnimplementedClustersServiceServer) mustEmbedUnimplementedClustersServiceServer() {}
 
 // UnsafeClustersServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -749,6 +819,24 @@ func _ClustersService_CreateDeletionPullRequest_Handler(srv interface{}, ctx con
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ClustersService_CreateUpgradePullRequest_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateUpgradePullRequestRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ClustersServiceServer).CreateUpgradePullRequest(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/cluster_services.v1.ClustersService/CreateUpgradePullRequest",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ClustersServiceServer).CreateUpgradePullRequest(ctx, req.(*CreateUpgradePullRequestRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _ClustersService_RenderAutomation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(RenderAutomationRequest)
 	if err := dec(in); err != nil {
@@ -1217,6 +1305,42 @@ func _ClustersService_GetPolicyConfig_Handler(srv interface{}, ctx context.Conte
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ClustersService_CreateTenantsPullRequest_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateTenantsPullRequestRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ClustersServiceServer).CreateTenantsPullRequest(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/cluster_services.v1.ClustersService/CreateTenantsPullRequest",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ClustersServiceServer).CreateTenantsPullRequest(ctx, req.(*CreateTenantsPullRequestRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ClustersService_CheckTenants_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CheckTenantsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ClustersServiceServer).CheckTenants(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/cluster_services.v1.ClustersService/CheckTenants",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ClustersServiceServer).CheckTenants(ctx, req.(*CheckTenantsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // ClustersService_ServiceDesc is the grpc.ServiceDesc for ClustersService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -1252,6 +1376,10 @@ var ClustersService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "CreateDeletionPullRequest",
 			Handler:    _ClustersService_CreateDeletionPullRequest_Handler,
 		},
+		{
+			MethodName: "CreateUpgradePullRequest",
+			Handler:    _ClustersService_CreateUpgradePullRequest_Handler,
+		},
 		{
 			MethodName: "RenderAutomation",
 			Handler:    _ClustersService_RenderAutomation_Handler,
@@ -1356,6 +1484,14 @@ var ClustersService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetPolicyConfig",
 			Handler:    _ClustersService_GetPolicyConfig_Handler,
 		},
+		{
+			MethodName: "CreateTenantsPullRequest",
+			Handler:    _ClustersService_CreateTenantsPullRequest_Handler,
+		},
+		{
+			MethodName: "CheckTenants",
+			Handler:    _ClustersService_CheckTenants_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "cluster_services.proto",
@@ -169,7 +169,8 @@ func (s *server) CreatePullRequest(ctx context.Context, msg *capiv1_proto.Create
 	// Get list of previous files to be added as deleted files in the commit,
 	// Update the  previous values to be nil to skip including it in the updated create-request annotation
 	prevFiles := &GetFilesReturn{}
-	if msg.PreviousValues != nil {
+	editing := msg.PreviousValues != nil
+	if editing {
 		prevFiles, err = GetFiles(
 			ctx,
 			client,
@@ -219,7 +220,7 @@ func (s *server) CreatePullRequest(ctx context.Context, msg *capiv1_proto.Create
 		msg,
 	)
 	if err != nil {
-		return nil, err
+		return nil, invalidParamValuesError("parameter_values", err)
 	}
 
 	files := []git.CommitFile{}
@@ -257,6 +258,19 @@ func (s *server) CreatePullRequest(ctx context.Context, msg *capiv1_proto.Create
 		return nil, grpcStatus.Errorf(codes.Unauthenticated, "failed to access repo %s: %s", repositoryURL, err)
 	}
 
+	if editing {
+		var conflicts []*capiv1_proto.FileConflict
+		files, conflicts, err = s.mergeRepositoryChanges(ctx, *gp, repositoryURL, baseBranch, prevFiles, files)
+		if err != nil {
+			return nil, fmt.Errorf("failed to merge changes made to files in %s: %w", repositoryURL, err)
+		}
+		if len(conflicts) > 0 {
+			return &capiv1_proto.CreatePullRequestResponse{
+				Conflicts: conflicts,
+			}, nil
+		}
+	}
+
 	res, err := s.provider.WriteFilesToBranchAndCreatePullRequest(ctx, csgit.WriteFilesToBranchAndCreatePullRequestRequest{
 		GitProvider:   *gp,
 		RepositoryURL: repositoryURL,
@@ -701,6 +715,11 @@ func generateProfileFiles(ctx context.Context, tmpl templatesv1.Template, cluste
 		return nil, err
 	}
 
+	parameterValues, err := tmplProcessor.ParamValues(args.parameterValues)
+	if err != nil {
+		return nil, err
+	}
+
 	var installs []charts.ChartInstall
 
 	requiredProfiles, err := templates.GetProfilesFromTemplate(tmpl)
@@ -763,7 +782,7 @@ func generateProfileFiles(ctx context.Context, tmpl templatesv1.Template, cluste
 			}
 		}
 
-		values, err := renderValues(v, *tmplProcessor, args.parameterValues)
+		values, err := renderValues(v, *tmplProcessor, parameterValues)
 		if err != nil {
 			return nil, fmt.Errorf("cannot get values for profile %s: %w", v.Name, err)
 		}
@@ -771,7 +790,7 @@ func generateProfileFiles(ctx context.Context, tmpl templatesv1.Template, cluste
 		profileTemplate := []byte{}
 		requiredProfile := requiredProfilesIndex[v.Name]
 		if requiredProfile != nil {
-			profileTemplate, err = tmplProcessor.Render([]byte(requiredProfile.ProfileTemplate), args.parameterValues)
+			profileTemplate, err = tmplProcessor.Render([]byte(requiredProfile.ProfileTemplate), parameterValues)
 			if err != nil {
 				return nil, fmt.Errorf("cannot render spec of profile %s: %w", v.Name, err)
 			}
@@ -809,7 +828,7 @@ func generateProfileFiles(ctx context.Context, tmpl templatesv1.Template, cluste
 	// For each path, we join the content of relative profiles and add to a commit file
 	for path := range profilesByPath {
 		profileContent := string(bytes.Join(profilesByPath[path], []byte("---\n")))
-		renderedPath, err := tmplProcessor.Render([]byte(path), args.parameterValues)
+		renderedPath, err := tmplProcessor.Render([]byte(path), parameterValues)
 		if err != nil {
 			return nil, fmt.Errorf("cannot render path %s: %w", path, err)
 		}
@@ -937,6 +956,22 @@ func getClusterDirPath(cluster types.NamespacedName) string {
 	)
 }
 
+// getTenantsDirPath returns the directory the files of the tenants of a cluster are written to.
+// The management cluster has no namespace.
+func getTenantsDirPath(cluster types.NamespacedName) string {
+	return filepath.Join(
+		getClusterDirPath(cluster),
+		"tenants",
+	)
+}
+
+func getTenantPath(cluster types.NamespacedName, tenant string) string {
+	return filepath.Join(
+		getTenantsDirPath(cluster),
+		fmt.Sprintf("%s.yaml", tenant),
+	)
+}
+
 func getCommonKustomizationPath(cluster types.NamespacedName) string {
 	return filepath.Join(
 		getClusterDirPath(cluster),
@@ -959,7 +994,7 @@ func getClusterProfilesPath(cluster types.NamespacedName) string {
 }
 
 // renderValues renders the "values.yaml" section of a HelmRelease, as it can also contain template parameters.
-func renderValues(v *capiv1_proto.ProfileValues, tmplProcessor templates.TemplateProcessor, parameterValues map[string]string) (map[string]interface{}, error) {
+func renderValues(v *capiv1_proto.ProfileValues, tmplProcessor templates.TemplateProcessor, parameterValues map[string]interface{}) (map[string]interface{}, error) {
 	// FIXME: look into decoding the base64 in the proto API rather than here.
 	decoded, err := base64.StdEncoding.DecodeString(v.Values)
 	if err != nil {
@@ -1225,6 +1260,123 @@ func getDeletedFiles(prevFiles *GetFilesReturn, newFiles *GetFilesReturn) []git.
 	return deletedFiles
 }
 
+// mergeRepositoryChanges merges the changes made in the branch of the
+// repository to the files rendered with the previous values into the files
+// rendered with the new values, so that editing resources created from a
+// template doesn't discard them. It returns the merged files, and the files
+// whose changes conflict with the changes to the rendered files.
+func (s *server) mergeRepositoryChanges(ctx context.Context, gp csgit.GitProvider, repositoryURL, branch string, prevFiles *GetFilesReturn, files []git.CommitFile) ([]git.CommitFile, []*capiv1_proto.FileConflict, error) {
+	previous := map[string]string{}
+	for _, f := range allFiles(prevFiles) {
+		if f.Content != nil {
+			previous[f.Path] = *f.Content
+		}
+	}
+
+	current, err := s.provider.GetFiles(ctx, gp, repositoryURL, branch, filePaths(files))
+	if err != nil {
+		return nil, nil, err
+	}
+	currentContents := map[string]string{}
+	for _, f := range current {
+		if f.Content != nil {
+			currentContents[f.Path] = *f.Content
+		}
+	}
+
+	merged := []git.CommitFile{}
+	conflicts := []*capiv1_proto.FileConflict{}
+	for _, f := range files {
+		prev, rendered := previous[f.Path]
+		cur, exists := currentContents[f.Path]
+		// The annotations holding the request the files were rendered with differ between the
+		// branch, which holds the request that created them, and the renders, so they are taken
+		// from the new render rather than merged.
+		if f.Content != nil {
+			prev = withRequestAnnotations(prev, *f.Content)
+			cur = withRequestAnnotations(cur, *f.Content)
+		}
+		// Files that weren't rendered before, that aren't in the branch yet
+		// or that weren't changed there are replaced by the new render.
+		if !rendered || !exists || cur == prev {
+			merged = append(merged, f)
+			continue
+		}
+
+		// Files that are no longer rendered can't keep their changes.
+		if f.Content == nil {
+			conflicts = append(conflicts, &capiv1_proto.FileConflict{
+				Path:    f.Path,
+				Content: cur,
+			})
+			continue
+		}
+
+		content, conflicted := git.ThreeWayMerge(
+			git.MergeFile{Label: "previous", Content: prev},
+			git.MergeFile{Label: branch, Content: cur},
+			git.MergeFile{Label: "rendered", Content: *f.Content},
+		)
+		if conflicted {
+			conflicts = append(conflicts, &capiv1_proto.FileConflict{
+				Path:    f.Path,
+				Content: content,
+			})
+			continue
+		}
+		merged = append(merged, git.CommitFile{
+			Path:    f.Path,
+			Content: &content,
+		})
+	}
+
+	return merged, conflicts, nil
+}
+
+// withRequestAnnotations replaces the lines of the create-request and created-files
+// annotations of content with those of rendered, in the order they appear.
+func withRequestAnnotations(content, rendered string) string {
+	annotationLines := map[string][]string{}
+	for _, line := range strings.SplitAfter(rendered, "\n") {
+		if key, ok := requestAnnotationKey(line); ok {
+			annotationLines[key] = append(annotationLines[key], line)
+		}
+	}
+
+	lines := strings.SplitAfter(content, "\n")
+	for i, line := range lines {
+		key, ok := requestAnnotationKey(line)
+		if !ok || len(annotationLines[key]) == 0 {
+			continue
+		}
+
+		lines[i] = annotationLines[key][0]
+		annotationLines[key] = annotationLines[key][1:]
+	}
+
+	return strings.Join(lines, "")
+}
+
+func requestAnnotationKey(line string) (string, bool) {
+	for _, key := range []string{templates.CreateRequestAnnotation, templates.CreatedFilesAnnotation} {
+		if strings.HasPrefix(strings.TrimSpace(line), key+":") {
+			return key, true
+		}
+	}
+
+	return "", false
+}
+
+func allFiles(files *GetFilesReturn) []git.CommitFile {
+	all := []git.CommitFile{}
+	all = append(all, files.RenderedTemplate...)
+	all = append(all, files.ProfileFiles...)
+	all = append(all, files.KustomizationFiles...)
+	all = append(all, files.ExternalSecretsFiles...)
+
+	return all
+}
+
 func mergeKubeConfigs(main, overrides []byte) ([]byte, error) {
 	mainCfg, err := clientcmd.Load(main)
 	if err != nil {
@@ -407,6 +407,7 @@ func TestCreatePullRequest(t *testing.T) {
 		req            *capiv1_protos.CreatePullRequestRequest
 		expected       string
 		CommittedFiles []*capiv1_protos.CommitFile
+		conflicts      []*capiv1_protos.FileConflict
 		err            error
 	}{
 		{
@@ -553,7 +554,7 @@ metadata:
   namespace: "default"
   annotations:
     templates.weave.works/create-request: "{\"repository_url\":\"https://github.com/org/repo.git\",\"head_branch\":\"feature-01\",\"base_branch\":\"main\",\"title\":\"New Cluster\",\"description\":\"Creates a cluster through a CAPI template\",\"name\":\"cluster-template-1\",\"parameter_values\":{\"CLUSTER_NAME\":\"foo\",\"NAMESPACE\":\"default\"},\"commit_message\":\"Add cluster manifest\",\"namespace\":\"default\",\"template_kind\":\"CAPITemplate\"}"
-    templates.weave.works/created-files: "{\"files\":[\"clusters/my-cluster/clusters/default/foo.yaml\"]}"
+    templates.weave.works/created-files: "{\"checksums\":{\"clusters/my-cluster/clusters/default/foo.yaml\":\"f8db1705520d7927d5e1dda88bfa5b4a37e6bb8fdcd20a5986361b850575ad3f\"},\"files\":[\"clusters/my-cluster/clusters/default/foo.yaml\"]}"
 spec:
   machineTemplate:
     infrastructureRef:
@@ -642,7 +643,7 @@ metadata:
   namespace: "default"
   annotations:
     templates.weave.works/create-request: "{\"repository_url\":\"https://github.com/org/repo.git\",\"head_branch\":\"feature-01\",\"base_branch\":\"main\",\"title\":\"New Cluster\",\"description\":\"Creates a cluster through a CAPI template\",\"name\":\"cluster-template-sops\",\"parameter_values\":{\"CLUSTER_NAME\":\"foo\",\"NAMESPACE\":\"default\",\"SOPS_KUSTOMIZATION_NAME\":\"my-secrets\",\"SOPS_SECRET_REF\":\"sops-gpg\",\"SOPS_SECRET_REF_NAMESPACE\":\"flux-system\"},\"commit_message\":\"Add cluster manifest\",\"namespace\":\"default\",\"template_kind\":\"CAPITemplate\"}"
-    templates.weave.works/created-files: "{\"files\":[\"clusters/my-cluster/clusters/default/foo.yaml\"]}"
+    templates.weave.works/created-files: "{\"checksums\":{\"clusters/my-cluster/clusters/default/foo.yaml\":\"f8db1705520d7927d5e1dda88bfa5b4a37e6bb8fdcd20a5986361b850575ad3f\"},\"files\":[\"clusters/my-cluster/clusters/default/foo.yaml\"]}"
 spec:
   machineTemplate:
     infrastructureRef:
@@ -692,7 +693,7 @@ metadata:
     capi.weave.works/display-name: ClusterName
     kustomize.toolkit.fluxcd.io/prune: disabled
     templates.weave.works/create-request: "{\"repository_url\":\"https://github.com/org/repo.git\",\"head_branch\":\"feature-01\",\"base_branch\":\"main\",\"title\":\"New Cluster\",\"description\":\"Creates a cluster through a CAPI template\",\"name\":\"cluster-template-1\",\"parameter_values\":{\"CLUSTER_NAME\":\"dev\",\"NAMESPACE\":\"default\"},\"commit_message\":\"Add cluster manifest\",\"values\":[{\"name\":\"demo-profile\",\"version\":\"0.0.1\"}],\"namespace\":\"default\",\"template_kind\":\"CAPITemplate\"}"
-    templates.weave.works/created-files: "{\"files\":[\"clusters/my-cluster/clusters/default/dev.yaml\"]}"
+    templates.weave.works/created-files: "{\"checksums\":{\"clusters/my-cluster/clusters/default/dev.yaml\":\"d25594532e005f3e8268f13c8f3266523e68f740e6e8740b9e580938a250a1c4\"},\"files\":[\"clusters/my-cluster/clusters/default/dev.yaml\"]}"
   labels:
     templates.weave.works/template-name: cluster-template-1
     templates.weave.works/template-namespace: default
@@ -791,7 +792,7 @@ metadata:
     capi.weave.works/display-name: ClusterName
     kustomize.toolkit.fluxcd.io/prune: disabled
     templates.weave.works/create-request: "{\"repository_url\":\"https://github.com/org/repo.git\",\"head_branch\":\"feature-01\",\"base_branch\":\"main\",\"title\":\"New Cluster\",\"description\":\"Creates a cluster through a CAPI template\",\"name\":\"cluster-template-1\",\"parameter_values\":{\"CLUSTER_NAME\":\"foo\",\"NAMESPACE\":\"default\"},\"commit_message\":\"Add cluster manifest\",\"namespace\":\"default\",\"template_kind\":\"CAPITemplate\"}"
-    templates.weave.works/created-files: "{\"files\":[\"clusters/foo.yml\"]}"
+    templates.weave.works/created-files: "{\"checksums\":{\"clusters/foo.yml\":\"75a4cfa3598c56883c70b7d1538150aa9fbdd6b0502a60c98d087e356140b612\"},\"files\":[\"clusters/foo.yml\"]}"
   labels:
     templates.weave.works/template-name: cluster-template-1
     templates.weave.works/template-namespace: default
@@ -858,7 +859,7 @@ metadata:
     capi.weave.works/display-name: ClusterName
     kustomize.toolkit.fluxcd.io/prune: disabled
     templates.weave.works/create-request: "{\"repository_url\":\"https://github.com/org/repo.git\",\"head_branch\":\"feature-01\",\"base_branch\":\"main\",\"title\":\"New Cluster\",\"description\":\"Creates a cluster through a CAPI template\",\"name\":\"cluster-template-1\",\"parameter_values\":{\"CLUSTER_NAME\":\"dev\",\"NAMESPACE\":\"clusters-namespace\"},\"commit_message\":\"Add cluster manifest\",\"values\":[{\"name\":\"demo-profile\",\"version\":\"0.0.1\",\"namespace\":\"test-system\"}],\"namespace\":\"default\",\"template_kind\":\"CAPITemplate\"}"
-    templates.weave.works/created-files: "{\"files\":[\"clusters/my-cluster/clusters/clusters-namespace/dev.yaml\"]}"
+    templates.weave.works/created-files: "{\"checksums\":{\"clusters/my-cluster/clusters/clusters-namespace/dev.yaml\":\"dab9825072ea5bf7ca7e19c720091ea12a1b5bbef561cec312f5a0bd20e80fd5\"},\"files\":[\"clusters/my-cluster/clusters/clusters-namespace/dev.yaml\"]}"
   labels:
     templates.weave.works/template-name: cluster-template-1
     templates.weave.works/template-namespace: default
@@ -974,7 +975,7 @@ metadata:
     capi.weave.works/display-name: ClusterName
     kustomize.toolkit.fluxcd.io/prune: disabled
     templates.weave.works/create-request: "{\"repository_url\":\"https://github.com/org/repo.git\",\"head_branch\":\"feature-01\",\"base_branch\":\"main\",\"title\":\"New Cluster\",\"description\":\"Creates a cluster through a CAPI template\",\"name\":\"cluster-template-1\",\"parameter_values\":{\"CLUSTER_NAME\":\"dev\",\"NAMESPACE\":\"clusters-namespace\"},\"commit_message\":\"Add cluster manifest\",\"kustomizations\":[{\"metadata\":{\"name\":\"apps-capi\",\"namespace\":\"flux-system\"},\"spec\":{\"path\":\"./apps/capi\",\"source_ref\":{\"name\":\"flux-system\",\"namespace\":\"flux-system\"},\"target_namespace\":\"foo-ns\"}},{\"metadata\":{\"name\":\"apps-billing\",\"namespace\":\"flux-system\"},\"spec\":{\"path\":\"./apps/billing\",\"source_ref\":{\"name\":\"flux-system\",\"namespace\":\"flux-system\"}}}],\"namespace\":\"default\",\"template_kind\":\"CAPITemplate\"}"
-    templates.weave.works/created-files: "{\"files\":[\"clusters/my-cluster/clusters/clusters-namespace/dev.yaml\"]}"
+    templates.weave.works/created-files: "{\"checksums\":{\"clusters/my-cluster/clusters/clusters-namespace/dev.yaml\":\"dab9825072ea5bf7ca7e19c720091ea12a1b5bbef561cec312f5a0bd20e80fd5\"},\"files\":[\"clusters/my-cluster/clusters/clusters-namespace/dev.yaml\"]}"
   labels:
     templates.weave.works/template-name: cluster-template-1
     templates.weave.works/template-namespace: default
@@ -1159,7 +1160,7 @@ metadata:
     capi.weave.works/display-name: ClusterName
     kustomize.toolkit.fluxcd.io/prune: disabled
     templates.weave.works/create-request: "{\"repository_url\":\"https://github.com/org/repo.git\",\"head_branch\":\"feature-01\",\"base_branch\":\"main\",\"title\":\"Edit Cluster\",\"description\":\"Delete kustomization from cluster\",\"name\":\"cluster-template-1\",\"parameter_values\":{\"CLUSTER_NAME\":\"dev\",\"NAMESPACE\":\"clusters-namespace\"},\"commit_message\":\"Edits dev\",\"namespace\":\"default\",\"template_kind\":\"CAPITemplate\"}"
-    templates.weave.works/created-files: "{\"files\":[\"clusters/my-cluster/clusters/clusters-namespace/dev.yaml\"]}"
+    templates.weave.works/created-files: "{\"checksums\":{\"clusters/my-cluster/clusters/clusters-namespace/dev.yaml\":\"dab9825072ea5bf7ca7e19c720091ea12a1b5bbef561cec312f5a0bd20e80fd5\"},\"files\":[\"clusters/my-cluster/clusters/clusters-namespace/dev.yaml\"]}"
   labels:
     templates.weave.works/template-name: cluster-template-1
     templates.weave.works/template-namespace: default
@@ -1300,7 +1301,7 @@ metadata:
     capi.weave.works/display-name: ClusterName
     kustomize.toolkit.fluxcd.io/prune: disabled
     templates.weave.works/create-request: "{\"repository_url\":\"https://github.com/org/repo.git\",\"head_branch\":\"feature-01\",\"base_branch\":\"main\",\"title\":\"Edit Cluster\",\"description\":\"Edit namespace\",\"name\":\"cluster-template-1\",\"parameter_values\":{\"CLUSTER_NAME\":\"dev\",\"NAMESPACE\":\"clusters-namespace-2\"},\"commit_message\":\"Edits dev\",\"kustomizations\":[{\"metadata\":{\"name\":\"apps-capi\",\"namespace\":\"flux-system\"},\"spec\":{\"path\":\"./apps/capi\",\"source_ref\":{\"name\":\"flux-system\",\"namespace\":\"flux-system\"},\"target_namespace\":\"foo-ns\"}}],\"namespace\":\"default\",\"template_kind\":\"CAPITemplate\"}"
-    templates.weave.works/created-files: "{\"files\":[\"clusters/my-cluster/clusters/clusters-namespace-2/dev.yaml\"]}"
+    templates.weave.works/created-files: "{\"checksums\":{\"clusters/my-cluster/clusters/clusters-namespace-2/dev.yaml\":\"808c7c60a52496ae65dfdabe655607dc0fb971118e3a132156ab6284617c2f9d\"},\"files\":[\"clusters/my-cluster/clusters/clusters-namespace-2/dev.yaml\"]}"
   labels:
     templates.weave.works/template-name: cluster-template-1
     templates.weave.works/template-namespace: default
@@ -1419,7 +1420,7 @@ metadata:
     capi.weave.works/display-name: ClusterName
     kustomize.toolkit.fluxcd.io/prune: disabled
     templates.weave.works/create-request: "{\"repository_url\":\"https://github.com/org/repo.git\",\"head_branch\":\"feature-01\",\"base_branch\":\"main\",\"title\":\"Edit Cluster\",\"description\":\"Edit namespace\",\"name\":\"cluster-template-1\",\"parameter_values\":{\"CLUSTER_NAME\":\"dev\",\"NAMESPACE\":\"clusters-namespace-2\"},\"commit_message\":\"Edits dev\",\"kustomizations\":[{\"metadata\":{\"name\":\"apps-capi-2\",\"namespace\":\"flux-system\"},\"spec\":{\"path\":\"./apps/capi\",\"source_ref\":{\"name\":\"flux-system\",\"namespace\":\"flux-system\"},\"target_namespace\":\"foo-ns\"}}],\"namespace\":\"default\",\"template_kind\":\"CAPITemplate\"}"
-    templates.weave.works/created-files: "{\"files\":[\"clusters/my-cluster/clusters/clusters-namespace-2/dev.yaml\"]}"
+    templates.weave.works/created-files: "{\"checksums\":{\"clusters/my-cluster/clusters/clusters-namespace-2/dev.yaml\":\"808c7c60a52496ae65dfdabe655607dc0fb971118e3a132156ab6284617c2f9d\"},\"files\":[\"clusters/my-cluster/clusters/clusters-namespace-2/dev.yaml\"]}"
   labels:
     templates.weave.works/template-name: cluster-template-1
     templates.weave.works/template-namespace: default
@@ -1430,6 +1431,226 @@ metadata:
 			},
 			expected: "https://github.com/org/repo/pull/1",
 		},
+		{
+			name: "Edit cluster, keep changes made in the repository",
+			clusterState: []runtime.Object{
+				makeCAPITemplate(t),
+			},
+			provider: newFakeGitProviderWithContents("https://github.com/org/repo/pull/1", map[string]string{
+				"clusters/clusters-namespace/dev/apps-capi-flux-system-kustomization.yaml": `apiVersion: kustomize.toolkit.fluxcd.io/v1
+kind: Kustomization
+metadata:
+  creationTimestamp: null
+  name: apps-capi
+  namespace: flux-system
+spec:
+  interval: 1h0m0s
+  path: ./apps/capi
+  prune: true
+  sourceRef:
+    kind: GitRepository
+    name: flux-system
+    namespace: flux-system
+  targetNamespace: foo-ns
+status: {}
+`,
+			}),
+			req: &capiv1_protos.CreatePullRequestRequest{
+				Name: "cluster-template-1",
+				ParameterValues: map[string]string{
+					"CLUSTER_NAME": "dev",
+					"NAMESPACE":    "clusters-namespace",
+				},
+				RepositoryUrl: "https://github.com/org/repo.git",
+				HeadBranch:    "feature-01",
+				BaseBranch:    "main",
+				Title:         "Edit Cluster",
+				Description:   "Edit kustomization target namespace",
+				CommitMessage: "Edits dev",
+				Namespace:     "default",
+				Kustomizations: []*capiv1_protos.Kustomization{
+					{
+						Metadata: testNewMetadata(t, "apps-capi", "flux-system"),
+						Spec: &capiv1_protos.KustomizationSpec{
+							Path:            "./apps/capi",
+							SourceRef:       testNewSourceRef(t, "flux-system", "flux-system"),
+							TargetNamespace: "bar-ns",
+						},
+					},
+				},
+
+				PreviousValues: &capiv1_protos.PreviousValues{
+					ParameterValues: map[string]string{
+						"CLUSTER_NAME": "dev",
+						"NAMESPACE":    "clusters-namespace",
+					},
+					Kustomizations: []*capiv1_protos.Kustomization{
+						{
+							Metadata: testNewMetadata(t, "apps-capi", "flux-system"),
+							Spec: &capiv1_protos.KustomizationSpec{
+								Path:            "./apps/capi",
+								SourceRef:       testNewSourceRef(t, "flux-system", "flux-system"),
+								TargetNamespace: "foo-ns",
+							},
+						},
+					},
+					Credentials: &capiv1_protos.Credential{},
+				},
+			},
+			CommittedFiles: []*capiv1_protos.CommitFile{
+				{
+					Path: "clusters/clusters-namespace/dev/apps-capi-flux-system-kustomization.yaml",
+					Content: `apiVersion: kustomize.toolkit.fluxcd.io/v1
+kind: Kustomization
+metadata:
+  creationTimestamp: null
+  name: apps-capi
+  namespace: flux-system
+spec:
+  interval: 1h0m0s
+  path: ./apps/capi
+  prune: true
+  sourceRef:
+    kind: GitRepository
+    name: flux-system
+    namespace: flux-system
+  targetNamespace: bar-ns
+status: {}
+`,
+				},
+				{
+					Path: "clusters/clusters-namespace/dev/clusters-bases-kustomization.yaml",
+					Content: `apiVersion: kustomize.toolkit.fluxcd.io/v1
+kind: Kustomization
+metadata:
+  creationTimestamp: null
+  name: clusters-bases-kustomization
+  namespace: flux-system
+spec:
+  interval: 10m0s
+  path: clusters/bases
+  prune: true
+  sourceRef:
+    kind: GitRepository
+    name: flux-system
+status: {}
+`,
+				},
+				{
+					Path: "clusters/my-cluster/clusters/clusters-namespace/dev.yaml",
+					Content: `apiVersion: fooversion
+kind: fookind
+metadata:
+  annotations:
+    capi.weave.works/display-name: ClusterName
+    kustomize.toolkit.fluxcd.io/prune: disabled
+    templates.weave.works/create-request: "{\"repository_url\":\"https://github.com/org/repo.git\",\"head_branch\":\"feature-01\",\"base_branch\":\"main\",\"title\":\"Edit Cluster\",\"description\":\"Edit kustomization target namespace\",\"name\":\"cluster-template-1\",\"parameter_values\":{\"CLUSTER_NAME\":\"dev\",\"NAMESPACE\":\"clusters-namespace\"},\"commit_message\":\"Edits dev\",\"kustomizations\":[{\"metadata\":{\"name\":\"apps-capi\",\"namespace\":\"flux-system\"},\"spec\":{\"path\":\"./apps/capi\",\"source_ref\":{\"name\":\"flux-system\",\"namespace\":\"flux-system\"},\"target_namespace\":\"bar-ns\"}}],\"namespace\":\"default\",\"template_kind\":\"CAPITemplate\"}"
+    templates.weave.works/created-files: "{\"checksums\":{\"clusters/my-cluster/clusters/clusters-namespace/dev.yaml\":\"dab9825072ea5bf7ca7e19c720091ea12a1b5bbef561cec312f5a0bd20e80fd5\"},\"files\":[\"clusters/my-cluster/clusters/clusters-namespace/dev.yaml\"]}"
+  labels:
+    templates.weave.works/template-name: cluster-template-1
+    templates.weave.works/template-namespace: default
+  name: dev
+  namespace: clusters-namespace
+`,
+				},
+			},
+			expected: "https://github.com/org/repo/pull/1",
+		},
+		{
+			name: "Edit cluster, conflicting changes made in the repository",
+			clusterState: []runtime.Object{
+				makeCAPITemplate(t),
+			},
+			provider: newFakeGitProviderWithContents("https://github.com/org/repo/pull/1", map[string]string{
+				"clusters/clusters-namespace/dev/apps-capi-flux-system-kustomization.yaml": `apiVersion: kustomize.toolkit.fluxcd.io/v1
+kind: Kustomization
+metadata:
+  creationTimestamp: null
+  name: apps-capi
+  namespace: flux-system
+spec:
+  interval: 10m0s
+  path: ./apps/capi
+  prune: true
+  sourceRef:
+    kind: GitRepository
+    name: flux-system
+    namespace: flux-system
+  targetNamespace: baz-ns
+status: {}
+`,
+			}),
+			req: &capiv1_protos.CreatePullRequestRequest{
+				Name: "cluster-template-1",
+				ParameterValues: map[string]string{
+					"CLUSTER_NAME": "dev",
+					"NAMESPACE":    "clusters-namespace",
+				},
+				RepositoryUrl: "https://github.com/org/repo.git",
+				HeadBranch:    "feature-01",
+				BaseBranch:    "main",
+				Title:         "Edit Cluster",
+				Description:   "Edit kustomization target namespace",
+				CommitMessage: "Edits dev",
+				Namespace:     "default",
+				Kustomizations: []*capiv1_protos.Kustomization{
+					{
+						Metadata: testNewMetadata(t, "apps-capi", "flux-system"),
+						Spec: &capiv1_protos.KustomizationSpec{
+							Path:            "./apps/capi",
+							SourceRef:       testNewSourceRef(t, "flux-system", "flux-system"),
+							TargetNamespace: "bar-ns",
+						},
+					},
+				},
+
+				PreviousValues: &capiv1_protos.PreviousValues{
+					ParameterValues: map[string]string{
+						"CLUSTER_NAME": "dev",
+						"NAMESPACE":    "clusters-namespace",
+					},
+					Kustomizations: []*capiv1_protos.Kustomization{
+						{
+							Metadata: testNewMetadata(t, "apps-capi", "flux-system"),
+							Spec: &capiv1_protos.KustomizationSpec{
+								Path:            "./apps/capi",
+								SourceRef:       testNewSourceRef(t, "flux-system", "flux-system"),
+								TargetNamespace: "foo-ns",
+							},
+						},
+					},
+					Credentials: &capiv1_protos.Credential{},
+				},
+			},
+			conflicts: []*capiv1_protos.FileConflict{
+				{
+					Path: "clusters/clusters-namespace/dev/apps-capi-flux-system-kustomization.yaml",
+					Content: `apiVersion: kustomize.toolkit.fluxcd.io/v1
+kind: Kustomization
+metadata:
+  creationTimestamp: null
+  name: apps-capi
+  namespace: flux-system
+spec:
+  interval: 10m0s
+  path: ./apps/capi
+  prune: true
+  sourceRef:
+    kind: GitRepository
+    name: flux-system
+    namespace: flux-system
+<<<<<<< main
+  targetNamespace: baz-ns
+||||||| previous
+  targetNamespace: foo-ns
+=======
+  targetNamespace: bar-ns
+>>>>>>> rendered
+status: {}
+`,
+				},
+			},
+		},
 	}
 
 	for _, tt := range testCases {
@@ -1473,6 +1694,9 @@ metadata:
 				if diff := cmp.Diff(tt.expected, createPullRequestResponse.WebUrl, protocmp.Transform()); diff != "" {
 					t.Fatalf("pull request url didn't match expected:\n%s", diff)
 				}
+				if diff := cmp.Diff(tt.conflicts, createPullRequestResponse.Conflicts, protocmp.Transform()); diff != "" {
+					t.Fatalf("conflicts didn't match expected:\n%s", diff)
+				}
 				fakeGitProvider := (tt.provider).(*gitfakes.FakeGitProvider)
 
 				if diff := cmp.Diff(prepCommitedFiles(t, ts.URL, tt.CommittedFiles), fakeGitProvider.GetCommittedFiles(), protocmp.Transform()); len(tt.CommittedFiles) > 0 && diff != "" {
@@ -1483,6 +1707,111 @@ metadata:
 	}
 }
 
+func TestCreatePullRequest_EditCreatedResources(t *testing.T) {
+	viper.SetDefault("capi-clusters-namespace", "default")
+	setViperWithTestCleanup(t, map[string]string{
+		"capi-repository-path":          "clusters/my-cluster/clusters",
+		"capi-repository-clusters-path": "clusters",
+		"add-bases-kustomization":       "enabled",
+		"capi-templates-namespace":      "default",
+	})
+
+	ts := httptest.NewServer(makeServeMux(t))
+	defer ts.Close()
+	hr := makeTestHelmRepository(ts.URL, func(hr *sourcev1.HelmRepository) {
+		hr.Name = "weaveworks-charts"
+		hr.Namespace = "default"
+	})
+	newServer := func(provider csgit.Provider) capiv1_protos.ClustersServiceServer {
+		return createServer(t, serverOptions{
+			profileHelmRepository: &types.NamespacedName{Name: "weaveworks-charts", Namespace: "default"},
+			clusterState:          []runtime.Object{makeCAPITemplate(t), hr},
+			namespace:             "default",
+			provider:              provider,
+			chartsCache: testNewFakeChartCache(t,
+				nsn("management", ""),
+				helm.ObjectReference{Name: "weaveworks-charts", Namespace: "default"},
+				[]helm.Chart{}),
+		})
+	}
+	kustomization := func(targetNamespace string) *capiv1_protos.Kustomization {
+		return &capiv1_protos.Kustomization{
+			Metadata: testNewMetadata(t, "apps-capi", "flux-system"),
+			Spec: &capiv1_protos.KustomizationSpec{
+				Path:            "./apps/capi",
+				SourceRef:       testNewSourceRef(t, "flux-system", "flux-system"),
+				TargetNamespace: targetNamespace,
+			},
+		}
+	}
+	parameterValues := map[string]string{
+		"CLUSTER_NAME": "dev",
+		"NAMESPACE":    "clusters-namespace",
+	}
+
+	// The repository holds the files of the original create, with a change made to them since.
+	created := gitfakes.NewFakeGitProvider("https://github.com/org/repo/pull/1", nil, nil, nil, nil).(*gitfakes.FakeGitProvider)
+	_, err := newServer(created).CreatePullRequest(context.Background(), &capiv1_protos.CreatePullRequestRequest{
+		Name:            "cluster-template-1",
+		Namespace:       "default",
+		ParameterValues: parameterValues,
+		RepositoryUrl:   "https://github.com/org/repo.git",
+		BaseBranch:      "main",
+		Kustomizations:  []*capiv1_protos.Kustomization{kustomization("foo-ns")},
+	})
+	if err != nil {
+		t.Fatalf("failed to create a pull request:\n%s", err)
+	}
+
+	const clusterPath = "clusters/my-cluster/clusters/clusters-namespace/dev.yaml"
+	contents := map[string]string{}
+	for _, f := range created.CommittedFiles {
+		contents[f.Path] = *f.Content
+	}
+	contents[clusterPath] = strings.Replace(contents[clusterPath], "  name: dev\n", "  name: dev\n  # edited in the repository\n", 1)
+
+	edited := newFakeGitProviderWithContents("https://github.com/org/repo/pull/2", contents).(*gitfakes.FakeGitProvider)
+	res, err := newServer(edited).CreatePullRequest(context.Background(), &capiv1_protos.CreatePullRequestRequest{
+		Name:            "cluster-template-1",
+		Namespace:       "default",
+		ParameterValues: parameterValues,
+		RepositoryUrl:   "https://github.com/org/repo.git",
+		BaseBranch:      "main",
+		Kustomizations:  []*capiv1_protos.Kustomization{kustomization("bar-ns")},
+		PreviousValues: &capiv1_protos.PreviousValues{
+			ParameterValues: parameterValues,
+			Kustomizations:  []*capiv1_protos.Kustomization{kustomization("foo-ns")},
+			Credentials:     &capiv1_protos.Credential{},
+		},
+	})
+	if err != nil {
+		t.Fatalf("failed to create a pull request:\n%s", err)
+	}
+	if len(res.Conflicts) > 0 {
+		t.Fatalf("expected no conflicts, got %v", res.Conflicts)
+	}
+
+	committed := map[string]string{}
+	for _, f := range edited.GetCommittedFiles() {
+		committed[f.Path] = f.Content
+	}
+	if !strings.Contains(committed[clusterPath], "  # edited in the repository\n") {
+		t.Errorf("change made in the repository was discarded:\n%s", committed[clusterPath])
+	}
+	if !strings.Contains(committed[clusterPath], `\"target_namespace\":\"bar-ns\"`) {
+		t.Errorf("create-request annotation was not updated:\n%s", committed[clusterPath])
+	}
+	if !strings.Contains(committed["clusters/clusters-namespace/dev/apps-capi-flux-system-kustomization.yaml"], "targetNamespace: bar-ns") {
+		t.Errorf("kustomization was not updated:\n%s", committed["clusters/clusters-namespace/dev/apps-capi-flux-system-kustomization.yaml"])
+	}
+}
+
+func newFakeGitProviderWithContents(url string, contents map[string]string) csgit.Provider {
+	provider := gitfakes.NewFakeGitProvider(url, nil, nil, nil, nil).(*gitfakes.FakeGitProvider)
+	provider.OriginalContents = contents
+	return provider
+}
+
 func sortCommitFiles(files []*capiv1_protos.CommitFile) {
 	sort.Slice(files, func(i, j int) bool {
 		return files[i].Path < files[j].Path
@@ -1,10 +1,14 @@
 package server
 
 import (
+	"errors"
 	"fmt"
 	"strings"
 
 	"github.com/spf13/viper"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	grpcStatus "google.golang.org/grpc/status"
 	"k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/apimachinery/pkg/util/sets"
 
@@ -15,11 +19,19 @@ import (
 )
 
 func renderTemplateWithValues(t apitemplates.Template, name, namespace string, values map[string]string, mapper meta.RESTMapper) ([]templates.RenderedTemplate, error) {
+	labels := map[string]string{
+		"templates.weave.works/template-name":      name,
+		"templates.weave.works/template-namespace": viper.GetString("capi-templates-namespace"),
+	}
+	flavour, err := templates.FlavourFromTemplate(t)
+	if err != nil {
+		return nil, err
+	}
+	if flavour.Version != "" {
+		labels[templates.VersionLabel] = flavour.Version
+	}
 	opts := []templates.RenderOptFunc{
-		templates.InjectLabels(map[string]string{
-			"templates.weave.works/template-name":      name,
-			"templates.weave.works/template-namespace": viper.GetString("capi-templates-namespace"),
-		}),
+		templates.InjectLabels(labels),
 	}
 
 	if mapper != nil {
@@ -40,7 +52,7 @@ func renderTemplateWithValues(t apitemplates.Template, name, namespace string, v
 		if missing, ok := isMissingVariableError(err); ok {
 			return nil, fmt.Errorf("error rendering template %v due to missing variables: %s", name, missing)
 		}
-		return nil, fmt.Errorf("error rendering template %v, %v", name, err)
+		return nil, fmt.Errorf("error rendering template %v, %w", name, err)
 	}
 
 	return templateBits, nil
@@ -127,3 +139,50 @@ func getMissingFiles(originalFiles []git.CommitFile, extraFiles []git.CommitFile
 	return removedFilenames
 
 }
+
+// invalidParamValuesError reports the values of template parameters that are not
+// valid for their schemas as an InvalidArgument status, with a field violation
+// for each of the values. Other errors are returned as they are.
+func invalidParamValuesError(field string, err error) error {
+	var paramErrs templates.ParamErrors
+	if !errors.As(err, &paramErrs) {
+		return err
+	}
+
+	violations := []*errdetails.BadRequest_FieldViolation{}
+	for _, pe := range paramErrs {
+		violations = append(violations, &errdetails.BadRequest_FieldViolation{
+			Field:       fmt.Sprintf("%s.%s", field, pe.Name),
+			Description: pe.Message,
+		})
+	}
+
+	st := grpcStatus.New(codes.InvalidArgument, err.Error())
+	detailed, detailsErr := st.WithDetails(&errdetails.BadRequest{FieldViolations: violations})
+	if detailsErr != nil {
+		return st.Err()
+	}
+
+	return detailed.Err()
+}
+
+// invalidParamSchemasError reports the parameters of a template whose schemas are not
+// valid as a FailedPrecondition status, with a violation for each of the parameters.
+func invalidParamSchemasError(name string, paramErrs templates.ParamErrors) error {
+	violations := []*errdetails.PreconditionFailure_Violation{}
+	for _, pe := range paramErrs {
+		violations = append(violations, &errdetails.PreconditionFailure_Violation{
+			Type:        "PARAM_SCHEMA",
+			Subject:     pe.Name,
+			Description: pe.Message,
+		})
+	}
+
+	st := grpcStatus.Newf(codes.FailedPrecondition, "error looking up template params for %v, %v", name, paramErrs)
+	detailed, err := st.WithDetails(&errdetails.PreconditionFailure{Violations: violations})
+	if err != nil {
+		return st.Err()
+	}
+
+	return detailed.Err()
+}
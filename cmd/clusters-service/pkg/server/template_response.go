@@ -1,6 +1,7 @@
 package server
 
 import (
+	"encoding/json"
 	"fmt"
 
 	capiv1 "github.com/weaveworks/templates-controller/apis/capi/v1alpha2"
@@ -42,13 +43,26 @@ func ToTemplateResponse(t apitemplates.Template) *capiv1_proto.Template {
 	}
 
 	for _, p := range meta.Params {
-		res.Parameters = append(res.Parameters, &capiv1_proto.Parameter{
+		param := &capiv1_proto.Parameter{
 			Name:        p.Name,
 			Description: p.Description,
 			Options:     p.Options,
 			Required:    p.Required,
 			Default:     p.Default,
-		})
+		}
+		if p.Schema != nil {
+			schema, err := json.Marshal(p.Schema)
+			if err != nil {
+				res.Error = fmt.Sprintf("Couldn't encode schema of parameter %s: %s", p.Name, err.Error())
+				return res
+			}
+			param.Type = p.Schema.Type
+			if param.Type == "" {
+				param.Type = templates.ParamTypeString
+			}
+			param.Schema = string(schema)
+		}
+		res.Parameters = append(res.Parameters, param)
 	}
 	for _, o := range meta.Objects {
 		res.Objects = append(res.Objects, &capiv1_proto.TemplateObject{
@@ -45,7 +45,55 @@ type GetFilesReturn struct {
 	ExternalSecretsFiles []git.CommitFile
 }
 
+// getTemplate gets the template, with the templates that it includes merged in.
 func (s *server) getTemplate(ctx context.Context, name, namespace, templateKind string) (templatesv1.Template, error) {
+	t, err := s.fetchTemplate(ctx, name, namespace, templateKind)
+	if err != nil || t == nil {
+		return t, err
+	}
+
+	return templates.ResolveIncludes(ctx, t, s.includedTemplateGetter())
+}
+
+// includedTemplateGetter returns a getter of the templates that are included by others.
+func (s *server) includedTemplateGetter() templates.TemplateGetter {
+	return func(ctx context.Context, ref templates.TemplateRef) (templatesv1.Template, error) {
+		t, err := s.fetchTemplate(ctx, ref.Name, ref.Namespace, ref.Kind)
+		if err != nil {
+			return nil, err
+		}
+		if t == nil {
+			return nil, fmt.Errorf("unknown template kind %q", ref.Kind)
+		}
+
+		return t, nil
+	}
+}
+
+// listedTemplateGetter returns a getter of the included templates that looks them up in the
+// listed templates first, and only gets those that were not listed, e.g. from the namespaces
+// whose templates could not be listed.
+func (s *server) listedTemplateGetter(listed []templatesv1.Template) templates.TemplateGetter {
+	byRef := map[templates.TemplateRef]templatesv1.Template{}
+	for _, t := range listed {
+		byRef[templates.TemplateRef{
+			Kind:      t.GetObjectKind().GroupVersionKind().Kind,
+			Name:      t.GetName(),
+			Namespace: t.GetNamespace(),
+		}] = t
+	}
+
+	get := s.includedTemplateGetter()
+	return func(ctx context.Context, ref templates.TemplateRef) (templatesv1.Template, error) {
+		if t, ok := byRef[ref]; ok {
+			return t, nil
+		}
+
+		return get(ctx, ref)
+	}
+}
+
+func (s *server) fetchTemplate(ctx context.Context, name, namespace, templateKind string) (templatesv1.Template, error) {
 	if namespace == "" {
 		return nil, errors.New("need to specify template namespace")
 	}
@@ -89,6 +137,7 @@ func (s *server) ListTemplates(ctx context.Context, msg *capiv1_proto.ListTempla
 	errors := []*capiv1_proto.ListError{}
 	includeGitopsTemplates := msg.TemplateKind == "" || msg.TemplateKind == gapiv1.Kind
 	includeCAPITemplates := msg.TemplateKind == "" || msg.TemplateKind == capiv1.Kind
+	listed := []templatesv1.Template{}
 
 	if includeGitopsTemplates {
 		namespacedLists, err := s.managementFetcher.Fetch(ctx, gapiv1.Kind, func() client.ObjectList {
@@ -107,7 +156,10 @@ func (s *server) ListTemplates(ctx context.Context, msg *capiv1_proto.ListTempla
 			}
 			templatesList := namespacedList.List.(*gapiv1.GitOpsTemplateList)
 			for _, t := range templatesList.Items {
-				templates = append(templates, ToTemplateResponse(&t))
+				t := t
+				// https://github.com/kubernetes-sigs/controller-runtime/issues/1517#issuecomment-844703142
+				t.SetGroupVersionKind(gapiv1.GroupVersion.WithKind(gapiv1.Kind))
+				listed = append(listed, &t)
 			}
 		}
 	}
@@ -129,11 +181,21 @@ func (s *server) ListTemplates(ctx context.Context, msg *capiv1_proto.ListTempla
 			}
 			templatesList := namespacedList.List.(*capiv1.CAPITemplateList)
 			for _, t := range templatesList.Items {
-				templates = append(templates, ToTemplateResponse(&t))
+				t := t
+				// https://github.com/kubernetes-sigs/controller-runtime/issues/1517#issuecomment-844703142
+				t.SetGroupVersionKind(capiv1.GroupVersion.WithKind(capiv1.Kind))
+				listed = append(listed, &t)
 			}
 		}
 	}
 
+	get := s.listedTemplateGetter(listed)
+	for _, t := range listed {
+		if res := s.toListedTemplateResponse(ctx, t, get); res != nil {
+			templates = append(templates, res)
+		}
+	}
+
 	total := int32(len(templates))
 	if msg.Provider != "" {
 		if !isProviderRecognised(msg.Provider) {
@@ -151,6 +213,23 @@ func (s *server) ListTemplates(ctx context.Context, msg *capiv1_proto.ListTempla
 	}, nil
 }
 
+// toListedTemplateResponse returns the response for a listed template, with the templates that it
+// includes merged in, or nil for fragments, as they are only meant to be included.
+func (s *server) toListedTemplateResponse(ctx context.Context, t templatesv1.Template, get templates.TemplateGetter) *capiv1_proto.Template {
+	if templates.IsFragment(t) {
+		return nil
+	}
+
+	resolved, err := templates.ResolveIncludes(ctx, t, get)
+	if err != nil {
+		res := ToTemplateResponse(t)
+		res.Error = fmt.Sprintf("Couldn't resolve included templates: %s", err)
+		return res
+	}
+
+	return ToTemplateResponse(resolved)
+}
+
 func (s *server) GetTemplate(ctx context.Context, msg *capiv1_proto.GetTemplateRequest) (*capiv1_proto.GetTemplateResponse, error) {
 	// Default to CAPI kind to ease transition
 	if msg.TemplateKind == "" {
@@ -176,6 +255,10 @@ func (s *server) ListTemplateParams(ctx context.Context, msg *capiv1_proto.ListT
 	if err != nil {
 		return nil, fmt.Errorf("error looking up template %v: %v", msg.Name, err)
 	}
+	var paramErrs templates.ParamErrors
+	if _, err := templates.ParamsFromTemplate(tm); errors.As(err, &paramErrs) {
+		return nil, invalidParamSchemasError(msg.Name, paramErrs)
+	}
 	t := ToTemplateResponse(tm)
 	if t.Error != "" {
 		return nil, fmt.Errorf("error looking up template params for %v, %v", msg.Name, t.Error)
@@ -257,7 +340,7 @@ func (s *server) RenderTemplate(ctx context.Context, msg *capiv1_proto.RenderTem
 		nil,
 	)
 	if err != nil {
-		return nil, err
+		return nil, invalidParamValuesError("values", err)
 	}
 
 	profileFiles := toCommitFileProtos(files.ProfileFiles)
@@ -291,7 +374,7 @@ func GetFiles(
 	for _, renderedTemplate := range renderedTemplates {
 		tmplWithValues := renderedTemplate.Data
 		if createRequestMessage != nil {
-			tmplWithValues, err = templates.InjectJSONAnnotation(tmplWithValues, "templates.weave.works/create-request", createRequestMessage)
+			tmplWithValues, err = templates.InjectJSONAnnotation(tmplWithValues, templates.CreateRequestAnnotation, createRequestMessage)
 			if err != nil {
 				return nil, fmt.Errorf("failed to annotate template with parameter values: %w", err)
 			}
@@ -331,9 +414,24 @@ func GetFiles(
 	}(rendered)
 
 	if len(rendered) > 0 {
-		annotated, err := templates.InjectJSONAnnotation(rendered[0].content, "templates.weave.works/created-files", map[string]any{
+		createdFiles := map[string]any{
 			"files": filenames,
-		})
+		}
+		// The checksums of the files created for a request tell the files that are changed in
+		// the repository apart when the resource is upgraded.
+		if createRequestMessage != nil {
+			checksums := map[string]string{}
+			for _, r := range rendered {
+				checksum, err := renderedChecksum(string(bytes.Join(r.content, []byte("\n---\n"))))
+				if err != nil {
+					return nil, fmt.Errorf("failed to get checksum of rendered file %s: %w", r.filename, err)
+				}
+				checksums[r.filename] = checksum
+			}
+			createdFiles["checksums"] = checksums
+		}
+
+		annotated, err := templates.InjectJSONAnnotation(rendered[0].content, templates.CreatedFilesAnnotation, createdFiles)
 		if err != nil {
 			return nil, fmt.Errorf("failed to annotate template with created files: %w", err)
 		}
@@ -13,16 +13,21 @@ import (
 	"github.com/google/go-cmp/cmp"
 	"github.com/spf13/viper"
 	"github.com/stretchr/testify/assert"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	grpcStatus "google.golang.org/grpc/status"
 	"google.golang.org/protobuf/testing/protocmp"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	capiv1 "github.com/weaveworks/templates-controller/apis/capi/v1alpha2"
 	templatesv1 "github.com/weaveworks/templates-controller/apis/core"
 	gapiv1 "github.com/weaveworks/templates-controller/apis/gitops/v1alpha2"
 	capiv1_protos "github.com/weaveworks/weave-gitops-enterprise/cmd/clusters-service/pkg/protos"
+	"github.com/weaveworks/weave-gitops-enterprise/cmd/clusters-service/pkg/templates"
 	"github.com/weaveworks/weave-gitops-enterprise/pkg/estimation"
 	"github.com/weaveworks/weave-gitops-enterprise/pkg/git"
 	"github.com/weaveworks/weave-gitops-enterprise/pkg/helm"
@@ -130,6 +135,40 @@ func TestListTemplates(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "fragments are not listed",
+			clusterState: []runtime.Object{
+				makeCAPITemplate(t, func(ct *capiv1.CAPITemplate) {
+					ct.ObjectMeta.Name = "cluster-fragment"
+					ct.Annotations = map[string]string{templates.FragmentAnnotation: "true"}
+				}),
+				makeCAPITemplate(t),
+			},
+			expected: []*capiv1_protos.Template{
+				{
+					Name:         "cluster-template-1",
+					Description:  "this is test template 1",
+					Provider:     "",
+					TemplateKind: "CAPITemplate",
+					Namespace:    "default",
+					Objects: []*capiv1_protos.TemplateObject{
+						{
+							Name:        string("${CLUSTER_NAME}"),
+							DisplayName: string("ClusterName"),
+							ApiVersion:  "fooversion",
+							Kind:        "fookind",
+							Parameters:  []string{"CLUSTER_NAME"},
+						},
+					},
+					Parameters: []*capiv1_protos.Parameter{
+						{
+							Name:        "CLUSTER_NAME",
+							Description: "This is used for the cluster naming.",
+						},
+					},
+				},
+			},
+		},
 	}
 
 	ctx := auth.WithPrincipal(context.Background(), &auth.UserPrincipal{ID: "userID"})
@@ -296,6 +335,49 @@ func TestListClusterTemplates(t *testing.T) {
 	}
 }
 
+func TestListTemplates_IncludedTemplatesAreListed(t *testing.T) {
+	c := &countingClient{Client: createClient(t,
+		makeCAPITemplate(t, func(ct *capiv1.CAPITemplate) {
+			ct.Annotations = map[string]string{
+				templates.IncludesAnnotation: "- name: region-fragment\n",
+			}
+		}),
+		makeCAPITemplate(t, func(ct *capiv1.CAPITemplate) {
+			ct.ObjectMeta.Name = "cluster-template-2"
+			ct.Annotations = map[string]string{
+				templates.IncludesAnnotation: "- name: region-fragment\n",
+			}
+		}),
+		makeRegionFragment(t),
+	)}
+	s := createServer(t, serverOptions{
+		client:    c,
+		namespace: "default",
+	})
+
+	ctx := auth.WithPrincipal(context.Background(), &auth.UserPrincipal{ID: "userID"})
+	res, err := s.ListTemplates(ctx, &capiv1_protos.ListTemplatesRequest{TemplateKind: capiv1.Kind})
+	assert.NoError(t, err)
+
+	assert.Len(t, res.Templates, 2)
+	for _, tm := range res.Templates {
+		assert.Empty(t, tm.Error)
+		assert.Equal(t, "REGION", tm.Parameters[0].Name)
+	}
+	assert.Zero(t, c.gets, "included templates should be looked up in the listed templates")
+}
+
+// countingClient counts the objects that are got through the client.
+type countingClient struct {
+	client.Client
+	gets int
+}
+
+func (c *countingClient) Get(ctx context.Context, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+	c.gets++
+	return c.Client.Get(ctx, key, obj, opts...)
+}
+
 func TestListTemplates_FilterByProvider(t *testing.T) {
 	testCases := []struct {
 		name         string
@@ -519,6 +601,73 @@ func TestListTemplateParams(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "1 typed parameter",
+			clusterState: []runtime.Object{
+				makeCAPITemplate(t, func(ct *capiv1.CAPITemplate) {
+					ct.Annotations = map[string]string{
+						templates.ParamSchemasAnnotation: "CLUSTER_NAME:\n  pattern: ^[a-z-]+$\n",
+					}
+				}),
+			},
+			expected: []*capiv1_protos.Parameter{
+				{
+					Name:        "CLUSTER_NAME",
+					Description: "This is used for the cluster naming.",
+					Type:        "string",
+					Schema:      `{"pattern":"^[a-z-]+$"}`,
+				},
+			},
+		},
+		{
+			name: "invalid parameter schema",
+			clusterState: []runtime.Object{
+				makeCAPITemplate(t, func(ct *capiv1.CAPITemplate) {
+					ct.Annotations = map[string]string{
+						templates.ParamSchemasAnnotation: "CLUSTER_NAME:\n  type: number\n",
+					}
+				}),
+			},
+			err: errors.New(`rpc error: code = FailedPrecondition desc = error looking up template params for cluster-template-1, invalid parameters: CLUSTER_NAME: unknown type "number"`),
+		},
+		{
+			name: "parameters of included templates",
+			clusterState: []runtime.Object{
+				makeCAPITemplate(t, func(ct *capiv1.CAPITemplate) {
+					ct.Annotations = map[string]string{
+						templates.IncludesAnnotation: "- name: region-fragment\n",
+					}
+				}),
+				makeRegionFragment(t),
+			},
+			expected: []*capiv1_protos.Parameter{
+				{
+					Name:        "REGION",
+					Description: "The region of the cluster.",
+					Required:    true,
+				},
+				{
+					Name:        "CLUSTER_NAME",
+					Description: "This is used for the cluster naming.",
+				},
+			},
+		},
+		{
+			name: "conflicting parameters of included templates",
+			clusterState: []runtime.Object{
+				makeCAPITemplate(t, func(ct *capiv1.CAPITemplate) {
+					ct.Annotations = map[string]string{
+						templates.IncludesAnnotation: "- name: region-fragment\n- name: other-region-fragment\n",
+					}
+				}),
+				makeRegionFragment(t),
+				makeRegionFragment(t, func(ct *capiv1.CAPITemplate) {
+					ct.ObjectMeta.Name = "other-region-fragment"
+					ct.Spec.Params[0].Required = false
+				}),
+			},
+			err: errors.New("error looking up template cluster-template-1: conflicting declarations of param REGION in templates CAPITemplate default/region-fragment and CAPITemplate default/other-region-fragment"),
+		},
 	}
 
 	for _, tt := range testCases {
@@ -1441,6 +1590,45 @@ func TestRenderTemplate_MissingRequiredVariable(t *testing.T) {
 	}
 }
 
+func TestRenderTemplate_InvalidParameterValues(t *testing.T) {
+	clusterState := []runtime.Object{
+		makeCAPITemplate(t, func(ct *capiv1.CAPITemplate) {
+			ct.Annotations = map[string]string{
+				templates.ParamSchemasAnnotation: "CLUSTER_NAME:\n  enum: [dev, prod]\n",
+			}
+		}),
+	}
+	s := createServer(t, serverOptions{
+		clusterState: clusterState,
+		namespace:    "default",
+	})
+
+	_, err := s.RenderTemplate(context.Background(), &capiv1_protos.RenderTemplateRequest{
+		Name:      "cluster-template-1",
+		Namespace: "default",
+		Values: map[string]string{
+			"CLUSTER_NAME": "test",
+		},
+	})
+
+	st, ok := grpcStatus.FromError(err)
+	if !ok {
+		t.Fatalf("expected a status error, got %v", err)
+	}
+	assert.Equal(t, codes.InvalidArgument, st.Code())
+	assert.Equal(t, `failed to render template with parameter values: error rendering template cluster-template-1, invalid parameters: CLUSTER_NAME: must be one of "dev", "prod"`, st.Message())
+
+	badRequest, ok := st.Details()[0].(*errdetails.BadRequest)
+	if !ok {
+		t.Fatalf("expected BadRequest details, got %v", st.Details())
+	}
+	if diff := cmp.Diff([]*errdetails.BadRequest_FieldViolation{
+		{Field: "values.CLUSTER_NAME", Description: `must be one of "dev", "prod"`},
+	}, badRequest.FieldViolations, protocmp.Transform()); diff != "" {
+		t.Fatalf("got the wrong field violations:\n%s", diff)
+	}
+}
+
 func TestRenderTemplate_ValidateVariables(t *testing.T) {
 	testCases := []struct {
 		name         string
@@ -1644,6 +1832,26 @@ status: {}
 	}
 }
 
+func makeRegionFragment(t *testing.T, opts ...func(*capiv1.CAPITemplate)) *capiv1.CAPITemplate {
+	t.Helper()
+	return makeCAPITemplate(t, append([]func(*capiv1.CAPITemplate){func(ct *capiv1.CAPITemplate) {
+		ct.ObjectMeta.Name = "region-fragment"
+		ct.Annotations = map[string]string{templates.FragmentAnnotation: "true"}
+		ct.Spec.Params = []templatesv1.TemplateParam{
+			{
+				Name:        "REGION",
+				Description: "The region of the cluster.",
+				Required:    true,
+			},
+		}
+		ct.Spec.ResourceTemplates = []templatesv1.ResourceTemplate{
+			{
+				Content: []templatesv1.ResourceTemplateContent{{RawExtension: rawExtension(`{"apiVersion": "fooversion", "kind": "ConfigMap", "metadata": {"name": "${REGION}"}}`)}},
+			},
+		}
+	}}, opts...)...)
+}
+
 func makeTemplateWithProvider(t *testing.T, clusterKind string, opts ...func(*capiv1.CAPITemplate)) *capiv1.CAPITemplate {
 	t.Helper()
 	basicRaw := `
@@ -0,0 +1,267 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/spf13/viper"
+	"google.golang.org/grpc/codes"
+	grpcStatus "google.golang.org/grpc/status"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	csgit "github.com/weaveworks/weave-gitops-enterprise/cmd/clusters-service/pkg/git"
+	capiv1_proto "github.com/weaveworks/weave-gitops-enterprise/cmd/clusters-service/pkg/protos"
+	"github.com/weaveworks/weave-gitops-enterprise/pkg/cluster/fetcher"
+	"github.com/weaveworks/weave-gitops-enterprise/pkg/git"
+	"github.com/weaveworks/weave-gitops-enterprise/pkg/tenancy"
+	"github.com/weaveworks/weave-gitops/pkg/server/auth"
+)
+
+// CreateTenantsPullRequest renders the tenants declared by a tenancy file, and creates a pull request
+// that writes the resources of each tenant to a file of its own in the directory of each cluster.
+// The path of the file of a tenant only depends on its name, so that later pull requests update
+// the files rather than adding new ones.
+func (s *server) CreateTenantsPullRequest(ctx context.Context, msg *capiv1_proto.CreateTenantsPullRequestRequest) (*capiv1_proto.CreateTenantsPullRequestResponse, error) {
+	if msg.Tenants == "" {
+		return nil, grpcStatus.Error(codes.InvalidArgument, "tenants must be specified")
+	}
+
+	config, err := tenancy.ParseConfig([]byte(msg.Tenants))
+	if err != nil {
+		return nil, grpcStatus.Errorf(codes.InvalidArgument, "failed to parse tenants: %s", err)
+	}
+
+	tenantFiles, err := tenancy.TenantFiles(config)
+	if err != nil {
+		return nil, grpcStatus.Errorf(codes.InvalidArgument, "invalid tenants: %s", err)
+	}
+
+	clusters, err := s.getTenantsClusters(ctx, config, msg.Clusters)
+	if err != nil {
+		return nil, err
+	}
+
+	gp, err := getGitProvider(ctx, msg.RepositoryUrl)
+	if err != nil {
+		return nil, grpcStatus.Errorf(codes.Unauthenticated, "error creating pull request: %s", err.Error())
+	}
+
+	repositoryURL := viper.GetString("capi-templates-repository-url")
+	if msg.RepositoryUrl != "" {
+		repositoryURL = msg.RepositoryUrl
+	}
+	baseBranch := viper.GetString("capi-templates-repository-base-branch")
+	if msg.BaseBranch != "" {
+		baseBranch = msg.BaseBranch
+	}
+
+	_, err = s.provider.GetRepository(ctx, *gp, repositoryURL)
+	if err != nil {
+		return nil, grpcStatus.Errorf(codes.Unauthenticated, "failed to access repo %s: %s", repositoryURL, err)
+	}
+
+	tenants := []string{}
+	for tenant := range tenantFiles {
+		tenants = append(tenants, tenant)
+	}
+	sort.Strings(tenants)
+
+	var files []git.CommitFile
+	var clusterNames []string
+	for _, cluster := range clusters {
+		clusterNames = append(clusterNames, cluster.String())
+
+		declared := map[string]bool{}
+		for _, tenant := range tenants {
+			content := tenantFiles[tenant]
+			path := getTenantPath(cluster, tenant)
+			declared[path] = true
+
+			files = append(files, git.CommitFile{
+				Path:    path,
+				Content: &content,
+			})
+		}
+
+		if !msg.Prune {
+			continue
+		}
+
+		tenantsDirPath := getTenantsDirPath(cluster)
+		treeEntries, err := s.provider.GetTreeList(ctx, *gp, repositoryURL, baseBranch, tenantsDirPath, true)
+		if err != nil {
+			return nil, fmt.Errorf("error getting list of trees in repo: %s@%s: %w", repositoryURL, baseBranch, err)
+		}
+
+		for _, treeEntry := range treeEntries {
+			if declared[treeEntry.Path] || filepath.Dir(treeEntry.Path) != tenantsDirPath || filepath.Ext(treeEntry.Path) != ".yaml" {
+				continue
+			}
+
+			files = append(files, git.CommitFile{
+				Path:    treeEntry.Path,
+				Content: nil,
+			})
+		}
+	}
+
+	if msg.HeadBranch == "" {
+		msg.HeadBranch = getHash(msg.RepositoryUrl, strings.Join(clusterNames, ""), msg.Tenants, msg.BaseBranch)
+	}
+	if msg.Title == "" {
+		msg.Title = "Gitops add tenants"
+	}
+	if msg.Description == "" {
+		msg.Description = fmt.Sprintf("Pull request to add tenants to clusters: %s", strings.Join(clusterNames, ", "))
+	}
+	if msg.CommitMessage == "" {
+		msg.CommitMessage = "Add Tenants Manifests"
+	}
+
+	res, err := s.provider.WriteFilesToBranchAndCreatePullRequest(ctx, csgit.WriteFilesToBranchAndCreatePullRequestRequest{
+		GitProvider:   *gp,
+		RepositoryURL: repositoryURL,
+		HeadBranch:    msg.HeadBranch,
+		BaseBranch:    baseBranch,
+		Title:         msg.Title,
+		Description:   msg.Description,
+		CommitMessage: msg.CommitMessage,
+		Files:         files,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("unable to create pull request: %w", err)
+	}
+
+	return &capiv1_proto.CreateTenantsPullRequestResponse{
+		WebUrl: res.WebURL,
+	}, nil
+}
+
+// getTenantsClusters returns the clusters of the request, or the clusters that the tenancy file selects
+// when the request has none.
+func (s *server) getTenantsClusters(ctx context.Context, config *tenancy.Config, requested []*capiv1_proto.ClusterNamespacedName) ([]types.NamespacedName, error) {
+	if len(requested) > 0 {
+		clusters := []types.NamespacedName{}
+		for _, c := range requested {
+			if c.Name == "" {
+				return nil, grpcStatus.Error(codes.InvalidArgument, "cluster name must be specified")
+			}
+			clusters = append(clusters, types.NamespacedName{Namespace: c.Namespace, Name: c.Name})
+		}
+
+		return clusters, nil
+	}
+
+	if config.Clusters == nil {
+		return nil, grpcStatus.Error(codes.InvalidArgument, "at least one cluster must be specified, or selected by the tenants")
+	}
+
+	client, err := s.clientGetter.Client(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	clusters, err := tenancy.SelectClusters(ctx, client, *config.Clusters)
+	if err != nil {
+		return nil, grpcStatus.Errorf(codes.InvalidArgument, "failed to select clusters: %s", err)
+	}
+
+	if len(clusters) == 0 {
+		return nil, grpcStatus.Error(codes.InvalidArgument, "no clusters are selected by the tenants")
+	}
+
+	return clusters, nil
+}
+
+// CheckTenants compares the resources of the tenants declared by a tenancy file with the tenant resources
+// in the management cluster, or in the clusters that the tenancy file selects, and returns those that differ.
+func (s *server) CheckTenants(ctx context.Context, msg *capiv1_proto.CheckTenantsRequest) (*capiv1_proto.CheckTenantsResponse, error) {
+	if msg.Tenants == "" {
+		return nil, grpcStatus.Error(codes.InvalidArgument, "tenants must be specified")
+	}
+
+	config, err := tenancy.ParseConfig([]byte(msg.Tenants))
+	if err != nil {
+		return nil, grpcStatus.Errorf(codes.InvalidArgument, "failed to parse tenants: %s", err)
+	}
+
+	response := &capiv1_proto.CheckTenantsResponse{
+		Drifts: []*capiv1_proto.TenantDrift{},
+		Errors: []*capiv1_proto.ListError{},
+	}
+
+	if config.Clusters != nil {
+		clusters, err := s.getTenantsClusters(ctx, config, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		clientFor := func(ctx context.Context, cluster types.NamespacedName) (client.Client, error) {
+			clusterName := fetcher.ToClusterName(cluster)
+
+			clustersClient, err := s.clustersManager.GetImpersonatedClientForCluster(ctx, auth.Principal(ctx), clusterName)
+			if err != nil {
+				return nil, fmt.Errorf("error getting impersonating client: %w", err)
+			}
+
+			return clustersClient.Scoped(clusterName)
+		}
+
+		// The clusters that cannot be checked are reported, without losing the drifts of the others.
+		for _, result := range tenancy.CheckTenantsOfClusters(ctx, config, clusters, clientFor) {
+			if result.Err != nil {
+				response.Errors = append(response.Errors, &capiv1_proto.ListError{
+					ClusterName: result.Cluster.String(),
+					Message:     fmt.Sprintf("failed to check tenants: %s", result.Err),
+				})
+				continue
+			}
+
+			response.Drifts = append(response.Drifts, toTenantDrifts(result.Drifts, result.Cluster.String())...)
+		}
+
+		return response, nil
+	}
+
+	kubeClient, err := s.clientGetter.Client(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	drifts, err := tenancy.CheckTenants(ctx, config, kubeClient)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check tenants: %w", err)
+	}
+
+	response.Drifts = toTenantDrifts(drifts, "")
+
+	return response, nil
+}
+
+func toTenantDrifts(drifts []tenancy.Drift, cluster string) []*capiv1_proto.TenantDrift {
+	result := []*capiv1_proto.TenantDrift{}
+	for _, drift := range drifts {
+		fields := []*capiv1_proto.TenantFieldDiff{}
+		for _, field := range drift.Fields {
+			fields = append(fields, &capiv1_proto.TenantFieldDiff{
+				Path:     field.Path,
+				Expected: field.Expected,
+				Actual:   field.Actual,
+			})
+		}
+
+		result = append(result, &capiv1_proto.TenantDrift{
+			Type:    string(drift.Type),
+			Id:      drift.ID,
+			Tenant:  drift.Tenant,
+			Fields:  fields,
+			Cluster: cluster,
+		})
+	}
+
+	return result
+}
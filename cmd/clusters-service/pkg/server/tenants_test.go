@@ -0,0 +1,261 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	gitopsv1alpha1 "github.com/weaveworks/cluster-controller/api/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/weaveworks/weave-gitops-enterprise/cmd/clusters-service/pkg/git"
+	"github.com/weaveworks/weave-gitops-enterprise/cmd/clusters-service/pkg/git/gitfakes"
+	capiv1_protos "github.com/weaveworks/weave-gitops-enterprise/cmd/clusters-service/pkg/protos"
+	"github.com/weaveworks/weave-gitops/core/clustersmngr"
+	"github.com/weaveworks/weave-gitops/core/clustersmngr/clustersmngrfakes"
+)
+
+const testTenants = `
+tenants:
+  - name: foo-tenant
+    namespaces:
+    - foo-ns
+  - name: bar-tenant
+    namespaces:
+    - bar-ns
+`
+
+func TestCreateTenantsPullRequest(t *testing.T) {
+	viper.SetDefault("capi-repository-clusters-path", "clusters")
+
+	testCases := []struct {
+		name         string
+		clusterState []runtime.Object
+		provider     git.Provider
+		req          *capiv1_protos.CreateTenantsPullRequestRequest
+		// committed are the paths of the committed files, and whether they are deleted.
+		committed map[string]bool
+		err       string
+	}{
+		{
+			name: "no tenants",
+			req:  &capiv1_protos.CreateTenantsPullRequestRequest{},
+			err:  "rpc error: code = InvalidArgument desc = tenants must be specified",
+		},
+		{
+			name: "invalid tenants",
+			req: &capiv1_protos.CreateTenantsPullRequestRequest{
+				Tenants: "tenants:\n  - name: foo-tenant\n",
+			},
+			err: "rpc error: code = InvalidArgument desc = invalid tenants: failed to generate tenant output: 1 error occurred:\n\t* must provide at least one namespace\n\n",
+		},
+		{
+			name: "no clusters",
+			req: &capiv1_protos.CreateTenantsPullRequestRequest{
+				Tenants: testTenants,
+			},
+			err: "rpc error: code = InvalidArgument desc = at least one cluster must be specified, or selected by the tenants",
+		},
+		{
+			name:     "pull request failed",
+			provider: gitfakes.NewFakeGitProvider("", nil, errors.New("oops"), nil, nil),
+			req: &capiv1_protos.CreateTenantsPullRequestRequest{
+				RepositoryUrl: "https://github.com/org/repo.git",
+				Tenants:       testTenants,
+				Clusters:      []*capiv1_protos.ClusterNamespacedName{testNewClusterNamespacedName(t, "leaf-1", "default")},
+			},
+			err: "rpc error: code = Unauthenticated desc = failed to access repo https://github.com/org/repo.git: oops",
+		},
+		{
+			name:     "tenants of the requested clusters",
+			provider: gitfakes.NewFakeGitProvider("https://github.com/org/repo/pull/1", nil, nil, nil, nil),
+			req: &capiv1_protos.CreateTenantsPullRequestRequest{
+				RepositoryUrl: "https://github.com/org/repo.git",
+				Tenants:       testTenants,
+				Clusters: []*capiv1_protos.ClusterNamespacedName{
+					testNewClusterNamespacedName(t, "leaf-1", "default"),
+					testNewClusterNamespacedName(t, "management", ""),
+				},
+			},
+			committed: map[string]bool{
+				"clusters/default/leaf-1/tenants/bar-tenant.yaml": false,
+				"clusters/default/leaf-1/tenants/foo-tenant.yaml": false,
+				"clusters/management/tenants/bar-tenant.yaml":     false,
+				"clusters/management/tenants/foo-tenant.yaml":     false,
+			},
+		},
+		{
+			name: "tenants of the clusters selected by the tenancy file",
+			clusterState: []runtime.Object{
+				makeTestGitopsCluster(func(c *gitopsv1alpha1.GitopsCluster) {
+					c.Name = "leaf-1"
+					c.Namespace = "default"
+					c.Labels = map[string]string{"environment": "production"}
+				}),
+				makeTestGitopsCluster(func(c *gitopsv1alpha1.GitopsCluster) {
+					c.Name = "leaf-2"
+					c.Namespace = "default"
+				}),
+			},
+			provider: gitfakes.NewFakeGitProvider("https://github.com/org/repo/pull/1", nil, nil, nil, nil),
+			req: &capiv1_protos.CreateTenantsPullRequestRequest{
+				RepositoryUrl: "https://github.com/org/repo.git",
+				Tenants:       testTenants + "clusters:\n  selector:\n    matchLabels:\n      environment: production\n",
+			},
+			committed: map[string]bool{
+				"clusters/default/leaf-1/tenants/bar-tenant.yaml": false,
+				"clusters/default/leaf-1/tenants/foo-tenant.yaml": false,
+			},
+		},
+		{
+			name: "prune the tenants no longer declared",
+			provider: gitfakes.NewFakeGitProvider("https://github.com/org/repo/pull/1", nil, nil, []string{
+				"clusters/default/leaf-1/tenants/foo-tenant.yaml",
+				"clusters/default/leaf-1/tenants/old-tenant.yaml",
+				"clusters/default/leaf-1/tenants/README.md",
+				"clusters/default/leaf-1/tenants/nested/other.yaml",
+			}, nil),
+			req: &capiv1_protos.CreateTenantsPullRequestRequest{
+				RepositoryUrl: "https://github.com/org/repo.git",
+				Tenants:       testTenants,
+				Clusters:      []*capiv1_protos.ClusterNamespacedName{testNewClusterNamespacedName(t, "leaf-1", "default")},
+				Prune:         true,
+			},
+			committed: map[string]bool{
+				"clusters/default/leaf-1/tenants/bar-tenant.yaml": false,
+				"clusters/default/leaf-1/tenants/foo-tenant.yaml": false,
+				"clusters/default/leaf-1/tenants/old-tenant.yaml": true,
+			},
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			s := createServer(t, serverOptions{
+				clusterState: tt.clusterState,
+				namespace:    "default",
+				provider:     tt.provider,
+			})
+
+			res, err := s.CreateTenantsPullRequest(context.Background(), tt.req)
+			if tt.err != "" {
+				assert.EqualError(t, err, tt.err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, "https://github.com/org/repo/pull/1", res.WebUrl)
+
+			committed := map[string]bool{}
+			for _, f := range tt.provider.(*gitfakes.FakeGitProvider).CommittedFiles {
+				committed[f.Path] = f.Content == nil
+				if f.Content != nil {
+					assert.Contains(t, *f.Content, "toolkit.fluxcd.io/tenant")
+				}
+			}
+			assert.Equal(t, tt.committed, committed)
+		})
+	}
+}
+
+func TestCheckTenants(t *testing.T) {
+	s := createServer(t, serverOptions{
+		clusterState: []runtime.Object{
+			&corev1.Namespace{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:   "old-ns",
+					Labels: map[string]string{"toolkit.fluxcd.io/tenant": "old-tenant"},
+				},
+			},
+		},
+		namespace: "default",
+	})
+
+	_, err := s.CheckTenants(context.Background(), &capiv1_protos.CheckTenantsRequest{})
+	assert.EqualError(t, err, "rpc error: code = InvalidArgument desc = tenants must be specified")
+
+	res, err := s.CheckTenants(context.Background(), &capiv1_protos.CheckTenantsRequest{
+		Tenants: "tenants:\n  - name: foo-tenant\n    namespaces:\n    - foo-ns\n",
+	})
+	assert.NoError(t, err)
+
+	drifts := map[string]string{}
+	for _, drift := range res.Drifts {
+		drifts[drift.Id] = drift.Type
+	}
+	assert.Equal(t, map[string]string{
+		"namespace/foo-ns":                 "missing",
+		"foo-ns/serviceaccount/foo-tenant": "missing",
+		"foo-ns/rolebinding.rbac.authorization.k8s.io/foo-tenant-service-account-cluster-admin": "missing",
+		"policy.pac.weave.works/weave.policies.tenancy.foo-tenant-allowed-application-deploy":   "missing",
+		"namespace/old-ns": "extra",
+	}, drifts)
+}
+
+func TestCheckTenants_Clusters(t *testing.T) {
+	leafClient := createClient(t)
+	clientsPool := &clustersmngrfakes.FakeClientsPool{}
+	clientsPool.ClientStub = func(name string) (client.Client, error) {
+		if name == "default/leaf-1" {
+			return leafClient, nil
+		}
+		return nil, fmt.Errorf("cluster %s not found", name)
+	}
+	clustersManager := &clustersmngrfakes.FakeClustersManager{}
+	clustersManager.GetImpersonatedClientForClusterReturns(clustersmngr.NewClient(clientsPool, map[string][]corev1.Namespace{}, logr.Discard()), nil)
+
+	s := createServer(t, serverOptions{
+		clusterState: []runtime.Object{
+			makeTestGitopsCluster(func(c *gitopsv1alpha1.GitopsCluster) {
+				c.Name = "leaf-1"
+				c.Namespace = "default"
+			}),
+			makeTestGitopsCluster(func(c *gitopsv1alpha1.GitopsCluster) {
+				c.Name = "leaf-2"
+				c.Namespace = "default"
+			}),
+		},
+		clustersManager: clustersManager,
+		namespace:       "default",
+	})
+
+	res, err := s.CheckTenants(context.Background(), &capiv1_protos.CheckTenantsRequest{
+		Tenants: "tenants:\n  - name: foo-tenant\n    namespaces:\n    - foo-ns\nclusters:\n  names:\n  - default/leaf-1\n",
+	})
+	assert.NoError(t, err)
+
+	drifts := map[string]string{}
+	for _, drift := range res.Drifts {
+		drifts[drift.Cluster+" "+drift.Id] = drift.Type
+	}
+	assert.Equal(t, map[string]string{
+		"default/leaf-1 namespace/foo-ns":                                                                      "missing",
+		"default/leaf-1 foo-ns/serviceaccount/foo-tenant":                                                      "missing",
+		"default/leaf-1 foo-ns/rolebinding.rbac.authorization.k8s.io/foo-tenant-service-account-cluster-admin": "missing",
+		"default/leaf-1 policy.pac.weave.works/weave.policies.tenancy.foo-tenant-allowed-application-deploy":   "missing",
+	}, drifts)
+
+	assert.Empty(t, res.Errors)
+
+	// The clusters that cannot be checked are reported along with the drifts of the others.
+	res, err = s.CheckTenants(context.Background(), &capiv1_protos.CheckTenantsRequest{
+		Tenants: "tenants:\n  - name: foo-tenant\n    namespaces:\n    - foo-ns\nclusters:\n  names:\n  - default/leaf-2\n  - default/leaf-1\n",
+	})
+	assert.NoError(t, err)
+
+	clusters := map[string]bool{}
+	for _, drift := range res.Drifts {
+		clusters[drift.Cluster] = true
+	}
+	assert.Equal(t, map[string]bool{"default/leaf-1": true}, clusters)
+	assert.Len(t, res.Drifts, 4)
+	assert.Equal(t, []*capiv1_protos.ListError{
+		{ClusterName: "default/leaf-2", Message: "failed to check tenants: cluster default/leaf-2 not found"},
+	}, res.Errors)
+}
@@ -0,0 +1,450 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"crypto/md5"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"sort"
+
+	"github.com/spf13/viper"
+	capiv1 "github.com/weaveworks/templates-controller/apis/capi/v1alpha2"
+	templatesv1 "github.com/weaveworks/templates-controller/apis/core"
+	"google.golang.org/grpc/codes"
+	grpcStatus "google.golang.org/grpc/status"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	k8syaml "k8s.io/apimachinery/pkg/util/yaml"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	csgit "github.com/weaveworks/weave-gitops-enterprise/cmd/clusters-service/pkg/git"
+	capiv1_proto "github.com/weaveworks/weave-gitops-enterprise/cmd/clusters-service/pkg/protos"
+	"github.com/weaveworks/weave-gitops-enterprise/cmd/clusters-service/pkg/templates"
+	"github.com/weaveworks/weave-gitops-enterprise/pkg/git"
+)
+
+// templateResource is a resource created from a template, found in the
+// repository.
+type templateResource struct {
+	// path is the path of the file that declares the resource, whose first
+	// object is annotated with the files created with it.
+	path         string
+	version      string
+	request      *capiv1_proto.CreatePullRequestRequest
+	createdFiles []string
+	// checksums are the checksums of the created files when they were
+	// rendered, if they were recorded.
+	checksums map[string]string
+}
+
+// CreateUpgradePullRequest finds the resources created from other versions of
+// a template in the repository, renders them again with the values they were
+// created with against the current version of the template, and creates pull
+// requests with the files that change.
+//
+// Files that were changed in the repository since they were rendered can't be
+// merged with the new render, as the render they were changed from is lost
+// with the previous version of the template. They are overwritten, so that
+// the pull requests show the changes for review, and they are listed in the
+// edited files of the upgrades when their checksums were recorded.
+func (s *server) CreateUpgradePullRequest(ctx context.Context, msg *capiv1_proto.CreateUpgradePullRequestRequest) (*capiv1_proto.CreateUpgradePullRequestResponse, error) {
+	if msg.TemplateKind == "" {
+		msg.TemplateKind = capiv1.Kind
+	}
+	if msg.Name == "" {
+		return nil, grpcStatus.Error(codes.InvalidArgument, "template name must be specified")
+	}
+	if msg.Path == "" {
+		return nil, grpcStatus.Error(codes.InvalidArgument, "path must be specified")
+	}
+
+	gp, err := getGitProvider(ctx, msg.RepositoryUrl)
+	if err != nil {
+		return nil, grpcStatus.Errorf(codes.Unauthenticated, "error creating pull request: %s", err.Error())
+	}
+
+	tmpl, err := s.getTemplate(ctx, msg.Name, msg.Namespace, msg.TemplateKind)
+	if err != nil {
+		return nil, fmt.Errorf("error looking up template %v: %v", msg.Name, err)
+	}
+	if tmpl == nil {
+		return nil, grpcStatus.Errorf(codes.InvalidArgument, "unknown template kind %q", msg.TemplateKind)
+	}
+	flavour, err := templates.FlavourFromTemplate(tmpl)
+	if err != nil {
+		return nil, fmt.Errorf("error getting the version of template %v: %w", msg.Name, err)
+	}
+	version := flavour.Version
+
+	repositoryURL := viper.GetString("capi-templates-repository-url")
+	if msg.RepositoryUrl != "" {
+		repositoryURL = msg.RepositoryUrl
+	}
+	baseBranch := viper.GetString("capi-templates-repository-base-branch")
+	if msg.BaseBranch != "" {
+		baseBranch = msg.BaseBranch
+	}
+
+	_, err = s.provider.GetRepository(ctx, *gp, repositoryURL)
+	if err != nil {
+		return nil, grpcStatus.Errorf(codes.Unauthenticated, "failed to access repo %s: %s", repositoryURL, err)
+	}
+
+	resources, err := s.findTemplateResources(ctx, *gp, repositoryURL, baseBranch, msg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find the resources created from template %s in %s: %w", msg.Name, repositoryURL, err)
+	}
+
+	client, err := s.clientGetter.Client(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &capiv1_proto.CreateUpgradePullRequestResponse{
+		Version:  version,
+		Upgrades: []*capiv1_proto.TemplateResourceUpgrade{},
+	}
+	upgradedFiles := map[string][]git.CommitFile{}
+	for _, resource := range resources {
+		if version != "" && resource.version == version {
+			continue
+		}
+
+		files, editedFiles, err := s.getTemplateResourceUpgrade(ctx, client, *gp, repositoryURL, baseBranch, tmpl, resource)
+		if err != nil {
+			return nil, err
+		}
+		if len(files) == 0 {
+			continue
+		}
+
+		upgradedFiles[resource.path] = files
+		response.Upgrades = append(response.Upgrades, &capiv1_proto.TemplateResourceUpgrade{
+			Path:        resource.path,
+			Version:     resource.version,
+			Files:       toUpgradedFileProtos(files),
+			EditedFiles: editedFiles,
+		})
+	}
+
+	if msg.DryRun || len(response.Upgrades) == 0 {
+		return response, nil
+	}
+
+	if msg.Title == "" {
+		msg.Title = fmt.Sprintf("Gitops upgrade resources of template %s", msg.Name)
+		if version != "" {
+			msg.Title = fmt.Sprintf("%s to version %s", msg.Title, version)
+		}
+	}
+	if msg.CommitMessage == "" {
+		msg.CommitMessage = "Upgrade Template Manifests"
+	}
+
+	if !msg.PullRequestPerResource {
+		files := []git.CommitFile{}
+		paths := []string{}
+		editedFiles := []string{}
+		for _, upgrade := range response.Upgrades {
+			files = append(files, upgradedFiles[upgrade.Path]...)
+			paths = append(paths, upgrade.Path)
+			editedFiles = append(editedFiles, upgrade.EditedFiles...)
+		}
+
+		headBranch := msg.HeadBranch
+		if headBranch == "" {
+			headBranch = getHash(repositoryURL, msg.Namespace, msg.Name, version, baseBranch)
+		}
+		description := msg.Description
+		if description == "" {
+			description = fmt.Sprintf("Pull request to upgrade the resources of template %s declared by: %v", msg.Name, paths)
+		}
+		description += editedFilesDescription(editedFiles)
+
+		webURL, err := s.createUpgradePullRequest(ctx, *gp, repositoryURL, headBranch, baseBranch, msg.Title, description, msg.CommitMessage, files)
+		if err != nil {
+			return nil, err
+		}
+		for _, upgrade := range response.Upgrades {
+			upgrade.WebUrl = webURL
+		}
+
+		return response, nil
+	}
+
+	for _, upgrade := range response.Upgrades {
+		headBranch := getHash(repositoryURL, upgrade.Path, version, baseBranch)
+		if msg.HeadBranch != "" {
+			headBranch = fmt.Sprintf("%s-%.4x", msg.HeadBranch, md5.Sum([]byte(upgrade.Path)))
+		}
+		description := msg.Description
+		if description == "" {
+			description = fmt.Sprintf("Pull request to upgrade the resources of template %s declared by %s", msg.Name, upgrade.Path)
+		}
+		description += editedFilesDescription(upgrade.EditedFiles)
+
+		upgrade.WebUrl, err = s.createUpgradePullRequest(ctx, *gp, repositoryURL, headBranch, baseBranch, msg.Title, description, msg.CommitMessage, upgradedFiles[upgrade.Path])
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return response, nil
+}
+
+// editedFilesDescription returns the part of the description of a pull request
+// that warns about the changes to its files that it overwrites.
+func editedFilesDescription(editedFiles []string) string {
+	if len(editedFiles) == 0 {
+		return ""
+	}
+
+	return fmt.Sprintf("\n\nThe following files were changed in the repository since they were rendered, and these changes are overwritten: %v", editedFiles)
+}
+
+func (s *server) createUpgradePullRequest(ctx context.Context, gp csgit.GitProvider, repositoryURL, headBranch, baseBranch, title, description, commitMessage string, files []git.CommitFile) (string, error) {
+	res, err := s.provider.WriteFilesToBranchAndCreatePullRequest(ctx, csgit.WriteFilesToBranchAndCreatePullRequestRequest{
+		GitProvider:   gp,
+		RepositoryURL: repositoryURL,
+		HeadBranch:    headBranch,
+		BaseBranch:    baseBranch,
+		Title:         title,
+		Description:   description,
+		CommitMessage: commitMessage,
+		Files:         files,
+	})
+	if err != nil {
+		return "", fmt.Errorf("unable to create pull request for branch %q: %w", headBranch, err)
+	}
+
+	return res.WebURL, nil
+}
+
+// findTemplateResources finds the resources created from the template of the
+// request in the YAML files under the path of the request, by the
+// create-request annotation of the first object of each. Each file is fetched
+// from the git provider, hence the path being required.
+func (s *server) findTemplateResources(ctx context.Context, gp csgit.GitProvider, repositoryURL, branch string, msg *capiv1_proto.CreateUpgradePullRequestRequest) ([]templateResource, error) {
+	treeEntries, err := s.provider.GetTreeList(ctx, gp, repositoryURL, branch, msg.Path, true)
+	if err != nil {
+		return nil, fmt.Errorf("error getting list of trees in repo: %s@%s: %w", repositoryURL, branch, err)
+	}
+
+	paths := []string{}
+	for _, treeEntry := range treeEntries {
+		if ext := filepath.Ext(treeEntry.Path); ext == ".yaml" || ext == ".yml" {
+			paths = append(paths, treeEntry.Path)
+		}
+	}
+
+	files, err := s.provider.GetFiles(ctx, gp, repositoryURL, branch, paths)
+	if err != nil {
+		return nil, fmt.Errorf("error getting files in repo: %s@%s: %w", repositoryURL, branch, err)
+	}
+
+	resources := []templateResource{}
+	for _, f := range files {
+		if f.Content == nil {
+			continue
+		}
+
+		resource, err := parseTemplateResource(f.Path, *f.Content)
+		if err != nil {
+			s.log.V(1).Info("Skipping file that can't be parsed", "path", f.Path, "error", err)
+			continue
+		}
+		if resource == nil {
+			continue
+		}
+
+		templateKind := resource.request.TemplateKind
+		if templateKind == "" {
+			templateKind = capiv1.Kind
+		}
+		if resource.request.Name != msg.Name || resource.request.Namespace != msg.Namespace || templateKind != msg.TemplateKind {
+			continue
+		}
+
+		resources = append(resources, *resource)
+	}
+
+	sort.Slice(resources, func(i, j int) bool { return resources[i].path < resources[j].path })
+
+	return resources, nil
+}
+
+// parseTemplateResource returns the resource created from a template that the
+// file declares, if its objects are annotated with the files created with it.
+func parseTemplateResource(path, content string) (*templateResource, error) {
+	decoder := k8syaml.NewYAMLOrJSONDecoder(bytes.NewBufferString(content), 4096)
+	for {
+		var obj unstructured.Unstructured
+		if err := decoder.Decode(&obj.Object); err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil, nil
+			}
+			return nil, err
+		}
+
+		annotations := obj.GetAnnotations()
+		createRequest, createdFiles := annotations[templates.CreateRequestAnnotation], annotations[templates.CreatedFilesAnnotation]
+		if createRequest == "" || createdFiles == "" {
+			continue
+		}
+
+		var request capiv1_proto.CreatePullRequestRequest
+		if err := json.Unmarshal([]byte(createRequest), &request); err != nil {
+			return nil, fmt.Errorf("failed to parse %s annotation: %w", templates.CreateRequestAnnotation, err)
+		}
+
+		var created struct {
+			Files     []string          `json:"files"`
+			Checksums map[string]string `json:"checksums"`
+		}
+		if err := json.Unmarshal([]byte(createdFiles), &created); err != nil {
+			return nil, fmt.Errorf("failed to parse %s annotation: %w", templates.CreatedFilesAnnotation, err)
+		}
+
+		return &templateResource{
+			path:         path,
+			version:      obj.GetLabels()[templates.VersionLabel],
+			request:      &request,
+			createdFiles: created.Files,
+			checksums:    created.Checksums,
+		}, nil
+	}
+}
+
+// getTemplateResourceUpgrade renders the resource again with the values it
+// was created with, and returns the files that differ from the repository,
+// along with the files created with it that are no longer rendered. It also
+// returns the paths of those files that were changed in the repository since
+// they were rendered.
+func (s *server) getTemplateResourceUpgrade(ctx context.Context, cl client.Client, gp csgit.GitProvider, repositoryURL, branch string, tmpl templatesv1.Template, resource templateResource) ([]git.CommitFile, []string, error) {
+	req := resource.request
+	rendered, err := GetFiles(
+		ctx,
+		cl,
+		cl.RESTMapper(),
+		s.log,
+		s.estimator,
+		s.chartsCache,
+		types.NamespacedName{Name: s.cluster},
+		s.profileHelmRepository,
+		tmpl,
+		GetFilesRequest{
+			ClusterNamespace: getClusterNamespace(req.ParameterValues["NAMESPACE"]),
+			TemplateName:     req.Name,
+			ParameterValues:  req.ParameterValues,
+			Credentials:      req.Credentials,
+			Profiles:         req.Values,
+			Kustomizations:   req.Kustomizations,
+			ExternalSecrets:  req.ExternalSecrets,
+		},
+		req,
+	)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to render the resource declared by %s: %w", resource.path, err)
+	}
+
+	files := allFiles(rendered)
+	renderedPaths := sets.NewString(filePaths(files)...)
+	for _, path := range resource.createdFiles {
+		if !renderedPaths.Has(path) {
+			files = append(files, git.CommitFile{
+				Path:    path,
+				Content: nil,
+			})
+		}
+	}
+
+	current, err := s.provider.GetFiles(ctx, gp, repositoryURL, branch, filePaths(files))
+	if err != nil {
+		return nil, nil, fmt.Errorf("error getting files in repo: %s@%s: %w", repositoryURL, branch, err)
+	}
+	currentContents := map[string]string{}
+	for _, f := range current {
+		if f.Content != nil {
+			currentContents[f.Path] = *f.Content
+		}
+	}
+
+	changed := []git.CommitFile{}
+	edited := []string{}
+	for _, f := range files {
+		content, exists := currentContents[f.Path]
+		if f.Content == nil && !exists {
+			continue
+		}
+		if f.Content != nil && exists && content == *f.Content {
+			continue
+		}
+		changed = append(changed, f)
+
+		if checksum, ok := resource.checksums[f.Path]; ok && exists {
+			if current, err := renderedChecksum(content); err != nil || current != checksum {
+				edited = append(edited, f.Path)
+			}
+		}
+	}
+
+	return changed, edited, nil
+}
+
+// renderedChecksum returns the checksum of the objects that a rendered file
+// declares, without the annotations of the request they were rendered with,
+// so that it's left unchanged by editing the resources they declare.
+func renderedChecksum(content string) (string, error) {
+	objects := []map[string]any{}
+	decoder := k8syaml.NewYAMLOrJSONDecoder(bytes.NewBufferString(content), 4096)
+	for {
+		var obj unstructured.Unstructured
+		if err := decoder.Decode(&obj.Object); err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			return "", err
+		}
+		if obj.Object == nil {
+			continue
+		}
+
+		annotations := obj.GetAnnotations()
+		delete(annotations, templates.CreateRequestAnnotation)
+		delete(annotations, templates.CreatedFilesAnnotation)
+		if len(annotations) == 0 {
+			annotations = nil
+		}
+		obj.SetAnnotations(annotations)
+		objects = append(objects, obj.Object)
+	}
+
+	b, err := json.Marshal(objects)
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("%x", sha256.Sum256(b)), nil
+}
+
+func toUpgradedFileProtos(files []git.CommitFile) []*capiv1_proto.CommitFile {
+	protos := []*capiv1_proto.CommitFile{}
+	for _, f := range files {
+		content := ""
+		if f.Content != nil {
+			content = *f.Content
+		}
+		protos = append(protos, &capiv1_proto.CommitFile{
+			Path:    f.Path,
+			Content: content,
+		})
+	}
+
+	return protos
+}
@@ -0,0 +1,298 @@
+package server
+
+import (
+	"context"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	sourcev1 "github.com/fluxcd/source-controller/api/v1beta2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	capiv1 "github.com/weaveworks/templates-controller/apis/capi/v1alpha2"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/weaveworks/weave-gitops-enterprise/cmd/clusters-service/pkg/git/gitfakes"
+	capiv1_protos "github.com/weaveworks/weave-gitops-enterprise/cmd/clusters-service/pkg/protos"
+	"github.com/weaveworks/weave-gitops-enterprise/cmd/clusters-service/pkg/templates"
+	"github.com/weaveworks/weave-gitops-enterprise/pkg/helm"
+)
+
+func TestCreateUpgradePullRequest(t *testing.T) {
+	setViperWithTestCleanup(t, map[string]string{
+		"capi-repository-path":          "clusters/my-cluster/clusters",
+		"capi-repository-clusters-path": "clusters",
+		"add-bases-kustomization":       "disabled",
+		"capi-templates-namespace":      "default",
+	})
+
+	templateV1 := makeCAPITemplate(t, withTemplateVersion("1"))
+	templateV2 := makeCAPITemplate(t, withTemplateVersion("2"), func(c *capiv1.CAPITemplate) {
+		c.Spec.ResourceTemplates[0].Content[0].RawExtension = rawExtension(`{
+			"apiVersion": "fooversion",
+			"kind": "fookind",
+			"metadata": {
+				"name": "${CLUSTER_NAME}",
+				"annotations": {
+					"capi.weave.works/display-name": "ClusterName",
+					"example.com/upgraded": "true"
+				}
+			}
+		}`)
+	})
+
+	// The repository holds the resources created from the first version of
+	// the template, and a resource created from another template.
+	repository := map[string]string{
+		"clusters/README.yaml": "not: a resource\n",
+	}
+	for _, cluster := range []string{"dev", "prod"} {
+		for _, f := range createTemplateResource(t, templateV1, "cluster-template-1", cluster) {
+			repository[f.Path] = f.Content
+		}
+	}
+	for _, f := range createTemplateResource(t, makeCAPITemplate(t, func(c *capiv1.CAPITemplate) {
+		c.Name = "cluster-template-2"
+	}), "cluster-template-2", "staging") {
+		repository[f.Path] = f.Content
+	}
+
+	t.Run("dry run", func(t *testing.T) {
+		provider := newFakeGitProviderWithRepository("https://github.com/org/repo/pull/1", repository)
+		s := createUpgradeTestServer(t, templateV2, provider)
+
+		res, err := s.CreateUpgradePullRequest(context.Background(), &capiv1_protos.CreateUpgradePullRequestRequest{
+			RepositoryUrl: "https://github.com/org/repo.git",
+			BaseBranch:    "main",
+			Name:          "cluster-template-1",
+			Namespace:     "default",
+			Path:          "clusters",
+			DryRun:        true,
+		})
+		require.NoError(t, err)
+
+		assert.Equal(t, "2", res.Version)
+		assert.Equal(t, []string{
+			"clusters/my-cluster/clusters/default/dev.yaml",
+			"clusters/my-cluster/clusters/default/prod.yaml",
+		}, upgradePaths(res))
+		for _, upgrade := range res.Upgrades {
+			assert.Equal(t, "1", upgrade.Version)
+			assert.Empty(t, upgrade.WebUrl)
+			assert.Empty(t, upgrade.EditedFiles)
+			require.Len(t, upgrade.Files, 1)
+			assert.Contains(t, upgrade.Files[0].Content, "example.com/upgraded")
+			assert.Contains(t, upgrade.Files[0].Content, "templates.weave.works/template-version: \"2\"")
+		}
+		assert.Empty(t, provider.CommittedFiles)
+	})
+
+	t.Run("one pull request", func(t *testing.T) {
+		provider := newFakeGitProviderWithRepository("https://github.com/org/repo/pull/1", repository)
+		s := createUpgradeTestServer(t, templateV2, provider)
+
+		res, err := s.CreateUpgradePullRequest(context.Background(), &capiv1_protos.CreateUpgradePullRequestRequest{
+			RepositoryUrl: "https://github.com/org/repo.git",
+			BaseBranch:    "main",
+			Name:          "cluster-template-1",
+			Namespace:     "default",
+			Path:          "clusters",
+		})
+		require.NoError(t, err)
+
+		for _, upgrade := range res.Upgrades {
+			assert.Equal(t, "https://github.com/org/repo/pull/1", upgrade.WebUrl)
+		}
+		committed := []string{}
+		for _, f := range provider.GetCommittedFiles() {
+			committed = append(committed, f.Path)
+		}
+		assert.Equal(t, upgradePaths(res), committed)
+	})
+
+	t.Run("pull request per resource", func(t *testing.T) {
+		provider := newFakeGitProviderWithRepository("https://github.com/org/repo/pull/1", repository)
+		s := createUpgradeTestServer(t, templateV2, provider)
+
+		res, err := s.CreateUpgradePullRequest(context.Background(), &capiv1_protos.CreateUpgradePullRequestRequest{
+			RepositoryUrl:          "https://github.com/org/repo.git",
+			BaseBranch:             "main",
+			Name:                   "cluster-template-1",
+			Namespace:              "default",
+			Path:                   "clusters",
+			PullRequestPerResource: true,
+		})
+		require.NoError(t, err)
+
+		assert.Len(t, res.Upgrades, 2)
+		assert.Len(t, provider.CommittedFiles, 2)
+	})
+
+	t.Run("files edited in the repository", func(t *testing.T) {
+		edited := map[string]string{}
+		for path, content := range repository {
+			edited[path] = content
+		}
+		devPath := "clusters/my-cluster/clusters/default/dev.yaml"
+		edited[devPath] = strings.Replace(edited[devPath], "kind: fookind", "kind: barkind", 1)
+		provider := newFakeGitProviderWithRepository("https://github.com/org/repo/pull/1", edited)
+		s := createUpgradeTestServer(t, templateV2, provider)
+
+		res, err := s.CreateUpgradePullRequest(context.Background(), &capiv1_protos.CreateUpgradePullRequestRequest{
+			RepositoryUrl: "https://github.com/org/repo.git",
+			BaseBranch:    "main",
+			Name:          "cluster-template-1",
+			Namespace:     "default",
+			Path:          "clusters",
+			DryRun:        true,
+		})
+		require.NoError(t, err)
+
+		require.Len(t, res.Upgrades, 2)
+		assert.Equal(t, []string{devPath}, res.Upgrades[0].EditedFiles)
+		assert.Empty(t, res.Upgrades[1].EditedFiles)
+	})
+
+	t.Run("resources of the current version", func(t *testing.T) {
+		upgraded := map[string]string{}
+		for _, f := range createTemplateResource(t, templateV2, "cluster-template-1", "dev") {
+			upgraded[f.Path] = f.Content
+		}
+		provider := newFakeGitProviderWithRepository("https://github.com/org/repo/pull/1", upgraded)
+		s := createUpgradeTestServer(t, templateV2, provider)
+
+		res, err := s.CreateUpgradePullRequest(context.Background(), &capiv1_protos.CreateUpgradePullRequestRequest{
+			RepositoryUrl: "https://github.com/org/repo.git",
+			BaseBranch:    "main",
+			Name:          "cluster-template-1",
+			Namespace:     "default",
+			Path:          "clusters",
+		})
+		require.NoError(t, err)
+
+		assert.Empty(t, res.Upgrades)
+		assert.Empty(t, provider.CommittedFiles)
+	})
+
+	t.Run("template name must be specified", func(t *testing.T) {
+		s := createUpgradeTestServer(t, templateV2, newFakeGitProviderWithRepository("", nil))
+
+		_, err := s.CreateUpgradePullRequest(context.Background(), &capiv1_protos.CreateUpgradePullRequestRequest{
+			Namespace: "default",
+		})
+		assert.EqualError(t, err, "rpc error: code = InvalidArgument desc = template name must be specified")
+	})
+
+	t.Run("path must be specified", func(t *testing.T) {
+		s := createUpgradeTestServer(t, templateV2, newFakeGitProviderWithRepository("", nil))
+
+		_, err := s.CreateUpgradePullRequest(context.Background(), &capiv1_protos.CreateUpgradePullRequestRequest{
+			Name:      "cluster-template-1",
+			Namespace: "default",
+		})
+		assert.EqualError(t, err, "rpc error: code = InvalidArgument desc = path must be specified")
+	})
+}
+
+func withTemplateVersion(version string) func(*capiv1.CAPITemplate) {
+	return func(c *capiv1.CAPITemplate) {
+		c.Annotations = map[string]string{
+			templates.VersionAnnotation: version,
+		}
+	}
+}
+
+// createTemplateResource creates a cluster from the template, and returns the
+// files of the pull request.
+func createTemplateResource(t *testing.T, tmpl *capiv1.CAPITemplate, name, cluster string) []*capiv1_protos.CommitFile {
+	t.Helper()
+	provider := gitfakes.NewFakeGitProvider("https://github.com/org/repo/pull/1", nil, nil, nil, nil).(*gitfakes.FakeGitProvider)
+	s := createUpgradeTestServer(t, tmpl, provider)
+
+	_, err := s.CreatePullRequest(context.Background(), &capiv1_protos.CreatePullRequestRequest{
+		Name:      name,
+		Namespace: "default",
+		ParameterValues: map[string]string{
+			"CLUSTER_NAME": cluster,
+			"NAMESPACE":    "default",
+		},
+		RepositoryUrl: "https://github.com/org/repo.git",
+		BaseBranch:    "main",
+		Credentials:   &capiv1_protos.Credential{},
+	})
+	require.NoError(t, err)
+
+	return provider.GetCommittedFiles()
+}
+
+func createUpgradeTestServer(t *testing.T, tmpl *capiv1.CAPITemplate, provider *gitfakes.FakeGitProvider) capiv1_protos.ClustersServiceServer {
+	t.Helper()
+	ts := httptest.NewServer(makeServeMux(t))
+	t.Cleanup(ts.Close)
+	hr := makeTestHelmRepository(ts.URL, func(hr *sourcev1.HelmRepository) {
+		hr.Name = "weaveworks-charts"
+		hr.Namespace = "default"
+	})
+	fakeCache := testNewFakeChartCache(t,
+		nsn("management", ""),
+		helm.ObjectReference{
+			Name:      "weaveworks-charts",
+			Namespace: "default",
+		},
+		[]helm.Chart{})
+
+	return createServer(t, serverOptions{
+		profileHelmRepository: &types.NamespacedName{Name: "weaveworks-charts", Namespace: "default"},
+		clusterState:          []runtime.Object{tmpl, hr},
+		namespace:             "default",
+		provider:              provider,
+		chartsCache:           fakeCache,
+	})
+}
+
+func newFakeGitProviderWithRepository(url string, contents map[string]string) *gitfakes.FakeGitProvider {
+	paths := []string{}
+	for path := range contents {
+		paths = append(paths, path)
+	}
+	provider := gitfakes.NewFakeGitProvider(url, nil, nil, paths, nil).(*gitfakes.FakeGitProvider)
+	provider.OriginalContents = contents
+	return provider
+}
+
+func upgradePaths(res *capiv1_protos.CreateUpgradePullRequestResponse) []string {
+	paths := []string{}
+	for _, upgrade := range res.Upgrades {
+		paths = append(paths, upgrade.Path)
+	}
+	return paths
+}
+
+func TestParseTemplateResource(t *testing.T) {
+	resource, err := parseTemplateResource("clusters/dev.yaml", strings.Join([]string{
+		"apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: other\n",
+		`apiVersion: fooversion
+kind: fookind
+metadata:
+  name: dev
+  annotations:
+    templates.weave.works/create-request: '{"name":"cluster-template-1","namespace":"default","parameter_values":{"CLUSTER_NAME":"dev"}}'
+    templates.weave.works/created-files: '{"files":["clusters/dev.yaml"],"checksums":{"clusters/dev.yaml":"abc"}}'
+  labels:
+    templates.weave.works/template-version: "1"
+`,
+	}, "---\n"))
+	require.NoError(t, err)
+
+	assert.Equal(t, "clusters/dev.yaml", resource.path)
+	assert.Equal(t, "1", resource.version)
+	assert.Equal(t, "cluster-template-1", resource.request.Name)
+	assert.Equal(t, map[string]string{"CLUSTER_NAME": "dev"}, resource.request.ParameterValues)
+	assert.Equal(t, []string{"clusters/dev.yaml"}, resource.createdFiles)
+	assert.Equal(t, map[string]string{"clusters/dev.yaml": "abc"}, resource.checksums)
+
+	resource, err = parseTemplateResource("clusters/other.yaml", "apiVersion: v1\nkind: ConfigMap\n")
+	require.NoError(t, err)
+	assert.Nil(t, resource)
+}
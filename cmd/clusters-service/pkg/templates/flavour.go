@@ -1,5 +1,7 @@
 package templates
 
+import templatesv1 "github.com/weaveworks/templates-controller/apis/core"
+
 // Flavour represents a specific template.
 type Flavour struct {
 	Name        string  `json:"name"`
@@ -15,4 +17,23 @@ type Param struct {
 	Required    bool     `json:"required"`
 	Options     []string `json:"options"`
 	Default     string   `json:"default"`
+	// Schema is the type of the parameter, if declared.
+	Schema *ParamSchema `json:"schema,omitempty"`
+}
+
+// FlavourFromTemplate returns the flavour of a template. Templates have no
+// version field, so its version is the value of the VersionAnnotation of the
+// template, if any.
+func FlavourFromTemplate(t templatesv1.Template) (*Flavour, error) {
+	params, err := ParamsFromTemplate(t)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Flavour{
+		Name:        t.GetName(),
+		Description: t.GetSpec().Description,
+		Params:      params,
+		Version:     t.GetAnnotations()[VersionAnnotation],
+	}, nil
 }
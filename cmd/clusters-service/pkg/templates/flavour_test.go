@@ -0,0 +1,31 @@
+package templates
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestFlavourFromTemplate(t *testing.T) {
+	parsed := parseCAPITemplateFromFile(t, "testdata/template1.yaml")
+	parsed.Annotations = map[string]string{
+		VersionAnnotation: "1.2.0",
+	}
+
+	got, err := FlavourFromTemplate(parsed)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := &Flavour{
+		Name:        "cluster-template",
+		Description: "this is test template 1",
+		Params: []Param{
+			{Name: "CLUSTER_NAME", Description: "This is used for the cluster naming."},
+		},
+		Version: "1.2.0",
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Fatalf("failed to get flavour from template:\n%s", diff)
+	}
+}
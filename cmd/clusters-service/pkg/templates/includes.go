@@ -0,0 +1,345 @@
+package templates
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+
+	capiv1 "github.com/weaveworks/templates-controller/apis/capi/v1alpha2"
+	templatesv1 "github.com/weaveworks/templates-controller/apis/core"
+	gapiv1 "github.com/weaveworks/templates-controller/apis/gitops/v1alpha2"
+	"sigs.k8s.io/yaml"
+)
+
+// IncludesAnnotation can be added to a Template to include the resource
+// templates, params, param schemas and charts of other templates.
+//
+// It's assumed to be a YAML list of references to templates, e.g.
+//
+//	templates.weave.works/includes: |
+//	  - name: common-kustomizations
+//	  - kind: GitOpsTemplate
+//	    name: network-policies
+//	    namespace: platform
+//
+// The kind and namespace default to those of the including template.
+const IncludesAnnotation string = "templates.weave.works/includes"
+
+// FragmentAnnotation marks a Template as a fragment, that is only meant to be
+// included by other templates, and so is not listed with the templates.
+const FragmentAnnotation string = "templates.weave.works/fragment"
+
+// TemplateRef refers to a template that is included by another.
+type TemplateRef struct {
+	Kind      string `json:"kind,omitempty"`
+	Name      string `json:"name"`
+	Namespace string `json:"namespace,omitempty"`
+}
+
+func (r TemplateRef) String() string {
+	if r.Namespace == "" {
+		return fmt.Sprintf("%s %s", r.Kind, r.Name)
+	}
+
+	return fmt.Sprintf("%s %s/%s", r.Kind, r.Namespace, r.Name)
+}
+
+// TemplateGetter gets the templates that are included by other templates.
+type TemplateGetter func(ctx context.Context, ref TemplateRef) (templatesv1.Template, error)
+
+// IsFragment returns true if the template is only meant to be included by
+// other templates.
+func IsFragment(t templatesv1.Template) bool {
+	return t.GetAnnotations()[FragmentAnnotation] == "true"
+}
+
+// ResolveIncludes returns a copy of the template with the templates that it
+// includes merged in, or the template itself if it includes none.
+//
+// Included templates can include templates themselves, and each template is
+// only included once, after the templates that it includes. The resource
+// templates of included templates are rendered before those of the including
+// template, and their params, param schemas and charts are merged with those
+// of the including template, whose own declarations take precedence.
+// Declarations of the same param, schema or chart that differ between
+// included templates are conflicts.
+func ResolveIncludes(ctx context.Context, t templatesv1.Template, get TemplateGetter) (templatesv1.Template, error) {
+	c := &includesCollector{get: get, seen: map[string]bool{}}
+	if err := c.collect(ctx, t, []string{templateRef(t).String()}); err != nil {
+		return nil, err
+	}
+	if len(c.included) == 0 {
+		return t, nil
+	}
+
+	m := newTemplateMerger()
+	for _, included := range c.included {
+		if err := checkRenderedAlike(t, included); err != nil {
+			return nil, fmt.Errorf("cannot include template %s: %w", templateRef(included), err)
+		}
+		if err := m.include(templateRef(included).String(), included); err != nil {
+			return nil, err
+		}
+	}
+
+	return m.resolve(t)
+}
+
+// includesCollector collects the templates that a template includes, and
+// those that they include in turn.
+type includesCollector struct {
+	get      TemplateGetter
+	seen     map[string]bool
+	included []templatesv1.Template
+}
+
+// collect collects the templates included by the template, where chain is the
+// references of the templates that led to it.
+func (c *includesCollector) collect(ctx context.Context, t templatesv1.Template, chain []string) error {
+	refs, err := includedTemplates(t)
+	if err != nil {
+		return err
+	}
+
+	for _, ref := range refs {
+		if ref.Name == "" {
+			return fmt.Errorf("invalid %s annotation of %s: template name must be specified", IncludesAnnotation, templateRef(t))
+		}
+
+		for _, r := range chain {
+			if r == ref.String() {
+				return fmt.Errorf("templates include each other: %s -> %s", strings.Join(chain, " -> "), ref)
+			}
+		}
+
+		if c.seen[ref.String()] {
+			continue
+		}
+		c.seen[ref.String()] = true
+
+		included, err := c.get(ctx, ref)
+		if err != nil {
+			return fmt.Errorf("failed to get included template %s: %w", ref, err)
+		}
+
+		if err := c.collect(ctx, included, append(append([]string{}, chain...), ref.String())); err != nil {
+			return err
+		}
+		c.included = append(c.included, included)
+	}
+
+	return nil
+}
+
+// includedTemplates returns the references of the includes annotation, with
+// the defaults of the including template.
+func includedTemplates(t templatesv1.Template) ([]TemplateRef, error) {
+	ann, ok := t.GetAnnotations()[IncludesAnnotation]
+	if !ok {
+		return nil, nil
+	}
+
+	var refs []TemplateRef
+	if err := yaml.UnmarshalStrict([]byte(ann), &refs); err != nil {
+		return nil, fmt.Errorf("failed to parse %s annotation of %s: %w", IncludesAnnotation, templateRef(t), err)
+	}
+
+	self := templateRef(t)
+	for i := range refs {
+		if refs[i].Kind == "" {
+			refs[i].Kind = self.Kind
+		}
+		if refs[i].Namespace == "" {
+			refs[i].Namespace = self.Namespace
+		}
+	}
+
+	return refs, nil
+}
+
+func templateRef(t templatesv1.Template) TemplateRef {
+	return TemplateRef{
+		Kind:      t.GetObjectKind().GroupVersionKind().Kind,
+		Name:      t.GetName(),
+		Namespace: t.GetNamespace(),
+	}
+}
+
+// checkRenderedAlike checks that the included template is rendered the same
+// way as the including template, as they are rendered together.
+func checkRenderedAlike(t, included templatesv1.Template) error {
+	renderType := func(t templatesv1.Template) string {
+		if t.GetSpec().RenderType == "" {
+			return templatesv1.RenderTypeEnvsubst
+		}
+		return t.GetSpec().RenderType
+	}
+	if renderType(t) != renderType(included) {
+		return fmt.Errorf("renderType %s differs from %s", renderType(included), renderType(t))
+	}
+
+	delims := t.GetAnnotations()[TemplateDelimiterAnnotation]
+	if includedDelims := included.GetAnnotations()[TemplateDelimiterAnnotation]; includedDelims != delims {
+		return fmt.Errorf("delimiters %q differ from %q", includedDelims, delims)
+	}
+
+	return nil
+}
+
+// templateMerger merges the declarations of the included templates, and
+// records where each came from to report conflicts.
+type templateMerger struct {
+	resourceTemplates []templatesv1.ResourceTemplate
+	params            map[string]templatesv1.TemplateParam
+	paramOrder        []string
+	schemas           map[string]ParamSchema
+	charts            map[string]templatesv1.Chart
+	chartOrder        []string
+	helmRepoPath      string
+	sources           map[string]string
+}
+
+func newTemplateMerger() *templateMerger {
+	return &templateMerger{
+		params:  map[string]templatesv1.TemplateParam{},
+		schemas: map[string]ParamSchema{},
+		charts:  map[string]templatesv1.Chart{},
+		sources: map[string]string{},
+	}
+}
+
+// declare records the declaration of the key by the source, or returns an
+// error if another source declared it differently.
+func (m *templateMerger) declare(key, source string, declared bool, same bool) error {
+	if declared && !same {
+		return fmt.Errorf("conflicting declarations of %s in templates %s and %s", key, m.sources[key], source)
+	}
+	if !declared {
+		m.sources[key] = source
+	}
+
+	return nil
+}
+
+func (m *templateMerger) include(source string, t templatesv1.Template) error {
+	spec := t.GetSpec()
+	m.resourceTemplates = append(m.resourceTemplates, spec.ResourceTemplates...)
+
+	for _, param := range spec.Params {
+		existing, ok := m.params[param.Name]
+		if err := m.declare("param "+param.Name, source, ok, reflect.DeepEqual(existing, param)); err != nil {
+			return err
+		}
+		if !ok {
+			m.params[param.Name] = param
+			m.paramOrder = append(m.paramOrder, param.Name)
+		}
+	}
+
+	schemas, err := paramSchemas(t)
+	if err != nil {
+		return err
+	}
+	for _, name := range sortedKeys(schemas) {
+		existing, ok := m.schemas[name]
+		if err := m.declare("the schema of param "+name, source, ok, toJSON(existing) == toJSON(schemas[name])); err != nil {
+			return err
+		}
+		m.schemas[name] = schemas[name]
+	}
+
+	for _, chart := range spec.Charts.Items {
+		existing, ok := m.charts[chart.Chart]
+		if err := m.declare("chart "+chart.Chart, source, ok, reflect.DeepEqual(existing, chart)); err != nil {
+			return err
+		}
+		if !ok {
+			m.charts[chart.Chart] = chart
+			m.chartOrder = append(m.chartOrder, chart.Chart)
+		}
+	}
+
+	if path := spec.Charts.HelmRepositoryTemplate.Path; path != "" {
+		key := "the helmRepositoryTemplate path"
+		if err := m.declare(key, source, m.helmRepoPath != "", m.helmRepoPath == path); err != nil {
+			return err
+		}
+		m.helmRepoPath = path
+	}
+
+	return nil
+}
+
+// resolve returns a copy of the template with the declarations of the
+// included templates merged in.
+func (m *templateMerger) resolve(t templatesv1.Template) (templatesv1.Template, error) {
+	spec := t.GetSpec()
+
+	own := map[string]bool{}
+	for _, param := range spec.Params {
+		own[param.Name] = true
+	}
+	params := []templatesv1.TemplateParam{}
+	for _, name := range m.paramOrder {
+		if !own[name] {
+			params = append(params, m.params[name])
+		}
+	}
+	spec.Params = append(params, spec.Params...)
+
+	ownCharts := map[string]bool{}
+	for _, chart := range spec.Charts.Items {
+		ownCharts[chart.Chart] = true
+	}
+	charts := []templatesv1.Chart{}
+	for _, name := range m.chartOrder {
+		if !ownCharts[name] {
+			charts = append(charts, m.charts[name])
+		}
+	}
+	spec.Charts.Items = append(charts, spec.Charts.Items...)
+
+	if spec.Charts.HelmRepositoryTemplate.Path == "" {
+		spec.Charts.HelmRepositoryTemplate.Path = m.helmRepoPath
+	}
+
+	spec.ResourceTemplates = append(append([]templatesv1.ResourceTemplate{}, m.resourceTemplates...), spec.ResourceTemplates...)
+
+	annotations := map[string]string{}
+	for k, v := range t.GetAnnotations() {
+		annotations[k] = v
+	}
+	// The includes are resolved, so resolving the copy again changes nothing.
+	delete(annotations, IncludesAnnotation)
+
+	schemas, err := paramSchemas(t)
+	if err != nil {
+		return nil, err
+	}
+	for name, schema := range schemas {
+		m.schemas[name] = schema
+	}
+	if len(m.schemas) > 0 {
+		b, err := yaml.Marshal(m.schemas)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal param schemas: %w", err)
+		}
+		annotations[ParamSchemasAnnotation] = string(b)
+	}
+
+	switch t := t.(type) {
+	case *capiv1.CAPITemplate:
+		resolved := t.DeepCopy()
+		resolved.Spec = spec
+		resolved.SetAnnotations(annotations)
+		return resolved, nil
+	case *gapiv1.GitOpsTemplate:
+		resolved := t.DeepCopy()
+		resolved.Spec = spec
+		resolved.SetAnnotations(annotations)
+		return resolved, nil
+	}
+
+	return nil, fmt.Errorf("cannot include templates in %s, of type %T", templateRef(t), t)
+}
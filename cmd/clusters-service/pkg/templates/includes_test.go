@@ -0,0 +1,391 @@
+package templates
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	templatesv1 "github.com/weaveworks/templates-controller/apis/core"
+	gapiv1 "github.com/weaveworks/templates-controller/apis/gitops/v1alpha2"
+	"sigs.k8s.io/yaml"
+)
+
+const includingTemplate = `
+apiVersion: templates.weave.works/v1alpha2
+kind: GitOpsTemplate
+metadata:
+  name: app-template
+  namespace: default
+  annotations:
+    templates.weave.works/includes: |
+      - name: network-policies
+      - name: common-kustomizations
+        namespace: platform
+    templates.weave.works/param-schemas: |
+      REPLICAS:
+        type: integer
+spec:
+  params:
+    - name: NAMESPACE
+      description: The namespace of the app
+  charts:
+    items:
+      - chart: podinfo
+        version: 6.0.0
+  resourcetemplates:
+  - content:
+    - apiVersion: apps/v1
+      kind: Deployment
+      metadata:
+        name: "${APP_NAME}"
+        namespace: "${NAMESPACE}"
+      spec:
+        replicas: "${REPLICAS}"
+`
+
+const networkPoliciesFragment = `
+apiVersion: templates.weave.works/v1alpha2
+kind: GitOpsTemplate
+metadata:
+  name: network-policies
+  namespace: default
+  annotations:
+    templates.weave.works/fragment: "true"
+spec:
+  params:
+    - name: NAMESPACE
+      description: The namespace of the policies
+  resourcetemplates:
+  - content:
+    - apiVersion: networking.k8s.io/v1
+      kind: NetworkPolicy
+      metadata:
+        name: deny-all
+        namespace: "${NAMESPACE}"
+`
+
+const commonKustomizationsFragment = `
+apiVersion: templates.weave.works/v1alpha2
+kind: GitOpsTemplate
+metadata:
+  name: common-kustomizations
+  namespace: platform
+  annotations:
+    templates.weave.works/fragment: "true"
+    templates.weave.works/param-schemas: |
+      INTERVAL:
+        pattern: ^[0-9]+m$
+spec:
+  params:
+    - name: INTERVAL
+      default: 10m
+  charts:
+    items:
+      - chart: podinfo
+        version: 5.0.0
+      - chart: cert-manager
+        version: 1.0.0
+  resourcetemplates:
+  - content:
+    - apiVersion: kustomize.toolkit.fluxcd.io/v1beta2
+      kind: Kustomization
+      metadata:
+        name: "${APP_NAME}-common"
+        namespace: flux-system
+      spec:
+        interval: "${INTERVAL}"
+`
+
+func TestResolveIncludes(t *testing.T) {
+	get := fakeTemplateGetter(t, networkPoliciesFragment, commonKustomizationsFragment)
+	tmpl := parseGitOpsTemplateFromBytes(t, includingTemplate)
+
+	resolved, err := ResolveIncludes(context.Background(), tmpl, get)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, ok := resolved.GetAnnotations()[IncludesAnnotation]; ok {
+		t.Fatalf("resolved template still has the %s annotation", IncludesAnnotation)
+	}
+	if _, ok := tmpl.GetAnnotations()[IncludesAnnotation]; !ok {
+		t.Fatalf("the %s annotation was removed from the including template", IncludesAnnotation)
+	}
+
+	spec := resolved.GetSpec()
+	wantParams := []templatesv1.TemplateParam{
+		{Name: "INTERVAL", Default: "10m"},
+		{Name: "NAMESPACE", Description: "The namespace of the app"},
+	}
+	if diff := cmp.Diff(wantParams, spec.Params); diff != "" {
+		t.Fatalf("wrong params:\n%s", diff)
+	}
+
+	charts := []string{}
+	for _, chart := range spec.Charts.Items {
+		charts = append(charts, chart.Chart+"@"+chart.Version)
+	}
+	if diff := cmp.Diff([]string{"cert-manager@1.0.0", "podinfo@6.0.0"}, charts); diff != "" {
+		t.Fatalf("wrong charts:\n%s", diff)
+	}
+
+	rendered, err := mustNewProcessorForTemplate(t, resolved).RenderTemplates(map[string]string{
+		"APP_NAME":  "podinfo",
+		"NAMESPACE": "apps",
+		"REPLICAS":  "2",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	kinds := []string{}
+	for _, rt := range rendered {
+		for _, data := range rt.Data {
+			var obj map[string]interface{}
+			if err := yaml.Unmarshal(data, &obj); err != nil {
+				t.Fatal(err)
+			}
+			kinds = append(kinds, fmt.Sprint(obj["kind"]))
+		}
+	}
+	if diff := cmp.Diff([]string{"NetworkPolicy", "Kustomization", "Deployment"}, kinds); diff != "" {
+		t.Fatalf("wrong rendered resources:\n%s", diff)
+	}
+
+	params, err := ParamsFromTemplate(resolved)
+	if err != nil {
+		t.Fatal(err)
+	}
+	schemas := map[string]string{}
+	for _, p := range params {
+		if p.Schema != nil {
+			schemas[p.Name] = p.Schema.typeName()
+		}
+	}
+	if diff := cmp.Diff(map[string]string{"INTERVAL": "string", "REPLICAS": "integer"}, schemas); diff != "" {
+		t.Fatalf("wrong param schemas:\n%s", diff)
+	}
+}
+
+func TestResolveIncludes_no_includes(t *testing.T) {
+	tmpl := parseGitOpsTemplateFromBytes(t, networkPoliciesFragment)
+
+	resolved, err := ResolveIncludes(context.Background(), tmpl, fakeTemplateGetter(t))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if resolved != templatesv1.Template(tmpl) {
+		t.Fatal("template without includes was copied")
+	}
+}
+
+func TestResolveIncludes_included_once(t *testing.T) {
+	get := fakeTemplateGetter(t, networkPoliciesFragment, `
+apiVersion: templates.weave.works/v1alpha2
+kind: GitOpsTemplate
+metadata:
+  name: namespace
+  namespace: default
+  annotations:
+    templates.weave.works/includes: |
+      - name: network-policies
+spec:
+  resourcetemplates:
+  - content:
+    - apiVersion: v1
+      kind: Namespace
+      metadata:
+        name: "${NAMESPACE}"
+`)
+	tmpl := parseGitOpsTemplateFromBytes(t, `
+apiVersion: templates.weave.works/v1alpha2
+kind: GitOpsTemplate
+metadata:
+  name: app-template
+  namespace: default
+  annotations:
+    templates.weave.works/includes: |
+      - name: namespace
+      - name: network-policies
+spec:
+  resourcetemplates: []
+`)
+
+	resolved, err := ResolveIncludes(context.Background(), tmpl, get)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if l := len(resolved.GetSpec().ResourceTemplates); l != 2 {
+		t.Fatalf("want 2 resource templates, got %d", l)
+	}
+}
+
+func TestResolveIncludes_errors(t *testing.T) {
+	includes := func(refs string) string {
+		return `
+apiVersion: templates.weave.works/v1alpha2
+kind: GitOpsTemplate
+metadata:
+  name: app-template
+  namespace: default
+  annotations:
+    templates.weave.works/includes: |
+      ` + refs + `
+spec:
+  resourcetemplates: []
+`
+	}
+
+	errorTests := []struct {
+		name      string
+		template  string
+		templates []string
+		wantErr   string
+	}{
+		{
+			name:     "invalid annotation",
+			template: includes("- template: network-policies"),
+			wantErr:  `failed to parse templates.weave.works/includes annotation of GitOpsTemplate default/app-template: error unmarshaling JSON: while decoding JSON: json: unknown field "template"`,
+		},
+		{
+			name:     "missing name",
+			template: includes("- namespace: platform"),
+			wantErr:  "invalid templates.weave.works/includes annotation of GitOpsTemplate default/app-template: template name must be specified",
+		},
+		{
+			name:     "missing template",
+			template: includes("- name: missing"),
+			wantErr:  "failed to get included template GitOpsTemplate default/missing: not found",
+		},
+		{
+			name:     "includes itself",
+			template: includes("- name: app-template"),
+			wantErr:  "templates include each other: GitOpsTemplate default/app-template -> GitOpsTemplate default/app-template",
+		},
+		{
+			name:     "include each other",
+			template: includes("- name: other-template"),
+			templates: []string{`
+apiVersion: templates.weave.works/v1alpha2
+kind: GitOpsTemplate
+metadata:
+  name: other-template
+  namespace: default
+  annotations:
+    templates.weave.works/includes: |
+      - name: app-template
+spec:
+  resourcetemplates: []
+`},
+			wantErr: "templates include each other: GitOpsTemplate default/app-template -> GitOpsTemplate default/other-template -> GitOpsTemplate default/app-template",
+		},
+		{
+			name:      "conflicting params",
+			template:  includes("- name: network-policies\n      - name: namespace-policies"),
+			templates: []string{networkPoliciesFragment, renamed(networkPoliciesFragment, "namespace-policies", "The namespace to protect")},
+			wantErr:   "conflicting declarations of param NAMESPACE in templates GitOpsTemplate default/network-policies and GitOpsTemplate default/namespace-policies",
+		},
+		{
+			name:     "conflicting charts",
+			template: includes("- name: common-kustomizations\n        namespace: platform\n      - name: podinfo"),
+			templates: []string{commonKustomizationsFragment, `
+apiVersion: templates.weave.works/v1alpha2
+kind: GitOpsTemplate
+metadata:
+  name: podinfo
+  namespace: default
+spec:
+  charts:
+    items:
+      - chart: podinfo
+        version: 6.0.0
+`},
+			wantErr: "conflicting declarations of chart podinfo in templates GitOpsTemplate platform/common-kustomizations and GitOpsTemplate default/podinfo",
+		},
+		{
+			name:     "different render type",
+			template: includes("- name: templated"),
+			templates: []string{`
+apiVersion: templates.weave.works/v1alpha2
+kind: GitOpsTemplate
+metadata:
+  name: templated
+  namespace: default
+spec:
+  renderType: templating
+`},
+			wantErr: "cannot include template GitOpsTemplate default/templated: renderType templating differs from envsubst",
+		},
+	}
+
+	for _, tt := range errorTests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpl := parseGitOpsTemplateFromBytes(t, tt.template)
+			get := fakeTemplateGetter(t, append(tt.templates, tt.template)...)
+
+			_, err := ResolveIncludes(context.Background(), tmpl, get)
+			if err == nil {
+				t.Fatal("want an error, got none")
+			}
+			if diff := cmp.Diff(tt.wantErr, err.Error()); diff != "" {
+				t.Fatalf("wrong error:\n%s", diff)
+			}
+		})
+	}
+}
+
+// renamed returns the template with another name, and another description
+// for its first param.
+func renamed(s, name, description string) string {
+	tmpl := &gapiv1.GitOpsTemplate{}
+	if err := yaml.Unmarshal([]byte(s), tmpl); err != nil {
+		panic(err)
+	}
+	tmpl.Name = name
+	tmpl.Spec.Params[0].Description = description
+
+	b, err := yaml.Marshal(tmpl)
+	if err != nil {
+		panic(err)
+	}
+
+	return string(b)
+}
+
+func fakeTemplateGetter(t *testing.T, templates ...string) TemplateGetter {
+	byRef := map[string]templatesv1.Template{}
+	for _, s := range templates {
+		tmpl := parseGitOpsTemplateFromBytes(t, s)
+		byRef[templateRef(tmpl).String()] = tmpl
+	}
+
+	return func(_ context.Context, ref TemplateRef) (templatesv1.Template, error) {
+		if tmpl, ok := byRef[ref.String()]; ok {
+			return tmpl, nil
+		}
+		return nil, fmt.Errorf("not found")
+	}
+}
+
+func parseGitOpsTemplateFromBytes(t *testing.T, s string) *gapiv1.GitOpsTemplate {
+	t.Helper()
+	var tmpl gapiv1.GitOpsTemplate
+	if err := yaml.Unmarshal([]byte(s), &tmpl); err != nil {
+		t.Fatalf("failed to unmarshal: %s", err)
+	}
+
+	return &tmpl
+}
+
+func mustNewProcessorForTemplate(t *testing.T, tmpl templatesv1.Template) *TemplateProcessor {
+	t.Helper()
+	processor, err := NewProcessorForTemplate(tmpl)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return processor
+}
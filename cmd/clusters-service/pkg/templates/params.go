@@ -8,6 +8,9 @@ import templatesv1 "github.com/weaveworks/templates-controller/apis/core"
 //
 // Any fields in the templates, but not in the params will not be enriched, and
 // only the name will be returned.
+//
+// The params of included templates are only found once the includes are
+// resolved with ResolveIncludes.
 func ParamsFromTemplate(t templatesv1.Template) ([]Param, error) {
 	proc, err := NewProcessorForTemplate(t)
 	if err != nil {
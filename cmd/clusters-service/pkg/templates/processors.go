@@ -34,8 +34,9 @@ type RenderedTemplate struct {
 // Processor is a generic template parser/renderer.
 type Processor interface {
 	// Render implementations accept a template and a key/value map of params to
-	// render and render the template.
-	Render([]byte, map[string]string) ([]byte, error)
+	// render and render the template. The values are of the types declared by
+	// the schemas of the params.
+	Render([]byte, map[string]interface{}) ([]byte, error)
 	// ParamNames implementations parse a resource template and extract the
 	// parameters.
 	ParamNames([]byte) ([]string, error)
@@ -158,6 +159,37 @@ func (p TemplateProcessor) Params() ([]Param, error) {
 		}
 	}
 
+	schemas, err := paramSchemas(p)
+	if err != nil {
+		return nil, err
+	}
+
+	var schemaErrs ParamErrors
+	for _, name := range sortedKeys(schemas) {
+		m, ok := paramsMeta[name]
+		if !ok {
+			continue
+		}
+
+		schema := schemas[name]
+		errs := schema.check("")
+		if len(errs) == 0 && m.Default != "" {
+			if _, defaultErrs := schema.parse(m.Default); len(defaultErrs) > 0 {
+				errs = append(errs, fmt.Sprintf("invalid default: %s", strings.Join(defaultErrs, ", ")))
+			}
+		}
+		for _, msg := range errs {
+			schemaErrs = append(schemaErrs, ParamError{Name: name, Message: msg})
+		}
+
+		m.Schema = &schema
+		paramsMeta[name] = m
+	}
+
+	if len(schemaErrs) > 0 {
+		return nil, schemaErrs
+	}
+
 	var params []Param
 	for _, v := range paramsMeta {
 		params = append(params, v)
@@ -185,6 +217,23 @@ func (p TemplateProcessor) Params() ([]Param, error) {
 	return params, nil
 }
 
+// ParamValues returns the values of the parameters converted to the types
+// declared by their schemas, or ParamErrors for the values that are not valid.
+func (p TemplateProcessor) ParamValues(vars map[string]string) (map[string]interface{}, error) {
+	schemas, err := paramSchemas(p)
+	if err != nil {
+		return nil, err
+	}
+
+	var params []Param
+	for _, name := range sortedKeys(schemas) {
+		schema := schemas[name]
+		params = append(params, Param{Name: name, Schema: &schema})
+	}
+
+	return typedParamValues(params, vars)
+}
+
 // RenderTemplates renders all the resourceTemplates in the template.
 func (p TemplateProcessor) RenderTemplates(vars map[string]string, opts ...RenderOptFunc) ([]RenderedTemplate, error) {
 	params, err := p.Params()
@@ -211,6 +260,11 @@ func (p TemplateProcessor) RenderTemplates(vars map[string]string, opts ...Rende
 		}
 	}
 
+	values, err := typedParamValues(params, vars)
+	if err != nil {
+		return nil, err
+	}
+
 	var renderedTemplates []RenderedTemplate
 	for _, resourcetemplateDefinition := range p.GetSpec().ResourceTemplates {
 		if resourcetemplateDefinition.Content != nil && resourcetemplateDefinition.Raw != "" {
@@ -220,7 +274,7 @@ func (p TemplateProcessor) RenderTemplates(vars map[string]string, opts ...Rende
 
 		var renderedPath string
 		if resourcetemplateDefinition.Path != "" {
-			p, err := p.Processor.Render([]byte(resourcetemplateDefinition.Path), vars)
+			p, err := p.Processor.Render([]byte(resourcetemplateDefinition.Path), values)
 			if err != nil {
 				return nil, fmt.Errorf("failed to render resource template definition path: %w", err)
 			}
@@ -228,7 +282,7 @@ func (p TemplateProcessor) RenderTemplates(vars map[string]string, opts ...Rende
 		}
 		var processed [][]byte
 		if resourcetemplateDefinition.Raw != "" {
-			data, err := p.Processor.Render([]byte(resourcetemplateDefinition.Raw), vars)
+			data, err := p.Processor.Render([]byte(resourcetemplateDefinition.Raw), values)
 			if err != nil {
 				return nil, fmt.Errorf("processing template: %w", err)
 			}
@@ -241,7 +295,7 @@ func (p TemplateProcessor) RenderTemplates(vars map[string]string, opts ...Rende
 					return nil, fmt.Errorf("failed to convert back to YAML: %w", err)
 				}
 
-				data, err := p.Processor.Render(b, vars)
+				data, err := p.Processor.Render(b, values)
 				if err != nil {
 					return nil, fmt.Errorf("processing template: %w", err)
 				}
@@ -273,7 +327,7 @@ type TextTemplateProcessor struct {
 	template templatesv1.Template
 }
 
-func (p *TextTemplateProcessor) Render(tmpl []byte, values map[string]string) ([]byte, error) {
+func (p *TextTemplateProcessor) Render(tmpl []byte, values map[string]interface{}) ([]byte, error) {
 	parsed, err := p.parseToTemplate(tmpl)
 	if err != nil {
 		return nil, err
@@ -342,11 +396,11 @@ func NewEnvsubstTemplateProcessor() *EnvsubstTemplateProcessor {
 type EnvsubstTemplateProcessor struct {
 }
 
-func (p *EnvsubstTemplateProcessor) Render(tmpl []byte, values map[string]string) ([]byte, error) {
+func (p *EnvsubstTemplateProcessor) Render(tmpl []byte, values map[string]interface{}) ([]byte, error) {
 	proc := processor.NewSimpleProcessor()
 	rendered, err := proc.Process(tmpl, func(n string) (string, error) {
-		if s, ok := values[n]; ok {
-			return s, nil
+		if v, ok := values[n]; ok {
+			return paramValueString(v), nil
 		}
 		return "", fmt.Errorf("variable %s not found", n)
 	})
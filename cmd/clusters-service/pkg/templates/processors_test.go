@@ -78,6 +78,11 @@ func TestParamNames(t *testing.T) {
 			data: `foo: "{{ if .params.bar }}{{ .params.barValue }}{{ else }}{{ .params.foo }}{{ end }}"`,
 			want: []string{"bar", "barValue", "foo"},
 		},
+		{
+			name: "field of an object param",
+			data: `foo: "{{ .params.bar.baz }}"`,
+			want: []string{"bar"},
+		},
 	}
 
 	for _, tt := range paramTests {
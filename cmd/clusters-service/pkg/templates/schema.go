@@ -0,0 +1,399 @@
+package templates
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	templatesv1 "github.com/weaveworks/templates-controller/apis/core"
+	"sigs.k8s.io/yaml"
+)
+
+// ParamSchemasAnnotation can be added to a Template to declare the types of its
+// parameters.
+//
+// It's assumed to be a YAML map of parameter name to ParamSchema, e.g.
+//
+//	templates.weave.works/param-schemas: |
+//	  REPLICAS:
+//	    type: integer
+//	    minimum: 1
+//	    maximum: 5
+//	  CLUSTER_NAME:
+//	    pattern: ^[a-z][a-z0-9-]*$
+const ParamSchemasAnnotation string = "templates.weave.works/param-schemas"
+
+// The types of parameters, as named by JSON Schema.
+const (
+	ParamTypeString  = "string"
+	ParamTypeInteger = "integer"
+	ParamTypeBoolean = "boolean"
+	ParamTypeArray   = "array"
+	ParamTypeObject  = "object"
+)
+
+// ParamSchema declares the type of a parameter, and the constraints on its
+// values, in the style of JSON Schema. Parameters without a type are strings.
+//
+// Values are passed to the API as strings, and are parsed according to the
+// type: integers and booleans as such, arrays and objects as YAML or JSON.
+type ParamSchema struct {
+	Type string `json:"type,omitempty"`
+	// Enum are the values allowed.
+	Enum []interface{} `json:"enum,omitempty"`
+	// Pattern is a regular expression that strings must match.
+	Pattern string `json:"pattern,omitempty"`
+	// Minimum and Maximum are the inclusive bounds of integers.
+	Minimum *int64 `json:"minimum,omitempty"`
+	Maximum *int64 `json:"maximum,omitempty"`
+	// Items is the schema of the elements of arrays.
+	Items *ParamSchema `json:"items,omitempty"`
+	// Properties are the schemas of the fields of objects, and Required the
+	// fields that must be set.
+	Properties map[string]ParamSchema `json:"properties,omitempty"`
+	Required   []string               `json:"required,omitempty"`
+}
+
+// ParamError is an error in the schema or the value of a parameter.
+type ParamError struct {
+	Name    string
+	Message string
+}
+
+// ParamErrors are the errors in the parameters of a template, for each to be
+// reported against its parameter.
+type ParamErrors []ParamError
+
+func (e ParamErrors) Error() string {
+	msgs := []string{}
+	for _, pe := range e {
+		msgs = append(msgs, fmt.Sprintf("%s: %s", pe.Name, pe.Message))
+	}
+
+	return fmt.Sprintf("invalid parameters: %s", strings.Join(msgs, ", "))
+}
+
+// paramSchemas returns the schemas declared on the template, by parameter name.
+func paramSchemas(t templatesv1.Template) (map[string]ParamSchema, error) {
+	ann, ok := t.GetAnnotations()[ParamSchemasAnnotation]
+	if !ok {
+		return nil, nil
+	}
+
+	schemas := map[string]ParamSchema{}
+	if err := yaml.UnmarshalStrict([]byte(ann), &schemas); err != nil {
+		return nil, fmt.Errorf("failed to parse %s annotation: %w", ParamSchemasAnnotation, err)
+	}
+
+	return schemas, nil
+}
+
+func (s ParamSchema) typeName() string {
+	if s.Type == "" {
+		return ParamTypeString
+	}
+
+	return s.Type
+}
+
+// check returns the errors in the schema itself.
+func (s ParamSchema) check(path string) []string {
+	var errs []string
+	addErr := func(msg string) {
+		errs = append(errs, atPath(path, msg))
+	}
+
+	typeName := s.typeName()
+	switch typeName {
+	case ParamTypeString, ParamTypeInteger, ParamTypeBoolean, ParamTypeArray, ParamTypeObject:
+	default:
+		addErr(fmt.Sprintf("unknown type %q", s.Type))
+		return errs
+	}
+
+	if s.Pattern != "" {
+		if typeName != ParamTypeString {
+			addErr("pattern only applies to strings")
+		} else if _, err := regexp.Compile(s.Pattern); err != nil {
+			addErr(fmt.Sprintf("invalid pattern: %s", err))
+		}
+	}
+
+	if s.Minimum != nil || s.Maximum != nil {
+		if typeName != ParamTypeInteger {
+			addErr("minimum and maximum only apply to integers")
+		} else if s.Minimum != nil && s.Maximum != nil && *s.Minimum > *s.Maximum {
+			addErr("minimum must not be greater than maximum")
+		}
+	}
+
+	if s.Items != nil {
+		if typeName != ParamTypeArray {
+			addErr("items only apply to arrays")
+		} else {
+			errs = append(errs, s.Items.check(path+"[]")...)
+		}
+	}
+
+	if len(s.Properties) > 0 || len(s.Required) > 0 {
+		if typeName != ParamTypeObject {
+			addErr("properties only apply to objects")
+		} else {
+			for _, k := range sortedKeys(s.Properties) {
+				errs = append(errs, s.Properties[k].check(joinPath(path, k))...)
+			}
+			for _, k := range s.Required {
+				if _, ok := s.Properties[k]; !ok {
+					addErr(fmt.Sprintf("required property %q is not declared", k))
+				}
+			}
+		}
+	}
+
+	if len(errs) > 0 {
+		return errs
+	}
+
+	// The allowed values must be valid themselves.
+	unconstrained := s
+	unconstrained.Enum = nil
+	for i, v := range s.Enum {
+		if _, verrs := unconstrained.checkValue(joinPath(path, fmt.Sprintf("enum[%d]", i)), v); len(verrs) > 0 {
+			errs = append(errs, verrs...)
+		}
+	}
+
+	return errs
+}
+
+// parse parses a value of the parameter, and returns it as the declared type,
+// or the errors that make it invalid.
+func (s ParamSchema) parse(raw string) (interface{}, []string) {
+	var value interface{}
+	switch s.typeName() {
+	case ParamTypeString:
+		value = raw
+	case ParamTypeInteger:
+		n, err := strconv.ParseInt(strings.TrimSpace(raw), 10, 64)
+		if err != nil {
+			return nil, []string{"must be an integer"}
+		}
+		value = n
+	case ParamTypeBoolean:
+		b, err := strconv.ParseBool(strings.TrimSpace(raw))
+		if err != nil {
+			return nil, []string{"must be a boolean"}
+		}
+		value = b
+	default:
+		if err := yaml.Unmarshal([]byte(raw), &value); err != nil {
+			return nil, []string{fmt.Sprintf("must be an %s in YAML or JSON: %s", s.typeName(), err)}
+		}
+	}
+
+	return s.checkValue("", value)
+}
+
+// checkValue checks a value, as decoded from JSON, against the schema, and
+// returns it with the integers it contains as int64.
+func (s ParamSchema) checkValue(path string, value interface{}) (interface{}, []string) {
+	var errs []string
+	addErr := func(msg string) {
+		errs = append(errs, atPath(path, msg))
+	}
+
+	switch s.typeName() {
+	case ParamTypeString:
+		str, ok := value.(string)
+		if !ok {
+			addErr("must be a string")
+			break
+		}
+		if s.Pattern != "" {
+			if re, err := regexp.Compile(s.Pattern); err == nil && !re.MatchString(str) {
+				addErr(fmt.Sprintf("must match %q", s.Pattern))
+			}
+		}
+	case ParamTypeInteger:
+		n, ok := toInt64(value)
+		if !ok {
+			addErr("must be an integer")
+			break
+		}
+		value = n
+		if s.Minimum != nil && n < *s.Minimum {
+			addErr(fmt.Sprintf("must be at least %d", *s.Minimum))
+		}
+		if s.Maximum != nil && n > *s.Maximum {
+			addErr(fmt.Sprintf("must be at most %d", *s.Maximum))
+		}
+	case ParamTypeBoolean:
+		if _, ok := value.(bool); !ok {
+			addErr("must be a boolean")
+		}
+	case ParamTypeArray:
+		items, ok := value.([]interface{})
+		if !ok {
+			addErr("must be an array")
+			break
+		}
+		if s.Items != nil {
+			converted := make([]interface{}, len(items))
+			for i, item := range items {
+				v, itemErrs := s.Items.checkValue(fmt.Sprintf("%s[%d]", path, i), item)
+				converted[i] = v
+				errs = append(errs, itemErrs...)
+			}
+			value = converted
+		}
+	case ParamTypeObject:
+		fields, ok := value.(map[string]interface{})
+		if !ok {
+			addErr("must be an object")
+			break
+		}
+		for _, k := range s.Required {
+			if _, ok := fields[k]; !ok {
+				addErr(fmt.Sprintf("missing required property %q", k))
+			}
+		}
+		converted := map[string]interface{}{}
+		for k, v := range fields {
+			converted[k] = v
+		}
+		for _, k := range sortedKeys(s.Properties) {
+			v, ok := fields[k]
+			if !ok {
+				continue
+			}
+			v, fieldErrs := s.Properties[k].checkValue(joinPath(path, k), v)
+			converted[k] = v
+			errs = append(errs, fieldErrs...)
+		}
+		value = converted
+	}
+
+	if len(errs) > 0 {
+		return nil, errs
+	}
+
+	if len(s.Enum) > 0 && !inEnum(s.Enum, value) {
+		allowed := []string{}
+		for _, v := range s.Enum {
+			allowed = append(allowed, toJSON(v))
+		}
+		return nil, []string{atPath(path, fmt.Sprintf("must be one of %s", strings.Join(allowed, ", ")))}
+	}
+
+	return value, nil
+}
+
+// typedParamValues returns the values of the parameters, converted to the types
+// declared by their schemas. Values that are not set are left as they are.
+func typedParamValues(params []Param, vars map[string]string) (map[string]interface{}, error) {
+	values := map[string]interface{}{}
+	for k, v := range vars {
+		values[k] = v
+	}
+
+	sorted := append([]Param{}, params...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Name < sorted[j].Name })
+
+	var paramErrs ParamErrors
+	for _, param := range sorted {
+		val, ok := vars[param.Name]
+		if param.Schema == nil || !ok || val == "" {
+			continue
+		}
+
+		typed, errs := param.Schema.parse(val)
+		for _, msg := range errs {
+			paramErrs = append(paramErrs, ParamError{Name: param.Name, Message: msg})
+		}
+		values[param.Name] = typed
+	}
+
+	if len(paramErrs) > 0 {
+		return nil, paramErrs
+	}
+
+	return values, nil
+}
+
+// paramValueString formats a value for templates that can only be rendered
+// with strings, arrays and objects as JSON.
+func paramValueString(v interface{}) string {
+	if s, ok := v.(string); ok {
+		return s
+	}
+
+	return toJSON(v)
+}
+
+func toInt64(v interface{}) (int64, bool) {
+	switch n := v.(type) {
+	case int64:
+		return n, true
+	case int:
+		return int64(n), true
+	case float64:
+		if n != math.Trunc(n) || n > math.MaxInt64 || n < math.MinInt64 {
+			return 0, false
+		}
+		return int64(n), true
+	}
+
+	return 0, false
+}
+
+func inEnum(enum []interface{}, value interface{}) bool {
+	// Values are compared as JSON, as the integers of the enum are decoded as
+	// float64, and the integers of values as int64.
+	s := toJSON(value)
+	for _, v := range enum {
+		if toJSON(v) == s {
+			return true
+		}
+	}
+
+	return false
+}
+
+func atPath(path, msg string) string {
+	if path == "" {
+		return msg
+	}
+
+	return fmt.Sprintf("%s: %s", path, msg)
+}
+
+func joinPath(path, key string) string {
+	if path == "" {
+		return key
+	}
+
+	return path + "." + key
+}
+
+func sortedKeys(m map[string]ParamSchema) []string {
+	keys := []string{}
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	return keys
+}
+
+func toJSON(v interface{}) string {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprintf("%v", v)
+	}
+
+	return string(b)
+}
@@ -0,0 +1,235 @@
+package templates
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+const typedParamsTemplate = `
+apiVersion: capi.weave.works/v1alpha2
+kind: CAPITemplate
+metadata:
+  name: cluster-template-1
+  namespace: default
+  annotations:
+    templates.weave.works/param-schemas: |
+      REPLICAS:
+        type: integer
+        minimum: 1
+        maximum: 5
+      MONITORING:
+        type: boolean
+      SIZE:
+        enum: [small, large]
+      CLUSTER_NAME:
+        pattern: ^[a-z][a-z0-9-]*$
+      ZONES:
+        type: array
+        items:
+          type: string
+      NODES:
+        type: object
+        required: [count]
+        properties:
+          count:
+            type: integer
+            minimum: 1
+spec:
+  renderType: templating
+  params:
+    - name: REPLICAS
+      default: "3"
+  resourcetemplates:
+  - raw: |
+      apiVersion: cluster.x-k8s.io/v1alpha3
+      kind: Cluster
+      metadata:
+        name: "{{ .params.CLUSTER_NAME }}"
+      spec:
+        replicas: "{{ .params.REPLICAS }}"
+        highAvailability: "{{ gt .params.REPLICAS 2 }}"
+        size: "{{ .params.SIZE }}"
+        monitoring: "{{ if .params.MONITORING }}enabled{{ else }}disabled{{ end }}"
+        zones: "{{ len .params.ZONES }}"
+        nodes: "{{ .params.NODES.count }}"
+`
+
+func TestRenderTemplates_typed_params(t *testing.T) {
+	processor, err := NewProcessorForTemplate(parseCAPITemplateFromBytes(t, []byte(typedParamsTemplate)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rendered, err := processor.RenderTemplates(map[string]string{
+		"CLUSTER_NAME": "test-cluster",
+		"SIZE":         "large",
+		"MONITORING":   "false",
+		"ZONES":        "[eu-west-1a, eu-west-1b]",
+		"NODES":        `{"count": 2}`,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := `apiVersion: cluster.x-k8s.io/v1alpha3
+kind: Cluster
+metadata:
+  name: "test-cluster"
+spec:
+  replicas: "3"
+  highAvailability: "true"
+  size: "large"
+  monitoring: "disabled"
+  zones: "2"
+  nodes: "2"
+`
+	if diff := cmp.Diff(want, string(rendered[0].Data[0])); diff != "" {
+		t.Fatalf("rendering failure:\n%s", diff)
+	}
+}
+
+func TestRenderTemplates_invalid_typed_params(t *testing.T) {
+	processor, err := NewProcessorForTemplate(parseCAPITemplateFromBytes(t, []byte(typedParamsTemplate)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = processor.RenderTemplates(map[string]string{
+		"CLUSTER_NAME": "Test_Cluster",
+		"REPLICAS":     "6",
+		"SIZE":         "medium",
+		"MONITORING":   "maybe",
+		"ZONES":        "[eu-west-1a, 1]",
+		"NODES":        `{"count": 0}`,
+	})
+
+	var paramErrs ParamErrors
+	if !errors.As(err, &paramErrs) {
+		t.Fatalf("want ParamErrors, got %v", err)
+	}
+
+	want := ParamErrors{
+		{Name: "CLUSTER_NAME", Message: `must match "^[a-z][a-z0-9-]*$"`},
+		{Name: "MONITORING", Message: "must be a boolean"},
+		{Name: "NODES", Message: "count: must be at least 1"},
+		{Name: "REPLICAS", Message: "must be at most 5"},
+		{Name: "SIZE", Message: `must be one of "small", "large"`},
+		{Name: "ZONES", Message: "[1]: must be a string"},
+	}
+	if diff := cmp.Diff(want, paramErrs); diff != "" {
+		t.Fatalf("wrong errors:\n%s", diff)
+	}
+}
+
+func TestParams_schemas(t *testing.T) {
+	processor, err := NewProcessorForTemplate(parseCAPITemplateFromBytes(t, []byte(typedParamsTemplate)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	params, err := processor.Params()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	types := map[string]string{}
+	for _, p := range params {
+		if p.Schema == nil {
+			t.Fatalf("param %s has no schema", p.Name)
+		}
+		types[p.Name] = p.Schema.typeName()
+	}
+
+	want := map[string]string{
+		"CLUSTER_NAME": "string",
+		"MONITORING":   "boolean",
+		"NODES":        "object",
+		"REPLICAS":     "integer",
+		"SIZE":         "string",
+		"ZONES":        "array",
+	}
+	if diff := cmp.Diff(want, types); diff != "" {
+		t.Fatalf("wrong param types:\n%s", diff)
+	}
+}
+
+func TestParams_invalid_schemas(t *testing.T) {
+	processor, err := NewProcessorForTemplate(parseCAPITemplateFromBytes(t, []byte(`
+apiVersion: capi.weave.works/v1alpha2
+kind: CAPITemplate
+metadata:
+  name: cluster-template-1
+  namespace: default
+  annotations:
+    templates.weave.works/param-schemas: |
+      REPLICAS:
+        type: integer
+        minimum: 5
+        maximum: 1
+      SIZE:
+        type: number
+      NAME:
+        pattern: "[a-z"
+      ZONE:
+        enum: [eu-west-1, 1]
+      COUNT:
+        type: integer
+      UNUSED:
+        type: unknown
+spec:
+  params:
+    - name: COUNT
+      default: "many"
+  resourcetemplates:
+  - content:
+    - apiVersion: cluster.x-k8s.io/v1alpha3
+      kind: Cluster
+      metadata:
+        name: "${NAME}-${ZONE}"
+      spec:
+        replicas: "${REPLICAS}"
+        count: "${COUNT}"
+        size: "${SIZE}"
+`)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = processor.Params()
+
+	var paramErrs ParamErrors
+	if !errors.As(err, &paramErrs) {
+		t.Fatalf("want ParamErrors, got %v", err)
+	}
+
+	want := ParamErrors{
+		{Name: "COUNT", Message: "invalid default: must be an integer"},
+		{Name: "NAME", Message: "invalid pattern: error parsing regexp: missing closing ]: `[a-z`"},
+		{Name: "REPLICAS", Message: "minimum must not be greater than maximum"},
+		{Name: "SIZE", Message: `unknown type "number"`},
+		{Name: "ZONE", Message: "enum[1]: must be a string"},
+	}
+	if diff := cmp.Diff(want, paramErrs); diff != "" {
+		t.Fatalf("wrong errors:\n%s", diff)
+	}
+}
+
+func TestEnvsubstRender_typed_params(t *testing.T) {
+	processor := NewEnvsubstTemplateProcessor()
+
+	rendered, err := processor.Render([]byte("replicas: ${REPLICAS}\nzones: ${ZONES}\nname: ${NAME}\n"), map[string]interface{}{
+		"REPLICAS": int64(3),
+		"ZONES":    []interface{}{"eu-west-1a", "eu-west-1b"},
+		"NAME":     "test",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := "replicas: 3\nzones: [\"eu-west-1a\",\"eu-west-1b\"]\nname: test\n"
+	if diff := cmp.Diff(want, string(rendered)); diff != "" {
+		t.Fatalf("rendering failure:\n%s", diff)
+	}
+}
@@ -36,7 +36,13 @@ func listNodeFields(nodes []parse.Node) []string {
 				res = append(res, items[1:]...)
 			}
 		case parse.NodeField:
-			res = append(res, node.(*parse.FieldNode).Ident...)
+			ident := node.(*parse.FieldNode).Ident
+			// The fields of object params, e.g. `.params.NODES.count`, are
+			// not params themselves.
+			if len(ident) > 2 && ident[0] == "params" {
+				ident = ident[:2]
+			}
+			res = append(res, ident...)
 		}
 	}
 
@@ -0,0 +1,23 @@
+package templates
+
+// VersionAnnotation can be added to a Template to declare the version of its
+// Flavour, e.g.
+//
+//	templates.weave.works/version: "1.2.0"
+//
+// The resources rendered from a versioned template are labelled with its
+// version, so that those rendered from other versions can be upgraded.
+const VersionAnnotation string = "templates.weave.works/version"
+
+// VersionLabel is added to the resources rendered from a versioned template,
+// with the version of the template.
+const VersionLabel string = "templates.weave.works/template-version"
+
+// CreateRequestAnnotation is added to the first resource rendered from a
+// template, with the request it was rendered from, so that it can be rendered
+// again.
+const CreateRequestAnnotation string = "templates.weave.works/create-request"
+
+// CreatedFilesAnnotation is added to the first resource rendered from a
+// template, with the files that were created for it in the repository.
+const CreatedFilesAnnotation string = "templates.weave.works/created-files"
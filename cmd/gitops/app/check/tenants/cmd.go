@@ -0,0 +1,90 @@
+package tenants
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	tenantspr "github.com/weaveworks/weave-gitops-enterprise/cmd/gitops/pkg/tenants"
+	"github.com/weaveworks/weave-gitops-enterprise/pkg/tenancy"
+	"github.com/weaveworks/weave-gitops/pkg/kube"
+)
+
+type tenantCommandFlags struct {
+	fromFile string
+}
+
+var flags tenantCommandFlags
+
+// CheckCommand returns a cobra command that reports the drift of the tenant resources of a cluster.
+func CheckCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "tenants",
+		Short: "Check the tenant resources of the current cluster for drift",
+		Long: `Compare the resources generated for the tenants of a file with the tenant resources of the current cluster.
+Resources that are missing, that are no longer declared, or whose fields were changed are reported,
+and the command fails if there are any, for it to run in CI.
+
+If the file selects clusters, the GitopsClusters of the current cluster that it selects are checked instead.`,
+		Example: `
+	  # Check the tenants of a file for drift
+	  gitops check tenants --from-file tenants.yaml
+	`,
+		SilenceUsage: true,
+		RunE:         checkTenantsCmdRunE(),
+	}
+
+	cmd.Flags().StringVar(&flags.fromFile, "from-file", "", "the file containing the tenant declarations")
+
+	return cmd
+}
+
+func checkTenantsCmdRunE() func(*cobra.Command, []string) error {
+	return func(cmd *cobra.Command, args []string) error {
+		if flags.fromFile == "" {
+			return errors.New("--from-file is required")
+		}
+
+		tenancyConfig, err := tenancy.Parse(flags.fromFile)
+		if err != nil {
+			return fmt.Errorf("failed to parse tenants file %s: %w", flags.fromFile, err)
+		}
+
+		config, contextName, err := kube.RestConfig()
+		if err != nil {
+			return fmt.Errorf("could not create default config: %w", err)
+		}
+
+		kubeClient, err := kube.NewKubeHTTPClientWithConfig(config, contextName, tenantspr.SchemeBuilder...)
+		if err != nil {
+			return fmt.Errorf("failed to create kube client: %w", err)
+		}
+
+		ctx := context.Background()
+
+		if tenancyConfig.Clusters != nil {
+			clusters, err := tenancy.SelectClusters(ctx, kubeClient, *tenancyConfig.Clusters)
+			if err != nil {
+				return fmt.Errorf("failed to select clusters: %w", err)
+			}
+
+			clientFor, err := tenantspr.ClusterClients(ctx, config)
+			if err != nil {
+				return err
+			}
+
+			results := tenancy.CheckTenantsOfClusters(ctx, tenancyConfig, clusters, clientFor)
+
+			return tenancy.WriteClusterDriftReport(os.Stdout, results)
+		}
+
+		drifts, err := tenancy.CheckTenants(ctx, tenancyConfig, kubeClient)
+		if err != nil {
+			return err
+		}
+
+		return tenancy.WriteDriftReport(os.Stdout, drifts)
+	}
+}
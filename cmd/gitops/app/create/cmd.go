@@ -4,9 +4,11 @@ import (
 	"github.com/spf13/cobra"
 	"github.com/weaveworks/weave-gitops-enterprise/cmd/gitops/app/create/templates"
 	"github.com/weaveworks/weave-gitops-enterprise/cmd/gitops/app/create/tenants"
+	"github.com/weaveworks/weave-gitops-enterprise/cmd/gitops/pkg/adapters"
+	"github.com/weaveworks/weave-gitops/cmd/gitops/config"
 )
 
-func Command() *cobra.Command {
+func Command(opts *config.Options, client *adapters.HTTPClient) *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "create",
 		Short: "Create new resources",
@@ -18,7 +20,7 @@ gitops create tenants --from-file tenants.yaml
 gitops create templates template.yaml`,
 	}
 
-	cmd.AddCommand(tenants.CreateCommand)
+	cmd.AddCommand(tenants.CreateCommand(opts, client))
 	cmd.AddCommand(templates.CreateCommand)
 
 	return cmd
@@ -15,6 +15,7 @@ import (
 	"github.com/go-logr/logr"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
+	templatesv1 "github.com/weaveworks/templates-controller/apis/core"
 	gapiv1 "github.com/weaveworks/templates-controller/apis/gitops/v1alpha2"
 	capiv1_proto "github.com/weaveworks/weave-gitops-enterprise/cmd/clusters-service/pkg/protos"
 	"github.com/weaveworks/weave-gitops-enterprise/cmd/clusters-service/pkg/server"
@@ -40,6 +41,7 @@ type Config struct {
 	TemplateFile    string   `mapstructure:"template-file"`
 	HelmRepoName    string   `mapstructure:"helm-repo-name"`
 	Profiles        []string `mapstructure:"profiles"`
+	Includes        []string `mapstructure:"includes"`
 }
 
 var config Config
@@ -62,6 +64,9 @@ var CreateCommand = &cobra.Command{
 
 	  # specify template file and values in a config file
 	  gitops create --config config.yaml --output-dir ./out
+
+	  # find the templates included by the template in a directory
+	  gitops create template.yaml --includes ./fragments --export
 	`,
 	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
 		return initializeConfig(cmd)
@@ -77,6 +82,7 @@ func init() {
 	flags.String("template-file", "", "template file to use")
 	flags.StringArray("profiles", []string{}, "Set profiles values files on the command line (--profile 'name=foo-profile,version=0.0.1,namespace=foo-system' --profile 'name=bar-profile,namespace=bar-system,values=bar-values.yaml')")
 	flags.String("helm-repo-name", "weaveworks-charts", "name of the helm repo in the helm local cache")
+	flags.StringSlice("includes", []string{}, "template files, or directories of template files, to find the templates included by the template in, besides the directory of the template")
 	flags.StringVar(&configPath, "config", "", "config file to use")
 }
 
@@ -130,6 +136,16 @@ func templatesCmdRunE() func(*cobra.Command, []string) error {
 			return fmt.Errorf("failed to parse template file %s: %w", templateFile, err)
 		}
 
+		getter, err := clitemplates.LocalTemplateGetter(append([]string{filepath.Dir(templateFile)}, config.Includes...))
+		if err != nil {
+			return fmt.Errorf("failed to read included templates: %w", err)
+		}
+
+		resolvedTemplate, err := templates.ResolveIncludes(context.Background(), parsedTemplate, getter)
+		if err != nil {
+			return fmt.Errorf("failed to resolve the templates included by %s: %w", templateFile, err)
+		}
+
 		params := make(map[string]string)
 
 		// parse parameter values
@@ -154,7 +170,7 @@ func templatesCmdRunE() func(*cobra.Command, []string) error {
 			})
 		}
 
-		files, err := generateFilesLocally(parsedTemplate, params, config.HelmRepoName, capiProfileValues, cli.New(), log)
+		files, err := GenerateFilesLocally(resolvedTemplate, params, config.HelmRepoName, capiProfileValues, nil, cli.New(), log)
 		if err != nil {
 			return fmt.Errorf("failed to generate files locally: %w", err)
 		}
@@ -239,7 +255,10 @@ func export(template string, out io.Writer) error {
 	return nil
 }
 
-func generateFilesLocally(tmpl *gapiv1.GitOpsTemplate, params map[string]string, helmRepoName string, profiles []*capiv1_proto.ProfileValues, settings *cli.EnvSettings, log logr.Logger) ([]git.CommitFile, error) {
+// GenerateFilesLocally renders the template with the parameter values, and generates the files of
+// its profiles and kustomizations the way the API does, without a cluster. The charts of the profiles
+// are read from the local cache of the helm repo.
+func GenerateFilesLocally(tmpl templatesv1.Template, params map[string]string, helmRepoName string, profiles []*capiv1_proto.ProfileValues, kustomizations []*capiv1_proto.Kustomization, settings *cli.EnvSettings, log logr.Logger) ([]git.CommitFile, error) {
 	templateHasRequiredProfiles, err := templates.TemplateHasRequiredProfiles(tmpl)
 	if err != nil {
 		return nil, fmt.Errorf("failed to check if template has required profiles: %w", err)
@@ -275,9 +294,10 @@ func generateFilesLocally(tmpl *gapiv1.GitOpsTemplate, params map[string]string,
 		tmpl,
 		server.GetFilesRequest{
 			ParameterValues: params,
-			TemplateName:    tmpl.Name,
+			TemplateName:    tmpl.GetName(),
 			HelmRepository:  helmRepo,
 			Profiles:        profiles,
+			Kustomizations:  kustomizations,
 		},
 		nil, // FIXME: no create message request, generated resources won't be "editable" in the UI
 	)
@@ -147,7 +147,7 @@ func TestGenerateFilesLocally(t *testing.T) {
 	assert.NoError(t, err)
 
 	// don't have to specify any helm settings if no profiles are around
-	files, err := generateFilesLocally(tmpl, defaultParams, "test-repo", nil, nil, logr.Discard())
+	files, err := GenerateFilesLocally(tmpl, defaultParams, "test-repo", nil, nil, nil, logr.Discard())
 	assert.NoError(t, err)
 
 	expectedFiles := []string{
@@ -176,7 +176,7 @@ func TestGenerateFilesLocallyWithCharts(t *testing.T) {
 		},
 	}
 
-	files, err := generateFilesLocally(tmpl, defaultParams, "test-repo", profiles, testSettings, logr.Discard())
+	files, err := GenerateFilesLocally(tmpl, defaultParams, "test-repo", profiles, nil, testSettings, logr.Discard())
 	assert.NoError(t, err)
 
 	expectedFiles := []string{
@@ -2,17 +2,26 @@ package tenants
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"os"
+	"strings"
 
 	"github.com/spf13/cobra"
-	pacv2beta1 "github.com/weaveworks/policy-agent/api/v2beta1"
-	pacv2beta2 "github.com/weaveworks/policy-agent/api/v2beta2"
+	"github.com/weaveworks/weave-gitops-enterprise/cmd/gitops/internal"
+	"github.com/weaveworks/weave-gitops-enterprise/cmd/gitops/pkg/adapters"
+	tenantspr "github.com/weaveworks/weave-gitops-enterprise/cmd/gitops/pkg/tenants"
 	"github.com/weaveworks/weave-gitops-enterprise/pkg/tenancy"
+	"github.com/weaveworks/weave-gitops/cmd/gitops/cmderrors"
+	"github.com/weaveworks/weave-gitops/cmd/gitops/config"
+	"github.com/weaveworks/weave-gitops/pkg/gitproviders"
 	"github.com/weaveworks/weave-gitops/pkg/kube"
 
 	apiextentionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/rest"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
 )
 
 const (
@@ -24,16 +33,27 @@ type tenantCommandFlags struct {
 	namespaces          []string
 	fromFile            string
 	export              bool
+	outputDir           string
 	skipPreFlightChecks bool
 	Prune               bool
+	PR                  bool
+	Clusters            []string
+	RepositoryURL       string
+	BaseBranch          string
+	HeadBranch          string
+	Title               string
+	Description         string
+	CommitMessage       string
 }
 
 var flags tenantCommandFlags
 
-var CreateCommand = &cobra.Command{
-	Use:   "tenants",
-	Short: "Create or update tenant resources",
-	Example: `
+// CreateCommand returns a cobra command that provides support for creating tenants.
+func CreateCommand(opts *config.Options, client *adapters.HTTPClient) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "tenants",
+		Short: "Create or update tenant resources",
+		Example: `
 	  # Create a tenant using name and namespace flags
 	  gitops create tenants --name test-tenant1 --namespace test-ns1 --namespace test-ns2
 
@@ -45,20 +65,35 @@ var CreateCommand = &cobra.Command{
 
 	  # Export tenant resources to stdout
 	  gitops create tenants --from-file tenants.yaml --export
+
+	  # Export tenant resources to a directory per cluster, for files that select clusters
+	  gitops create tenants --from-file tenants.yaml --export --output-dir ./clusters
+
+	  # Create a pull request that adds the tenants to the clusters the file selects
+	  gitops create tenants --from-file tenants.yaml --pr --url https://github.com/org/fleet
+
+	  # Create a pull request that adds the tenants to the given clusters, and removes the tenants no longer declared
+	  gitops create tenants --from-file tenants.yaml --pr --url https://github.com/org/fleet --cluster default/leaf-1 --cluster management --prune
 	`,
-	RunE: applyTenantsCmdRunE(),
-}
+		RunE: applyTenantsCmdRunE(opts, client),
+	}
 
-func init() {
-	CreateCommand.Flags().StringVar(&flags.name, "name", "", "the name of the tenant to be created")
-	CreateCommand.Flags().StringSliceVar(&flags.namespaces, "namespace", []string{}, "a list of namespaces for the tenant")
-	CreateCommand.Flags().StringVar(&flags.fromFile, "from-file", "", "the file containing the tenant declarations")
-	CreateCommand.Flags().BoolVar(&flags.export, "export", false, "export in YAML format to stdout")
-	CreateCommand.Flags().BoolVar(&flags.skipPreFlightChecks, "skip-preflight-checks", false, "skip preflight checks before creating resources in cluster")
-	CreateCommand.Flags().BoolVar(&flags.Prune, "prune", false, "prunes resources not needed by the config file")
+	cmd.Flags().StringVar(&flags.name, "name", "", "the name of the tenant to be created")
+	cmd.Flags().StringSliceVar(&flags.namespaces, "namespace", []string{}, "a list of namespaces for the tenant")
+	cmd.Flags().StringVar(&flags.fromFile, "from-file", "", "the file containing the tenant declarations")
+	cmd.Flags().BoolVar(&flags.export, "export", false, "export in YAML format to stdout")
+	cmd.Flags().StringVar(&flags.outputDir, "output-dir", "", "the directory to export the resources of each cluster to, as <namespace>/<name>/tenants.yaml, when the file selects clusters")
+	cmd.Flags().BoolVar(&flags.skipPreFlightChecks, "skip-preflight-checks", false, "skip preflight checks before creating resources in cluster")
+	cmd.Flags().BoolVar(&flags.Prune, "prune", false, "prunes resources not needed by the config file")
+	cmd.Flags().BoolVar(&flags.PR, "pr", false, "create a pull request that writes the resources of each tenant to the management repository, rather than applying them")
+	cmd.Flags().StringSliceVar(&flags.Clusters, "cluster", []string{}, "the clusters to add the tenants to in the pull request, as <namespace>/<name>, or <name> for the management cluster. Defaults to the clusters the file selects")
+	cmd.Flags().StringVar(&flags.RepositoryURL, "url", "", "URL of remote repository to create the pull request")
+	internal.AddPRFlags(cmd, &flags.HeadBranch, &flags.BaseBranch, &flags.Description, &flags.CommitMessage, &flags.Title)
+
+	return cmd
 }
 
-func applyTenantsCmdRunE() func(*cobra.Command, []string) error {
+func applyTenantsCmdRunE(opts *config.Options, client *adapters.HTTPClient) func(*cobra.Command, []string) error {
 	return func(cmd *cobra.Command, args []string) error {
 		tenancyConfig := &tenancy.Config{}
 
@@ -77,7 +112,11 @@ func applyTenantsCmdRunE() func(*cobra.Command, []string) error {
 			})
 		}
 
-		if flags.export {
+		if flags.PR {
+			return createTenantsPullRequest(opts, client, tenancyConfig)
+		}
+
+		if flags.export && tenancyConfig.Clusters == nil {
 			err := tenancy.ExportTenants(tenancyConfig, os.Stdout)
 			if err != nil {
 				return err
@@ -86,6 +125,10 @@ func applyTenantsCmdRunE() func(*cobra.Command, []string) error {
 			return nil
 		}
 
+		if flags.export && flags.outputDir == "" {
+			return errors.New("--output-dir is required to export tenants for the clusters of the file")
+		}
+
 		ctx := context.Background()
 
 		config, contextName, err := kube.RestConfig()
@@ -93,11 +136,30 @@ func applyTenantsCmdRunE() func(*cobra.Command, []string) error {
 			return fmt.Errorf("could not create default config: %w", err)
 		}
 
-		kubeClient, err := kube.NewKubeHTTPClientWithConfig(config, contextName, pacv2beta1.AddToScheme, pacv2beta2.AddToScheme)
+		kubeClient, err := kube.NewKubeHTTPClientWithConfig(config, contextName, tenantspr.SchemeBuilder...)
 		if err != nil {
 			return fmt.Errorf("failed to create kube client: %w", err)
 		}
 
+		if tenancyConfig.Clusters != nil {
+			clusters, err := tenancy.SelectClusters(ctx, kubeClient, *tenancyConfig.Clusters)
+			if err != nil {
+				return fmt.Errorf("failed to select clusters: %w", err)
+			}
+
+			if flags.export {
+				if err := tenancy.ExportTenantsToClusters(tenancyConfig, clusters, flags.outputDir); err != nil {
+					return err
+				}
+
+				fmt.Printf("exported tenants for %d clusters to %s\n", len(clusters), flags.outputDir)
+
+				return nil
+			}
+
+			return applyTenantsToClusters(ctx, tenancyConfig, config, clusters)
+		}
+
 		if !flags.skipPreFlightChecks {
 			err := preFlightCheck(ctx, tenancyConfig, kubeClient)
 			if err != nil {
@@ -114,7 +176,114 @@ func applyTenantsCmdRunE() func(*cobra.Command, []string) error {
 	}
 }
 
-func preFlightCheck(ctx context.Context, config *tenancy.Config, kubeClient *kube.KubeHTTP) error {
+// applyTenantsToClusters connects to the clusters the way clusters-service does, with the kubeconfig
+// of their GitopsCluster, and applies the tenants to each of them.
+func applyTenantsToClusters(ctx context.Context, tenancyConfig *tenancy.Config, managementConfig *rest.Config, clusters []types.NamespacedName) error {
+	clusterClient, err := tenantspr.ClusterClients(ctx, managementConfig)
+	if err != nil {
+		return err
+	}
+
+	clientFor := func(ctx context.Context, name types.NamespacedName) (client.Client, error) {
+		kubeClient, err := clusterClient(ctx, name)
+		if err != nil {
+			return nil, err
+		}
+
+		if !flags.skipPreFlightChecks {
+			if err := preFlightCheck(ctx, tenancyConfig, kubeClient); err != nil {
+				return nil, fmt.Errorf("preflight check failed with error: %w", err)
+			}
+		}
+
+		return kubeClient, nil
+	}
+
+	results := tenancy.ApplyTenantsToClusters(ctx, tenancyConfig, clusters, clientFor, flags.Prune, os.Stdout)
+
+	return tenancy.WriteClusterResults(os.Stdout, results)
+}
+
+// createTenantsPullRequest has clusters-service create a pull request that writes the resources of
+// each tenant to a file of its own, in the directory of each cluster in the management repository.
+func createTenantsPullRequest(opts *config.Options, client *adapters.HTTPClient, tenancyConfig *tenancy.Config) error {
+	if flags.export {
+		return errors.New("--export and --pr cannot be used together")
+	}
+
+	if opts.Endpoint == "" {
+		return cmderrors.ErrNoWGEEndpoint
+	}
+
+	if flags.RepositoryURL == "" {
+		return cmderrors.ErrNoURL
+	}
+
+	clusters, err := parseClusters(flags.Clusters)
+	if err != nil {
+		return err
+	}
+
+	if len(clusters) == 0 && tenancyConfig.Clusters == nil {
+		return errors.New("--cluster is required to create a pull request for a file that does not select clusters")
+	}
+
+	url, err := gitproviders.NewRepoURL(flags.RepositoryURL)
+	if err != nil {
+		return fmt.Errorf("cannot parse url: %w", err)
+	}
+
+	token, err := internal.GetToken(url, os.LookupEnv)
+	if err != nil {
+		return err
+	}
+
+	tenantsYAML, err := yaml.Marshal(tenancyConfig)
+	if err != nil {
+		return fmt.Errorf("failed to marshal tenants: %w", err)
+	}
+
+	if err := client.ConfigureClientWithOptions(opts, os.Stdout); err != nil {
+		return err
+	}
+
+	params := tenantspr.CreateTenantsPullRequestParams{
+		GitProviderToken: token,
+		Tenants:          string(tenantsYAML),
+		Clusters:         clusters,
+		Prune:            flags.Prune,
+		RepositoryURL:    flags.RepositoryURL,
+		HeadBranch:       flags.HeadBranch,
+		BaseBranch:       flags.BaseBranch,
+		Title:            flags.Title,
+		Description:      flags.Description,
+		CommitMessage:    flags.CommitMessage,
+	}
+
+	return tenantspr.CreateTenantsPullRequest(params, client, os.Stdout)
+}
+
+// parseClusters parses clusters named as <namespace>/<name>, or <name> for the management cluster.
+func parseClusters(names []string) ([]tenantspr.Cluster, error) {
+	clusters := []tenantspr.Cluster{}
+
+	for _, name := range names {
+		namespace, clusterName, ok := strings.Cut(name, "/")
+		if !ok {
+			namespace, clusterName = "", name
+		}
+
+		if clusterName == "" || (ok && namespace == "") || strings.Contains(clusterName, "/") {
+			return nil, fmt.Errorf("invalid cluster %q, must be <namespace>/<name> or <name>", name)
+		}
+
+		clusters = append(clusters, tenantspr.Cluster{Namespace: namespace, Name: clusterName})
+	}
+
+	return clusters, nil
+}
+
+func preFlightCheck(ctx context.Context, config *tenancy.Config, kubeClient client.Client) error {
 	crd := &apiextentionsv1.CustomResourceDefinition{}
 	err := kubeClient.Get(ctx, client.ObjectKey{Name: policyCRDName}, crd)
 	if err != nil {
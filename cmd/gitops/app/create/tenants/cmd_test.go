@@ -6,14 +6,17 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
-	pacv2beta1 "github.com/weaveworks/policy-agent/api/v2beta1"
+	"github.com/stretchr/testify/require"
+	tenantspr "github.com/weaveworks/weave-gitops-enterprise/cmd/gitops/pkg/tenants"
 	"github.com/weaveworks/weave-gitops-enterprise/pkg/tenancy"
 	"github.com/weaveworks/weave-gitops/pkg/kube"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
 	apiextentionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
-	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 )
@@ -128,13 +131,8 @@ func Test_PreFlightChecks(t *testing.T) {
 func newFakeClient(t *testing.T, objs ...runtime.Object) client.Client {
 	t.Helper()
 
-	scheme := runtime.NewScheme()
-
-	if err := clientgoscheme.AddToScheme(scheme); err != nil {
-		t.Fatal(err)
-	}
-
-	if err := pacv2beta1.AddToScheme(scheme); err != nil {
+	scheme, err := tenantspr.NewScheme()
+	if err != nil {
 		t.Fatal(err)
 	}
 
@@ -162,3 +160,49 @@ func setResourceVersion[T client.Object](obj T, rv int) T {
 
 	return obj
 }
+
+func Test_parseClusters(t *testing.T) {
+	clusters, err := parseClusters([]string{"default/leaf-1", "management"})
+	assert.NoError(t, err)
+	assert.Equal(t, []tenantspr.Cluster{
+		{Namespace: "default", Name: "leaf-1"},
+		{Name: "management"},
+	}, clusters)
+
+	for _, name := range []string{"", "/leaf-1", "default/", "default/leaf/1"} {
+		_, err := parseClusters([]string{name})
+		assert.Error(t, err, name)
+	}
+}
+
+func Test_NewScheme(t *testing.T) {
+	scheme, err := tenantspr.NewScheme()
+	require.NoError(t, err)
+
+	resources, err := tenancy.GenerateTenantResources(&tenancy.Config{
+		Tenants: []tenancy.Tenant{
+			{
+				Name:       "test-tenant",
+				Namespaces: []string{"test-ns"},
+				AllowedRepositories: []tenancy.AllowedRepository{
+					{URL: "https://github.com/testorg/testrepo", Kind: "GitRepository"},
+				},
+				ResourceQuotas: []tenancy.TenantResourceQuota{
+					{Name: "test-quota", Hard: corev1.ResourceList{corev1.ResourcePods: resource.MustParse("10")}},
+				},
+				LimitRanges: []tenancy.TenantLimitRange{
+					{Name: "test-limits", Limits: []corev1.LimitRangeItem{{Type: corev1.LimitTypeContainer}}},
+				},
+				NetworkPolicy: &tenancy.TenantNetworkPolicy{
+					AllowedPeers: []networkingv1.NetworkPolicyPeer{{NamespaceSelector: &metav1.LabelSelector{}}},
+				},
+			},
+		},
+	})
+	require.NoError(t, err)
+
+	for _, obj := range resources {
+		_, _, err := scheme.ObjectKinds(obj)
+		assert.NoError(t, err, "%T", obj)
+	}
+}
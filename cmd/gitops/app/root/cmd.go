@@ -11,12 +11,14 @@ import (
 	"github.com/spf13/viper"
 	"github.com/weaveworks/weave-gitops-enterprise/cmd/gitops/app/add"
 	"github.com/weaveworks/weave-gitops-enterprise/cmd/gitops/app/bootstrap"
+	checktenants "github.com/weaveworks/weave-gitops-enterprise/cmd/gitops/app/check/tenants"
 	"github.com/weaveworks/weave-gitops-enterprise/cmd/gitops/app/connect"
 	"github.com/weaveworks/weave-gitops-enterprise/cmd/gitops/app/create"
 	"github.com/weaveworks/weave-gitops-enterprise/cmd/gitops/app/delete"
 	"github.com/weaveworks/weave-gitops-enterprise/cmd/gitops/app/disconnect"
 	"github.com/weaveworks/weave-gitops-enterprise/cmd/gitops/app/generate"
 	"github.com/weaveworks/weave-gitops-enterprise/cmd/gitops/app/get"
+	"github.com/weaveworks/weave-gitops-enterprise/cmd/gitops/app/test"
 	"github.com/weaveworks/weave-gitops-enterprise/cmd/gitops/app/update"
 	"github.com/weaveworks/weave-gitops-enterprise/cmd/gitops/app/upgrade"
 	"github.com/weaveworks/weave-gitops-enterprise/cmd/gitops/pkg/adapters"
@@ -129,14 +131,17 @@ func Command(client *adapters.HTTPClient) *cobra.Command {
 	rootCmd.AddCommand(version.Cmd)
 	rootCmd.AddCommand(get.Command(options, client))
 	rootCmd.AddCommand(add.Command(options, client))
-	rootCmd.AddCommand(create.Command())
+	rootCmd.AddCommand(create.Command(options, client))
 	rootCmd.AddCommand(update.Command(options, client))
 	rootCmd.AddCommand(delete.Command(options, client))
-	rootCmd.AddCommand(upgrade.Cmd)
+	rootCmd.AddCommand(upgrade.Command(options, client))
 	rootCmd.AddCommand(docs.Cmd)
-	rootCmd.AddCommand(check.GetCommand(options))
+	checkCmd := check.GetCommand(options)
+	checkCmd.AddCommand(checktenants.CheckCommand())
+	rootCmd.AddCommand(checkCmd)
 	rootCmd.AddCommand(set.SetCommand(options))
 	rootCmd.AddCommand(generate.Command())
+	rootCmd.AddCommand(test.Command())
 	rootCmd.AddCommand(bootstrap.Command(options))
 	rootCmd.AddCommand(connect.Command(options))
 	rootCmd.AddCommand(disconnect.Command(options))
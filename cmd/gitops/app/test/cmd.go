@@ -0,0 +1,20 @@
+package test
+
+import (
+	"github.com/spf13/cobra"
+	"github.com/weaveworks/weave-gitops-enterprise/cmd/gitops/app/test/templates"
+)
+
+func Command() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "test",
+		Short: "Test one or many resources",
+		Example: `
+# Run the tests of a template
+gitops test templates template.test.yaml`,
+	}
+
+	cmd.AddCommand(templates.TestCommand())
+
+	return cmd
+}
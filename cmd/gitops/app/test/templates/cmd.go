@@ -0,0 +1,140 @@
+package templates
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/weaveworks/weave-gitops/core/logger"
+	"helm.sh/helm/v3/pkg/cli"
+)
+
+type templatesCommandFlags struct {
+	JUnitReport  string
+	Update       bool
+	HelmRepoName string
+}
+
+var flags templatesCommandFlags
+
+// TestCommand returns a cobra command that runs the test cases of templates.
+func TestCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "templates TEST_FILE...",
+		Short: "Test what templates render",
+		Long: `Render templates offline with the parameter values, profiles and kustomizations of test cases,
+and check the rendered files against expected files and JSONPath assertions.
+
+A test file names the template it tests, relative to itself, and holds its test cases:
+
+  template: ../cluster-template.yaml
+  tests:
+    - name: renders the cluster
+      values:
+        CLUSTER_NAME: dev
+      expectedFiles:
+        clusters/dev.yaml: expected/dev.yaml
+      assertions:
+        - file: clusters/dev.yaml
+          kind: Cluster
+          path: "{.metadata.name}"
+          value: dev
+    - name: rejects an invalid name
+      values:
+        CLUSTER_NAME: Dev
+      expectedError: invalid parameters`,
+		Example: `
+	  # Run the tests of a template
+	  gitops test templates cluster-template.test.yaml
+
+	  # Run the tests of templates, and write a JUnit report for CI
+	  gitops test templates tests/*.test.yaml --junit-report report.xml
+
+	  # Update the expected files with the rendered files
+	  gitops test templates cluster-template.test.yaml --update
+	`,
+		SilenceUsage: true,
+		Args:         cobra.MinimumNArgs(1),
+		RunE:         testTemplatesCmdRunE(),
+	}
+
+	cmd.Flags().StringVar(&flags.JUnitReport, "junit-report", "", "write the results to a file as a JUnit XML report")
+	cmd.Flags().BoolVar(&flags.Update, "update", false, "update the expected files with the rendered files")
+	cmd.Flags().StringVar(&flags.HelmRepoName, "helm-repo-name", "weaveworks-charts", "name of the helm repo in the helm local cache")
+
+	return cmd
+}
+
+func testTemplatesCmdRunE() func(*cobra.Command, []string) error {
+	return func(cmd *cobra.Command, args []string) error {
+		log, err := logger.New(logger.DefaultLogLevel, true)
+		if err != nil {
+			return fmt.Errorf("failed to create logger: %w", err)
+		}
+
+		runner := Runner{
+			HelmRepoName: flags.HelmRepoName,
+			Settings:     cli.New(),
+			Update:       flags.Update,
+			Log:          log,
+		}
+
+		results := []SuiteResult{}
+		for _, filename := range args {
+			results = append(results, runner.RunFile(filename))
+		}
+
+		if flags.JUnitReport != "" {
+			f, err := os.Create(flags.JUnitReport)
+			if err != nil {
+				return fmt.Errorf("failed to create JUnit report: %w", err)
+			}
+			defer f.Close()
+
+			if err := WriteJUnitReport(f, results); err != nil {
+				return err
+			}
+		}
+
+		return WriteResults(cmd.OutOrStdout(), results)
+	}
+}
+
+// WriteResults writes the outcome of each test case, and returns an error if any failed.
+func WriteResults(out io.Writer, results []SuiteResult) error {
+	total, failed := 0, 0
+	for _, result := range results {
+		if result.Err != nil {
+			total++
+			failed++
+			fmt.Fprintf(out, "ERROR %s: %s\n", result.File, result.Err)
+			continue
+		}
+
+		for _, c := range result.Cases {
+			total++
+			switch {
+			case c.Err != nil:
+				failed++
+				fmt.Fprintf(out, "ERROR %s/%s: %s\n", result.Name, c.Name, c.Err)
+			case len(c.Failures) > 0:
+				failed++
+				fmt.Fprintf(out, "FAIL  %s/%s\n", result.Name, c.Name)
+				for _, failure := range c.Failures {
+					fmt.Fprintf(out, "    %s\n", failure)
+				}
+			default:
+				fmt.Fprintf(out, "PASS  %s/%s\n", result.Name, c.Name)
+			}
+		}
+	}
+
+	fmt.Fprintf(out, "%d passed, %d failed\n", total-failed, failed)
+
+	if failed > 0 {
+		return fmt.Errorf("%d of %d template tests failed", failed, total)
+	}
+
+	return nil
+}
@@ -0,0 +1,39 @@
+package templates
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTestCommand(t *testing.T) {
+	t.Setenv("HELM_REPOSITORY_CACHE", testSettings.RepositoryCache)
+	t.Setenv("HELM_REPOSITORY_CONFIG", testSettings.RepositoryConfig)
+
+	report := filepath.Join(t.TempDir(), "report.xml")
+
+	cmd := TestCommand()
+	out := &bytes.Buffer{}
+	cmd.SetOut(out)
+	cmd.SetErr(out)
+	cmd.SetArgs([]string{
+		"testdata/template.test.yaml",
+		"testdata/failing.test.yaml",
+		"--helm-repo-name", "test-repo",
+		"--junit-report", report,
+	})
+
+	err := cmd.Execute()
+	assert.EqualError(t, err, "3 of 6 template tests failed")
+	assert.Contains(t, out.String(), "PASS  test-template/renders the deployment\n")
+	assert.Contains(t, out.String(), "FAIL  test-template/renders the wrong deployment\n    file apps/missing.yaml was not rendered\n")
+	assert.Contains(t, out.String(), "ERROR test-template/misses a required parameter: ")
+	assert.Contains(t, out.String(), "3 passed, 3 failed\n")
+
+	b, err := os.ReadFile(report)
+	assert.NoError(t, err)
+	assert.Contains(t, string(b), `<testsuites tests="6" failures="2" errors="1"`)
+}
@@ -0,0 +1,112 @@
+package templates
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+type junitTestSuites struct {
+	XMLName  xml.Name         `xml:"testsuites"`
+	Tests    int              `xml:"tests,attr"`
+	Failures int              `xml:"failures,attr"`
+	Errors   int              `xml:"errors,attr"`
+	Time     string           `xml:"time,attr"`
+	Suites   []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name     string          `xml:"name,attr"`
+	File     string          `xml:"file,attr,omitempty"`
+	Tests    int             `xml:"tests,attr"`
+	Failures int             `xml:"failures,attr"`
+	Errors   int             `xml:"errors,attr"`
+	Time     string          `xml:"time,attr"`
+	Cases    []junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	Classname string        `xml:"classname,attr"`
+	Time      string        `xml:"time,attr"`
+	Failure   *junitMessage `xml:"failure,omitempty"`
+	Error     *junitMessage `xml:"error,omitempty"`
+}
+
+type junitMessage struct {
+	Message string `xml:"message,attr"`
+	Content string `xml:",chardata"`
+}
+
+// WriteJUnitReport writes the results as a JUnit XML report, with a test suite for each test file.
+func WriteJUnitReport(out io.Writer, results []SuiteResult) error {
+	report := junitTestSuites{}
+
+	var total time.Duration
+	for _, result := range results {
+		total += result.Time
+		suite := junitTestSuite{
+			Name: result.Name,
+			File: result.File,
+			Time: junitTime(result.Time),
+		}
+
+		if result.Err != nil {
+			suite.Cases = append(suite.Cases, junitTestCase{
+				Name:      result.File,
+				Classname: result.Name,
+				Time:      junitTime(result.Time),
+				Error:     &junitMessage{Message: result.Err.Error()},
+			})
+			suite.Errors++
+		}
+
+		for _, c := range result.Cases {
+			tc := junitTestCase{
+				Name:      c.Name,
+				Classname: result.Name,
+				Time:      junitTime(c.Time),
+			}
+
+			switch {
+			case c.Err != nil:
+				tc.Error = &junitMessage{Message: c.Err.Error()}
+				suite.Errors++
+			case len(c.Failures) > 0:
+				tc.Failure = &junitMessage{
+					Message: fmt.Sprintf("%d checks failed", len(c.Failures)),
+					Content: strings.Join(c.Failures, "\n"),
+				}
+				suite.Failures++
+			}
+
+			suite.Cases = append(suite.Cases, tc)
+		}
+
+		suite.Tests = len(suite.Cases)
+		report.Tests += suite.Tests
+		report.Failures += suite.Failures
+		report.Errors += suite.Errors
+		report.Suites = append(report.Suites, suite)
+	}
+	report.Time = junitTime(total)
+
+	if _, err := io.WriteString(out, xml.Header); err != nil {
+		return err
+	}
+
+	enc := xml.NewEncoder(out)
+	enc.Indent("", "  ")
+	if err := enc.Encode(report); err != nil {
+		return fmt.Errorf("failed to write JUnit report: %w", err)
+	}
+
+	_, err := io.WriteString(out, "\n")
+	return err
+}
+
+func junitTime(d time.Duration) string {
+	return fmt.Sprintf("%.3f", d.Seconds())
+}
@@ -0,0 +1,373 @@
+package templates
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/google/go-cmp/cmp"
+	templatesv1 "github.com/weaveworks/templates-controller/apis/core"
+	capiv1_proto "github.com/weaveworks/weave-gitops-enterprise/cmd/clusters-service/pkg/protos"
+	"github.com/weaveworks/weave-gitops-enterprise/cmd/clusters-service/pkg/templates"
+	createtemplates "github.com/weaveworks/weave-gitops-enterprise/cmd/gitops/app/create/templates"
+	clitemplates "github.com/weaveworks/weave-gitops-enterprise/cmd/gitops/pkg/templates"
+	"helm.sh/helm/v3/pkg/cli"
+	utilyaml "k8s.io/apimachinery/pkg/util/yaml"
+	"k8s.io/client-go/util/jsonpath"
+	"sigs.k8s.io/yaml"
+)
+
+// TestFile declares the test cases of a template.
+type TestFile struct {
+	// Template is the path to the template file, relative to the test file.
+	Template string `json:"template"`
+	// Includes are the paths to the template files, or directories of template files, that
+	// the templates included by the template are found in, relative to the test file. The
+	// directory of the template is always searched.
+	Includes []string   `json:"includes,omitempty"`
+	Tests    []TestCase `json:"tests"`
+}
+
+// TestCase renders the template with parameter values, profiles and kustomizations,
+// and checks the files it renders.
+type TestCase struct {
+	Name           string            `json:"name"`
+	Values         map[string]string `json:"values,omitempty"`
+	Profiles       []Profile         `json:"profiles,omitempty"`
+	Kustomizations []Kustomization   `json:"kustomizations,omitempty"`
+	// ExpectedFiles are the paths of the files the template must render, with the paths
+	// to the files holding their expected content, relative to the test file.
+	ExpectedFiles map[string]string `json:"expectedFiles,omitempty"`
+	Assertions    []Assertion       `json:"assertions,omitempty"`
+	// ExpectedError is a part of the error rendering must fail with.
+	ExpectedError string `json:"expectedError,omitempty"`
+}
+
+// Profile is a profile to add to the rendered files, with its values as YAML.
+type Profile struct {
+	Name      string `json:"name"`
+	Version   string `json:"version,omitempty"`
+	Namespace string `json:"namespace,omitempty"`
+	Values    string `json:"values,omitempty"`
+}
+
+// Kustomization is a kustomization to add to the rendered files.
+type Kustomization struct {
+	Name            string    `json:"name"`
+	Namespace       string    `json:"namespace,omitempty"`
+	Path            string    `json:"path"`
+	SourceRef       SourceRef `json:"sourceRef"`
+	TargetNamespace string    `json:"targetNamespace,omitempty"`
+	CreateNamespace bool      `json:"createNamespace,omitempty"`
+}
+
+type SourceRef struct {
+	Name      string `json:"name"`
+	Namespace string `json:"namespace,omitempty"`
+}
+
+// Assertion checks the value of a field of a resource in a rendered file.
+type Assertion struct {
+	File string `json:"file"`
+	// Kind and Name select the resource of the file, the first one if not set.
+	Kind string `json:"kind,omitempty"`
+	Name string `json:"name,omitempty"`
+	// Path is a JSONPath expression, e.g. `{.spec.replicas}`.
+	Path  string `json:"path"`
+	Value string `json:"value"`
+}
+
+// SuiteResult is the outcome of the test cases of a test file.
+type SuiteResult struct {
+	// Name is the name of the template, or the test file if it couldn't be read.
+	Name  string
+	File  string
+	Cases []CaseResult
+	// Err is an error reading the test file or the template.
+	Err  error
+	Time time.Duration
+}
+
+// CaseResult is the outcome of a test case. Failures are the checks that failed, and Err
+// an error that stopped the test case from running.
+type CaseResult struct {
+	Name     string
+	Failures []string
+	Err      error
+	Time     time.Duration
+}
+
+// Passed tells whether the test case passed.
+func (r CaseResult) Passed() bool {
+	return len(r.Failures) == 0 && r.Err == nil
+}
+
+// Runner runs the test cases of templates.
+type Runner struct {
+	// HelmRepoName is the helm repo of the local cache the charts of profiles are read from.
+	HelmRepoName string
+	Settings     *cli.EnvSettings
+	// Update writes the rendered files to the expected files, rather than comparing them.
+	Update bool
+	Log    logr.Logger
+}
+
+// RunFile runs the test cases of a test file.
+func (r Runner) RunFile(filename string) SuiteResult {
+	start := time.Now()
+	result := SuiteResult{Name: filename, File: filename}
+
+	testFile, tmpl, err := loadTestFile(filename)
+	if err != nil {
+		result.Err = err
+		result.Time = time.Since(start)
+		return result
+	}
+	result.Name = tmpl.GetName()
+
+	dir := filepath.Dir(filename)
+	for _, tc := range testFile.Tests {
+		result.Cases = append(result.Cases, r.runCase(tmpl, dir, tc))
+	}
+	result.Time = time.Since(start)
+
+	return result
+}
+
+func (r Runner) runCase(tmpl templatesv1.Template, dir string, tc TestCase) CaseResult {
+	start := time.Now()
+	result := CaseResult{Name: tc.Name}
+	defer func() {
+		result.Time = time.Since(start)
+	}()
+
+	files, err := r.render(tmpl, tc)
+	if tc.ExpectedError != "" {
+		switch {
+		case err == nil:
+			result.Failures = append(result.Failures, fmt.Sprintf("expected an error containing %q, got none", tc.ExpectedError))
+		case !strings.Contains(err.Error(), tc.ExpectedError):
+			result.Failures = append(result.Failures, fmt.Sprintf("expected an error containing %q, got %q", tc.ExpectedError, err.Error()))
+		}
+		return result
+	}
+	if err != nil {
+		result.Err = err
+		return result
+	}
+
+	paths := []string{}
+	for path := range tc.ExpectedFiles {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	for _, path := range paths {
+		failure, err := r.checkFile(files, path, filepath.Join(dir, tc.ExpectedFiles[path]))
+		if err != nil {
+			result.Err = err
+			return result
+		}
+		if failure != "" {
+			result.Failures = append(result.Failures, failure)
+		}
+	}
+
+	for _, assertion := range tc.Assertions {
+		if failure := checkAssertion(files, assertion); failure != "" {
+			result.Failures = append(result.Failures, failure)
+		}
+	}
+
+	return result
+}
+
+// render renders the files of the test case, by path.
+func (r Runner) render(tmpl templatesv1.Template, tc TestCase) (map[string]string, error) {
+	// Rendering sets the defaults of the parameters in the values.
+	values := map[string]string{}
+	for k, v := range tc.Values {
+		values[k] = v
+	}
+
+	profiles := []*capiv1_proto.ProfileValues{}
+	for _, p := range tc.Profiles {
+		profiles = append(profiles, &capiv1_proto.ProfileValues{
+			Name:      p.Name,
+			Version:   p.Version,
+			Namespace: p.Namespace,
+			Values:    base64.StdEncoding.EncodeToString([]byte(p.Values)),
+		})
+	}
+
+	kustomizations := []*capiv1_proto.Kustomization{}
+	for _, k := range tc.Kustomizations {
+		kustomizations = append(kustomizations, &capiv1_proto.Kustomization{
+			Metadata: &capiv1_proto.Metadata{
+				Name:      k.Name,
+				Namespace: k.Namespace,
+			},
+			Spec: &capiv1_proto.KustomizationSpec{
+				Path: k.Path,
+				SourceRef: &capiv1_proto.SourceRef{
+					Name:      k.SourceRef.Name,
+					Namespace: k.SourceRef.Namespace,
+				},
+				TargetNamespace: k.TargetNamespace,
+				CreateNamespace: k.CreateNamespace,
+			},
+		})
+	}
+
+	commitFiles, err := createtemplates.GenerateFilesLocally(tmpl, values, r.HelmRepoName, profiles, kustomizations, r.Settings, r.Log)
+	if err != nil {
+		return nil, err
+	}
+
+	files := map[string]string{}
+	for _, f := range commitFiles {
+		if f.Content != nil {
+			files[f.Path] = *f.Content
+		}
+	}
+
+	return files, nil
+}
+
+// checkFile compares a rendered file with its expected content, or writes it to the
+// expected file when updating them.
+func (r Runner) checkFile(files map[string]string, path, expectedFile string) (string, error) {
+	content, ok := files[path]
+	if !ok {
+		return fmt.Sprintf("file %s was not rendered", path), nil
+	}
+
+	if r.Update {
+		if err := os.MkdirAll(filepath.Dir(expectedFile), 0o755); err != nil {
+			return "", fmt.Errorf("failed to create directory for %s: %w", expectedFile, err)
+		}
+		if err := os.WriteFile(expectedFile, []byte(content), 0o644); err != nil {
+			return "", fmt.Errorf("failed to update %s: %w", expectedFile, err)
+		}
+		return "", nil
+	}
+
+	expected, err := os.ReadFile(expectedFile)
+	if err != nil {
+		return "", fmt.Errorf("failed to read expected file for %s: %w", path, err)
+	}
+
+	if diff := cmp.Diff(strings.TrimSpace(string(expected)), strings.TrimSpace(content)); diff != "" {
+		return fmt.Sprintf("file %s differs from %s (-expected +rendered):\n%s", path, expectedFile, diff), nil
+	}
+
+	return "", nil
+}
+
+func checkAssertion(files map[string]string, a Assertion) string {
+	content, ok := files[a.File]
+	if !ok {
+		return fmt.Sprintf("file %s was not rendered", a.File)
+	}
+
+	obj, err := findResource(content, a.Kind, a.Name)
+	if err != nil {
+		return fmt.Sprintf("file %s: %s", a.File, err)
+	}
+
+	jp := jsonpath.New("assertion")
+	if err := jp.Parse(a.Path); err != nil {
+		return fmt.Sprintf("invalid path %q: %s", a.Path, err)
+	}
+
+	var out bytes.Buffer
+	if err := jp.Execute(&out, obj); err != nil {
+		return fmt.Sprintf("file %s: %s: %s", a.File, a.Path, err)
+	}
+
+	if out.String() != a.Value {
+		return fmt.Sprintf("file %s: %s is %q, expected %q", a.File, a.Path, out.String(), a.Value)
+	}
+
+	return ""
+}
+
+// findResource returns the first resource of the file of the kind and name, if they are set.
+func findResource(content, kind, name string) (interface{}, error) {
+	decoder := utilyaml.NewYAMLOrJSONDecoder(strings.NewReader(content), 4096)
+	for {
+		obj := map[string]interface{}{}
+		if err := decoder.Decode(&obj); err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			return nil, fmt.Errorf("failed to parse: %w", err)
+		}
+		if len(obj) == 0 {
+			continue
+		}
+
+		if kind != "" && obj["kind"] != kind {
+			continue
+		}
+		if name != "" {
+			metadata, _ := obj["metadata"].(map[string]interface{})
+			if metadata["name"] != name {
+				continue
+			}
+		}
+
+		return obj, nil
+	}
+
+	return nil, fmt.Errorf("no resource of kind %q and name %q", kind, name)
+}
+
+// loadTestFile reads a test file and the template it tests.
+func loadTestFile(filename string) (*TestFile, templatesv1.Template, error) {
+	b, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read test file %s: %w", filename, err)
+	}
+
+	testFile := &TestFile{}
+	if err := yaml.UnmarshalStrict(b, testFile); err != nil {
+		return nil, nil, fmt.Errorf("failed to parse test file %s: %w", filename, err)
+	}
+
+	if testFile.Template == "" {
+		return nil, nil, fmt.Errorf("test file %s must specify a template", filename)
+	}
+
+	dir := filepath.Dir(filename)
+	templateFile := filepath.Join(dir, testFile.Template)
+	tmpl, err := clitemplates.ParseTemplateFile(templateFile)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	includes := []string{filepath.Dir(templateFile)}
+	for _, path := range testFile.Includes {
+		includes = append(includes, filepath.Join(dir, path))
+	}
+	getter, err := clitemplates.LocalTemplateGetter(includes)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	tmpl, err = templates.ResolveIncludes(context.Background(), tmpl, getter)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to resolve the templates included by %s: %w", templateFile, err)
+	}
+
+	return testFile, tmpl, nil
+}
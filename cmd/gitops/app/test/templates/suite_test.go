@@ -0,0 +1,146 @@
+package templates
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/google/go-cmp/cmp"
+	"github.com/stretchr/testify/assert"
+	"helm.sh/helm/v3/pkg/cli"
+)
+
+var testSettings *cli.EnvSettings = &cli.EnvSettings{
+	RepositoryConfig: "testdata/repositories.yaml",
+	RepositoryCache:  "testdata/repository",
+}
+
+func newTestRunner() Runner {
+	return Runner{
+		HelmRepoName: "test-repo",
+		Settings:     testSettings,
+		Log:          logr.Discard(),
+	}
+}
+
+func TestRunFile(t *testing.T) {
+	result := newTestRunner().RunFile("testdata/template.test.yaml")
+	assert.NoError(t, result.Err)
+	assert.Equal(t, "test-template", result.Name)
+
+	names := []string{}
+	for _, c := range result.Cases {
+		assert.True(t, c.Passed(), "%s: %v %v", c.Name, c.Failures, c.Err)
+		names = append(names, c.Name)
+	}
+	assert.Equal(t, []string{"renders the deployment", "renders the profiles", "rejects too many replicas"}, names)
+}
+
+func TestRunFile_failures(t *testing.T) {
+	result := newTestRunner().RunFile("testdata/failing.test.yaml")
+	assert.NoError(t, result.Err)
+
+	if len(result.Cases) != 3 {
+		t.Fatalf("expected 3 test cases, got %d", len(result.Cases))
+	}
+
+	wrong := result.Cases[0]
+	assert.NoError(t, wrong.Err)
+	if len(wrong.Failures) != 4 {
+		t.Fatalf("expected 4 failures, got %q", wrong.Failures)
+	}
+	assert.Equal(t, "file apps/missing.yaml was not rendered", wrong.Failures[0])
+	assert.Contains(t, wrong.Failures[1], "file apps/podinfo.yaml differs from testdata/expected/podinfo.yaml (-expected +rendered):")
+	assert.Equal(t, `file apps/podinfo.yaml: {.spec.replicas} is "1", expected "3"`, wrong.Failures[2])
+	assert.Equal(t, `file apps/podinfo.yaml: no resource of kind "Service" and name ""`, wrong.Failures[3])
+
+	assert.Equal(t, []string{`expected an error containing "must be at most 6", got "failed to get template resources: failed to render template with parameter values: error rendering template test-template, invalid parameters: REPLICAS: must be at most 5"`}, result.Cases[1].Failures)
+
+	assert.EqualError(t, result.Cases[2].Err, "failed to get template resources: failed to render template with parameter values: error rendering template test-template, missing required parameter: NAMESPACE")
+}
+
+func TestRunFile_invalid(t *testing.T) {
+	dir := t.TempDir()
+	filename := filepath.Join(dir, "invalid.test.yaml")
+	if err := os.WriteFile(filename, []byte("template: missing.yaml\ntests: []\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	result := newTestRunner().RunFile(filename)
+	assert.ErrorContains(t, result.Err, "failed to read template file")
+	assert.Equal(t, filename, result.Name)
+}
+
+func TestRunFile_update(t *testing.T) {
+	dir := t.TempDir()
+	for _, name := range []string{"template.yaml", "template.test.yaml"} {
+		b, err := os.ReadFile(filepath.Join("testdata", name))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(dir, name), b, 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	runner := newTestRunner()
+	runner.Update = true
+	result := runner.RunFile(filepath.Join(dir, "template.test.yaml"))
+	assert.NoError(t, result.Err)
+	for _, c := range result.Cases {
+		assert.True(t, c.Passed(), "%s: %v %v", c.Name, c.Failures, c.Err)
+	}
+
+	updated, err := os.ReadFile(filepath.Join(dir, "expected/podinfo.yaml"))
+	assert.NoError(t, err)
+	expected, err := os.ReadFile("testdata/expected/podinfo.yaml")
+	assert.NoError(t, err)
+	assert.Equal(t, string(expected), string(updated))
+}
+
+func TestWriteJUnitReport(t *testing.T) {
+	results := []SuiteResult{
+		{
+			Name: "test-template",
+			File: "template.test.yaml",
+			Cases: []CaseResult{
+				{Name: "passes"},
+				{Name: "fails", Failures: []string{"file a.yaml was not rendered", "file b.yaml was not rendered"}},
+				{Name: "errors", Err: errors.New("missing required parameter: NAMESPACE")},
+			},
+		},
+		{
+			Name: "broken.test.yaml",
+			File: "broken.test.yaml",
+			Err:  errors.New("failed to read test file broken.test.yaml"),
+		},
+	}
+
+	out := &bytes.Buffer{}
+	assert.NoError(t, WriteJUnitReport(out, results))
+
+	expected := `<?xml version="1.0" encoding="UTF-8"?>
+<testsuites tests="4" failures="1" errors="2" time="0.000">
+  <testsuite name="test-template" file="template.test.yaml" tests="3" failures="1" errors="1" time="0.000">
+    <testcase name="passes" classname="test-template" time="0.000"></testcase>
+    <testcase name="fails" classname="test-template" time="0.000">
+      <failure message="2 checks failed">file a.yaml was not rendered&#xA;file b.yaml was not rendered</failure>
+    </testcase>
+    <testcase name="errors" classname="test-template" time="0.000">
+      <error message="missing required parameter: NAMESPACE"></error>
+    </testcase>
+  </testsuite>
+  <testsuite name="broken.test.yaml" file="broken.test.yaml" tests="1" failures="0" errors="1" time="0.000">
+    <testcase name="broken.test.yaml" classname="broken.test.yaml" time="0.000">
+      <error message="failed to read test file broken.test.yaml"></error>
+    </testcase>
+  </testsuite>
+</testsuites>
+`
+	if diff := cmp.Diff(expected, out.String()); diff != "" {
+		t.Fatalf("report didn't match expected:\n%s", diff)
+	}
+}
@@ -6,8 +6,11 @@ import (
 	"os"
 
 	"github.com/spf13/cobra"
+	"github.com/weaveworks/weave-gitops-enterprise/cmd/gitops/app/upgrade/templates"
 	"github.com/weaveworks/weave-gitops-enterprise/cmd/gitops/internal"
+	"github.com/weaveworks/weave-gitops-enterprise/cmd/gitops/pkg/adapters"
 	"github.com/weaveworks/weave-gitops-enterprise/cmd/gitops/pkg/upgrade"
+	"github.com/weaveworks/weave-gitops/cmd/gitops/config"
 	"github.com/weaveworks/weave-gitops/pkg/flux"
 	"github.com/weaveworks/weave-gitops/pkg/kube"
 	"github.com/weaveworks/weave-gitops/pkg/logger"
@@ -24,26 +27,32 @@ var example = `  # Upgrade Weave GitOps
   gitops upgrade --version 0.0.17 --set "agentTemplate.natsURL=my-cluster.acme.org:4222" \
     --config-repo https://github.com/my-org/my-management-cluster.git`
 
-var Cmd = &cobra.Command{
-	Use:           "upgrade",
-	Short:         "Upgrade to Weave GitOps Enterprise",
-	Example:       example,
-	RunE:          upgradeCmdRunE(),
-	SilenceErrors: true,
-	SilenceUsage:  true,
-}
+// Command returns the upgrade command, which upgrades Weave GitOps
+// Enterprise, and whose subcommands upgrade other resources.
+func Command(opts *config.Options, client *adapters.HTTPClient) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:           "upgrade",
+		Short:         "Upgrade to Weave GitOps Enterprise",
+		Example:       example,
+		RunE:          upgradeCmdRunE(),
+		SilenceErrors: true,
+		SilenceUsage:  true,
+	}
+
+	cmd.Flags().StringVar(&upgradeCmdFlags.ConfigRepo, "config-repo", "", "URL of external repository that will hold automation manifests")
+	cmd.Flags().StringVar(&upgradeCmdFlags.Version, "version", "", "Version of Weave GitOps Enterprise to be installed")
+	cmd.Flags().StringVar(&upgradeCmdFlags.BaseBranch, "base", "", "The base branch to open the pull request against")
+	cmd.Flags().StringVar(&upgradeCmdFlags.HeadBranch, "branch", "tier-upgrade-enterprise", "The branch to create the pull request from")
+	cmd.Flags().StringVar(&upgradeCmdFlags.ClusterPath, "path", "", "The path within the Git repository containing files for the current cluster")
+	cmd.Flags().StringVar(&upgradeCmdFlags.CommitMessage, "commit-message", "Upgrade to WGE", "The commit message")
+	cmd.Flags().StringArrayVar(&upgradeCmdFlags.Values, "set", []string{}, "set profile values on the command line (can specify multiple or separate values with commas: key1=val1,key2=val2)")
+	cmd.Flags().BoolVar(&upgradeCmdFlags.DryRun, "dry-run", false, "Output the generated profile without creating a pull request")
+
+	cobra.CheckErr(cmd.MarkFlagRequired("version"))
+
+	cmd.AddCommand(templates.UpgradeCommand(opts, client))
 
-func init() {
-	Cmd.PersistentFlags().StringVar(&upgradeCmdFlags.ConfigRepo, "config-repo", "", "URL of external repository that will hold automation manifests")
-	Cmd.PersistentFlags().StringVar(&upgradeCmdFlags.Version, "version", "", "Version of Weave GitOps Enterprise to be installed")
-	Cmd.PersistentFlags().StringVar(&upgradeCmdFlags.BaseBranch, "base", "", "The base branch to open the pull request against")
-	Cmd.PersistentFlags().StringVar(&upgradeCmdFlags.HeadBranch, "branch", "tier-upgrade-enterprise", "The branch to create the pull request from")
-	Cmd.PersistentFlags().StringVar(&upgradeCmdFlags.ClusterPath, "path", "", "The path within the Git repository containing files for the current cluster")
-	Cmd.PersistentFlags().StringVar(&upgradeCmdFlags.CommitMessage, "commit-message", "Upgrade to WGE", "The commit message")
-	Cmd.PersistentFlags().StringArrayVar(&upgradeCmdFlags.Values, "set", []string{}, "set profile values on the command line (can specify multiple or separate values with commas: key1=val1,key2=val2)")
-	Cmd.PersistentFlags().BoolVar(&upgradeCmdFlags.DryRun, "dry-run", false, "Output the generated profile without creating a pull request")
-
-	cobra.CheckErr(Cmd.MarkPersistentFlagRequired("version"))
+	return cmd
 }
 
 func upgradeCmdRunE() func(*cobra.Command, []string) error {
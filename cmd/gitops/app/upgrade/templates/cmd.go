@@ -0,0 +1,118 @@
+package templates
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/weaveworks/weave-gitops-enterprise/cmd/gitops/internal"
+	"github.com/weaveworks/weave-gitops-enterprise/cmd/gitops/pkg/adapters"
+	"github.com/weaveworks/weave-gitops-enterprise/cmd/gitops/pkg/templates"
+	"github.com/weaveworks/weave-gitops/cmd/gitops/cmderrors"
+	"github.com/weaveworks/weave-gitops/cmd/gitops/config"
+	"github.com/weaveworks/weave-gitops/pkg/gitproviders"
+)
+
+type templatesUpgradeFlags struct {
+	RepositoryURL          string
+	BaseBranch             string
+	HeadBranch             string
+	Title                  string
+	Description            string
+	CommitMessage          string
+	TemplateNamespace      string
+	TemplateKind           string
+	Path                   string
+	PullRequestPerResource bool
+	DryRun                 bool
+}
+
+var flags templatesUpgradeFlags
+
+// UpgradeCommand returns a cobra command that upgrades the resources
+// created from a template to the current version of the template.
+func UpgradeCommand(opts *config.Options, client *adapters.HTTPClient) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "templates",
+		Aliases: []string{"template"},
+		Short:   "Upgrade the resources created from a template to its current version",
+		Long: `Find the resources in a directory of a repository that were created from a template, render them again
+with the parameters they were created with against the current version of the template,
+and open a pull request of the changes.`,
+		Example: `
+# Upgrade the resources created from a template in a directory of the repository
+gitops upgrade templates <template-name> --url https://github.com/org/repo.git --path clusters
+
+# Show the changes of upgrading the resources of a directory, without creating a pull request
+gitops upgrade templates <template-name> --url https://github.com/org/repo.git --path clusters/dev --dry-run
+
+# Open a pull request for each upgraded resource
+gitops upgrade templates <template-name> --url https://github.com/org/repo.git --path clusters --pr-per-resource
+		`,
+		SilenceUsage:  true,
+		SilenceErrors: true,
+		PreRunE:       upgradeTemplatesCmdPreRunE(&opts.Endpoint),
+		RunE:          upgradeTemplatesCmdRunE(opts, client),
+		Args:          cobra.ExactArgs(1),
+	}
+
+	cmd.Flags().StringVar(&flags.RepositoryURL, "url", "", "The repository to open a pull request against")
+	cmd.Flags().StringVar(&flags.TemplateNamespace, "template-namespace", "default", "The namespace of the template")
+	cmd.Flags().StringVar(&flags.TemplateKind, "template-kind", templates.CAPITemplateKind.String(), "The kind of the template. Supported templates: CAPITemplate, GitOpsTemplate")
+	cmd.Flags().StringVar(&flags.Path, "path", "", "The directory of the repository to search for resources created from the template")
+	cobra.CheckErr(cmd.MarkFlagRequired("path"))
+	cmd.Flags().BoolVar(&flags.PullRequestPerResource, "pr-per-resource", false, "Open a pull request for each upgraded resource")
+	cmd.Flags().BoolVar(&flags.DryRun, "dry-run", false, "Output the upgraded files without creating a pull request")
+	internal.AddPRFlags(cmd, &flags.HeadBranch, &flags.BaseBranch, &flags.Description, &flags.CommitMessage, &flags.Title)
+
+	return cmd
+}
+
+func upgradeTemplatesCmdPreRunE(endpoint *string) func(*cobra.Command, []string) error {
+	return func(cmd *cobra.Command, args []string) error {
+		if *endpoint == "" {
+			return cmderrors.ErrNoWGEEndpoint
+		}
+
+		return nil
+	}
+}
+
+func upgradeTemplatesCmdRunE(opts *config.Options, client *adapters.HTTPClient) func(*cobra.Command, []string) error {
+	return func(cmd *cobra.Command, args []string) error {
+		err := client.ConfigureClientWithOptions(opts, os.Stdout)
+		if err != nil {
+			return err
+		}
+
+		if flags.RepositoryURL == "" {
+			return cmderrors.ErrNoURL
+		}
+
+		url, err := gitproviders.NewRepoURL(flags.RepositoryURL)
+		if err != nil {
+			return fmt.Errorf("cannot parse url: %w", err)
+		}
+
+		token, err := internal.GetToken(url, os.LookupEnv)
+		if err != nil {
+			return err
+		}
+
+		return templates.UpgradeTemplateResources(templates.UpgradeTemplateResourcesParams{
+			GitProviderToken:       token,
+			TemplateName:           args[0],
+			TemplateNamespace:      flags.TemplateNamespace,
+			TemplateKind:           flags.TemplateKind,
+			RepositoryURL:          flags.RepositoryURL,
+			HeadBranch:             flags.HeadBranch,
+			BaseBranch:             flags.BaseBranch,
+			Title:                  flags.Title,
+			Description:            flags.Description,
+			CommitMessage:          flags.CommitMessage,
+			Path:                   flags.Path,
+			PullRequestPerResource: flags.PullRequestPerResource,
+			DryRun:                 flags.DryRun,
+		}, client, os.Stdout)
+	}
+}
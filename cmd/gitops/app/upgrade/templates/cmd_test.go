@@ -0,0 +1,69 @@
+package templates_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/weaveworks/weave-gitops-enterprise/cmd/gitops/app/root"
+	"github.com/weaveworks/weave-gitops-enterprise/cmd/gitops/pkg/adapters"
+)
+
+func TestEndpointNotSet(t *testing.T) {
+	client := adapters.NewHTTPClient()
+	cmd := root.Command(client)
+	cmd.SetArgs([]string{
+		"upgrade", "templates",
+		"cluster-template",
+		"--path=clusters",
+	})
+
+	err := cmd.Execute()
+	assert.EqualError(t, err, "the Weave GitOps Enterprise HTTP API endpoint flag (--endpoint) has not been set")
+}
+
+func TestPayload(t *testing.T) {
+	t.Setenv("GITHUB_TOKEN", "test-token")
+
+	client := adapters.NewHTTPClient()
+
+	httpmock.ActivateNonDefault(client.GetBaseClient())
+	defer httpmock.DeactivateAndReset()
+	httpmock.RegisterResponder(
+		http.MethodPost,
+		"http://localhost:8000/v1/namespaces/templates/templates/cluster-template/upgrade-pull-request",
+		func(r *http.Request) (*http.Response, error) {
+			h, ok := r.Header["Git-Provider-Token"]
+			assert.True(t, ok)
+			assert.Contains(t, h, "test-token")
+
+			var m map[string]interface{}
+			err := json.NewDecoder(r.Body).Decode(&m)
+			assert.NoError(t, err)
+
+			assert.Equal(t, "https://github.com/weaveworks/test-repo", m["repositoryUrl"])
+			assert.Equal(t, "CAPITemplate", m["templateKind"])
+			assert.Equal(t, "clusters/dev", m["path"])
+			assert.Equal(t, true, m["pullRequestPerResource"])
+			assert.Equal(t, false, m["dryRun"])
+
+			return httpmock.NewJsonResponse(http.StatusOK, httpmock.File("../../../pkg/adapters/testdata/template_upgrade_pull_request_created.json"))
+		},
+	)
+
+	cmd := root.Command(client)
+	cmd.SetArgs([]string{
+		"upgrade", "templates",
+		"cluster-template",
+		"--template-namespace=templates",
+		"--url=https://github.com/weaveworks/test-repo",
+		"--path=clusters/dev",
+		"--pr-per-resource",
+		"--endpoint", "http://localhost:8000",
+	})
+
+	err := cmd.Execute()
+	assert.NoError(t, err)
+}
@@ -17,6 +17,7 @@ import (
 
 	"github.com/weaveworks/weave-gitops-enterprise/cmd/gitops/pkg/clusters"
 	"github.com/weaveworks/weave-gitops-enterprise/cmd/gitops/pkg/templates"
+	"github.com/weaveworks/weave-gitops-enterprise/cmd/gitops/pkg/tenants"
 	"github.com/weaveworks/weave-gitops-enterprise/pkg/services/profiles"
 	"github.com/weaveworks/weave-gitops/cmd/gitops/config"
 	kubecfg "sigs.k8s.io/controller-runtime/pkg/client/config"
@@ -474,6 +475,126 @@ func (c *HTTPClient) CreatePullRequestFromTemplate(params templates.CreatePullRe
 	return result.WebURL, nil
 }
 
+// UpgradeTemplateResources re-renders the resources created from a template
+// against its current version and creates pull requests of the changes.
+func (c *HTTPClient) UpgradeTemplateResources(params templates.UpgradeTemplateResourcesParams) (*templates.UpgradeTemplateResourcesResponse, error) {
+	endpoint := "v1/namespaces/{namespace}/templates/{name}/upgrade-pull-request"
+
+	type CreateUpgradePullRequestRequest struct {
+		RepositoryURL          string `json:"repositoryUrl"`
+		HeadBranch             string `json:"headBranch"`
+		BaseBranch             string `json:"baseBranch"`
+		Title                  string `json:"title"`
+		Description            string `json:"description"`
+		CommitMessage          string `json:"commitMessage"`
+		TemplateKind           string `json:"templateKind"`
+		Path                   string `json:"path"`
+		PullRequestPerResource bool   `json:"pullRequestPerResource"`
+		DryRun                 bool   `json:"dryRun"`
+	}
+
+	var (
+		result     templates.UpgradeTemplateResourcesResponse
+		serviceErr *ServiceError
+	)
+
+	res, err := c.client.R().
+		SetHeader("Accept", "application/json").
+		SetHeader(gitProviderTokenHeaderName, params.GitProviderToken).
+		SetPathParams(map[string]string{
+			"namespace": params.TemplateNamespace,
+			"name":      params.TemplateName,
+		}).
+		SetBody(CreateUpgradePullRequestRequest{
+			RepositoryURL:          params.RepositoryURL,
+			HeadBranch:             params.HeadBranch,
+			BaseBranch:             params.BaseBranch,
+			Title:                  params.Title,
+			Description:            params.Description,
+			CommitMessage:          params.CommitMessage,
+			TemplateKind:           params.TemplateKind,
+			Path:                   params.Path,
+			PullRequestPerResource: params.PullRequestPerResource,
+			DryRun:                 params.DryRun,
+		}).
+		SetResult(&result).
+		SetError(&serviceErr).
+		Post(endpoint)
+
+	if serviceErr != nil {
+		return nil, fmt.Errorf("unable to POST template upgrade and create pull request to %q: %s", res.Request.URL, serviceErr.Message)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("unable to POST template upgrade and create pull request to %q: %w", res.Request.URL, err)
+	}
+
+	if res.StatusCode() != http.StatusOK {
+		return nil, fmt.Errorf("response status for POST %q was %d", res.Request.URL, res.StatusCode())
+	}
+
+	return &result, nil
+}
+
+// CreateTenantsPullRequest commits the resources of the tenants to the
+// specified branch and creates a pull request of that branch.
+func (c *HTTPClient) CreateTenantsPullRequest(params tenants.CreateTenantsPullRequestParams) (string, error) {
+	endpoint := "v1/tenants/pull-request"
+
+	type CreateTenantsPullRequestRequest struct {
+		RepositoryURL string            `json:"repositoryUrl"`
+		HeadBranch    string            `json:"headBranch"`
+		BaseBranch    string            `json:"baseBranch"`
+		Title         string            `json:"title"`
+		Description   string            `json:"description"`
+		CommitMessage string            `json:"commitMessage"`
+		Tenants       string            `json:"tenants"`
+		Clusters      []tenants.Cluster `json:"clusters"`
+		Prune         bool              `json:"prune"`
+	}
+
+	type CreateTenantsPullRequestResponse struct {
+		WebURL string `json:"webUrl"`
+	}
+
+	var (
+		result     CreateTenantsPullRequestResponse
+		serviceErr *ServiceError
+	)
+
+	res, err := c.client.R().
+		SetHeader("Accept", "application/json").
+		SetHeader(gitProviderTokenHeaderName, params.GitProviderToken).
+		SetBody(CreateTenantsPullRequestRequest{
+			RepositoryURL: params.RepositoryURL,
+			HeadBranch:    params.HeadBranch,
+			BaseBranch:    params.BaseBranch,
+			Title:         params.Title,
+			Description:   params.Description,
+			CommitMessage: params.CommitMessage,
+			Tenants:       params.Tenants,
+			Clusters:      params.Clusters,
+			Prune:         params.Prune,
+		}).
+		SetResult(&result).
+		SetError(&serviceErr).
+		Post(endpoint)
+
+	if serviceErr != nil {
+		return "", fmt.Errorf("unable to POST tenants and create pull request to %q: %s", res.Request.URL, serviceErr.Message)
+	}
+
+	if err != nil {
+		return "", fmt.Errorf("unable to POST tenants and create pull request to %q: %w", res.Request.URL, err)
+	}
+
+	if res.StatusCode() != http.StatusOK {
+		return "", fmt.Errorf("response status for POST %q was %d", res.Request.URL, res.StatusCode())
+	}
+
+	return result.WebURL, nil
+}
+
 // RetrieveCredentials returns a list of all CAPI credentials.
 func (c *HTTPClient) RetrieveCredentials() ([]templates.Credentials, error) {
 	endpoint := "v1/credentials"
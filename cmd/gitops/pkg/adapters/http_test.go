@@ -15,6 +15,7 @@ import (
 	"github.com/weaveworks/weave-gitops-enterprise/cmd/gitops/pkg/adapters"
 	"github.com/weaveworks/weave-gitops-enterprise/cmd/gitops/pkg/clusters"
 	"github.com/weaveworks/weave-gitops-enterprise/cmd/gitops/pkg/templates"
+	"github.com/weaveworks/weave-gitops-enterprise/cmd/gitops/pkg/tenants"
 	"github.com/weaveworks/weave-gitops/cmd/gitops/config"
 	"github.com/weaveworks/weave-gitops/pkg/testutils"
 )
@@ -798,6 +799,132 @@ func TestDeleteClusters(t *testing.T) {
 	}
 }
 
+func TestCreateTenantsPullRequest(t *testing.T) {
+	tests := []struct {
+		name       string
+		responder  httpmock.Responder
+		assertFunc func(t *testing.T, result string, err error)
+	}{
+		{
+			name:      "pull request created",
+			responder: httpmock.NewJsonResponderOrPanic(200, httpmock.File("./testdata/pull_request_created.json")),
+			assertFunc: func(t *testing.T, result string, err error) {
+				assert.Equal(t, result, "https://github.com/org/repo/pull/1")
+			},
+		},
+		{
+			name:      "service error",
+			responder: httpmock.NewJsonResponderOrPanic(500, httpmock.File("./testdata/service_error.json")),
+			assertFunc: func(t *testing.T, result string, err error) {
+				assert.EqualError(t, err, "unable to POST tenants and create pull request to \"https://weave.works/api/v1/tenants/pull-request\": something bad happened")
+			},
+		},
+		{
+			name:      "error returned",
+			responder: httpmock.NewErrorResponder(errors.New("oops")),
+			assertFunc: func(t *testing.T, result string, err error) {
+				assert.EqualError(t, err, "unable to POST tenants and create pull request to \"https://weave.works/api/v1/tenants/pull-request\": Post \"https://weave.works/api/v1/tenants/pull-request\": oops")
+			},
+		},
+		{
+			name:      "unexpected status code",
+			responder: httpmock.NewStringResponder(http.StatusBadRequest, ""),
+			assertFunc: func(t *testing.T, result string, err error) {
+				assert.EqualError(t, err, "response status for POST \"https://weave.works/api/v1/tenants/pull-request\" was 400")
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := &config.Options{
+				Endpoint: testutils.BaseURI,
+			}
+			client := adapters.NewHTTPClient()
+			httpmock.ActivateNonDefault(client.GetBaseClient())
+			defer httpmock.DeactivateAndReset()
+			httpmock.RegisterResponder("POST", testutils.BaseURI+"/v1/tenants/pull-request", tt.responder)
+
+			err := client.ConfigureClientWithOptions(opts, os.Stdout)
+			assert.NoError(t, err)
+			result, err := client.CreateTenantsPullRequest(tenants.CreateTenantsPullRequestParams{
+				Tenants:  "tenants: []",
+				Clusters: []tenants.Cluster{{Namespace: "default", Name: "leaf-1"}},
+			})
+			tt.assertFunc(t, result, err)
+		})
+	}
+}
+
+func TestUpgradeTemplateResources(t *testing.T) {
+	tests := []struct {
+		name       string
+		responder  httpmock.Responder
+		assertFunc func(t *testing.T, result *templates.UpgradeTemplateResourcesResponse, err error)
+	}{
+		{
+			name:      "pull request created",
+			responder: httpmock.NewJsonResponderOrPanic(200, httpmock.File("./testdata/template_upgrade_pull_request_created.json")),
+			assertFunc: func(t *testing.T, result *templates.UpgradeTemplateResourcesResponse, err error) {
+				assert.Equal(t, &templates.UpgradeTemplateResourcesResponse{
+					Version: "2",
+					Upgrades: []templates.TemplateResourceUpgrade{
+						{
+							Path:    "clusters/default/dev.yaml",
+							Version: "1",
+							Files: []templates.CommitFile{
+								{Path: "clusters/default/dev.yaml", Content: "kind: Cluster\n"},
+							},
+							WebURL: "https://github.com/org/repo/pull/1",
+						},
+					},
+				}, result)
+			},
+		},
+		{
+			name:      "service error",
+			responder: httpmock.NewJsonResponderOrPanic(500, httpmock.File("./testdata/service_error.json")),
+			assertFunc: func(t *testing.T, result *templates.UpgradeTemplateResourcesResponse, err error) {
+				assert.EqualError(t, err, "unable to POST template upgrade and create pull request to \"https://weave.works/api/v1/namespaces/default/templates/cluster-template/upgrade-pull-request\": something bad happened")
+			},
+		},
+		{
+			name:      "error returned",
+			responder: httpmock.NewErrorResponder(errors.New("oops")),
+			assertFunc: func(t *testing.T, result *templates.UpgradeTemplateResourcesResponse, err error) {
+				assert.EqualError(t, err, "unable to POST template upgrade and create pull request to \"https://weave.works/api/v1/namespaces/default/templates/cluster-template/upgrade-pull-request\": Post \"https://weave.works/api/v1/namespaces/default/templates/cluster-template/upgrade-pull-request\": oops")
+			},
+		},
+		{
+			name:      "unexpected status code",
+			responder: httpmock.NewStringResponder(http.StatusBadRequest, ""),
+			assertFunc: func(t *testing.T, result *templates.UpgradeTemplateResourcesResponse, err error) {
+				assert.EqualError(t, err, "response status for POST \"https://weave.works/api/v1/namespaces/default/templates/cluster-template/upgrade-pull-request\" was 400")
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := &config.Options{
+				Endpoint: testutils.BaseURI,
+			}
+			client := adapters.NewHTTPClient()
+			httpmock.ActivateNonDefault(client.GetBaseClient())
+			defer httpmock.DeactivateAndReset()
+			httpmock.RegisterResponder("POST", testutils.BaseURI+"/v1/namespaces/default/templates/cluster-template/upgrade-pull-request", tt.responder)
+
+			err := client.ConfigureClientWithOptions(opts, os.Stdout)
+			assert.NoError(t, err)
+			result, err := client.UpgradeTemplateResources(templates.UpgradeTemplateResourcesParams{
+				TemplateName:      "cluster-template",
+				TemplateNamespace: "default",
+			})
+			tt.assertFunc(t, result, err)
+		})
+	}
+}
+
 func TestEntitlementExpiredHeader(t *testing.T) {
 	opts := &config.Options{
 		Endpoint: testutils.BaseURI,
@@ -0,0 +1,101 @@
+package templates
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	capiv1 "github.com/weaveworks/templates-controller/apis/capi/v1alpha2"
+	templatesv1 "github.com/weaveworks/templates-controller/apis/core"
+	gapiv1 "github.com/weaveworks/templates-controller/apis/gitops/v1alpha2"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/serializer"
+
+	apitemplates "github.com/weaveworks/weave-gitops-enterprise/cmd/clusters-service/pkg/templates"
+)
+
+// ParseTemplateFile parses a file that holds a GitOpsTemplate or a CAPITemplate.
+func ParseTemplateFile(filename string) (templatesv1.Template, error) {
+	b, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read template file %s: %w", filename, err)
+	}
+
+	scheme := runtime.NewScheme()
+	if err := gapiv1.AddToScheme(scheme); err != nil {
+		return nil, fmt.Errorf("failed to add GitOpsTemplate to scheme: %w", err)
+	}
+	if err := capiv1.AddToScheme(scheme); err != nil {
+		return nil, fmt.Errorf("failed to add CAPITemplate to scheme: %w", err)
+	}
+
+	obj, gvk, err := serializer.NewCodecFactory(scheme).UniversalDeserializer().Decode(b, nil, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode template file %s: %w", filename, err)
+	}
+
+	tmpl, ok := obj.(templatesv1.Template)
+	if !ok {
+		return nil, fmt.Errorf("template file %s holds a %s, not a template", filename, gvk.Kind)
+	}
+	// The kind tells how the template is rendered.
+	tmpl.GetObjectKind().SetGroupVersionKind(*gvk)
+
+	return tmpl, nil
+}
+
+// LocalTemplateGetter returns a getter of the templates that are included by
+// other templates, that finds them in template files. The paths are template
+// files, or directories whose template files are all searched; files in
+// directories that are not templates are skipped.
+//
+// Templates without a namespace are found by the references of any namespace,
+// as files rarely set one.
+func LocalTemplateGetter(paths []string) (apitemplates.TemplateGetter, error) {
+	var found []templatesv1.Template
+	for _, path := range paths {
+		info, err := os.Stat(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read templates from %s: %w", path, err)
+		}
+
+		if !info.IsDir() {
+			t, err := ParseTemplateFile(path)
+			if err != nil {
+				return nil, err
+			}
+			found = append(found, t)
+			continue
+		}
+
+		entries, err := os.ReadDir(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read templates from %s: %w", path, err)
+		}
+		for _, entry := range entries {
+			ext := filepath.Ext(entry.Name())
+			if entry.IsDir() || (ext != ".yaml" && ext != ".yml") {
+				continue
+			}
+			t, err := ParseTemplateFile(filepath.Join(path, entry.Name()))
+			if err != nil {
+				continue
+			}
+			found = append(found, t)
+		}
+	}
+
+	return func(_ context.Context, ref apitemplates.TemplateRef) (templatesv1.Template, error) {
+		for _, t := range found {
+			if t.GetObjectKind().GroupVersionKind().Kind != ref.Kind || t.GetName() != ref.Name {
+				continue
+			}
+			if t.GetNamespace() == "" || t.GetNamespace() == ref.Namespace {
+				return t, nil
+			}
+		}
+
+		return nil, fmt.Errorf("no template file declares %s", ref)
+	}, nil
+}
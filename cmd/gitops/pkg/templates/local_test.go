@@ -0,0 +1,89 @@
+package templates_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	apitemplates "github.com/weaveworks/weave-gitops-enterprise/cmd/clusters-service/pkg/templates"
+	"github.com/weaveworks/weave-gitops-enterprise/cmd/gitops/pkg/templates"
+)
+
+const testFragment = `apiVersion: templates.weave.works/v1alpha2
+kind: GitOpsTemplate
+metadata:
+  name: network-policies
+  annotations:
+    templates.weave.works/fragment: "true"
+spec:
+  resourcetemplates:
+  - content:
+    - apiVersion: networking.k8s.io/v1
+      kind: NetworkPolicy
+      metadata:
+        name: deny-all
+        namespace: "${NAMESPACE}"
+`
+
+const testIncludingTemplate = `apiVersion: templates.weave.works/v1alpha2
+kind: GitOpsTemplate
+metadata:
+  name: app-template
+  namespace: default
+  annotations:
+    templates.weave.works/includes: |
+      - name: network-policies
+spec:
+  resourcetemplates:
+  - content:
+    - apiVersion: v1
+      kind: Namespace
+      metadata:
+        name: "${NAMESPACE}"
+`
+
+func TestLocalTemplateGetter(t *testing.T) {
+	dir := t.TempDir()
+	writeTestFile(t, filepath.Join(dir, "fragments", "network-policies.yaml"), testFragment)
+	writeTestFile(t, filepath.Join(dir, "fragments", "values.yaml"), "foo: bar\n")
+	writeTestFile(t, filepath.Join(dir, "template.yaml"), testIncludingTemplate)
+
+	tmpl, err := templates.ParseTemplateFile(filepath.Join(dir, "template.yaml"))
+	assert.NoError(t, err)
+
+	_, err = templates.LocalTemplateGetter([]string{filepath.Join(dir, "missing")})
+	assert.ErrorContains(t, err, "failed to read templates from")
+
+	getter, err := templates.LocalTemplateGetter([]string{dir})
+	assert.NoError(t, err)
+	_, err = apitemplates.ResolveIncludes(context.Background(), tmpl, getter)
+	assert.EqualError(t, err, "failed to get included template GitOpsTemplate default/network-policies: no template file declares GitOpsTemplate default/network-policies")
+
+	getter, err = templates.LocalTemplateGetter([]string{dir, filepath.Join(dir, "fragments")})
+	assert.NoError(t, err)
+	resolved, err := apitemplates.ResolveIncludes(context.Background(), tmpl, getter)
+	assert.NoError(t, err)
+
+	kinds := []string{}
+	for _, rt := range resolved.GetSpec().ResourceTemplates {
+		for _, content := range rt.Content {
+			kinds = append(kinds, string(content.Raw))
+		}
+	}
+	assert.Len(t, kinds, 2)
+	assert.Contains(t, kinds[0], "NetworkPolicy")
+	assert.Contains(t, kinds[1], "Namespace")
+}
+
+func writeTestFile(t *testing.T, filename, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(filename), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filename, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
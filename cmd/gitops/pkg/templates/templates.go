@@ -103,6 +103,108 @@ func CreatePullRequestFromTemplate(params CreatePullRequestFromTemplateParams, r
 	return nil
 }
 
+type UpgradeTemplateResourcesParams struct {
+	GitProviderToken  string
+	TemplateName      string
+	TemplateNamespace string
+	TemplateKind      string
+	RepositoryURL     string
+	HeadBranch        string
+	BaseBranch        string
+	Title             string
+	Description       string
+	CommitMessage     string
+	// Path is the directory of the repository to search for resources
+	// created from the template.
+	Path                   string
+	PullRequestPerResource bool
+	DryRun                 bool
+}
+
+// TemplateResourceUpgrade is a resource created from a template,
+// re-rendered against the current version of the template.
+type TemplateResourceUpgrade struct {
+	Path string `json:"path"`
+	// Version is the version of the template the resource was created from.
+	Version string       `json:"version"`
+	Files   []CommitFile `json:"files"`
+	WebURL  string       `json:"webUrl"`
+	// EditedFiles are the files changed in the repository since they were
+	// rendered, whose changes the upgrade overwrites.
+	EditedFiles []string `json:"editedFiles"`
+}
+
+type UpgradeTemplateResourcesResponse struct {
+	Version  string                    `json:"version"`
+	Upgrades []TemplateResourceUpgrade `json:"upgrades"`
+}
+
+// TemplateUpgrader defines the interface that adapters
+// need to implement in order to upgrade the resources
+// created from a template to its current version.
+type TemplateUpgrader interface {
+	UpgradeTemplateResources(params UpgradeTemplateResourcesParams) (*UpgradeTemplateResourcesResponse, error)
+}
+
+// UpgradeTemplateResources uses a TemplateUpgrader adapter to
+// re-render the resources created from a template and to create
+// pull requests of the changes.
+func UpgradeTemplateResources(params UpgradeTemplateResourcesParams, r TemplateUpgrader, w io.Writer) error {
+	res, err := r.UpgradeTemplateResources(params)
+	if err != nil {
+		return fmt.Errorf("unable to upgrade resources of template %q: %w", params.TemplateName, err)
+	}
+
+	if len(res.Upgrades) == 0 {
+		fmt.Fprintf(w, "No resources of template %q need upgrading.\n", params.TemplateName)
+		return nil
+	}
+
+	if params.DryRun {
+		for _, upgrade := range res.Upgrades {
+			fmt.Fprintf(w, "# Upgrade %s%s\n", upgrade.Path, versionChange(upgrade.Version, res.Version))
+			for _, path := range upgrade.EditedFiles {
+				fmt.Fprintf(w, "# Overwrites the changes made to %s since it was rendered\n", path)
+			}
+			for _, file := range upgrade.Files {
+				if file.Content == "" {
+					fmt.Fprintf(w, "\n---\n# %s (deleted)\n", file.Path)
+					continue
+				}
+				fmt.Fprintf(w, "\n---\n# %s\n\n%s", file.Path, file.Content)
+			}
+			fmt.Fprintln(w)
+		}
+		return nil
+	}
+
+	pullRequests := []string{}
+	for _, upgrade := range res.Upgrades {
+		fmt.Fprintf(w, "Upgraded %s%s\n", upgrade.Path, versionChange(upgrade.Version, res.Version))
+		for _, path := range upgrade.EditedFiles {
+			fmt.Fprintf(w, "Overwrote the changes made to %s since it was rendered\n", path)
+		}
+		if len(pullRequests) == 0 || pullRequests[len(pullRequests)-1] != upgrade.WebURL {
+			pullRequests = append(pullRequests, upgrade.WebURL)
+		}
+	}
+	for _, pr := range pullRequests {
+		fmt.Fprintf(w, "Created pull request: %s\n", pr)
+	}
+
+	return nil
+}
+
+func versionChange(from, to string) string {
+	if to == "" {
+		return ""
+	}
+	if from == "" {
+		from = "unknown"
+	}
+	return fmt.Sprintf(" from version %s to %s", from, to)
+}
+
 // TemplatesRetriever defines the interface that adapters
 // need to implement in order to return an array of templates.
 type TemplatesRetriever interface {
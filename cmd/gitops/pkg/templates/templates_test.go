@@ -493,6 +493,90 @@ func TestCreatePullRequest(t *testing.T) {
 	}
 }
 
+func TestUpgradeTemplateResources(t *testing.T) {
+	upgrades := []templates.TemplateResourceUpgrade{
+		{
+			Path:    "clusters/dev.yaml",
+			Version: "1",
+			Files: []templates.CommitFile{
+				{Path: "clusters/dev.yaml", Content: "kind: Cluster\n"},
+				{Path: "clusters/dev/profile.yaml", Content: ""},
+			},
+			WebURL:      "https://github.com/org/repo/pull/1",
+			EditedFiles: []string{"clusters/dev.yaml"},
+		},
+		{
+			Path:    "clusters/prod.yaml",
+			Version: "1",
+			Files: []templates.CommitFile{
+				{Path: "clusters/prod.yaml", Content: "kind: Cluster\n"},
+			},
+			WebURL: "https://github.com/org/repo/pull/1",
+		},
+	}
+
+	tests := []struct {
+		name             string
+		params           templates.UpgradeTemplateResourcesParams
+		result           *templates.UpgradeTemplateResourcesResponse
+		err              error
+		expected         string
+		expectedErrorStr string
+	}{
+		{
+			name:             "error returned",
+			params:           templates.UpgradeTemplateResourcesParams{TemplateName: "cluster-template"},
+			err:              errors.New("something went wrong"),
+			expectedErrorStr: "unable to upgrade resources of template \"cluster-template\": something went wrong",
+		},
+		{
+			name:     "no upgrades",
+			params:   templates.UpgradeTemplateResourcesParams{TemplateName: "cluster-template"},
+			result:   &templates.UpgradeTemplateResourcesResponse{Version: "2"},
+			expected: "No resources of template \"cluster-template\" need upgrading.\n",
+		},
+		{
+			name:   "pull request created",
+			params: templates.UpgradeTemplateResourcesParams{TemplateName: "cluster-template"},
+			result: &templates.UpgradeTemplateResourcesResponse{Version: "2", Upgrades: upgrades},
+			expected: `Upgraded clusters/dev.yaml from version 1 to 2
+Overwrote the changes made to clusters/dev.yaml since it was rendered
+Upgraded clusters/prod.yaml from version 1 to 2
+Created pull request: https://github.com/org/repo/pull/1
+`,
+		},
+		{
+			name:   "dry run",
+			params: templates.UpgradeTemplateResourcesParams{TemplateName: "cluster-template", DryRun: true},
+			result: &templates.UpgradeTemplateResourcesResponse{Upgrades: upgrades[:1]},
+			expected: `# Upgrade clusters/dev.yaml
+# Overwrites the changes made to clusters/dev.yaml since it was rendered
+
+---
+# clusters/dev.yaml
+
+kind: Cluster
+
+---
+# clusters/dev/profile.yaml (deleted)
+
+`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &fakeUpgrader{res: tt.result, err: tt.err}
+			w := new(bytes.Buffer)
+			err := templates.UpgradeTemplateResources(tt.params, c, w)
+			assert.Equal(t, tt.expected, w.String())
+			if err != nil {
+				assert.EqualError(t, err, tt.expectedErrorStr)
+			}
+		})
+	}
+}
+
 func TestGetCredentials(t *testing.T) {
 	tests := []struct {
 		name             string
@@ -770,3 +854,16 @@ func (c *fakeClient) RetrieveTemplateProfiles(name string, namespace string) ([]
 
 	return c.fs, nil
 }
+
+type fakeUpgrader struct {
+	res *templates.UpgradeTemplateResourcesResponse
+	err error
+}
+
+func (c *fakeUpgrader) UpgradeTemplateResources(params templates.UpgradeTemplateResourcesParams) (*templates.UpgradeTemplateResourcesResponse, error) {
+	if c.err != nil {
+		return nil, c.err
+	}
+
+	return c.res, nil
+}
@@ -0,0 +1,81 @@
+package tenants
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/go-logr/logr"
+	gitopsv1alpha1 "github.com/weaveworks/cluster-controller/api/v1alpha1"
+	pacv2beta1 "github.com/weaveworks/policy-agent/api/v2beta1"
+	pacv2beta2 "github.com/weaveworks/policy-agent/api/v2beta2"
+	"github.com/weaveworks/weave-gitops-enterprise/pkg/cluster/fetcher"
+	mngrcluster "github.com/weaveworks/weave-gitops/core/clustersmngr/cluster"
+	"github.com/weaveworks/weave-gitops/pkg/kube"
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/rest"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// SchemeBuilder adds the kinds of the tenant resources and of the GitopsClusters they are applied to,
+// on top of the kinds of kube.CreateScheme.
+var SchemeBuilder = runtime.SchemeBuilder{
+	pacv2beta1.AddToScheme,
+	pacv2beta2.AddToScheme,
+	gitopsv1alpha1.AddToScheme,
+	networkingv1.AddToScheme,
+}
+
+// NewScheme returns the scheme of the kube clients of the tenants commands.
+func NewScheme() (*runtime.Scheme, error) {
+	scheme, err := kube.CreateScheme()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := SchemeBuilder.AddToScheme(scheme); err != nil {
+		return nil, err
+	}
+
+	return scheme, nil
+}
+
+// ClusterClients connects to the GitopsClusters of the management cluster the way clusters-service
+// does, with their kubeconfig, and returns a func returning the client of a cluster.
+func ClusterClients(ctx context.Context, managementConfig *rest.Config) (func(context.Context, types.NamespacedName) (client.Client, error), error) {
+	scheme, err := NewScheme()
+	if err != nil {
+		return nil, err
+	}
+
+	managementCluster, err := mngrcluster.NewSingleCluster("management", managementConfig, scheme, kube.UserPrefixes{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create management cluster: %w", err)
+	}
+
+	leafClusters, err := fetcher.NewGitopsClusterFetcher(logr.Discard(), managementCluster, "", scheme, false, kube.UserPrefixes{}).Fetch(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch clusters: %w", err)
+	}
+
+	byName := map[string]mngrcluster.Cluster{}
+	for _, c := range leafClusters {
+		byName[c.GetName()] = c
+	}
+
+	return func(ctx context.Context, name types.NamespacedName) (client.Client, error) {
+		c, ok := byName[name.String()]
+		if !ok {
+			return nil, errors.New("cluster is not ready, or its kubeconfig cannot be read")
+		}
+
+		kubeClient, err := c.GetServerClient()
+		if err != nil {
+			return nil, fmt.Errorf("failed to create kube client: %w", err)
+		}
+
+		return kubeClient, nil
+	}, nil
+}
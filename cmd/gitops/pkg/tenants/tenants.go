@@ -0,0 +1,48 @@
+package tenants
+
+import (
+	"fmt"
+	"io"
+)
+
+// Cluster is a cluster that tenants are added to.
+// The management cluster has no namespace.
+type Cluster struct {
+	Name      string `json:"name"`
+	Namespace string `json:"namespace,omitempty"`
+}
+
+type CreateTenantsPullRequestParams struct {
+	GitProviderToken string
+	// Tenants is the tenancy file declaring the tenants, in YAML.
+	Tenants       string
+	Clusters      []Cluster
+	Prune         bool
+	RepositoryURL string
+	HeadBranch    string
+	BaseBranch    string
+	Title         string
+	Description   string
+	CommitMessage string
+}
+
+// TenantsPullRequester defines the interface that adapters
+// need to implement in order to create a pull request that
+// adds tenants to clusters.
+// Implementers should return the web URI of the pull request.
+type TenantsPullRequester interface {
+	CreateTenantsPullRequest(params CreateTenantsPullRequestParams) (string, error)
+}
+
+// CreateTenantsPullRequest uses a TenantsPullRequester
+// adapter to create a pull request that adds tenants to clusters.
+func CreateTenantsPullRequest(params CreateTenantsPullRequestParams, r TenantsPullRequester, w io.Writer) error {
+	res, err := r.CreateTenantsPullRequest(params)
+	if err != nil {
+		return fmt.Errorf("unable to create pull request: %w", err)
+	}
+
+	fmt.Fprintf(w, "Created pull request: %s\n", res)
+
+	return nil
+}
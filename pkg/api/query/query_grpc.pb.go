@@ -12,6 +12,7 @@ package api
 
 import (
 	context "context"
+	httpbody "google.golang.org/genproto/googleapis/api/httpbody"
 	grpc "google.golang.org/grpc"
 	codes "google.golang.org/grpc/codes"
 	status "google.golang.org/grpc/status"
@@ -24,8 +25,17 @@ const _ = grpc.SupportPackageIsVersion7
 
 const (
 	Query_DoQuery_FullMethodName               = "/query.v1.Query/DoQuery"
+	Query_WatchQuery_FullMethodName            = "/query.v1.Query/WatchQuery"
+	Query_AggregateQuery_FullMethodName        = "/query.v1.Query/AggregateQuery"
+	Query_ExportQuery_FullMethodName           = "/query.v1.Query/ExportQuery"
+	Query_ListSavedQueries_FullMethodName      = "/query.v1.Query/ListSavedQueries"
+	Query_CreateSavedQuery_FullMethodName      = "/query.v1.Query/CreateSavedQuery"
+	Query_DeleteSavedQuery_FullMethodName      = "/query.v1.Query/DeleteSavedQuery"
+	Query_GetObjectHistory_FullMethodName      = "/query.v1.Query/GetObjectHistory"
+	Query_GetObjectGraph_FullMethodName        = "/query.v1.Query/GetObjectGraph"
 	Query_ListFacets_FullMethodName            = "/query.v1.Query/ListFacets"
 	Query_DebugGetAccessRules_FullMethodName   = "/query.v1.Query/DebugGetAccessRules"
+	Query_ExplainAccess_FullMethodName         = "/query.v1.Query/ExplainAccess"
 	Query_ListEnabledComponents_FullMethodName = "/query.v1.Query/ListEnabledComponents"
 )
 
@@ -37,11 +47,39 @@ type QueryClient interface {
 	// Query for resources across clusters
 	DoQuery(ctx context.Context, in *DoQueryRequest, opts ...grpc.CallOption) (*DoQueryResponse, error)
 	//
+	// Watch the changes to the objects matching a query
+	WatchQuery(ctx context.Context, in *WatchQueryRequest, opts ...grpc.CallOption) (Query_WatchQueryClient, error)
+	//
+	// Count the objects matching a query, grouped by fields or labels
+	AggregateQuery(ctx context.Context, in *AggregateQueryRequest, opts ...grpc.CallOption) (*AggregateQueryResponse, error)
+	//
+	// Export every object matching a query as CSV or NDJSON
+	ExportQuery(ctx context.Context, in *ExportQueryRequest, opts ...grpc.CallOption) (Query_ExportQueryClient, error)
+	//
+	// List the queries saved by the user, and those shared with its groups
+	ListSavedQueries(ctx context.Context, in *ListSavedQueriesRequest, opts ...grpc.CallOption) (*ListSavedQueriesResponse, error)
+	//
+	// Save a query, optionally shared with one of the groups of the user
+	//
+	// Saved queries are kept in the explorer store: they are only kept
+	// across restarts, and shared between replicas, with the postgres backend.
+	CreateSavedQuery(ctx context.Context, in *CreateSavedQueryRequest, opts ...grpc.CallOption) (*CreateSavedQueryResponse, error)
+	//
+	// Delete a query saved by the user
+	DeleteSavedQuery(ctx context.Context, in *DeleteSavedQueryRequest, opts ...grpc.CallOption) (*DeleteSavedQueryResponse, error)
+	//
+	// Get the history of changes to the status, message and spec of an object
+	GetObjectHistory(ctx context.Context, in *GetObjectHistoryRequest, opts ...grpc.CallOption) (*GetObjectHistoryResponse, error)
+	//
 	// List facets available for querying
+	GetObjectGraph(ctx context.Context, in *GetObjectGraphRequest, opts ...grpc.CallOption) (*GetObjectGraphResponse, error)
 	ListFacets(ctx context.Context, in *ListFacetsRequest, opts ...grpc.CallOption) (*ListFacetsResponse, error)
 	//
 	// Get debug access rules
 	DebugGetAccessRules(ctx context.Context, in *DebugGetAccessRulesRequest, opts ...grpc.CallOption) (*DebugGetAccessRulesResponse, error)
+	//
+	// Explain why a user can or cannot see an object
+	ExplainAccess(ctx context.Context, in *ExplainAccessRequest, opts ...grpc.CallOption) (*ExplainAccessResponse, error)
 	// FIXME
 	ListEnabledComponents(ctx context.Context, in *ListEnabledComponentsRequest, opts ...grpc.CallOption) (*ListEnabledComponentsResponse, error)
 }
@@ -63,6 +101,124 @@ func (c *queryClient) DoQuery(ctx context.Context, in *DoQueryRequest, opts ...g
 	return out, nil
 }
 
+func (c *queryClient) WatchQuery(ctx context.Context, in *WatchQueryRequest, opts ...grpc.CallOption) (Query_WatchQueryClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Query_ServiceDesc.Streams[0], Query_WatchQuery_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &queryWatchQueryClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Query_WatchQueryClient interface {
+	Recv() (*WatchQueryResponse, error)
+	grpc.ClientStream
+}
+
+type queryWatchQueryClient struct {
+	grpc.ClientStream
+}
+
+func (x *queryWatchQueryClient) Recv() (*WatchQueryResponse, error) {
+	m := new(WatchQueryResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *queryClient) AggregateQuery(ctx context.Context, in *AggregateQueryRequest, opts ...grpc.CallOption) (*AggregateQueryResponse, error) {
+	out := new(AggregateQueryResponse)
+	err := c.cc.Invoke(ctx, Query_AggregateQuery_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *queryClient) ExportQuery(ctx context.Context, in *ExportQueryRequest, opts ...grpc.CallOption) (Query_ExportQueryClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Query_ServiceDesc.Streams[1], Query_ExportQuery_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &queryExportQueryClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Query_ExportQueryClient interface {
+	Recv() (*httpbody.HttpBody, error)
+	grpc.ClientStream
+}
+
+type queryExportQueryClient struct {
+	grpc.ClientStream
+}
+
+func (x *queryExportQueryClient) Recv() (*httpbody.HttpBody, error) {
+	m := new(httpbody.HttpBody)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *queryClient) ListSavedQueries(ctx context.Context, in *ListSavedQueriesRequest, opts ...grpc.CallOption) (*ListSavedQueriesResponse, error) {
+	out := new(ListSavedQueriesResponse)
+	err := c.cc.Invoke(ctx, Query_ListSavedQueries_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *queryClient) CreateSavedQuery(ctx context.Context, in *CreateSavedQueryRequest, opts ...grpc.CallOption) (*CreateSavedQueryResponse, error) {
+	out := new(CreateSavedQueryResponse)
+	err := c.cc.Invoke(ctx, Query_CreateSavedQuery_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *queryClient) DeleteSavedQuery(ctx context.Context, in *DeleteSavedQueryRequest, opts ...grpc.CallOption) (*DeleteSavedQueryResponse, error) {
+	out := new(DeleteSavedQueryResponse)
+	err := c.cc.Invoke(ctx, Query_DeleteSavedQuery_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *queryClient) GetObjectHistory(ctx context.Context, in *GetObjectHistoryRequest, opts ...grpc.CallOption) (*GetObjectHistoryResponse, error) {
+	out := new(GetObjectHistoryResponse)
+	err := c.cc.Invoke(ctx, Query_GetObjectHistory_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *queryClient) GetObjectGraph(ctx context.Context, in *GetObjectGraphRequest, opts ...grpc.CallOption) (*GetObjectGraphResponse, error) {
+	out := new(GetObjectGraphResponse)
+	err := c.cc.Invoke(ctx, Query_GetObjectGraph_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *queryClient) ListFacets(ctx context.Context, in *ListFacetsRequest, opts ...grpc.CallOption) (*ListFacetsResponse, error) {
 	out := new(ListFacetsResponse)
 	err := c.cc.Invoke(ctx, Query_ListFacets_FullMethodName, in, out, opts...)
@@ -81,6 +237,15 @@ func (c *queryClient) DebugGetAccessRules(ctx context.Context, in *DebugGetAcces
 	return out, nil
 }
 
+func (c *queryClient) ExplainAccess(ctx context.Context, in *ExplainAccessRequest, opts ...grpc.CallOption) (*ExplainAccessResponse, error) {
+	out := new(ExplainAccessResponse)
+	err := c.cc.Invoke(ctx, Query_ExplainAccess_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *queryClient) ListEnabledComponents(ctx context.Context, in *ListEnabledComponentsRequest, opts ...grpc.CallOption) (*ListEnabledComponentsResponse, error) {
 	out := new(ListEnabledComponentsResponse)
 	err := c.cc.Invoke(ctx, Query_ListEnabledComponents_FullMethodName, in, out, opts...)
@@ -98,11 +263,39 @@ type QueryServer interface {
 	// Query for resources across clusters
 	DoQuery(context.Context, *DoQueryRequest) (*DoQueryResponse, error)
 	//
+	// Watch the changes to the objects matching a query
+	WatchQuery(*WatchQueryRequest, Query_WatchQueryServer) error
+	//
+	// Count the objects matching a query, grouped by fields or labels
+	AggregateQuery(context.Context, *AggregateQueryRequest) (*AggregateQueryResponse, error)
+	//
+	// Export every object matching a query as CSV or NDJSON
+	ExportQuery(*ExportQueryRequest, Query_ExportQueryServer) error
+	//
+	// List the queries saved by the user, and those shared with its groups
+	ListSavedQueries(context.Context, *ListSavedQueriesRequest) (*ListSavedQueriesResponse, error)
+	//
+	// Save a query, optionally shared with one of the groups of the user
+	//
+	// Saved queries are kept in the explorer store: they are only kept
+	// across restarts, and shared between replicas, with the postgres backend.
+	CreateSavedQuery(context.Context, *CreateSavedQueryRequest) (*CreateSavedQueryResponse, error)
+	//
+	// Delete a query saved by the user
+	DeleteSavedQuery(context.Context, *DeleteSavedQueryRequest) (*DeleteSavedQueryResponse, error)
+	//
+	// Get the history of changes to the status, message and spec of an object
+	GetObjectHistory(context.Context, *GetObjectHistoryRequest) (*GetObjectHistoryResponse, error)
+	//
 	// List facets available for querying
+	GetObjectGraph(context.Context, *GetObjectGraphRequest) (*GetObjectGraphResponse, error)
 	ListFacets(context.Context, *ListFacetsRequest) (*ListFacetsResponse, error)
 	//
 	// Get debug access rules
 	DebugGetAccessRules(context.Context, *DebugGetAccessRulesRequest) (*DebugGetAccessRulesResponse, error)
+	//
+	// Explain why a user can or cannot see an object
+	ExplainAccess(context.Context, *ExplainAccessRequest) (*ExplainAccessResponse, error)
 	// FIXME
 	ListEnabledComponents(context.Context, *ListEnabledComponentsRequest) (*ListEnabledComponentsResponse, error)
 	mustEmbedUnimplementedQueryServer()
@@ -115,12 +308,39 @@ type UnimplementedQueryServer struct {
 func (UnimplementedQueryServer) DoQuery(context.Context, *DoQueryRequest) (*DoQueryResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method DoQuery not implemented")
 }
+func (UnimplementedQueryServer) WatchQuery(*WatchQueryRequest, Query_WatchQueryServer) error {
+	return status.Errorf(codes.Unimplemented, "method WatchQuery not implemented")
+}
+func (UnimplementedQueryServer) AggregateQuery(context.Context, *AggregateQueryRequest) (*AggregateQueryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AggregateQuery not implemented")
+}
+func (UnimplementedQueryServer) ExportQuery(*ExportQueryRequest, Query_ExportQueryServer) error {
+	return status.Errorf(codes.Unimplemented, "method ExportQuery not implemented")
+}
+func (UnimplementedQueryServer) ListSavedQueries(context.Context, *ListSavedQueriesRequest) (*ListSavedQueriesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListSavedQueries not implemented")
+}
+func (UnimplementedQueryServer) CreateSavedQuery(context.Context, *CreateSavedQueryRequest) (*CreateSavedQueryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateSavedQuery not implemented")
+}
+func (UnimplementedQueryServer) DeleteSavedQuery(context.Context, *DeleteSavedQueryRequest) (*DeleteSavedQueryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteSavedQuery not implemented")
+}
+func (UnimplementedQueryServer) GetObjectHistory(context.Context, *GetObjectHistoryRequest) (*GetObjectHistoryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetObjectHistory not implemented")
+}
+func (UnimplementedQueryServer) GetObjectGraph(context.Context, *GetObjectGraphRequest) (*GetObjectGraphResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetObjectGraph not implemented")
+}
 func (UnimplementedQueryServer) ListFacets(context.Context, *ListFacetsRequest) (*ListFacetsResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ListFacets not implemented")
 }
 func (UnimplementedQueryServer) DebugGetAccessRules(context.Context, *DebugGetAccessRulesRequest) (*DebugGetAccessRulesResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method DebugGetAccessRules not implemented")
 }
+func (UnimplementedQueryServer) ExplainAccess(context.Context, *ExplainAccessRequest) (*ExplainAccessResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ExplainAccess not implemented")
+}
 func (UnimplementedQueryServer) ListEnabledComponents(context.Context, *ListEnabledComponentsRequest) (*ListEnabledComponentsResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ListEnabledComponents not implemented")
 }
@@ -155,6 +375,156 @@ func _Query_DoQuery_Handler(srv interface{}, ctx context.Context, dec func(inter
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Query_WatchQuery_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchQueryRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(QueryServer).WatchQuery(m, &queryWatchQueryServer{stream})
+}
+
+type Query_WatchQueryServer interface {
+	Send(*WatchQueryResponse) error
+	grpc.ServerStream
+}
+
+type queryWatchQueryServer struct {
+	grpc.ServerStream
+}
+
+func (x *queryWatchQueryServer) Send(m *WatchQueryResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Query_AggregateQuery_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AggregateQueryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(QueryServer).AggregateQuery(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Query_AggregateQuery_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QueryServer).AggregateQuery(ctx, req.(*AggregateQueryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Query_ExportQuery_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ExportQueryRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(QueryServer).ExportQuery(m, &queryExportQueryServer{stream})
+}
+
+type Query_ExportQueryServer interface {
+	Send(*httpbody.HttpBody) error
+	grpc.ServerStream
+}
+
+type queryExportQueryServer struct {
+	grpc.ServerStream
+}
+
+func (x *queryExportQueryServer) Send(m *httpbody.HttpBody) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Query_ListSavedQueries_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListSavedQueriesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(QueryServer).ListSavedQueries(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Query_ListSavedQueries_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QueryServer).ListSavedQueries(ctx, req.(*ListSavedQueriesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Query_CreateSavedQuery_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateSavedQueryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(QueryServer).CreateSavedQuery(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Query_CreateSavedQuery_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QueryServer).CreateSavedQuery(ctx, req.(*CreateSavedQueryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Query_DeleteSavedQuery_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteSavedQueryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(QueryServer).DeleteSavedQuery(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Query_DeleteSavedQuery_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QueryServer).DeleteSavedQuery(ctx, req.(*DeleteSavedQueryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Query_GetObjectHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetObjectHistoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(QueryServer).GetObjectHistory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Query_GetObjectHistory_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QueryServer).GetObjectHistory(ctx, req.(*GetObjectHistoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Query_GetObjectGraph_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetObjectGraphRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(QueryServer).GetObjectGraph(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Query_GetObjectGraph_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QueryServer).GetObjectGraph(ctx, req.(*GetObjectGraphRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Query_ListFacets_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(ListFacetsRequest)
 	if err := dec(in); err != nil {
@@ -191,6 +561,24 @@ func _Query_DebugGetAccessRules_Handler(srv interface{}, ctx context.Context, de
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Query_ExplainAccess_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExplainAccessRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(QueryServer).ExplainAccess(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Query_ExplainAccess_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QueryServer).ExplainAccess(ctx, req.(*ExplainAccessRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Query_ListEnabledComponents_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(ListEnabledComponentsRequest)
 	if err := dec(in); err != nil {
@@ -220,6 +608,30 @@ var Query_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "DoQuery",
 			Handler:    _Query_DoQuery_Handler,
 		},
+		{
+			MethodName: "AggregateQuery",
+			Handler:    _Query_AggregateQuery_Handler,
+		},
+		{
+			MethodName: "ListSavedQueries",
+			Handler:    _Query_ListSavedQueries_Handler,
+		},
+		{
+			MethodName: "CreateSavedQuery",
+			Handler:    _Query_CreateSavedQuery_Handler,
+		},
+		{
+			MethodName: "DeleteSavedQuery",
+			Handler:    _Query_DeleteSavedQuery_Handler,
+		},
+		{
+			MethodName: "GetObjectHistory",
+			Handler:    _Query_GetObjectHistory_Handler,
+		},
+		{
+			MethodName: "GetObjectGraph",
+			Handler:    _Query_GetObjectGraph_Handler,
+		},
 		{
 			MethodName: "ListFacets",
 			Handler:    _Query_ListFacets_Handler,
@@ -228,11 +640,26 @@ var Query_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "DebugGetAccessRules",
 			Handler:    _Query_DebugGetAccessRules_Handler,
 		},
+		{
+			MethodName: "ExplainAccess",
+			Handler:    _Query_ExplainAccess_Handler,
+		},
 		{
 			MethodName: "ListEnabledComponents",
 			Handler:    _Query_ListEnabledComponents_Handler,
 		},
 	},
-	Streams:  []grpc.StreamDesc{},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WatchQuery",
+			Handler:       _Query_WatchQuery_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "ExportQuery",
+			Handler:       _Query_ExportQuery_Handler,
+			ServerStreams: true,
+		},
+	},
 	Metadata: "api/query/query.proto",
 }
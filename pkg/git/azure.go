@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"net/http"
 	"net/url"
 
 	"github.com/go-logr/logr"
@@ -187,6 +188,41 @@ func (p *AzureDevOpsProvider) ListPullRequests(ctx context.Context, repoURL stri
 	return prs, nil
 }
 
+func (p *AzureDevOpsProvider) GetFiles(ctx context.Context, repoURL, branch string, paths []string) ([]CommitFile, error) {
+	repoURL, err := GetGitProviderUrl(repoURL)
+	if err != nil {
+		return nil, fmt.Errorf("unable to get git provider url: %w", err)
+	}
+
+	// At this point GetGitProviderUrl should fail if it's not a valid URL.
+	u, _ := url.Parse(repoURL)
+	jsmc := JenkinsSCM{}
+
+	repo, err := jsmc.GetRepository(ctx, p.log, p.client, u)
+	if err != nil {
+		return nil, fmt.Errorf("unable to find repository: %w", err)
+	}
+
+	files := []CommitFile{}
+	for _, path := range paths {
+		file, res, err := p.client.Contents.Find(ctx, repo.FullName, path, branch)
+		if res != nil && res.Status == http.StatusNotFound {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("unable to get file %q: %w", path, err)
+		}
+
+		content := string(file.Data)
+		files = append(files, CommitFile{
+			Path:    path,
+			Content: &content,
+		})
+	}
+
+	return files, nil
+}
+
 func (p *AzureDevOpsProvider) sendRawRequest(ctx context.Context, request *scm.Request, response interface{}) (*scm.Response, error) {
 	resp, err := p.client.Do(ctx, request)
 	if err != nil {
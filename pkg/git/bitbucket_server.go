@@ -3,6 +3,8 @@ package git
 import (
 	"context"
 	"fmt"
+	"net/http"
+	"net/url"
 
 	"github.com/fluxcd/go-git-providers/gitprovider"
 	"github.com/fluxcd/go-git-providers/stash"
@@ -143,3 +145,67 @@ func (p *BitBucketServerProvider) ListPullRequests(ctx context.Context, repoURL
 
 	return ggp.ListPullRequests(ctx, repo)
 }
+
+func (p *BitBucketServerProvider) GetFiles(ctx context.Context, repoURL, branch string, paths []string) ([]CommitFile, error) {
+	repoURL, err := GetGitProviderUrl(repoURL)
+	if err != nil {
+		return nil, fmt.Errorf("unable to get git provider url: %w", err)
+	}
+
+	ggp := goGitProvider{}
+
+	repo, err := ggp.GetBitbucketRepository(ctx, p.log, p.client, repoURL)
+	if err != nil {
+		return nil, err
+	}
+
+	// The files client of go-git-providers is not implemented for Bitbucket
+	// Server, so each file is fetched with the raw file API.
+	client, ok := p.client.Raw().(*stash.Client)
+	if !ok {
+		return nil, fmt.Errorf("unexpected Bitbucket Server client of type %T", p.client.Raw())
+	}
+	projectKey, repoSlug := bitbucketRepositoryRefs(repo.Repository())
+
+	files := []CommitFile{}
+	for _, path := range paths {
+		req, err := client.NewRequest(ctx, http.MethodGet,
+			fmt.Sprintf("/rest/api/1.0/projects/%s/repos/%s/raw/%s", projectKey, repoSlug, path),
+			stash.WithQuery(url.Values{"at": []string{branch}}))
+		if err != nil {
+			return nil, err
+		}
+
+		b, res, err := client.Do(req)
+		if res != nil && res.StatusCode == http.StatusNotFound {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("unable to get file %q: %w", path, err)
+		}
+
+		content := string(b)
+		files = append(files, CommitFile{
+			Path:    path,
+			Content: &content,
+		})
+	}
+
+	return files, nil
+}
+
+// bitbucketRepositoryRefs returns the project key and repository slug of the
+// repository, the way go-git-providers does for its requests.
+func bitbucketRepositoryRefs(ref gitprovider.RepositoryRef) (string, string) {
+	repoSlug := ref.GetRepository()
+	if slugger, ok := ref.(gitprovider.Slugger); ok && slugger.Slug() != "" {
+		repoSlug = slugger.Slug()
+	}
+
+	projectKey := ref.GetIdentity()
+	if keyer, ok := ref.(gitprovider.Keyer); ok && keyer.Key() != "" {
+		projectKey = keyer.Key()
+	}
+
+	return projectKey, repoSlug
+}
@@ -40,6 +40,8 @@ func (f *ProviderFactory) Create(providerName string, opts ...ProviderWithFn) (P
 		provider, err = NewBitBucketServerProvider(f.log)
 	case AzureDevOpsProviderName:
 		provider, err = NewAzureDevOpsProvider(f.log)
+	case GenericProviderName:
+		provider, err = NewGenericProvider(f.log)
 	default:
 		return nil, fmt.Errorf("provider %q is not supported", providerName)
 	}
@@ -70,6 +72,10 @@ type ProviderOption struct {
 	Token               string
 	Username            string
 	ConditionalRequests bool
+	// PrivateKey is the PEM encoded SSH key of the generic provider.
+	PrivateKey []byte
+	// PullRequestAPI is the API the generic provider opens pull requests with.
+	PullRequestAPI string
 }
 
 type ProviderWithFn func(o *ProviderOption) error
@@ -122,3 +128,19 @@ func WithUsername(username string) ProviderWithFn {
 		return nil
 	}
 }
+
+func WithPrivateKey(key []byte) ProviderWithFn {
+	return func(p *ProviderOption) error {
+		p.PrivateKey = key
+
+		return nil
+	}
+}
+
+func WithPullRequestAPI(api string) ProviderWithFn {
+	return func(p *ProviderOption) error {
+		p.PullRequestAPI = api
+
+		return nil
+	}
+}
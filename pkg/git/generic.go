@@ -0,0 +1,459 @@
+package git
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"strings"
+	"time"
+
+	"code.gitea.io/sdk/gitea"
+	"github.com/go-git/go-billy/v5/memfs"
+	"github.com/go-git/go-billy/v5/util"
+	gogit "github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/config"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/filemode"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/go-git/go-git/v5/plumbing/transport"
+	githttp "github.com/go-git/go-git/v5/plumbing/transport/http"
+	"github.com/go-git/go-git/v5/plumbing/transport/ssh"
+	"github.com/go-git/go-git/v5/storage/memory"
+	"github.com/go-logr/logr"
+)
+
+const (
+	GenericProviderName string = "generic"
+
+	// BranchOnlyPullRequestAPI pushes the branch of the changes
+	// without opening a pull request.
+	BranchOnlyPullRequestAPI string = "none"
+	// GiteaPullRequestAPI opens pull requests with the Gitea API,
+	// which Forgejo implements too.
+	GiteaPullRequestAPI string = "gitea"
+
+	genericCommitAuthorName  string = "Weave GitOps"
+	genericCommitAuthorEmail string = "weave-gitops@weave.works"
+)
+
+// GenericProvider is used to interact with any git server, using only
+// git operations over HTTPS or SSH. Opening pull requests, which is
+// not part of git, is left to the configured pull request API.
+type GenericProvider struct {
+	log          logr.Logger
+	username     string
+	token        string
+	privateKey   []byte
+	pullRequests pullRequestAPI
+}
+
+// pullRequestAPI opens and lists the pull requests of the branches pushed
+// by the GenericProvider.
+type pullRequestAPI interface {
+	CreatePullRequest(ctx context.Context, repo *Repository, input PullRequestInput) (*PullRequest, error)
+	ListPullRequests(ctx context.Context, repo *Repository) ([]*PullRequest, error)
+}
+
+func NewGenericProvider(log logr.Logger) (Provider, error) {
+	return &GenericProvider{
+		log: log,
+	}, nil
+}
+
+func (p *GenericProvider) Setup(opts ProviderOption) error {
+	p.username = opts.Username
+	if p.username == "" {
+		p.username = "git"
+	}
+	p.token = opts.Token
+	p.privateKey = opts.PrivateKey
+
+	switch opts.PullRequestAPI {
+	case "", BranchOnlyPullRequestAPI:
+		p.pullRequests = branchOnlyPullRequests{}
+	case GiteaPullRequestAPI:
+		p.pullRequests = giteaPullRequests{
+			url:   opts.Hostname,
+			token: opts.Token,
+		}
+	default:
+		return fmt.Errorf("pull request API %q is not supported", opts.PullRequestAPI)
+	}
+
+	return nil
+}
+
+func (p *GenericProvider) GetRepository(ctx context.Context, repoURL string) (*Repository, error) {
+	repo, err := parseGenericRepository(repoURL)
+	if err != nil {
+		return nil, err
+	}
+
+	auth, err := p.auth(repoURL)
+	if err != nil {
+		return nil, err
+	}
+
+	remote := gogit.NewRemote(memory.NewStorage(), &config.RemoteConfig{
+		Name: gogit.DefaultRemoteName,
+		URLs: []string{repoURL},
+	})
+	if _, err := remote.ListContext(ctx, &gogit.ListOptions{Auth: auth}); err != nil {
+		return nil, fmt.Errorf("unable to list references of repository %q: %w", repoURL, err)
+	}
+
+	return repo, nil
+}
+
+func (p *GenericProvider) CreatePullRequest(ctx context.Context, input PullRequestInput) (*PullRequest, error) {
+	repo, err := parseGenericRepository(input.RepositoryURL)
+	if err != nil {
+		return nil, err
+	}
+
+	auth, err := p.auth(input.RepositoryURL)
+	if err != nil {
+		return nil, err
+	}
+
+	// The history is needed for the push to only send the new commits.
+	fs := memfs.New()
+	r, err := gogit.CloneContext(ctx, memory.NewStorage(), fs, &gogit.CloneOptions{
+		URL:           input.RepositoryURL,
+		Auth:          auth,
+		ReferenceName: plumbing.NewBranchReferenceName(input.Base),
+		SingleBranch:  true,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("unable to clone branch %q: %w", input.Base, err)
+	}
+
+	wt, err := r.Worktree()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := wt.Checkout(&gogit.CheckoutOptions{
+		Branch: plumbing.NewBranchReferenceName(input.Head),
+		Create: true,
+	}); err != nil {
+		return nil, fmt.Errorf("unable to create new branch %q from branch %q: %w", input.Head, input.Base, err)
+	}
+
+	for _, c := range input.Commits {
+		for _, file := range c.Files {
+			if file.Content == nil {
+				if _, err := fs.Stat(file.Path); os.IsNotExist(err) {
+					continue
+				}
+				if _, err := wt.Remove(file.Path); err != nil {
+					return nil, fmt.Errorf("unable to delete file %q: %w", file.Path, err)
+				}
+				continue
+			}
+
+			if err := util.WriteFile(fs, file.Path, []byte(*file.Content), 0o644); err != nil {
+				return nil, fmt.Errorf("unable to write file %q: %w", file.Path, err)
+			}
+			if _, err := wt.Add(file.Path); err != nil {
+				return nil, fmt.Errorf("unable to add file %q: %w", file.Path, err)
+			}
+		}
+
+		status, err := wt.Status()
+		if err != nil {
+			return nil, err
+		}
+		if status.IsClean() {
+			continue
+		}
+
+		hash, err := wt.Commit(c.CommitMessage, &gogit.CommitOptions{
+			Author: &object.Signature{
+				Name:  genericCommitAuthorName,
+				Email: genericCommitAuthorEmail,
+				When:  time.Now(),
+			},
+		})
+		if err != nil {
+			return nil, fmt.Errorf("unable to commit changes to %q: %w", input.Head, err)
+		}
+		p.log.WithValues("sha", hash.String(), "branch", input.Head).Info("Files committed")
+	}
+
+	// The branch is created anew from the base branch, so it replaces the one pushed
+	// by a previous request for the same branch.
+	refSpec := config.RefSpec(fmt.Sprintf("+refs/heads/%[1]s:refs/heads/%[1]s", input.Head))
+	if err := r.PushContext(ctx, &gogit.PushOptions{
+		RemoteName: gogit.DefaultRemoteName,
+		RefSpecs:   []config.RefSpec{refSpec},
+		Auth:       auth,
+	}); err != nil && !errors.Is(err, gogit.NoErrAlreadyUpToDate) {
+		return nil, fmt.Errorf("unable to push branch %q: %w", input.Head, err)
+	}
+
+	return p.pullRequests.CreatePullRequest(ctx, repo, input)
+}
+
+// GetTreeList lists the entries under the path of the branch sha.
+func (p *GenericProvider) GetTreeList(ctx context.Context, repoUrl string, sha string, treePath string) ([]*TreeEntry, error) {
+	tree, err := p.branchTree(ctx, repoUrl, sha)
+	if err != nil {
+		return nil, err
+	}
+
+	treePath = strings.Trim(treePath, "/")
+	if treePath != "" {
+		tree, err = tree.Tree(treePath)
+		if errors.Is(err, object.ErrDirectoryNotFound) {
+			return []*TreeEntry{}, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	files := []*TreeEntry{}
+
+	walker := object.NewTreeWalker(tree, true, nil)
+	defer walker.Close()
+
+	for {
+		name, entry, err := walker.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		treeEntry := &TreeEntry{
+			Name: entry.Name,
+			Path: path.Join(treePath, name),
+			Type: "blob",
+			SHA:  entry.Hash.String(),
+		}
+		if entry.Mode == filemode.Dir {
+			treeEntry.Type = "tree"
+		} else {
+			file, err := tree.TreeEntryFile(&entry)
+			if err != nil {
+				return nil, err
+			}
+			treeEntry.Size = int(file.Size)
+		}
+
+		files = append(files, treeEntry)
+	}
+
+	return files, nil
+}
+
+func (p *GenericProvider) ListPullRequests(ctx context.Context, repoURL string) ([]*PullRequest, error) {
+	repo, err := parseGenericRepository(repoURL)
+	if err != nil {
+		return nil, err
+	}
+
+	return p.pullRequests.ListPullRequests(ctx, repo)
+}
+
+func (p *GenericProvider) GetFiles(ctx context.Context, repoURL, branch string, paths []string) ([]CommitFile, error) {
+	tree, err := p.branchTree(ctx, repoURL, branch)
+	if err != nil {
+		return nil, err
+	}
+
+	files := []CommitFile{}
+	for _, path := range paths {
+		file, err := tree.File(path)
+		if errors.Is(err, object.ErrFileNotFound) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("unable to get file %q: %w", path, err)
+		}
+
+		content, err := file.Contents()
+		if err != nil {
+			return nil, fmt.Errorf("unable to read file %q: %w", path, err)
+		}
+
+		files = append(files, CommitFile{
+			Path:    path,
+			Content: &content,
+		})
+	}
+
+	return files, nil
+}
+
+// branchTree returns the tree of the last commit of the branch.
+func (p *GenericProvider) branchTree(ctx context.Context, repoURL, branch string) (*object.Tree, error) {
+	auth, err := p.auth(repoURL)
+	if err != nil {
+		return nil, err
+	}
+
+	r, err := gogit.CloneContext(ctx, memory.NewStorage(), nil, &gogit.CloneOptions{
+		URL:           repoURL,
+		Auth:          auth,
+		ReferenceName: plumbing.NewBranchReferenceName(branch),
+		SingleBranch:  true,
+		Depth:         1,
+		NoCheckout:    true,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("unable to clone branch %q: %w", branch, err)
+	}
+
+	head, err := r.Head()
+	if err != nil {
+		return nil, err
+	}
+
+	commit, err := r.CommitObject(head.Hash())
+	if err != nil {
+		return nil, err
+	}
+
+	return commit.Tree()
+}
+
+// auth returns the credentials for the protocol of the repository URL.
+// HTTPS uses the token as password, and SSH uses the private key, or
+// the SSH agent when there is none.
+func (p *GenericProvider) auth(repoURL string) (transport.AuthMethod, error) {
+	ep, err := transport.NewEndpoint(repoURL)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse repository URL %q: %w", repoURL, err)
+	}
+
+	switch ep.Protocol {
+	case "http", "https":
+		if p.token == "" {
+			return nil, nil
+		}
+
+		return &githttp.BasicAuth{
+			Username: p.username,
+			Password: p.token,
+		}, nil
+	case "ssh":
+		user := ep.User
+		if user == "" {
+			user = p.username
+		}
+
+		if len(p.privateKey) == 0 {
+			return ssh.NewSSHAgentAuth(user)
+		}
+
+		return ssh.NewPublicKeys(user, p.privateKey, "")
+	default:
+		return nil, nil
+	}
+}
+
+func parseGenericRepository(repoURL string) (*Repository, error) {
+	ep, err := transport.NewEndpoint(repoURL)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse repository URL %q: %w", repoURL, err)
+	}
+
+	repoPath := strings.TrimSuffix(strings.Trim(ep.Path, "/"), ".git")
+
+	return &Repository{
+		Domain: ep.Host,
+		Org:    path.Dir(repoPath),
+		Name:   path.Base(repoPath),
+	}, nil
+}
+
+// branchOnlyPullRequests leaves the pushed branches for the users
+// to open pull requests of.
+type branchOnlyPullRequests struct{}
+
+func (branchOnlyPullRequests) CreatePullRequest(ctx context.Context, repo *Repository, input PullRequestInput) (*PullRequest, error) {
+	return &PullRequest{
+		Title:       input.Title,
+		Description: input.Body,
+	}, nil
+}
+
+func (branchOnlyPullRequests) ListPullRequests(ctx context.Context, repo *Repository) ([]*PullRequest, error) {
+	return []*PullRequest{}, nil
+}
+
+// giteaPullRequests opens pull requests with the Gitea API.
+type giteaPullRequests struct {
+	url   string
+	token string
+}
+
+func (g giteaPullRequests) client(ctx context.Context, repo *Repository) (*gitea.Client, error) {
+	url := g.url
+	if url == "" {
+		url = "https://" + repo.Domain
+	}
+
+	client, err := gitea.NewClient(url, gitea.SetToken(g.token), gitea.SetContext(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("unable to create Gitea client for %q: %w", url, err)
+	}
+
+	return client, nil
+}
+
+func (g giteaPullRequests) CreatePullRequest(ctx context.Context, repo *Repository, input PullRequestInput) (*PullRequest, error) {
+	client, err := g.client(ctx, repo)
+	if err != nil {
+		return nil, err
+	}
+
+	pr, _, err := client.CreatePullRequest(repo.Org, repo.Name, gitea.CreatePullRequestOption{
+		Head:  input.Head,
+		Base:  input.Base,
+		Title: input.Title,
+		Body:  input.Body,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("unable to create new pull request for branch %q: %w", input.Head, err)
+	}
+
+	return &PullRequest{
+		Title:       pr.Title,
+		Description: pr.Body,
+		Link:        pr.HTMLURL,
+		Merged:      pr.HasMerged,
+	}, nil
+}
+
+func (g giteaPullRequests) ListPullRequests(ctx context.Context, repo *Repository) ([]*PullRequest, error) {
+	client, err := g.client(ctx, repo)
+	if err != nil {
+		return nil, err
+	}
+
+	prs, _, err := client.ListRepoPullRequests(repo.Org, repo.Name, gitea.ListPullRequestsOptions{
+		State: gitea.StateAll,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("unable to list pull requests: %w", err)
+	}
+
+	pullRequests := []*PullRequest{}
+	for _, pr := range prs {
+		pullRequests = append(pullRequests, &PullRequest{
+			Title:       pr.Title,
+			Description: pr.Body,
+			Link:        pr.HTMLURL,
+			Merged:      pr.HasMerged,
+		})
+	}
+
+	return pullRequests, nil
+}
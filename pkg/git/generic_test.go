@@ -0,0 +1,237 @@
+package git_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	gogit "github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/weaveworks/weave-gitops-enterprise/pkg/git"
+)
+
+func TestGenericProvider(t *testing.T) {
+	repoURL := newBareRepository(t, map[string]string{
+		"README.md":                       "# fleet\n",
+		"clusters/dev/cluster.yaml":       "kind: Cluster\n",
+		"clusters/dev/kustomization.yaml": "kind: Kustomization\n",
+	})
+	ctx := context.Background()
+
+	provider, err := git.NewFactory(logr.Discard()).Create(git.GenericProviderName)
+	require.NoError(t, err)
+
+	repo, err := provider.GetRepository(ctx, repoURL)
+	require.NoError(t, err)
+	assert.Equal(t, "org", filepath.Base(repo.Org))
+	assert.Equal(t, "fleet", repo.Name)
+
+	input := git.PullRequestInput{
+		RepositoryURL: repoURL,
+		Title:         "Add prod cluster",
+		Body:          "Adds the prod cluster",
+		Head:          "add-prod",
+		Base:          "main",
+		Commits: []git.Commit{
+			{
+				CommitMessage: "Add prod cluster",
+				Files: []git.CommitFile{
+					{Path: "clusters/prod/cluster.yaml", Content: fileContent("kind: Cluster\n")},
+					{Path: "clusters/dev/cluster.yaml", Content: fileContent("kind: Cluster\nmetadata:\n  name: dev\n")},
+					{Path: "clusters/dev/kustomization.yaml"},
+					{Path: "clusters/staging/cluster.yaml"},
+				},
+			},
+			{
+				CommitMessage: "Nothing to change",
+				Files: []git.CommitFile{
+					{Path: "README.md", Content: fileContent("# fleet\n")},
+				},
+			},
+		},
+	}
+
+	pr, err := provider.CreatePullRequest(ctx, input)
+	require.NoError(t, err)
+	assert.Equal(t, &git.PullRequest{Title: "Add prod cluster", Description: "Adds the prod cluster"}, pr)
+
+	files, err := provider.GetFiles(ctx, repoURL, "add-prod", []string{
+		"clusters/prod/cluster.yaml",
+		"clusters/dev/cluster.yaml",
+		"clusters/dev/kustomization.yaml",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []git.CommitFile{
+		{Path: "clusters/prod/cluster.yaml", Content: fileContent("kind: Cluster\n")},
+		{Path: "clusters/dev/cluster.yaml", Content: fileContent("kind: Cluster\nmetadata:\n  name: dev\n")},
+	}, files)
+
+	// The base branch is left unchanged.
+	files, err = provider.GetFiles(ctx, repoURL, "main", []string{"clusters/prod/cluster.yaml", "clusters/dev/kustomization.yaml"})
+	require.NoError(t, err)
+	assert.Equal(t, []git.CommitFile{
+		{Path: "clusters/dev/kustomization.yaml", Content: fileContent("kind: Kustomization\n")},
+	}, files)
+
+	entries, err := provider.GetTreeList(ctx, repoURL, "add-prod", "clusters")
+	require.NoError(t, err)
+	paths := []string{}
+	for _, entry := range entries {
+		paths = append(paths, entry.Type+":"+entry.Path)
+	}
+	assert.ElementsMatch(t, []string{
+		"tree:clusters/dev",
+		"blob:clusters/dev/cluster.yaml",
+		"tree:clusters/prod",
+		"blob:clusters/prod/cluster.yaml",
+	}, paths)
+
+	commits := branchCommitMessages(t, repoURL, "add-prod")
+	assert.Equal(t, []string{"Add prod cluster", "Initial commit"}, commits)
+
+	prs, err := provider.ListPullRequests(ctx, repoURL)
+	require.NoError(t, err)
+	assert.Empty(t, prs)
+
+	// The same request can be made again, as when a pull request is retried.
+	_, err = provider.CreatePullRequest(ctx, input)
+	require.NoError(t, err)
+
+	commits = branchCommitMessages(t, repoURL, "add-prod")
+	assert.Equal(t, []string{"Add prod cluster", "Initial commit"}, commits)
+
+	_, err = provider.CreatePullRequest(ctx, git.PullRequestInput{
+		RepositoryURL: repoURL,
+		Head:          "add-prod",
+		Base:          "missing",
+	})
+	assert.ErrorContains(t, err, `unable to clone branch "missing"`)
+}
+
+func TestGenericProvider_GiteaPullRequestAPI(t *testing.T) {
+	repoURL := newBareRepository(t, map[string]string{
+		"README.md": "# fleet\n",
+	})
+
+	var created map[string]interface{}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/v1/version", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"version": "1.20.0"}`))
+	})
+	mux.HandleFunc("/api/v1/repos/", func(w http.ResponseWriter, r *http.Request) {
+		assert.True(t, strings.HasSuffix(r.URL.Path, "/fleet/pulls"))
+		assert.Equal(t, "token test-token", r.Header.Get("Authorization"))
+
+		if r.Method == http.MethodPost {
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&created))
+			_, _ = w.Write([]byte(`{"title": "Add prod cluster", "html_url": "https://gitea.example.com/org/fleet/pulls/1"}`))
+			return
+		}
+		_, _ = w.Write([]byte(`[{"title": "Add prod cluster", "html_url": "https://gitea.example.com/org/fleet/pulls/1", "merged": true}]`))
+	})
+	ts := httptest.NewServer(mux)
+	t.Cleanup(ts.Close)
+
+	provider, err := git.NewFactory(logr.Discard()).Create(git.GenericProviderName,
+		git.WithToken("", "test-token"),
+		git.WithDomain(ts.URL),
+		git.WithPullRequestAPI(git.GiteaPullRequestAPI),
+	)
+	require.NoError(t, err)
+
+	pr, err := provider.CreatePullRequest(context.Background(), git.PullRequestInput{
+		RepositoryURL: repoURL,
+		Title:         "Add prod cluster",
+		Head:          "add-prod",
+		Base:          "main",
+		Commits: []git.Commit{
+			{
+				CommitMessage: "Add prod cluster",
+				Files: []git.CommitFile{
+					{Path: "clusters/prod/cluster.yaml", Content: fileContent("kind: Cluster\n")},
+				},
+			},
+		},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "https://gitea.example.com/org/fleet/pulls/1", pr.Link)
+	assert.Equal(t, "add-prod", created["head"])
+	assert.Equal(t, "main", created["base"])
+
+	prs, err := provider.ListPullRequests(context.Background(), repoURL)
+	require.NoError(t, err)
+	assert.Equal(t, []*git.PullRequest{
+		{Title: "Add prod cluster", Link: "https://gitea.example.com/org/fleet/pulls/1", Merged: true},
+	}, prs)
+}
+
+func TestGenericProvider_UnsupportedPullRequestAPI(t *testing.T) {
+	_, err := git.NewFactory(logr.Discard()).Create(git.GenericProviderName, git.WithPullRequestAPI("github"))
+	assert.EqualError(t, err, `unable to apply options on provider "generic": pull request API "github" is not supported`)
+}
+
+// newBareRepository creates a bare repository with a commit of the files
+// in its main branch, and returns its URL.
+func newBareRepository(t *testing.T, files map[string]string) string {
+	t.Helper()
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("the git binary is needed to serve local repositories")
+	}
+
+	dir := t.TempDir()
+	workDir := filepath.Join(dir, "work")
+	r, err := gogit.PlainInitWithOptions(workDir, &gogit.PlainInitOptions{
+		InitOptions: gogit.InitOptions{DefaultBranch: plumbing.Main},
+	})
+	require.NoError(t, err)
+	wt, err := r.Worktree()
+	require.NoError(t, err)
+	for path, content := range files {
+		require.NoError(t, os.MkdirAll(filepath.Join(workDir, filepath.Dir(path)), 0o755))
+		require.NoError(t, os.WriteFile(filepath.Join(workDir, path), []byte(content), 0o644))
+		_, err := wt.Add(path)
+		require.NoError(t, err)
+	}
+	_, err = wt.Commit("Initial commit", &gogit.CommitOptions{
+		Author: &object.Signature{Name: "test", Email: "test@example.com", When: time.Now()},
+	})
+	require.NoError(t, err)
+
+	bareDir := filepath.Join(dir, "org", "fleet.git")
+	_, err = gogit.PlainClone(bareDir, true, &gogit.CloneOptions{URL: workDir})
+	require.NoError(t, err)
+
+	return "file://" + bareDir
+}
+
+func branchCommitMessages(t *testing.T, repoURL, branch string) []string {
+	t.Helper()
+	r, err := gogit.PlainOpen(strings.TrimPrefix(repoURL, "file://"))
+	require.NoError(t, err)
+	ref, err := r.Reference(plumbing.NewBranchReferenceName(branch), true)
+	require.NoError(t, err)
+	iter, err := r.Log(&gogit.LogOptions{From: ref.Hash()})
+	require.NoError(t, err)
+
+	messages := []string{}
+	require.NoError(t, iter.ForEach(func(c *object.Commit) error {
+		messages = append(messages, c.Message)
+		return nil
+	}))
+	return messages
+}
+
+func fileContent(s string) *string {
+	return &s
+}
@@ -3,10 +3,12 @@ package git
 import (
 	"context"
 	"fmt"
+	"net/http"
 
 	"github.com/fluxcd/go-git-providers/github"
 	"github.com/fluxcd/go-git-providers/gitprovider"
 	"github.com/go-logr/logr"
+	gogithub "github.com/google/go-github/v52/github"
 )
 
 const GitHubProviderName string = "github"
@@ -139,3 +141,51 @@ func (p *GitHubProvider) ListPullRequests(ctx context.Context, repoURL string) (
 
 	return ggp.ListPullRequests(ctx, repo)
 }
+
+func (p *GitHubProvider) GetFiles(ctx context.Context, repoURL, branch string, paths []string) ([]CommitFile, error) {
+	url, err := GetGitProviderUrl(repoURL)
+	if err != nil {
+		return nil, fmt.Errorf("unable to get git provider url: %w", err)
+	}
+
+	ggp := goGitProvider{}
+
+	repo, err := ggp.GetRepository(ctx, p.log, p.client, url)
+	if err != nil {
+		return nil, err
+	}
+
+	// The files client of go-git-providers only gets the files of directories
+	// without subdirectories, so each file is fetched with the GitHub client.
+	client, ok := p.client.Raw().(*gogithub.Client)
+	if !ok {
+		return nil, fmt.Errorf("unexpected GitHub client of type %T", p.client.Raw())
+	}
+	owner, name := repo.Repository().GetIdentity(), repo.Repository().GetRepository()
+
+	files := []CommitFile{}
+	for _, path := range paths {
+		file, _, res, err := client.Repositories.GetContents(ctx, owner, name, path, &gogithub.RepositoryContentGetOptions{Ref: branch})
+		if res != nil && res.StatusCode == http.StatusNotFound {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("unable to get file %q: %w", path, err)
+		}
+		if file == nil {
+			// The path is a directory.
+			continue
+		}
+
+		content, err := file.GetContent()
+		if err != nil {
+			return nil, fmt.Errorf("unable to decode file %q: %w", path, err)
+		}
+		files = append(files, CommitFile{
+			Path:    path,
+			Content: &content,
+		})
+	}
+
+	return files, nil
+}
@@ -3,10 +3,12 @@ package git
 import (
 	"context"
 	"fmt"
+	"net/http"
 
 	"github.com/fluxcd/go-git-providers/gitlab"
 	"github.com/fluxcd/go-git-providers/gitprovider"
 	"github.com/go-logr/logr"
+	gogitlab "github.com/xanzy/go-gitlab"
 )
 
 const (
@@ -169,3 +171,42 @@ func (p *GitLabProvider) ListPullRequests(ctx context.Context, repoURL string) (
 
 	return ggp.ListPullRequests(ctx, repo)
 }
+
+func (p *GitLabProvider) GetFiles(ctx context.Context, repoURL, branch string, paths []string) ([]CommitFile, error) {
+	url, err := GetGitProviderUrl(repoURL)
+	if err != nil {
+		return nil, fmt.Errorf("unable to get git provider url: %w", err)
+	}
+
+	ggp := goGitProvider{}
+
+	repo, err := ggp.GetRepository(ctx, p.log, p.client, url)
+	if err != nil {
+		return nil, err
+	}
+
+	client, ok := p.client.Raw().(*gogitlab.Client)
+	if !ok {
+		return nil, fmt.Errorf("unexpected GitLab client of type %T", p.client.Raw())
+	}
+	project := repo.Repository().GetIdentity() + "/" + repo.Repository().GetRepository()
+
+	files := []CommitFile{}
+	for _, path := range paths {
+		b, res, err := client.RepositoryFiles.GetRawFile(project, path, &gogitlab.GetRawFileOptions{Ref: &branch}, gogitlab.WithContext(ctx))
+		if res != nil && res.StatusCode == http.StatusNotFound {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("unable to get file %q: %w", path, err)
+		}
+
+		content := string(b)
+		files = append(files, CommitFile{
+			Path:    path,
+			Content: &content,
+		})
+	}
+
+	return files, nil
+}
@@ -0,0 +1,117 @@
+package git
+
+import (
+	"strings"
+
+	"github.com/pmezard/go-difflib/difflib"
+)
+
+// MergeFile is a version of the content of a file to merge, with the label
+// of its section in conflict markers.
+type MergeFile struct {
+	Label   string
+	Content string
+}
+
+// ThreeWayMerge merges the changes that ours and theirs made to base, line by
+// line, the way git merges files. It returns the merged content, and whether
+// it has conflicts: lines changed differently by both, which are marked in
+// the content the way git marks them with the diff3 conflict style.
+func ThreeWayMerge(base, ours, theirs MergeFile) (string, bool) {
+	baseLines := splitLines(base.Content)
+	ourLines := splitLines(ours.Content)
+	theirLines := splitLines(theirs.Content)
+
+	ourMatches := matchingLines(baseLines, ourLines)
+	theirMatches := matchingLines(baseLines, theirLines)
+
+	var merged strings.Builder
+	conflicted := false
+	mergeChunk := func(b, o, t []string) {
+		switch {
+		case equalLines(o, b):
+			writeLines(&merged, t)
+		case equalLines(t, b), equalLines(o, t):
+			writeLines(&merged, o)
+		default:
+			conflicted = true
+			writeConflictSection(&merged, "<<<<<<< "+ours.Label, o)
+			writeConflictSection(&merged, "||||||| "+base.Label, b)
+			writeConflictSection(&merged, "=======", t)
+			merged.WriteString(">>>>>>> " + theirs.Label + "\n")
+		}
+	}
+
+	// Lines of the base that both ours and theirs kept split the files into
+	// chunks, that are merged one by one.
+	b, o, t := 0, 0, 0
+	for i := range baseLines {
+		oi, inOurs := ourMatches[i]
+		ti, inTheirs := theirMatches[i]
+		if !inOurs || !inTheirs {
+			continue
+		}
+
+		mergeChunk(baseLines[b:i], ourLines[o:oi], theirLines[t:ti])
+		merged.WriteString(baseLines[i])
+		b, o, t = i+1, oi+1, ti+1
+	}
+	mergeChunk(baseLines[b:], ourLines[o:], theirLines[t:])
+
+	return merged.String(), conflicted
+}
+
+// matchingLines returns the indexes of the lines of other that match lines
+// of base, by the index of the line of base.
+func matchingLines(base, other []string) map[int]int {
+	matches := map[int]int{}
+	matcher := difflib.NewMatcherWithJunk(base, other, false, nil)
+	for _, m := range matcher.GetMatchingBlocks() {
+		for i := 0; i < m.Size; i++ {
+			matches[m.A+i] = m.B + i
+		}
+	}
+
+	return matches
+}
+
+// splitLines splits the content into lines, that keep their line endings.
+func splitLines(content string) []string {
+	if content == "" {
+		return nil
+	}
+
+	lines := strings.SplitAfter(content, "\n")
+	if lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+
+	return lines
+}
+
+func equalLines(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+
+	return true
+}
+
+func writeLines(sb *strings.Builder, lines []string) {
+	for _, line := range lines {
+		sb.WriteString(line)
+	}
+}
+
+func writeConflictSection(sb *strings.Builder, marker string, lines []string) {
+	sb.WriteString(marker + "\n")
+	writeLines(sb, lines)
+	if len(lines) > 0 && !strings.HasSuffix(lines[len(lines)-1], "\n") {
+		sb.WriteString("\n")
+	}
+}
@@ -0,0 +1,118 @@
+package git_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/weaveworks/weave-gitops-enterprise/pkg/git"
+)
+
+const mergeBase = `apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: podinfo
+data:
+  replicas: "1"
+  region: eu-west-1
+`
+
+func TestThreeWayMerge(t *testing.T) {
+	type testCase struct {
+		caseName   string
+		base       string
+		ours       string
+		theirs     string
+		merged     string
+		conflicted bool
+	}
+
+	testCases := []testCase{
+		{
+			caseName: "unchanged",
+			ours:     mergeBase,
+			theirs:   mergeBase,
+			merged:   mergeBase,
+		},
+		{
+			caseName: "changed by theirs",
+			ours:     mergeBase,
+			theirs:   withReplicas("2"),
+			merged:   withReplicas("2"),
+		},
+		{
+			caseName: "changed by ours",
+			ours:     mergeBase + "  owner: team-a\n",
+			theirs:   mergeBase,
+			merged:   mergeBase + "  owner: team-a\n",
+		},
+		{
+			caseName: "different lines changed by both",
+			ours:     mergeBase + "  owner: team-a\n",
+			theirs:   withReplicas("2"),
+			merged:   withReplicas("2") + "  owner: team-a\n",
+		},
+		{
+			caseName: "same change by both",
+			ours:     withReplicas("2"),
+			theirs:   withReplicas("2"),
+			merged:   withReplicas("2"),
+		},
+		{
+			caseName: "same line changed differently",
+			ours:     withReplicas("3"),
+			theirs:   withReplicas("2"),
+			merged: `apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: podinfo
+data:
+<<<<<<< current
+  replicas: "3"
+||||||| previous
+  replicas: "1"
+=======
+  replicas: "2"
+>>>>>>> rendered
+  region: eu-west-1
+`,
+			conflicted: true,
+		},
+		{
+			caseName: "missing final newline",
+			base:     "a\n",
+			ours:     "a\nb",
+			theirs:   "a\nc",
+			merged: `a
+<<<<<<< current
+b
+||||||| previous
+=======
+c
+>>>>>>> rendered
+`,
+			conflicted: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.caseName, func(t *testing.T) {
+			base := tc.base
+			if base == "" {
+				base = mergeBase
+			}
+
+			merged, conflicted := git.ThreeWayMerge(
+				git.MergeFile{Label: "previous", Content: base},
+				git.MergeFile{Label: "current", Content: tc.ours},
+				git.MergeFile{Label: "rendered", Content: tc.theirs},
+			)
+			assert.Equal(t, tc.merged, merged)
+			assert.Equal(t, tc.conflicted, conflicted)
+		})
+	}
+}
+
+func withReplicas(replicas string) string {
+	return strings.Replace(mergeBase, `replicas: "1"`, `replicas: "`+replicas+`"`, 1)
+}
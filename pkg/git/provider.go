@@ -19,6 +19,10 @@ type Provider interface {
 	GetRepository(ctx context.Context, repoURL string) (*Repository, error)
 	GetTreeList(ctx context.Context, repoUrl, sha, path string) ([]*TreeEntry, error)
 	ListPullRequests(ctx context.Context, repoURL string) ([]*PullRequest, error)
+
+	// GetFiles returns the content of the files at the paths in the branch.
+	// Paths of files that don't exist in the branch are left out.
+	GetFiles(ctx context.Context, repoURL, branch string, paths []string) ([]CommitFile, error)
 }
 
 // CommitFile represents the contents of file in the repository.
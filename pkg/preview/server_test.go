@@ -941,3 +941,7 @@ func (p *TestProvider) GetTreeList(ctx context.Context, repoUrl, sha, path strin
 func (p *TestProvider) ListPullRequests(ctx context.Context, repoUrl string) ([]*git.PullRequest, error) {
 	return nil, nil
 }
+
+func (p *TestProvider) GetFiles(ctx context.Context, repoURL, branch string, paths []string) ([]git.CommitFile, error) {
+	return nil, nil
+}
@@ -18,6 +18,7 @@ import (
 	"github.com/weaveworks/weave-gitops-enterprise/pkg/query/internal/models"
 	"github.com/weaveworks/weave-gitops-enterprise/pkg/query/rbac"
 	store "github.com/weaveworks/weave-gitops-enterprise/pkg/query/store"
+	"github.com/weaveworks/weave-gitops/pkg/kube"
 	"github.com/weaveworks/weave-gitops/pkg/server/auth"
 )
 
@@ -541,7 +542,7 @@ func TestRunQuery_AccessRules(t *testing.T) {
 			kindToResourceMap, err := testutils.CreateDefaultResourceKindMap()
 			assert.NoError(t, err)
 
-			authz := rbac.NewAuthorizer(kindToResourceMap)
+			authz := rbac.NewAuthorizer(kindToResourceMap, kube.UserPrefixes{})
 
 			qs, err := NewQueryService(QueryServiceOpts{
 				Log:         logr.Discard(),
@@ -552,7 +553,7 @@ func TestRunQuery_AccessRules(t *testing.T) {
 
 			assert.NoError(t, err)
 
-			actual, err := qs.RunQuery(ctx, &query{}, nil)
+			actual, _, err := qs.RunQuery(ctx, &query{}, nil)
 			assert.NoError(t, err)
 
 			opt := cmpopts.IgnoreFields(models.Object{}, "ID", "CreatedAt", "UpdatedAt", "DeletedAt", "Category")
@@ -2,12 +2,20 @@ package accesschecker
 
 import (
 	"github.com/weaveworks/weave-gitops-enterprise/pkg/query/internal/models"
+	"github.com/weaveworks/weave-gitops/pkg/kube"
 	"github.com/weaveworks/weave-gitops/pkg/server/auth"
 )
 
-func RelevantRulesForUser(user *auth.UserPrincipal, rules []models.AccessRule) []models.AccessRule {
+// RelevantRulesForUser returns the rules whose subjects include the user or one of its groups,
+// with the prefixes that are added to them when the user is impersonated.
+func RelevantRulesForUser(user *auth.UserPrincipal, rules []models.AccessRule, prefixes kube.UserPrefixes) []models.AccessRule {
 	matchingRules := []models.AccessRule{}
 
+	// Principals with a token are not impersonated, see kube.ConfigWithPrincipal.
+	if user.Token() != "" {
+		prefixes = kube.UserPrefixes{}
+	}
+
 	for _, rule := range rules {
 		if rule.AccessibleKinds == nil || len(rule.AccessibleKinds) == 0 {
 			// Not sure how this rule got created, but it doesn't provide any kinds, so ignore.
@@ -15,13 +23,13 @@ func RelevantRulesForUser(user *auth.UserPrincipal, rules []models.AccessRule) [
 		}
 
 		for _, subject := range rule.Subjects {
-			if subject.Kind == "User" && subject.Name == user.ID {
+			if subject.Kind == "User" && subject.Name == prefixes.UsernamePrefix+user.ID {
 				matchingRules = append(matchingRules, rule)
 				continue
 			}
 
 			for _, group := range user.Groups {
-				if subject.Kind == "Group" && subject.Name == group {
+				if subject.Kind == "Group" && subject.Name == prefixes.GroupsPrefix+group {
 					matchingRules = append(matchingRules, rule)
 				}
 			}
@@ -0,0 +1,149 @@
+package query
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/weaveworks/weave-gitops-enterprise/pkg/query/internal/models"
+)
+
+// ErrInvalidGroupBy is returned when objects are grouped by a field that is not supported.
+var ErrInvalidGroupBy = errors.New("invalid group by")
+
+const labelFieldPrefix = "labels."
+
+// groupByFields are the fields of an object that aggregations can group by,
+// besides its labels as `labels.<key>`.
+var groupByFields = map[string]func(models.Object) string{
+	"cluster":    func(o models.Object) string { return o.Cluster },
+	"namespace":  func(o models.Object) string { return o.Namespace },
+	"kind":       func(o models.Object) string { return o.Kind },
+	"name":       func(o models.Object) string { return o.Name },
+	"status":     func(o models.Object) string { return o.Status },
+	"apiGroup":   func(o models.Object) string { return o.APIGroup },
+	"apiVersion": func(o models.Object) string { return o.APIVersion },
+	"category":   func(o models.Object) string { return string(o.Category) },
+	"tenant":     func(o models.Object) string { return o.Tenant },
+}
+
+func validateGroupBy(groupBy []string) error {
+	if len(groupBy) == 0 {
+		return fmt.Errorf("%w: at least one field is required", ErrInvalidGroupBy)
+	}
+
+	seen := map[string]bool{}
+	for _, field := range groupBy {
+		if seen[field] {
+			return fmt.Errorf("%w: field %q is repeated", ErrInvalidGroupBy, field)
+		}
+		seen[field] = true
+
+		if _, ok := groupByFields[field]; ok {
+			continue
+		}
+		if strings.HasPrefix(field, labelFieldPrefix) && len(field) > len(labelFieldPrefix) {
+			continue
+		}
+
+		return fmt.Errorf("%w: field %q is not supported", ErrInvalidGroupBy, field)
+	}
+
+	return nil
+}
+
+// aggregationBatchSize is the number of objects read from the index at a time while aggregating.
+var aggregationBatchSize = 500
+
+// aggregator counts objects by the values of the fields they are grouped by.
+// Objects are added a batch at a time, so that they are not all held in memory.
+type aggregator struct {
+	groupBy []string
+	buckets map[string]*models.AggregationBucket
+}
+
+func newAggregator(groupBy []string) *aggregator {
+	return &aggregator{
+		groupBy: groupBy,
+		buckets: map[string]*models.AggregationBucket{},
+	}
+}
+
+// add counts the objects in the buckets of their groups.
+func (a *aggregator) add(objects []models.Object) {
+	for _, obj := range objects {
+		labels := objectLabels(obj)
+
+		group := map[string]string{}
+		values := []string{}
+		for _, field := range a.groupBy {
+			var value string
+			if get, ok := groupByFields[field]; ok {
+				value = get(obj)
+			} else {
+				value = labels[strings.TrimPrefix(field, labelFieldPrefix)]
+			}
+
+			group[field] = value
+			values = append(values, value)
+		}
+
+		key := strings.Join(values, "\x00")
+		bucket, ok := a.buckets[key]
+		if !ok {
+			bucket = &models.AggregationBucket{Group: group}
+			a.buckets[key] = bucket
+		}
+		bucket.Count++
+	}
+}
+
+// result returns the buckets counted, sorted by count, largest first, then by group.
+func (a *aggregator) result() []models.AggregationBucket {
+	result := []models.AggregationBucket{}
+	for _, bucket := range a.buckets {
+		result = append(result, *bucket)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Count != result[j].Count {
+			return result[i].Count > result[j].Count
+		}
+		return groupKey(result[i], a.groupBy) < groupKey(result[j], a.groupBy)
+	})
+
+	return result
+}
+
+func groupKey(bucket models.AggregationBucket, groupBy []string) string {
+	values := []string{}
+	for _, field := range groupBy {
+		values = append(values, bucket.Group[field])
+	}
+	return strings.Join(values, "\x00")
+}
+
+// objectLabels returns the labels of an object. Only the labels configured for its kind
+// are kept on the object, so the rest are read from the object itself.
+func objectLabels(obj models.Object) map[string]string {
+	labels := map[string]string{}
+
+	raw := struct {
+		Metadata struct {
+			Labels map[string]string `json:"labels"`
+		} `json:"metadata"`
+	}{}
+	if content := obj.Content(); len(content) > 0 && json.Unmarshal(content, &raw) == nil {
+		for k, v := range raw.Metadata.Labels {
+			labels[k] = v
+		}
+	}
+
+	for k, v := range obj.Labels {
+		labels[k] = v
+	}
+
+	return labels
+}
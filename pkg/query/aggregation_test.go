@@ -0,0 +1,194 @@
+package query
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"testing"
+
+	"github.com/go-logr/logr"
+	. "github.com/onsi/gomega"
+	"github.com/weaveworks/weave-gitops-enterprise/pkg/query/internal/models"
+	"github.com/weaveworks/weave-gitops-enterprise/pkg/query/store"
+	"github.com/weaveworks/weave-gitops/pkg/server/auth"
+)
+
+func TestValidateGroupBy(t *testing.T) {
+	tests := []struct {
+		name    string
+		groupBy []string
+		wantErr string
+	}{
+		{
+			name:    "fields and labels",
+			groupBy: []string{"cluster", "status", "labels.app.kubernetes.io/name"},
+		},
+		{
+			name:    "no fields",
+			groupBy: nil,
+			wantErr: "invalid group by: at least one field is required",
+		},
+		{
+			name:    "unknown field",
+			groupBy: []string{"unstructured"},
+			wantErr: `invalid group by: field "unstructured" is not supported`,
+		},
+		{
+			name:    "label without key",
+			groupBy: []string{"labels."},
+			wantErr: `invalid group by: field "labels." is not supported`,
+		},
+		{
+			name:    "repeated field",
+			groupBy: []string{"kind", "kind"},
+			wantErr: `invalid group by: field "kind" is repeated`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			err := validateGroupBy(tt.groupBy)
+			if tt.wantErr == "" {
+				g.Expect(err).NotTo(HaveOccurred())
+				return
+			}
+
+			g.Expect(err).To(MatchError(ErrInvalidGroupBy))
+			g.Expect(err).To(MatchError(tt.wantErr))
+		})
+	}
+}
+
+func TestAggregate(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	dir, err := os.MkdirTemp("", "test")
+	g.Expect(err).NotTo(HaveOccurred())
+
+	db, err := store.CreateSQLiteDB(dir)
+	g.Expect(err).NotTo(HaveOccurred())
+
+	s, err := store.NewSQLiteStore(db, logr.Discard())
+	g.Expect(err).NotTo(HaveOccurred())
+
+	idx, err := store.NewIndexer(s, dir, logr.Discard())
+	g.Expect(err).NotTo(HaveOccurred())
+
+	ctx := auth.WithPrincipal(context.Background(), &auth.UserPrincipal{
+		ID: "test",
+	})
+
+	release := func(cluster, namespace, name, status, team string) models.Object {
+		// Objects are stored as the collector writes them.
+		raw, err := json.Marshal(map[string]interface{}{
+			"Object": map[string]interface{}{
+				"metadata": map[string]interface{}{
+					"labels": map[string]string{"team": team},
+				},
+			},
+		})
+		g.Expect(err).NotTo(HaveOccurred())
+
+		return models.Object{
+			Cluster:      cluster,
+			Namespace:    namespace,
+			Name:         name,
+			Kind:         "HelmRelease",
+			APIGroup:     "helm.toolkit.fluxcd.io",
+			APIVersion:   "v2beta1",
+			Status:       status,
+			Unstructured: raw,
+		}
+	}
+
+	objects := []models.Object{
+		release("cluster-1", "flux-system", "a", "Failed", "blue"),
+		release("cluster-1", "flux-system", "b", "Failed", "red"),
+		release("cluster-1", "flux-system", "c", "Success", "blue"),
+		release("cluster-2", "flux-system", "d", "Failed", "blue"),
+		release("cluster-2", "apps", "e", "Success", "blue"),
+		// The principal cannot see the objects of this cluster, so they must not be counted.
+		release("cluster-3", "flux-system", "f", "Failed", "blue"),
+	}
+
+	g.Expect(idx.Add(context.Background(), objects)).To(Succeed())
+	g.Expect(store.SeedObjects(db, objects)).To(Succeed())
+
+	tenants := []models.Tenant{
+		{ID: "tenant-a", Name: "Tenant A", Namespace: "apps", ClusterName: "cluster-2"},
+	}
+	g.Expect(db.Create(&tenants).Error).NotTo(HaveOccurred())
+
+	q := &qs{
+		log:   logr.Discard(),
+		debug: logr.Discard(),
+		r:     s,
+		index: idx,
+		authorizer: predicateAuthz{
+			predicate: func(obj models.Object) (bool, error) {
+				return obj.Cluster != "cluster-3", nil
+			},
+		},
+	}
+
+	t.Run("failed releases per cluster", func(t *testing.T) {
+		g := NewWithT(t)
+
+		buckets, err := q.Aggregate(ctx, &query{query: "status:Failed"}, []string{"cluster"})
+		g.Expect(err).NotTo(HaveOccurred())
+		g.Expect(buckets).To(Equal([]models.AggregationBucket{
+			{Group: map[string]string{"cluster": "cluster-1"}, Count: 2},
+			{Group: map[string]string{"cluster": "cluster-2"}, Count: 1},
+		}))
+	})
+
+	t.Run("objects per tenant and status", func(t *testing.T) {
+		g := NewWithT(t)
+
+		buckets, err := q.Aggregate(ctx, &query{}, []string{"tenant", "status"})
+		g.Expect(err).NotTo(HaveOccurred())
+		g.Expect(buckets).To(Equal([]models.AggregationBucket{
+			{Group: map[string]string{"tenant": "", "status": "Failed"}, Count: 3},
+			{Group: map[string]string{"tenant": "", "status": "Success"}, Count: 1},
+			{Group: map[string]string{"tenant": "Tenant A", "status": "Success"}, Count: 1},
+		}))
+	})
+
+	t.Run("objects per label", func(t *testing.T) {
+		g := NewWithT(t)
+
+		buckets, err := q.Aggregate(ctx, &query{}, []string{"labels.team"})
+		g.Expect(err).NotTo(HaveOccurred())
+		g.Expect(buckets).To(Equal([]models.AggregationBucket{
+			{Group: map[string]string{"labels.team": "blue"}, Count: 4},
+			{Group: map[string]string{"labels.team": "red"}, Count: 1},
+		}))
+	})
+
+	t.Run("objects are counted a batch at a time", func(t *testing.T) {
+		g := NewWithT(t)
+
+		defer func(size int) {
+			aggregationBatchSize = size
+		}(aggregationBatchSize)
+		aggregationBatchSize = 2
+
+		buckets, err := q.Aggregate(ctx, &query{}, []string{"cluster", "status"})
+		g.Expect(err).NotTo(HaveOccurred())
+		g.Expect(buckets).To(Equal([]models.AggregationBucket{
+			{Group: map[string]string{"cluster": "cluster-1", "status": "Failed"}, Count: 2},
+			{Group: map[string]string{"cluster": "cluster-1", "status": "Success"}, Count: 1},
+			{Group: map[string]string{"cluster": "cluster-2", "status": "Failed"}, Count: 1},
+			{Group: map[string]string{"cluster": "cluster-2", "status": "Success"}, Count: 1},
+		}))
+	})
+
+	t.Run("unsupported field", func(t *testing.T) {
+		g := NewWithT(t)
+
+		_, err := q.Aggregate(ctx, &query{}, []string{"message"})
+		g.Expect(err).To(MatchError(ErrInvalidGroupBy))
+	})
+}
@@ -0,0 +1,261 @@
+package alerting
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/yaml"
+
+	"github.com/weaveworks/weave-gitops-enterprise/pkg/query/configuration"
+)
+
+// DefaultInterval is how often rules are evaluated when no interval is configured.
+const DefaultInterval = time.Minute
+
+// Config declares the alerting rules of explorer, and the sinks their notifications are sent to, e.g.
+//
+//	interval: 1m
+//	sinks:
+//	  - name: flux
+//	    flux:
+//	      address: http://notification-controller.flux-system.svc.cluster.local./
+//	rules:
+//	  - name: failed-helmreleases
+//	    query:
+//	      filters:
+//	        - kind:HelmRelease
+//	    condition:
+//	      status: Failed
+//	      for: 10m
+type Config struct {
+	// Interval is how often the rules are evaluated.
+	Interval metav1.Duration `json:"interval,omitempty"`
+	Sinks    []SinkConfig    `json:"sinks"`
+	Rules    []Rule          `json:"rules"`
+}
+
+// SinkConfig declares a sink. Exactly one of Webhook and Flux must be set.
+type SinkConfig struct {
+	Name    string         `json:"name"`
+	Webhook *WebhookConfig `json:"webhook,omitempty"`
+	Flux    *FluxConfig    `json:"flux,omitempty"`
+}
+
+// WebhookConfig declares a sink that posts notifications as JSON to a URL.
+type WebhookConfig struct {
+	URL string `json:"url"`
+	// Headers are added to every request, e.g. for authorization.
+	Headers map[string]string `json:"headers,omitempty"`
+}
+
+// FluxConfig declares a sink that posts notifications as events to the Flux notification-controller,
+// for its Alerts to forward them to their providers.
+type FluxConfig struct {
+	// Address is the address of the event receiver of the notification-controller.
+	Address string `json:"address"`
+}
+
+// Severity is the severity of the notifications of a rule, as Flux events have.
+type Severity string
+
+const (
+	SeverityInfo  Severity = "info"
+	SeverityError Severity = "error"
+)
+
+// Rule raises an alert for each object matched by a query that has met a condition for a while.
+// The query is either declared inline, or is a saved query.
+type Rule struct {
+	Name string `json:"name"`
+	// Query is the query that matches the objects.
+	Query *Query `json:"query,omitempty"`
+	// SavedQuery is the ID of the saved query that matches the objects, instead of Query. The query is
+	// read on every evaluation, so changes to it apply to the rule. Saved queries are only kept across
+	// restarts by the postgres store, so rules can only reference them with it.
+	SavedQuery string    `json:"savedQuery,omitempty"`
+	Condition  Condition `json:"condition,omitempty"`
+	// Severity is error by default.
+	Severity Severity `json:"severity,omitempty"`
+	// Sinks are the names of the sinks the notifications of the rule are sent to. They are sent to every sink by default.
+	Sinks []string `json:"sinks,omitempty"`
+}
+
+// Query is a query declared by a rule, with the fields of a saved query.
+type Query struct {
+	Terms   string   `json:"terms,omitempty"`
+	Filters []string `json:"filters,omitempty"`
+	Query   string   `json:"query,omitempty"`
+}
+
+func (q Query) GetTerms() string {
+	return q.Terms
+}
+
+func (q Query) GetFilters() []string {
+	return q.Filters
+}
+
+func (q Query) GetQuery() string {
+	return q.Query
+}
+
+// Condition is met by the objects matched by a rule once they have had its status for its duration.
+type Condition struct {
+	// Status is the status the objects must have. Any status meets the condition when empty.
+	Status configuration.ObjectStatus `json:"status,omitempty"`
+	// For is how long the objects must keep meeting the condition before they alert. They alert
+	// as soon as they meet it when zero.
+	For metav1.Duration `json:"for,omitempty"`
+}
+
+// LoadConfig reads and validates the YAML configuration at path.
+func LoadConfig(path string) (Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return Config{}, fmt.Errorf("cannot read alerting configuration: %w", err)
+	}
+
+	var config Config
+	if err := yaml.UnmarshalStrict(data, &config); err != nil {
+		return Config{}, fmt.Errorf("cannot parse alerting configuration: %w", err)
+	}
+
+	if err := config.Validate(); err != nil {
+		return Config{}, fmt.Errorf("invalid alerting configuration: %w", err)
+	}
+
+	return config, nil
+}
+
+var statuses = map[configuration.ObjectStatus]bool{
+	configuration.Success:       true,
+	configuration.Failed:        true,
+	configuration.Reconciling:   true,
+	configuration.Suspended:     true,
+	configuration.PendingAction: true,
+	configuration.NoStatus:      true,
+}
+
+func (c Config) Validate() error {
+	if c.Interval.Duration < 0 {
+		return errors.New("interval cannot be negative")
+	}
+
+	sinks := map[string]bool{}
+	for _, s := range c.Sinks {
+		if s.Name == "" {
+			return errors.New("sink name is required")
+		}
+		if sinks[s.Name] {
+			return fmt.Errorf("sink %q is repeated", s.Name)
+		}
+		sinks[s.Name] = true
+
+		if err := s.validate(); err != nil {
+			return fmt.Errorf("sink %q: %w", s.Name, err)
+		}
+	}
+
+	rules := map[string]bool{}
+	for _, r := range c.Rules {
+		if r.Name == "" {
+			return errors.New("rule name is required")
+		}
+		if rules[r.Name] {
+			return fmt.Errorf("rule %q is repeated", r.Name)
+		}
+		rules[r.Name] = true
+
+		if err := r.validate(sinks); err != nil {
+			return fmt.Errorf("rule %q: %w", r.Name, err)
+		}
+	}
+
+	return nil
+}
+
+func (s SinkConfig) validate() error {
+	address := ""
+	switch {
+	case s.Webhook != nil && s.Flux != nil:
+		return errors.New("only one of webhook and flux can be set")
+	case s.Webhook != nil:
+		address = s.Webhook.URL
+	case s.Flux != nil:
+		address = s.Flux.Address
+	default:
+		return errors.New("one of webhook and flux is required")
+	}
+
+	u, err := url.Parse(address)
+	if err != nil {
+		return fmt.Errorf("invalid address: %w", err)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return fmt.Errorf("invalid address %q: scheme must be http or https", address)
+	}
+
+	return nil
+}
+
+func (r Rule) validate(sinks map[string]bool) error {
+	switch {
+	case r.Query != nil && r.SavedQuery != "":
+		return errors.New("only one of query and saved query can be set")
+	case r.Query == nil && r.SavedQuery == "":
+		return errors.New("one of query and saved query is required")
+	}
+
+	if r.Condition.Status != "" && !statuses[r.Condition.Status] {
+		return fmt.Errorf("unsupported status %q", r.Condition.Status)
+	}
+	if r.Condition.For.Duration < 0 {
+		return errors.New("condition duration cannot be negative")
+	}
+
+	if r.Severity != "" && r.Severity != SeverityInfo && r.Severity != SeverityError {
+		return fmt.Errorf("unsupported severity %q", r.Severity)
+	}
+
+	for _, name := range r.Sinks {
+		if !sinks[name] {
+			return fmt.Errorf("sink %q is not declared", name)
+		}
+	}
+
+	return nil
+}
+
+// SavedQueryRules returns the names of the rules that reference saved queries.
+func (c Config) SavedQueryRules() []string {
+	names := []string{}
+	for _, r := range c.Rules {
+		if r.SavedQuery != "" {
+			names = append(names, r.Name)
+		}
+	}
+
+	return names
+}
+
+// NewSinks creates the sinks of the configuration, by name.
+func (c Config) NewSinks() (map[string]Sink, error) {
+	sinks := map[string]Sink{}
+
+	for _, s := range c.Sinks {
+		switch {
+		case s.Webhook != nil:
+			sinks[s.Name] = NewWebhookSink(s.Webhook.URL, s.Webhook.Headers)
+		case s.Flux != nil:
+			sinks[s.Name] = NewFluxSink(s.Flux.Address)
+		default:
+			return nil, fmt.Errorf("sink %q has no type", s.Name)
+		}
+	}
+
+	return sinks, nil
+}
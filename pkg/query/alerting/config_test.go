@@ -0,0 +1,116 @@
+package alerting
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestLoadConfig(t *testing.T) {
+	g := NewWithT(t)
+
+	path := filepath.Join(t.TempDir(), "alerting.yaml")
+	g.Expect(os.WriteFile(path, []byte(`
+interval: 30s
+sinks:
+  - name: ops
+    webhook:
+      url: https://alerts.example.com/hooks/explorer
+  - name: flux
+    flux:
+      address: http://notification-controller.flux-system.svc.cluster.local./
+rules:
+  - name: failed-releases
+    query:
+      filters:
+        - kind:HelmRelease
+    condition:
+      status: Failed
+      for: 10m
+    sinks: [flux]
+`), 0o600)).To(Succeed())
+
+	config, err := LoadConfig(path)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(config.Interval.Duration).To(Equal(30 * time.Second))
+	g.Expect(config.Rules).To(HaveLen(1))
+	g.Expect(config.Rules[0].Query.GetFilters()).To(Equal([]string{"kind:HelmRelease"}))
+	g.Expect(config.Rules[0].Condition.For.Duration).To(Equal(10 * time.Minute))
+	g.Expect(config.SavedQueryRules()).To(BeEmpty())
+
+	sinks, err := config.NewSinks()
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(sinks).To(HaveKey("ops"))
+	g.Expect(sinks).To(HaveKey("flux"))
+}
+
+func TestConfig_Validate(t *testing.T) {
+	webhook := SinkConfig{Name: "ops", Webhook: &WebhookConfig{URL: "https://alerts.example.com"}}
+	rule := Rule{Name: "rule", Query: &Query{Terms: "podinfo"}}
+
+	tests := []struct {
+		name    string
+		config  Config
+		wantErr string
+	}{
+		{
+			name:   "valid",
+			config: Config{Sinks: []SinkConfig{webhook}, Rules: []Rule{rule}},
+		},
+		{
+			name:    "sink with no type",
+			config:  Config{Sinks: []SinkConfig{{Name: "ops"}}},
+			wantErr: `sink "ops": one of webhook and flux is required`,
+		},
+		{
+			name:    "sink with an invalid address",
+			config:  Config{Sinks: []SinkConfig{{Name: "ops", Flux: &FluxConfig{Address: "notification-controller"}}}},
+			wantErr: "scheme must be http or https",
+		},
+		{
+			name:    "repeated sink",
+			config:  Config{Sinks: []SinkConfig{webhook, webhook}},
+			wantErr: `sink "ops" is repeated`,
+		},
+		{
+			name:   "rule with a saved query",
+			config: Config{Rules: []Rule{{Name: "rule", SavedQuery: "query"}}},
+		},
+		{
+			name:    "rule with no query",
+			config:  Config{Rules: []Rule{{Name: "rule"}}},
+			wantErr: `rule "rule": one of query and saved query is required`,
+		},
+		{
+			name:    "rule with a query and a saved query",
+			config:  Config{Rules: []Rule{{Name: "rule", Query: &Query{Terms: "podinfo"}, SavedQuery: "query"}}},
+			wantErr: `rule "rule": only one of query and saved query can be set`,
+		},
+		{
+			name:    "rule with an unsupported status",
+			config:  Config{Rules: []Rule{{Name: "rule", SavedQuery: "query", Condition: Condition{Status: "Broken"}}}},
+			wantErr: `unsupported status "Broken"`,
+		},
+		{
+			name:    "rule with an undeclared sink",
+			config:  Config{Rules: []Rule{{Name: "rule", SavedQuery: "query", Sinks: []string{"ops"}}}},
+			wantErr: `sink "ops" is not declared`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			err := tt.config.Validate()
+			if tt.wantErr == "" {
+				g.Expect(err).NotTo(HaveOccurred())
+				return
+			}
+			g.Expect(err).To(MatchError(ContainSubstring(tt.wantErr)))
+		})
+	}
+}
@@ -0,0 +1,291 @@
+// Package alerting raises alerts for the objects that explorer queries match.
+package alerting
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/weaveworks/weave-gitops/core/logger"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/rest"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+
+	"github.com/weaveworks/weave-gitops-enterprise/pkg/query/internal/models"
+	"github.com/weaveworks/weave-gitops-enterprise/pkg/query/store"
+)
+
+type EvaluatorOpts struct {
+	Log logr.Logger
+	// Store is where the saved queries of the rules are read from, for the rules that reference them.
+	Store store.StoreReader
+	// Index is where the saved queries are run.
+	Index store.IndexReader
+	// Interval is how often the rules are evaluated, DefaultInterval if zero.
+	Interval time.Duration
+	Rules    []Rule
+	// Sinks are the sinks the rules send notifications to, by name.
+	Sinks map[string]Sink
+}
+
+func (o EvaluatorOpts) Validate() error {
+	if o.Store == nil {
+		return errors.New("store is required")
+	}
+	if o.Index == nil {
+		return errors.New("index is required")
+	}
+
+	for _, r := range o.Rules {
+		for _, name := range r.Sinks {
+			if _, ok := o.Sinks[name]; !ok {
+				return fmt.Errorf("sink %q of rule %q not found", name, r.Name)
+			}
+		}
+	}
+
+	return nil
+}
+
+// Evaluator runs the rules on an interval, and notifies the sinks of the objects that start
+// or stop alerting. Whether objects alert is kept in memory, so after a restart, or once another
+// replica is elected, the objects that meet a condition start over waiting for its duration, and
+// no resolution is sent for the alerts raised before.
+type Evaluator struct {
+	log      logr.Logger
+	debug    logr.Logger
+	store    store.StoreReader
+	index    store.IndexReader
+	interval time.Duration
+	rules    []Rule
+	sinks    map[string]Sink
+	now      func() time.Time
+	// alerts holds the objects that meet the condition of each rule, by rule name and object ID.
+	alerts map[string]map[string]*alert
+}
+
+type alert struct {
+	object models.Object
+	since  time.Time
+	firing bool
+}
+
+func NewEvaluator(opts EvaluatorOpts) (*Evaluator, error) {
+	if err := opts.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid evaluator options: %w", err)
+	}
+
+	interval := opts.Interval
+	if interval == 0 {
+		interval = DefaultInterval
+	}
+
+	return &Evaluator{
+		log:      opts.Log.WithName("alerting"),
+		debug:    opts.Log.WithName("alerting").V(logger.LogLevelDebug),
+		store:    opts.Store,
+		index:    opts.Index,
+		interval: interval,
+		rules:    opts.Rules,
+		sinks:    opts.Sinks,
+		now:      time.Now,
+		alerts:   map[string]map[string]*alert{},
+	}, nil
+}
+
+// Start evaluates the rules on every interval until the context is done.
+func (e *Evaluator) Start(ctx context.Context) error {
+	ticker := time.NewTicker(e.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			if err := e.Evaluate(ctx); err != nil {
+				e.log.Error(err, "could not evaluate alerting rules")
+			}
+		}
+	}
+}
+
+// LeaderElectionID is the name of the lease that the replicas of explorer hold to evaluate the rules.
+const LeaderElectionID = "explorer-alerting.weave.works"
+
+// StartWithLeaderElection evaluates the rules on every interval until the context is done, while
+// holding the lease of the namespace of the pod in the cluster of cfg. Only one of the replicas evaluates
+// the rules at a time, so the sinks are not notified once per replica.
+func (e *Evaluator) StartWithLeaderElection(ctx context.Context, cfg *rest.Config) error {
+	mgr, err := ctrl.NewManager(cfg, ctrl.Options{
+		Scheme:             runtime.NewScheme(),
+		Logger:             e.log,
+		LeaderElection:     true,
+		LeaderElectionID:   LeaderElectionID,
+		MetricsBindAddress: "0",
+	})
+	if err != nil {
+		return fmt.Errorf("cannot create controller manager: %w", err)
+	}
+
+	if err := mgr.Add(manager.RunnableFunc(e.Start)); err != nil {
+		return fmt.Errorf("cannot add evaluator to controller manager: %w", err)
+	}
+
+	return mgr.Start(ctx)
+}
+
+// Evaluate runs every rule once, and sends the notifications due. A rule that fails does not
+// stop the others from running, and the notifications that could not be sent are sent again
+// on the next evaluation.
+func (e *Evaluator) Evaluate(ctx context.Context) error {
+	queries := map[string]models.SavedQuery{}
+	if e.usesSavedQueries() {
+		saved, err := e.store.GetSavedQueries(ctx)
+		if err != nil {
+			return fmt.Errorf("cannot get saved queries: %w", err)
+		}
+
+		for _, q := range saved {
+			queries[q.ID] = q
+		}
+	}
+
+	var errs []error
+	for _, r := range e.rules {
+		if err := e.evaluateRule(ctx, r, queries); err != nil {
+			errs = append(errs, fmt.Errorf("rule %q: %w", r.Name, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+func (e *Evaluator) usesSavedQueries() bool {
+	for _, r := range e.rules {
+		if r.SavedQuery != "" {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (e *Evaluator) evaluateRule(ctx context.Context, r Rule, queries map[string]models.SavedQuery) error {
+	var q store.Query
+	if r.Query != nil {
+		q = r.Query
+	} else {
+		saved, ok := queries[r.SavedQuery]
+		if !ok {
+			// The objects alerting keep doing so until the query is back, rather than being resolved.
+			return fmt.Errorf("saved query %q not found", r.SavedQuery)
+		}
+		q = saved
+	}
+
+	iter, err := e.index.Search(ctx, q, nil)
+	if err != nil {
+		return fmt.Errorf("cannot run query: %w", err)
+	}
+	defer iter.Close()
+
+	objects, err := iter.All()
+	if err != nil {
+		return fmt.Errorf("cannot read objects: %w", err)
+	}
+
+	alerts, ok := e.alerts[r.Name]
+	if !ok {
+		alerts = map[string]*alert{}
+		e.alerts[r.Name] = alerts
+	}
+
+	now := e.now()
+	meeting := map[string]bool{}
+	var errs []error
+
+	for _, obj := range objects {
+		if r.Condition.Status != "" && obj.Status != string(r.Condition.Status) {
+			continue
+		}
+
+		id := obj.GetID()
+		meeting[id] = true
+
+		a, ok := alerts[id]
+		if !ok {
+			a = &alert{since: now}
+			alerts[id] = a
+		}
+		a.object = obj
+
+		if a.firing || now.Sub(a.since) < r.Condition.For.Duration {
+			continue
+		}
+
+		if err := e.notify(ctx, r, a, NotificationFiring, now); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		a.firing = true
+	}
+
+	for id, a := range alerts {
+		if meeting[id] {
+			continue
+		}
+
+		if a.firing {
+			if err := e.notify(ctx, r, a, NotificationResolved, now); err != nil {
+				errs = append(errs, err)
+				continue
+			}
+		}
+		delete(alerts, id)
+	}
+
+	e.debug.Info("rule evaluated", "rule", r.Name, "numMatched", len(objects), "numMeeting", len(meeting))
+	return errors.Join(errs...)
+}
+
+// notify sends a notification to the sinks of the rule. If some of them fail, it is sent to all of them
+// again on the next evaluation.
+func (e *Evaluator) notify(ctx context.Context, r Rule, a *alert, state NotificationState, now time.Time) error {
+	severity := r.Severity
+	if severity == "" {
+		severity = SeverityError
+	}
+
+	n := Notification{
+		Rule:      r.Name,
+		State:     state,
+		Severity:  severity,
+		Object:    a.object,
+		Since:     a.since,
+		Timestamp: now,
+	}
+
+	names := r.Sinks
+	if len(names) == 0 {
+		for name := range e.sinks {
+			names = append(names, name)
+		}
+	}
+
+	var errs []error
+	for _, name := range names {
+		if err := e.sinks[name].Send(ctx, n); err != nil {
+			errs = append(errs, fmt.Errorf("cannot notify sink %q of object %s: %w", name, a.object.GetID(), err))
+		}
+	}
+
+	if len(errs) == 0 {
+		e.debug.Info("notification sent", "rule", r.Name, "state", state, "object", a.object.GetID())
+	}
+
+	return errors.Join(errs...)
+}
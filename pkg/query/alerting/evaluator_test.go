@@ -0,0 +1,228 @@
+package alerting
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/weaveworks/weave-gitops-enterprise/pkg/query/internal/models"
+	"github.com/weaveworks/weave-gitops-enterprise/pkg/query/store"
+	"github.com/weaveworks/weave-gitops-enterprise/pkg/query/store/storefakes"
+)
+
+type recordingSink struct {
+	notifications []Notification
+	err           error
+}
+
+func (s *recordingSink) Send(_ context.Context, n Notification) error {
+	if s.err != nil {
+		return s.err
+	}
+	s.notifications = append(s.notifications, n)
+	return nil
+}
+
+func TestEvaluator(t *testing.T) {
+	g := NewWithT(t)
+
+	failed := models.Object{
+		Cluster:    "management",
+		Namespace:  "flux-system",
+		APIGroup:   "helm.toolkit.fluxcd.io",
+		APIVersion: "v2beta1",
+		Kind:       "HelmRelease",
+		Name:       "podinfo",
+		Status:     "Failed",
+		Message:    "install retries exhausted",
+	}
+	healthy := failed
+	healthy.Name = "nginx"
+	healthy.Status = "Success"
+
+	s := &storefakes.FakeStoreReader{}
+	s.GetSavedQueriesReturns([]models.SavedQuery{{
+		ID:    "releases",
+		Name:  "releases",
+		Query: "kind:HelmRelease",
+	}}, nil)
+
+	// The objects the saved query matches.
+	matching := []models.Object{failed, healthy}
+	idx := &storefakes.FakeIndexReader{}
+	idx.SearchStub = func(_ context.Context, _ store.Query, _ store.QueryOption) (store.Iterator, error) {
+		iter := &storefakes.FakeIterator{}
+		iter.AllReturns(matching, nil)
+		return iter, nil
+	}
+
+	sink := &recordingSink{}
+	e, err := NewEvaluator(EvaluatorOpts{
+		Log:   logr.Discard(),
+		Store: s,
+		Index: idx,
+		Rules: []Rule{{
+			Name:       "failed-releases",
+			SavedQuery: "releases",
+			Condition: Condition{
+				Status: "Failed",
+				For:    metav1.Duration{Duration: 10 * time.Minute},
+			},
+		}},
+		Sinks: map[string]Sink{"test": sink},
+	})
+	g.Expect(err).NotTo(HaveOccurred())
+
+	start := time.Date(2023, 6, 1, 12, 0, 0, 0, time.UTC)
+	now := start
+	e.now = func() time.Time { return now }
+
+	ctx := context.Background()
+
+	t.Run("objects do not alert before the duration of the condition", func(t *testing.T) {
+		g := NewWithT(t)
+
+		g.Expect(e.Evaluate(ctx)).To(Succeed())
+		now = start.Add(5 * time.Minute)
+		g.Expect(e.Evaluate(ctx)).To(Succeed())
+
+		g.Expect(sink.notifications).To(BeEmpty())
+	})
+
+	t.Run("objects that keep meeting the condition alert once", func(t *testing.T) {
+		g := NewWithT(t)
+
+		now = start.Add(10 * time.Minute)
+		g.Expect(e.Evaluate(ctx)).To(Succeed())
+		now = start.Add(15 * time.Minute)
+		g.Expect(e.Evaluate(ctx)).To(Succeed())
+
+		g.Expect(sink.notifications).To(HaveLen(1))
+		n := sink.notifications[0]
+		g.Expect(n.Rule).To(Equal("failed-releases"))
+		g.Expect(n.State).To(Equal(NotificationFiring))
+		g.Expect(n.Severity).To(Equal(SeverityError))
+		g.Expect(n.Object.Name).To(Equal("podinfo"))
+		g.Expect(n.Since).To(Equal(start))
+		g.Expect(n.Message()).To(ContainSubstring("install retries exhausted"))
+	})
+
+	t.Run("objects that no longer meet the condition are resolved", func(t *testing.T) {
+		g := NewWithT(t)
+
+		matching = []models.Object{healthy}
+		now = start.Add(20 * time.Minute)
+		g.Expect(e.Evaluate(ctx)).To(Succeed())
+
+		g.Expect(sink.notifications).To(HaveLen(2))
+		g.Expect(sink.notifications[1].State).To(Equal(NotificationResolved))
+		g.Expect(sink.notifications[1].Object.Name).To(Equal("podinfo"))
+	})
+
+	t.Run("objects that stop meeting the condition before alerting start over", func(t *testing.T) {
+		g := NewWithT(t)
+
+		matching = []models.Object{failed}
+		now = start.Add(30 * time.Minute)
+		g.Expect(e.Evaluate(ctx)).To(Succeed())
+
+		matching = []models.Object{}
+		now = start.Add(35 * time.Minute)
+		g.Expect(e.Evaluate(ctx)).To(Succeed())
+
+		matching = []models.Object{failed}
+		now = start.Add(41 * time.Minute)
+		g.Expect(e.Evaluate(ctx)).To(Succeed())
+
+		g.Expect(sink.notifications).To(HaveLen(2))
+	})
+
+	t.Run("notifications that fail are sent again", func(t *testing.T) {
+		g := NewWithT(t)
+
+		sink.err = errors.New("unavailable")
+		now = start.Add(52 * time.Minute)
+		g.Expect(e.Evaluate(ctx)).To(MatchError(ContainSubstring("unavailable")))
+		g.Expect(sink.notifications).To(HaveLen(2))
+
+		sink.err = nil
+		now = start.Add(53 * time.Minute)
+		g.Expect(e.Evaluate(ctx)).To(Succeed())
+		g.Expect(sink.notifications).To(HaveLen(3))
+		g.Expect(sink.notifications[2].State).To(Equal(NotificationFiring))
+		g.Expect(sink.notifications[2].Since).To(Equal(start.Add(41 * time.Minute)))
+	})
+
+	t.Run("rules with a missing saved query keep their alerts", func(t *testing.T) {
+		g := NewWithT(t)
+
+		s.GetSavedQueriesReturns([]models.SavedQuery{}, nil)
+		now = start.Add(60 * time.Minute)
+		g.Expect(e.Evaluate(ctx)).To(MatchError(ContainSubstring(`saved query "releases" not found`)))
+
+		g.Expect(sink.notifications).To(HaveLen(3))
+	})
+}
+
+func TestEvaluator_Query(t *testing.T) {
+	g := NewWithT(t)
+
+	failed := models.Object{
+		Cluster:    "management",
+		Namespace:  "flux-system",
+		APIGroup:   "helm.toolkit.fluxcd.io",
+		APIVersion: "v2beta1",
+		Kind:       "HelmRelease",
+		Name:       "podinfo",
+		Status:     "Failed",
+	}
+
+	var searched store.Query
+	idx := &storefakes.FakeIndexReader{}
+	idx.SearchStub = func(_ context.Context, q store.Query, _ store.QueryOption) (store.Iterator, error) {
+		searched = q
+		iter := &storefakes.FakeIterator{}
+		iter.AllReturns([]models.Object{failed}, nil)
+		return iter, nil
+	}
+
+	s := &storefakes.FakeStoreReader{}
+	sink := &recordingSink{}
+	e, err := NewEvaluator(EvaluatorOpts{
+		Log:   logr.Discard(),
+		Store: s,
+		Index: idx,
+		Rules: []Rule{{
+			Name:      "failed-releases",
+			Query:     &Query{Filters: []string{"kind:HelmRelease"}},
+			Condition: Condition{Status: "Failed"},
+		}},
+		Sinks: map[string]Sink{"test": sink},
+	})
+	g.Expect(err).NotTo(HaveOccurred())
+
+	g.Expect(e.Evaluate(context.Background())).To(Succeed())
+
+	g.Expect(searched.GetFilters()).To(Equal([]string{"kind:HelmRelease"}))
+	g.Expect(s.GetSavedQueriesCallCount()).To(BeZero())
+	g.Expect(sink.notifications).To(HaveLen(1))
+	g.Expect(sink.notifications[0].Object.Name).To(Equal("podinfo"))
+}
+
+func TestNewEvaluator_UnknownSink(t *testing.T) {
+	g := NewWithT(t)
+
+	_, err := NewEvaluator(EvaluatorOpts{
+		Log:   logr.Discard(),
+		Store: &storefakes.FakeStoreReader{},
+		Index: &storefakes.FakeIndexReader{},
+		Rules: []Rule{{Name: "rule", SavedQuery: "query", Sinks: []string{"missing"}}},
+		Sinks: map[string]Sink{},
+	})
+	g.Expect(err).To(MatchError(ContainSubstring(`sink "missing" of rule "rule" not found`)))
+}
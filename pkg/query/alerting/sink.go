@@ -0,0 +1,233 @@
+package alerting
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/weaveworks/weave-gitops-enterprise/pkg/query/internal/models"
+)
+
+// NotificationState tells whether an alert is raised or cleared.
+type NotificationState string
+
+const (
+	// NotificationFiring is sent once an object has met the condition of a rule for its duration.
+	NotificationFiring NotificationState = "firing"
+	// NotificationResolved is sent once an object that alerted no longer meets the condition of its rule.
+	NotificationResolved NotificationState = "resolved"
+)
+
+// Notification tells about an object that started or stopped alerting.
+type Notification struct {
+	Rule     string
+	State    NotificationState
+	Severity Severity
+	Object   models.Object
+	// Since is when the object started meeting the condition of the rule.
+	Since time.Time
+	// Timestamp is when the notification was raised.
+	Timestamp time.Time
+}
+
+// Message describes the notification for people.
+func (n Notification) Message() string {
+	o := n.Object
+	if n.State == NotificationResolved {
+		return fmt.Sprintf("%s %s/%s in cluster %s no longer meets the condition of rule %s", o.Kind, o.Namespace, o.Name, o.Cluster, n.Rule)
+	}
+
+	msg := fmt.Sprintf("%s %s/%s in cluster %s has met the condition of rule %s since %s", o.Kind, o.Namespace, o.Name, o.Cluster, n.Rule, n.Since.UTC().Format(time.RFC3339))
+	if o.Status != "" {
+		msg = fmt.Sprintf("%s, with status %s", msg, o.Status)
+	}
+	if o.Message != "" {
+		msg = fmt.Sprintf("%s: %s", msg, o.Message)
+	}
+
+	return msg
+}
+
+// Sink is where notifications are sent to.
+type Sink interface {
+	Send(ctx context.Context, n Notification) error
+}
+
+// sinkTimeout is how long a sink waits for a notification to be accepted.
+const sinkTimeout = 15 * time.Second
+
+// NewWebhookSink returns a sink that posts notifications as JSON to a URL, with the given headers.
+func NewWebhookSink(url string, headers map[string]string) Sink {
+	return &webhookSink{
+		url:     url,
+		headers: headers,
+		client:  &http.Client{Timeout: sinkTimeout},
+	}
+}
+
+type webhookSink struct {
+	url     string
+	headers map[string]string
+	client  *http.Client
+}
+
+// webhookPayload is the body of the requests of webhook sinks.
+type webhookPayload struct {
+	Rule      string            `json:"rule"`
+	State     NotificationState `json:"state"`
+	Severity  Severity          `json:"severity"`
+	Message   string            `json:"message"`
+	Since     time.Time         `json:"since"`
+	Timestamp time.Time         `json:"timestamp"`
+	Object    webhookObject     `json:"object"`
+}
+
+type webhookObject struct {
+	ID         string `json:"id"`
+	Cluster    string `json:"cluster"`
+	Namespace  string `json:"namespace"`
+	APIGroup   string `json:"apiGroup"`
+	APIVersion string `json:"apiVersion"`
+	Kind       string `json:"kind"`
+	Name       string `json:"name"`
+	Status     string `json:"status"`
+	Message    string `json:"message"`
+	Tenant     string `json:"tenant,omitempty"`
+}
+
+func (s *webhookSink) Send(ctx context.Context, n Notification) error {
+	o := n.Object
+
+	return post(ctx, s.client, s.url, s.headers, webhookPayload{
+		Rule:      n.Rule,
+		State:     n.State,
+		Severity:  n.Severity,
+		Message:   n.Message(),
+		Since:     n.Since,
+		Timestamp: n.Timestamp,
+		Object: webhookObject{
+			ID:         o.GetID(),
+			Cluster:    o.Cluster,
+			Namespace:  o.Namespace,
+			APIGroup:   o.APIGroup,
+			APIVersion: o.APIVersion,
+			Kind:       o.Kind,
+			Name:       o.Name,
+			Status:     o.Status,
+			Message:    o.Message,
+			Tenant:     o.Tenant,
+		},
+	})
+}
+
+// fluxReportingController is the controller that Flux events are reported by.
+const fluxReportingController = "weave-gitops-enterprise-explorer"
+
+const (
+	fluxReasonFiring   = "AlertFiring"
+	fluxReasonResolved = "AlertResolved"
+)
+
+// NewFluxSink returns a sink that posts notifications as events to the event receiver of the Flux
+// notification-controller at address. The event is about the object that alerted, so that Alerts
+// select it by its kind, name and namespace as they do for the events of Flux controllers.
+func NewFluxSink(address string) Sink {
+	return &fluxSink{
+		address: address,
+		client:  &http.Client{Timeout: sinkTimeout},
+	}
+}
+
+type fluxSink struct {
+	address string
+	client  *http.Client
+}
+
+// fluxEvent is the event accepted by the notification-controller,
+// as defined by github.com/fluxcd/pkg/apis/event/v1beta1.
+type fluxEvent struct {
+	InvolvedObject      corev1.ObjectReference `json:"involvedObject"`
+	Severity            Severity               `json:"severity"`
+	Timestamp           metav1.Time            `json:"timestamp"`
+	Message             string                 `json:"message"`
+	Reason              string                 `json:"reason"`
+	Metadata            map[string]string      `json:"metadata,omitempty"`
+	ReportingController string                 `json:"reportingController"`
+}
+
+func (s *fluxSink) Send(ctx context.Context, n Notification) error {
+	o := n.Object
+
+	apiVersion := o.APIVersion
+	if o.APIGroup != "" {
+		apiVersion = o.APIGroup + "/" + o.APIVersion
+	}
+
+	event := fluxEvent{
+		InvolvedObject: corev1.ObjectReference{
+			APIVersion: apiVersion,
+			Kind:       o.Kind,
+			Namespace:  o.Namespace,
+			Name:       o.Name,
+		},
+		Severity:            n.Severity,
+		Timestamp:           metav1.NewTime(n.Timestamp),
+		Message:             n.Message(),
+		Reason:              fluxReasonFiring,
+		ReportingController: fluxReportingController,
+	}
+
+	if n.State == NotificationResolved {
+		event.Severity = SeverityInfo
+		event.Reason = fluxReasonResolved
+	}
+
+	// The notification-controller only keeps the metadata prefixed with the API group of the object.
+	if o.APIGroup != "" {
+		event.Metadata = map[string]string{
+			o.APIGroup + "/rule":    n.Rule,
+			o.APIGroup + "/cluster": o.Cluster,
+		}
+	}
+
+	return post(ctx, s.client, s.address, nil, event)
+}
+
+func post(ctx context.Context, client *http.Client, url string, headers map[string]string, payload interface{}) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("cannot encode notification: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("cannot create request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("cannot send notification: %w", err)
+	}
+	defer resp.Body.Close()
+
+	// The body is drained for the connection to be reused.
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("notification rejected by %s: %s", url, resp.Status)
+	}
+
+	return nil
+}
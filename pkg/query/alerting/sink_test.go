@@ -0,0 +1,123 @@
+package alerting
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/weaveworks/weave-gitops-enterprise/pkg/query/internal/models"
+)
+
+func testNotification(state NotificationState) Notification {
+	return Notification{
+		Rule:     "failed-releases",
+		State:    state,
+		Severity: SeverityError,
+		Object: models.Object{
+			Cluster:    "management",
+			Namespace:  "flux-system",
+			APIGroup:   "helm.toolkit.fluxcd.io",
+			APIVersion: "v2beta1",
+			Kind:       "HelmRelease",
+			Name:       "podinfo",
+			Status:     "Failed",
+			Message:    "install retries exhausted",
+		},
+		Since:     time.Date(2023, 6, 1, 12, 0, 0, 0, time.UTC),
+		Timestamp: time.Date(2023, 6, 1, 12, 10, 0, 0, time.UTC),
+	}
+}
+
+// receive starts a server that records the requests it receives, and answers with status.
+func receive(t *testing.T, status int) (*httptest.Server, *[]*http.Request, *[]map[string]interface{}) {
+	requests := []*http.Request{}
+	bodies := []map[string]interface{}{}
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		data, err := io.ReadAll(r.Body)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		body := map[string]interface{}{}
+		if err := json.Unmarshal(data, &body); err != nil {
+			t.Fatal(err)
+		}
+
+		requests = append(requests, r)
+		bodies = append(bodies, body)
+		w.WriteHeader(status)
+	}))
+	t.Cleanup(srv.Close)
+
+	return srv, &requests, &bodies
+}
+
+func TestWebhookSink(t *testing.T) {
+	g := NewWithT(t)
+
+	srv, requests, bodies := receive(t, http.StatusOK)
+
+	sink := NewWebhookSink(srv.URL, map[string]string{"Authorization": "Bearer token"})
+	g.Expect(sink.Send(context.Background(), testNotification(NotificationFiring))).To(Succeed())
+
+	g.Expect(*requests).To(HaveLen(1))
+	g.Expect((*requests)[0].Method).To(Equal(http.MethodPost))
+	g.Expect((*requests)[0].Header.Get("Authorization")).To(Equal("Bearer token"))
+	g.Expect((*requests)[0].Header.Get("Content-Type")).To(Equal("application/json"))
+
+	body := (*bodies)[0]
+	g.Expect(body).To(HaveKeyWithValue("rule", "failed-releases"))
+	g.Expect(body).To(HaveKeyWithValue("state", "firing"))
+	g.Expect(body).To(HaveKeyWithValue("severity", "error"))
+	g.Expect(body).To(HaveKeyWithValue("since", "2023-06-01T12:00:00Z"))
+	g.Expect(body["object"]).To(HaveKeyWithValue("id", "management/flux-system/helm.toolkit.fluxcd.io/v2beta1/HelmRelease/podinfo"))
+	g.Expect(body["object"]).To(HaveKeyWithValue("status", "Failed"))
+}
+
+func TestWebhookSink_Rejected(t *testing.T) {
+	g := NewWithT(t)
+
+	srv, _, _ := receive(t, http.StatusInternalServerError)
+
+	sink := NewWebhookSink(srv.URL, nil)
+	g.Expect(sink.Send(context.Background(), testNotification(NotificationFiring))).To(MatchError(ContainSubstring("500 Internal Server Error")))
+}
+
+func TestFluxSink(t *testing.T) {
+	g := NewWithT(t)
+
+	srv, _, bodies := receive(t, http.StatusAccepted)
+
+	sink := NewFluxSink(srv.URL)
+	g.Expect(sink.Send(context.Background(), testNotification(NotificationFiring))).To(Succeed())
+	g.Expect(sink.Send(context.Background(), testNotification(NotificationResolved))).To(Succeed())
+
+	g.Expect(*bodies).To(HaveLen(2))
+
+	firing := (*bodies)[0]
+	g.Expect(firing["involvedObject"]).To(Equal(map[string]interface{}{
+		"apiVersion": "helm.toolkit.fluxcd.io/v2beta1",
+		"kind":       "HelmRelease",
+		"namespace":  "flux-system",
+		"name":       "podinfo",
+	}))
+	g.Expect(firing).To(HaveKeyWithValue("severity", "error"))
+	g.Expect(firing).To(HaveKeyWithValue("reason", "AlertFiring"))
+	g.Expect(firing).To(HaveKeyWithValue("reportingController", "weave-gitops-enterprise-explorer"))
+	g.Expect(firing).To(HaveKeyWithValue("timestamp", "2023-06-01T12:10:00Z"))
+	g.Expect(firing["metadata"]).To(Equal(map[string]interface{}{
+		"helm.toolkit.fluxcd.io/rule":    "failed-releases",
+		"helm.toolkit.fluxcd.io/cluster": "management",
+	}))
+
+	resolved := (*bodies)[1]
+	g.Expect(resolved).To(HaveKeyWithValue("severity", "info"))
+	g.Expect(resolved).To(HaveKeyWithValue("reason", "AlertResolved"))
+}
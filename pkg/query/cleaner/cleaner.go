@@ -3,6 +3,7 @@ package cleaner
 import (
 	"context"
 	"fmt"
+	"sync"
 	"time"
 
 	"github.com/go-logr/logr"
@@ -15,11 +16,14 @@ import (
 type ObjectCleaner interface {
 	Start() error
 	Stop() error
+	// SetObjectKinds changes the kinds whose objects are removed when they expire.
+	SetObjectKinds(kinds []configuration.ObjectKind)
 }
 
 type objectCleaner struct {
 	log              logr.Logger
 	ticker           *time.Ticker
+	configMu         sync.Mutex
 	config           []configuration.ObjectKind
 	idx              store.IndexWriter
 	store            store.Store
@@ -83,6 +87,20 @@ func (oc *objectCleaner) Stop() error {
 	return nil
 }
 
+func (oc *objectCleaner) SetObjectKinds(kinds []configuration.ObjectKind) {
+	oc.configMu.Lock()
+	defer oc.configMu.Unlock()
+
+	oc.config = kinds
+}
+
+func (oc *objectCleaner) objectKinds() []configuration.ObjectKind {
+	oc.configMu.Lock()
+	defer oc.configMu.Unlock()
+
+	return oc.config
+}
+
 // setStatus sets cleaner status and records it as a metric.
 func (oc *objectCleaner) setStatus(s string) {
 	if oc.status != "" {
@@ -125,12 +143,17 @@ func (oc *objectCleaner) removeOldObjects(ctx context.Context) (retErr error) {
 		return fmt.Errorf("could not iterate over objects: %w", err)
 	}
 
+	// The history of the objects that are kept expires with the same retention policy.
+	history := map[configuration.RetentionPolicy][]string{}
+
+	config := oc.objectKinds()
+
 	for _, obj := range all {
-		for i, k := range oc.config {
+		for i, k := range config {
 			kind := fmt.Sprintf("%s/%s", k.Gvk.GroupVersion().String(), k.Gvk.Kind)
 			gvk := obj.GroupVersionKind()
 			if kind == gvk {
-				objKind := oc.config[i]
+				objKind := config[i]
 
 				if models.IsExpired(objKind.RetentionPolicy, obj) {
 					remove := []models.Object{obj}
@@ -142,10 +165,20 @@ func (oc *objectCleaner) removeOldObjects(ctx context.Context) (retErr error) {
 					if err := oc.idx.Remove(ctx, remove); err != nil {
 						oc.log.Error(err, "could not delete object with ID: %s from index", obj.ID)
 					}
+				} else if objKind.RetentionPolicy != configuration.NoRetentionPolicy {
+					history[objKind.RetentionPolicy] = append(history[objKind.RetentionPolicy], obj.ID)
 				}
 			}
 		}
 
 	}
+
+	for policy, ids := range history {
+		before := time.Now().Add(-time.Duration(policy))
+		if err := oc.store.DeleteObjectRevisions(ctx, ids, before); err != nil {
+			oc.log.Error(err, "could not delete object history", "before", before)
+		}
+	}
+
 	return nil
 }
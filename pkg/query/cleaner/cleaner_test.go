@@ -15,6 +15,7 @@ import (
 	"github.com/weaveworks/weave-gitops-enterprise/pkg/query/configuration"
 	"github.com/weaveworks/weave-gitops-enterprise/pkg/query/internal/models"
 	"github.com/weaveworks/weave-gitops-enterprise/pkg/query/store/storefakes"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 )
 
 func TestObjectCleaner(t *testing.T) {
@@ -71,12 +72,67 @@ func TestObjectCleaner(t *testing.T) {
 	_, idxResult := index.RemoveArgsForCall(0)
 	g.Expect(idxResult).To(Equal([]models.Object{objs[0]}))
 
+	// The history of the object that is kept expires with the retention policy.
+	g.Expect(s.DeleteObjectRevisionsCallCount()).To(Equal(1))
+	_, ids, before := s.DeleteObjectRevisionsArgsForCall(0)
+	g.Expect(ids).To(Equal([]string{objs[1].ID}))
+	g.Expect(before).To(BeTemporally("~", time.Now().Add(-60*time.Second), time.Second))
+
 	// Call it again, make sure it doesn't delete anything.
 	// The `iter` mock will return only the second object, which is not old enough to be deleted.
 	g.Expect(oc.removeOldObjects(context.Background())).To(Succeed())
 	g.Expect(s.DeleteObjectsCallCount()).To(Equal(1))
 }
 
+func TestObjectCleaner_SetObjectKinds(t *testing.T) {
+	g := NewWithT(t)
+	s := storefakes.FakeStore{}
+
+	custom := configuration.ObjectKind{
+		Gvk:             schema.GroupVersionKind{Group: "cert-manager.io", Version: "v1", Kind: "Certificate"},
+		Category:        configuration.CategorySource,
+		RetentionPolicy: configuration.RetentionPolicy(60 * time.Second),
+	}
+
+	objs := []models.Object{
+		{
+			Cluster:    "cluster1",
+			Kind:       custom.Gvk.Kind,
+			Name:       "tls",
+			APIGroup:   custom.Gvk.Group,
+			APIVersion: custom.Gvk.Version,
+			// Deleted 1 hour ago, the retention policy of the custom kind is 60s.
+			KubernetesDeletedAt: time.Now().Add(-time.Hour),
+		},
+	}
+
+	iter := storefakes.FakeIterator{}
+	iter.AllReturns(objs, nil)
+	s.GetAllObjectsReturns(&iter, nil)
+
+	index := storefakes.FakeIndexWriter{}
+
+	oc := objectCleaner{
+		log:    logr.Discard(),
+		store:  &s,
+		idx:    &index,
+		config: []configuration.ObjectKind{configuration.BucketObjectKind},
+	}
+
+	// The object is kept while its kind is not one of the kinds of the cleaner.
+	g.Expect(oc.removeOldObjects(context.Background())).To(Succeed())
+	g.Expect(s.DeleteObjectsCallCount()).To(Equal(0))
+
+	oc.SetObjectKinds([]configuration.ObjectKind{configuration.BucketObjectKind, custom})
+
+	g.Expect(oc.removeOldObjects(context.Background())).To(Succeed())
+	g.Expect(s.DeleteObjectsCallCount()).To(Equal(1))
+	_, result := s.DeleteObjectsArgsForCall(0)
+	g.Expect(result).To(Equal(objs))
+
+	g.Expect(index.RemoveCallCount()).To(Equal(1))
+}
+
 func TestObjectCleanerMetrics(t *testing.T) {
 	g := NewWithT(t)
 	s := storefakes.FakeStore{}
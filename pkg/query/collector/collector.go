@@ -13,6 +13,7 @@ import (
 const (
 	ClusterWatchingStarting = "starting"
 	ClusterWatchingStarted  = "started"
+	ClusterWatchingSynced   = "synced"
 	ClusterWatchingStopped  = "stopped"
 	ClusterWatchingFailed   = "failed"
 	ClusterWatchingErrored  = "error"
@@ -31,6 +32,13 @@ type Starter interface {
 	Start(context.Context) error
 }
 
+// Syncer is implemented by the watchers that tell when the records of a cluster are in
+// sync with it after starting, so it can be watched again without removing its records first.
+type Syncer interface {
+	// Synced receives the result of syncing the records of the cluster once the watcher has started.
+	Synced() <-chan error
+}
+
 // Function to create a watcher for a set of kinds. Operations target an store.
 type NewWatcherFunc = func(clusterName string, config *rest.Config) (Starter, error)
 
@@ -46,6 +54,9 @@ type StopWatcherFunc = func(clusterName string) error
 type Collector interface {
 	ClusterWatcher
 	Starter
+	// RestartWatchers replaces the watcher of every cluster with a new one from NewWatcherFunc,
+	// for example to watch a different set of kinds. The records of the clusters are kept.
+	RestartWatchers()
 }
 
 type ImpersonateServiceAccount struct {
@@ -9,6 +9,10 @@ import (
 )
 
 type FakeCollector struct {
+	RestartWatchersStub        func()
+	restartWatchersMutex       sync.RWMutex
+	restartWatchersArgsForCall []struct {
+	}
 	StartStub        func(context.Context) error
 	startMutex       sync.RWMutex
 	startArgsForCall []struct {
@@ -37,6 +41,30 @@ type FakeCollector struct {
 	invocationsMutex sync.RWMutex
 }
 
+func (fake *FakeCollector) RestartWatchers() {
+	fake.restartWatchersMutex.Lock()
+	fake.restartWatchersArgsForCall = append(fake.restartWatchersArgsForCall, struct {
+	}{})
+	stub := fake.RestartWatchersStub
+	fake.recordInvocation("RestartWatchers", []interface{}{})
+	fake.restartWatchersMutex.Unlock()
+	if stub != nil {
+		fake.RestartWatchersStub()
+	}
+}
+
+func (fake *FakeCollector) RestartWatchersCallCount() int {
+	fake.restartWatchersMutex.RLock()
+	defer fake.restartWatchersMutex.RUnlock()
+	return len(fake.restartWatchersArgsForCall)
+}
+
+func (fake *FakeCollector) RestartWatchersCalls(stub func()) {
+	fake.restartWatchersMutex.Lock()
+	defer fake.restartWatchersMutex.Unlock()
+	fake.RestartWatchersStub = stub
+}
+
 func (fake *FakeCollector) Start(arg1 context.Context) error {
 	fake.startMutex.Lock()
 	ret, specificReturn := fake.startReturnsOnCall[len(fake.startArgsForCall)]
@@ -165,6 +193,8 @@ func (fake *FakeCollector) StatusReturnsOnCall(i int, result1 string, result2 er
 func (fake *FakeCollector) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
+	fake.restartWatchersMutex.RLock()
+	defer fake.restartWatchersMutex.RUnlock()
 	fake.startMutex.RLock()
 	defer fake.startMutex.RUnlock()
 	fake.statusMutex.RLock()
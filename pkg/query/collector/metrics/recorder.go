@@ -1,6 +1,8 @@
 package metrics
 
 import (
+	"time"
+
 	"github.com/prometheus/client_golang/prometheus"
 )
 
@@ -14,8 +16,21 @@ var clusterWatcher = prometheus.NewGaugeVec(prometheus.GaugeOpts{
 	Help:      "number of active cluster watchers by watcher status",
 }, []string{"collector", "status"})
 
+var clusterLastSync = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+	Subsystem: collectorSubsystem,
+	Name:      "cluster_last_sync_timestamp_seconds",
+	Help:      "time the records of a cluster were last synced with it",
+}, []string{"collector", "cluster"})
+
+var clusterSyncDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+	Subsystem: collectorSubsystem,
+	Name:      "cluster_sync_duration_seconds",
+	Help:      "time taken from starting a cluster watcher to the records of the cluster being synced",
+	Buckets:   prometheus.ExponentialBuckets(0.1, 2, 12),
+}, []string{"collector"})
+
 func init() {
-	prometheus.MustRegister(clusterWatcher)
+	prometheus.MustRegister(clusterWatcher, clusterLastSync, clusterSyncDuration)
 }
 
 // ClusterWatcherDecrease decreases collector_cluster_watcher for status
@@ -27,3 +42,14 @@ func ClusterWatcherDecrease(collector string, status string) {
 func ClusterWatcherIncrease(collector string, status string) {
 	clusterWatcher.WithLabelValues(collector, status).Inc()
 }
+
+// ClusterSynced records that the records of cluster were synced, duration after its watcher started
+func ClusterSynced(collector string, cluster string, duration time.Duration) {
+	clusterLastSync.WithLabelValues(collector, cluster).SetToCurrentTime()
+	clusterSyncDuration.WithLabelValues(collector).Observe(duration.Seconds())
+}
+
+// ClusterSyncRemoved removes the sync metrics of a cluster that is no longer watched
+func ClusterSyncRemoved(collector string, cluster string) {
+	clusterLastSync.DeleteLabelValues(collector, cluster)
+}
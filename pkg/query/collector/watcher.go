@@ -1,14 +1,19 @@
 package collector
 
 import (
+	"context"
 	"fmt"
+	"time"
 
 	"github.com/weaveworks/weave-gitops-enterprise/pkg/query/configuration"
 
 	"github.com/go-logr/logr"
 	"github.com/weaveworks/weave-gitops-enterprise/pkg/query/collector/reconciler"
 	"github.com/weaveworks/weave-gitops-enterprise/pkg/query/internal/models"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
 
 	_ "k8s.io/client-go/plugin/pkg/client/auth"
@@ -17,6 +22,130 @@ import (
 )
 
 func NewWatcher(clusterName string, cfg *rest.Config, kinds []configuration.ObjectKind, objectChannel chan []models.ObjectTransaction, log logr.Logger) (manager.Manager, error) {
+	return newManager(clusterName, cfg, kinds, objectChannel, log)
+}
+
+// NewSyncingWatcher creates a watcher that, besides sending a transaction for each object reconciled,
+// lists the objects of the cluster once it has started and sends them in a sync transaction. This lets
+// the records of a cluster be brought up to date when it is watched again, rather than deleted and collected
+// from scratch. The watcher tells when the sync transaction has been processed through Synced().
+func NewSyncingWatcher(clusterName string, cfg *rest.Config, kinds []configuration.ObjectKind, objectChannel chan []models.ObjectTransaction, log logr.Logger) (Starter, error) {
+	mgr, err := newManager(clusterName, cfg, kinds, objectChannel, log)
+	if err != nil {
+		return nil, err
+	}
+
+	w := &syncingWatcher{
+		Manager: mgr,
+		synced:  make(chan error, 1),
+	}
+
+	sync := func(ctx context.Context) error {
+		if !mgr.GetCache().WaitForCacheSync(ctx) {
+			return nil
+		}
+
+		listedAt := time.Now()
+		ids, err := listObjectIDs(ctx, mgr.GetClient(), mgr.GetScheme(), clusterName, kinds)
+		if err != nil {
+			w.synced <- fmt.Errorf("cannot list objects: %w", err)
+			return nil
+		}
+
+		tx := NewSyncTransaction(clusterName, kinds, ids, listedAt, func(err error) {
+			w.synced <- err
+		})
+
+		select {
+		case objectChannel <- []models.ObjectTransaction{tx}:
+		case <-ctx.Done():
+		}
+		return nil
+	}
+
+	if err := mgr.Add(manager.RunnableFunc(sync)); err != nil {
+		return nil, fmt.Errorf("cannot add sync to manager: %w", err)
+	}
+
+	return w, nil
+}
+
+type syncingWatcher struct {
+	manager.Manager
+	synced chan error
+}
+
+func (w *syncingWatcher) Synced() <-chan error {
+	return w.synced
+}
+
+// listObjectIDs returns the IDs of the objects of kinds that would be collected, as read from the client.
+func listObjectIDs(ctx context.Context, c client.Client, scheme *runtime.Scheme, clusterName string, kinds []configuration.ObjectKind) (map[string]bool, error) {
+	ids := map[string]bool{}
+	for _, kind := range kinds {
+		list, err := newObjectList(scheme, kind)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := c.List(ctx, list); err != nil {
+			return nil, fmt.Errorf("cannot list %s: %w", kind.Gvk, err)
+		}
+
+		err = meta.EachListItem(list, func(item runtime.Object) error {
+			obj, ok := item.(client.Object)
+			if !ok {
+				return fmt.Errorf("unexpected list item of type %T", item)
+			}
+
+			if kind.FilterFunc != nil && !kind.FilterFunc(obj) {
+				return nil
+			}
+
+			object := models.Object{
+				Cluster:    clusterName,
+				Namespace:  obj.GetNamespace(),
+				APIGroup:   kind.Gvk.Group,
+				APIVersion: kind.Gvk.Version,
+				Kind:       kind.Gvk.Kind,
+				Name:       obj.GetName(),
+			}
+			ids[object.GetID()] = true
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return ids, nil
+}
+
+// newObjectList returns an empty list for kind, of the same flavour as the objects the reconcilers watch,
+// so that it is read from the same informer.
+func newObjectList(scheme *runtime.Scheme, kind configuration.ObjectKind) (client.ObjectList, error) {
+	listGvk := kind.Gvk.GroupVersion().WithKind(kind.Gvk.Kind + "List")
+
+	if _, ok := kind.NewClientObjectFunc().(*unstructured.Unstructured); ok {
+		list := &unstructured.UnstructuredList{}
+		list.SetGroupVersionKind(listGvk)
+		return list, nil
+	}
+
+	obj, err := scheme.New(listGvk)
+	if err != nil {
+		return nil, fmt.Errorf("cannot create list for %s: %w", kind.Gvk, err)
+	}
+
+	list, ok := obj.(client.ObjectList)
+	if !ok {
+		return nil, fmt.Errorf("%s is not a list", listGvk)
+	}
+
+	return list, nil
+}
+
+func newManager(clusterName string, cfg *rest.Config, kinds []configuration.ObjectKind, objectChannel chan []models.ObjectTransaction, log logr.Logger) (manager.Manager, error) {
 	scheme := runtime.NewScheme()
 	for _, objectKind := range kinds {
 		if err := objectKind.AddToSchemeFunc(scheme); err != nil {
@@ -87,3 +216,62 @@ func (t deleteAllTransaction) TransactionType() models.TransactionType {
 func (r deleteAllTransaction) RetentionPolicy() configuration.RetentionPolicy {
 	return 0
 }
+
+// NewSyncTransaction returns an object transaction that holds the IDs of the objects
+// of kinds that exist in a cluster, for particular collectors to remove the ones they
+// have a record of but no longer exist. done is called once it has been processed.
+func NewSyncTransaction(clusterName string, kinds []configuration.ObjectKind, ids map[string]bool, listedAt time.Time, done func(error)) models.SyncTransaction {
+	return syncTransaction{
+		clusterName: clusterName,
+		kinds:       kinds,
+		ids:         ids,
+		listedAt:    listedAt,
+		done:        done,
+	}
+}
+
+type syncTransaction struct {
+	clusterName string
+	kinds       []configuration.ObjectKind
+	ids         map[string]bool
+	listedAt    time.Time
+	done        func(error)
+}
+
+func (t syncTransaction) ClusterName() string {
+	return t.clusterName
+}
+
+func (t syncTransaction) Object() models.NormalizedObject {
+	return nil
+}
+
+func (t syncTransaction) String() string {
+	return fmt.Sprintf("%s/%s", t.clusterName, t.TransactionType())
+}
+
+func (t syncTransaction) TransactionType() models.TransactionType {
+	return models.TransactionTypeSync
+}
+
+func (t syncTransaction) RetentionPolicy() configuration.RetentionPolicy {
+	return 0
+}
+
+func (t syncTransaction) ObjectKinds() []configuration.ObjectKind {
+	return t.kinds
+}
+
+func (t syncTransaction) ObjectIDs() map[string]bool {
+	return t.ids
+}
+
+func (t syncTransaction) ListedAt() time.Time {
+	return t.listedAt
+}
+
+func (t syncTransaction) Done(err error) {
+	if t.done != nil {
+		t.done(err)
+	}
+}
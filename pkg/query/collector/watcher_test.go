@@ -0,0 +1,60 @@
+package collector
+
+import (
+	"context"
+	"testing"
+
+	"github.com/fluxcd/helm-controller/api/v2beta1"
+	"github.com/go-logr/logr"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/weaveworks/weave-gitops-enterprise/pkg/query/configuration"
+	"github.com/weaveworks/weave-gitops-enterprise/pkg/query/configuration/v1alpha1"
+	"github.com/weaveworks/weave-gitops-enterprise/pkg/query/utils/testutils"
+)
+
+func TestListObjectIDs(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	scheme := runtime.NewScheme()
+	g.Expect(configuration.HelmReleaseObjectKind.AddToSchemeFunc(scheme)).To(Succeed())
+
+	helmReleaseKind := configuration.HelmReleaseObjectKind
+	helmReleaseKind.FilterFunc = func(obj client.Object) bool {
+		return obj.GetName() != "filteredHelmRelease"
+	}
+
+	widgetKind, err := configuration.NewCustomObjectKind(v1alpha1.ObjectKindSpec{
+		Group:    "example.com",
+		Version:  "v1",
+		Kind:     "Widget",
+		Category: string(configuration.CategoryAutomation),
+	}, logr.Discard())
+	g.Expect(err).NotTo(HaveOccurred())
+
+	widget := &unstructured.Unstructured{}
+	widget.SetGroupVersionKind(widgetKind.Gvk)
+	widget.SetName("widget")
+	widget.SetNamespace("default")
+
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		testutils.NewHelmRelease("helmRelease", "flux-system", func(hr *v2beta1.HelmRelease) {
+			hr.TypeMeta.APIVersion = v2beta1.GroupVersion.String()
+		}),
+		testutils.NewHelmRelease("filteredHelmRelease", "flux-system", func(hr *v2beta1.HelmRelease) {
+			hr.TypeMeta.APIVersion = v2beta1.GroupVersion.String()
+		}),
+		widget,
+	).Build()
+
+	ids, err := listObjectIDs(context.Background(), fakeClient, scheme, "test-cluster", []configuration.ObjectKind{helmReleaseKind, widgetKind})
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(ids).To(Equal(map[string]bool{
+		"test-cluster/flux-system/helm.toolkit.fluxcd.io/v2beta1/HelmRelease/helmRelease": true,
+		"test-cluster/default/example.com/v1/Widget/widget":                               true,
+	}))
+}
@@ -23,7 +23,9 @@ func (c *watchingCollector) Start(ctx context.Context) error {
 
 	ratelimiter := workqueue.DefaultControllerRateLimiter() // TODO make a bespoke one, this retries too fast
 	// TODO: check the queue methods work with Cluster, since we get new values each time.
+	c.clusterWatchersMu.Lock()
 	c.queue = workqueue.NewNamedRateLimitingQueue(ratelimiter, "collector-"+c.name)
+	c.clusterWatchersMu.Unlock()
 
 	for _, cluster := range c.subscriber.GetClusters() {
 		c.queue.Add(cluster)
@@ -59,18 +61,29 @@ outer:
 		case <-ctx.Done():
 			break outer
 		case updates := <-c.sub.Updates():
+			// A cluster that is both removed and added has changed (e.g., its
+			// address), rather than gone away.
+			added := map[string]bool{}
 			for _, cluster := range updates.Added {
-				c.queue.Add(cluster)
+				added[cluster.GetName()] = true
 			}
 
 			// Do unwatches straight away; there's no reason to go
-			// through the queue on these.
+			// through the queue on these. They are done before
+			// queueing the added clusters, so a changed cluster is
+			// not unwatched after being watched again.
 			for _, cluster := range updates.Removed {
 				// remove from the queue (Done) and rate limiter
 				// (Forget). If it comes back around, we'll start
 				// afresh.
 				c.queue.Done(cluster)
 				c.queue.Forget(cluster)
+				if added[cluster.GetName()] {
+					if err := c.reconnect(cluster.GetName()); err != nil {
+						c.log.Error(err, "cannot unwatch cluster", "cluster", cluster.GetName())
+					}
+					continue
+				}
 				err := c.unwatch(cluster.GetName())
 				if err != nil {
 					c.log.Error(err, "cannot unwatch cluster", "cluster", cluster.GetName())
@@ -78,6 +91,10 @@ outer:
 				}
 				c.log.Info("unwatched cluster", "cluster", cluster.GetName())
 			}
+
+			for _, cluster := range updates.Added {
+				c.queue.Add(cluster)
+			}
 		}
 	}
 
@@ -190,10 +207,17 @@ func (w *watchingCollector) watch(cluster cluster.Cluster) (retErr error) {
 		return fmt.Errorf("failed to create watcher for cluster %s: %w", cluster.GetName(), err)
 	}
 
+	w.clusterWatchersMu.Lock()
+	c.Starter = watcher
+	w.clusterWatchersMu.Unlock()
+
 	go func() {
 		w.clusterWatchersMu.Lock()
 		c.setStatus(ClusterWatchingStarted)
 		w.clusterWatchersMu.Unlock()
+		if syncer, ok := watcher.(Syncer); ok {
+			go w.waitForSync(childctx, clusterName, c, syncer)
+		}
 		err := watcher.Start(childctx)
 		if err != nil {
 			w.log.Error(err, "watcher for cluster failed", "cluster", cluster.GetName())
@@ -207,7 +231,10 @@ func (w *watchingCollector) watch(cluster cluster.Cluster) (retErr error) {
 		// TODO remove from map?
 		w.clusterWatchersMu.Lock()
 		c.setStatus(ClusterWatchingStopped)
-		w.clusterWatchers[clusterName] = nil
+		// The watcher may have been replaced while it was stopping.
+		if w.clusterWatchers[clusterName] == c {
+			w.clusterWatchers[clusterName] = nil
+		}
 
 		w.clusterWatchersMu.Unlock()
 	}()
@@ -231,21 +258,92 @@ func (w *watchingCollector) unwatch(clusterName string) error {
 	if clusterWatcher.cancel != nil {
 		clusterWatcher.cancel()
 	}
+	metrics.ClusterSyncRemoved(w.name, clusterName)
 	if err := w.stopWatcherFunc(clusterName); err != nil {
 		return fmt.Errorf("stop watcher hook failed: %w", err)
 	}
 	return nil
 }
 
-// Status returns a cluster watcher status for the cluster named as clusterName.
+// reconnect stops watching a cluster that is about to be watched again. If its watcher
+// syncs the records of the cluster when it starts, the records are kept for the next
+// watcher to bring up to date; otherwise, the cluster is unwatched as usual.
+func (w *watchingCollector) reconnect(clusterName string) error {
+	w.clusterWatchersMu.Lock()
+	clusterWatcher := w.clusterWatchers[clusterName]
+	if clusterWatcher == nil {
+		w.clusterWatchersMu.Unlock()
+		return w.unwatch(clusterName)
+	}
+	if _, ok := clusterWatcher.Starter.(Syncer); !ok {
+		w.clusterWatchersMu.Unlock()
+		return w.unwatch(clusterName)
+	}
+	delete(w.clusterWatchers, clusterName)
+	w.clusterWatchersMu.Unlock()
+
+	if clusterWatcher.cancel != nil {
+		clusterWatcher.cancel()
+	}
+	w.log.Info("reconnecting cluster", "cluster", clusterName)
+	return nil
+}
+
+// waitForSync sets the status of a cluster to synced once its watcher has synced the records of the cluster.
+func (w *watchingCollector) waitForSync(ctx context.Context, clusterName string, c *child, syncer Syncer) {
+	select {
+	case <-ctx.Done():
+		return
+	case err := <-syncer.Synced():
+		if err != nil {
+			w.log.Error(err, "cannot sync cluster", "cluster", clusterName)
+			return
+		}
+	}
+
+	w.clusterWatchersMu.Lock()
+	defer w.clusterWatchersMu.Unlock()
+	if c.status != ClusterWatchingStarted {
+		return
+	}
+	metrics.ClusterSynced(w.name, clusterName, time.Since(c.lastStatusChange))
+	c.setStatus(ClusterWatchingSynced)
+	w.log.Info("synced cluster", "cluster", clusterName)
+}
+
+func (w *watchingCollector) RestartWatchers() {
+	w.clusterWatchersMu.Lock()
+	for clusterName, c := range w.clusterWatchers {
+		if c != nil && c.cancel != nil {
+			c.cancel()
+		}
+		delete(w.clusterWatchers, clusterName)
+	}
+	queue := w.queue
+	w.clusterWatchersMu.Unlock()
+
+	if queue == nil {
+		// Not started yet, so the clusters will be watched with the new watchers on start.
+		return
+	}
+
+	for _, cluster := range w.subscriber.GetClusters() {
+		queue.Add(cluster)
+	}
+
+	w.log.Info("restarting cluster watchers")
+}
+
+// Status returns a cluster watcher status for the cluster named as clusterName. The status of a
+// cluster whose watcher syncs its records goes from started to synced once they are in sync.
 // It returns an error if empty, cluster does not exist or the status cannot be retrieved.
 func (w *watchingCollector) Status(clusterName string) (string, error) {
 	if clusterName == "" {
 		return "", fmt.Errorf("cluster name is empty")
 	}
 	w.clusterWatchersMu.Lock()
+	defer w.clusterWatchersMu.Unlock()
 	watcher := w.clusterWatchers[clusterName]
-	w.clusterWatchersMu.Unlock()
 	if watcher == nil {
 		return "", fmt.Errorf("cluster not found: %s", clusterName)
 	}
@@ -21,6 +21,7 @@ import (
 	"k8s.io/client-go/rest"
 
 	"github.com/weaveworks/weave-gitops-enterprise/pkg/query/collector/clusters/clustersfakes"
+	"github.com/weaveworks/weave-gitops/core/clustersmngr"
 	"github.com/weaveworks/weave-gitops/core/clustersmngr/cluster"
 	"github.com/weaveworks/weave-gitops/core/clustersmngr/cluster/clusterfakes"
 	l "github.com/weaveworks/weave-gitops/core/logger"
@@ -401,6 +402,114 @@ func (f *fakeWatcher) Start(ctx context.Context) error {
 	return nil
 }
 
+// syncingFakeWatcher is a fake watcher that syncs the records of its cluster as soon as it starts.
+type syncingFakeWatcher struct {
+	synced chan error
+}
+
+func newSyncingFakeWatcher() *syncingFakeWatcher {
+	return &syncingFakeWatcher{synced: make(chan error, 1)}
+}
+
+func (f *syncingFakeWatcher) Start(ctx context.Context) error {
+	f.synced <- nil
+	<-ctx.Done()
+	return nil
+}
+
+func (f *syncingFakeWatcher) Synced() <-chan error {
+	return f.synced
+}
+
+func TestClusterWatcher_Reconnect(t *testing.T) {
+	_, h := metrics.NewDefaultPrometheusHandler()
+	ts := httptest.NewServer(h)
+	defer ts.Close()
+
+	tests := []struct {
+		name              string
+		newWatcher        func() Starter
+		expectedStatus    string
+		expectedStopCalls int32
+		expectedMetrics   []string
+	}{
+		{
+			name:              "records are kept for watchers that sync them",
+			newWatcher:        func() Starter { return newSyncingFakeWatcher() },
+			expectedStatus:    ClusterWatchingSynced,
+			expectedStopCalls: 0,
+			expectedMetrics: []string{
+				`collector_cluster_watcher{collector="reconnect",status="synced"} 1`,
+				`collector_cluster_last_sync_timestamp_seconds{cluster="test-cluster",collector="reconnect"}`,
+				`collector_cluster_sync_duration_seconds_count{collector="reconnect"} 2`,
+			},
+		},
+		{
+			name:              "records are removed for watchers that do not sync them",
+			newWatcher:        func() Starter { return &fakeWatcher{log: logr.Discard()} },
+			expectedStatus:    ClusterWatchingStarted,
+			expectedStopCalls: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewGomegaWithT(t)
+			clustersManager := &clustersfakes.FakeSubscriber{}
+			sub := &clustersfakes.FakeSubscription{}
+			updates := make(chan clustersmngr.ClusterListUpdate)
+			sub.UpdatesReturns(updates)
+			clustersManager.SubscribeReturns(sub)
+
+			existingClusterName := "test-cluster"
+			clustersManager.GetClustersReturns([]cluster.Cluster{makeValidFakeCluster(existingClusterName)})
+
+			var newcalls, stopcalls atomic.Int32
+			collector, err := newWatchingCollector(CollectorOpts{
+				Name:     "reconnect",
+				Clusters: clustersManager,
+				Log:      logr.Discard(),
+				NewWatcherFunc: func(clusterName string, config *rest.Config) (Starter, error) {
+					newcalls.Add(1)
+					return tt.newWatcher(), nil
+				},
+				StopWatcherFunc: func(string) error {
+					stopcalls.Add(1)
+					return nil
+				},
+				ServiceAccount: ImpersonateServiceAccount{
+					Namespace: "flux-system",
+					Name:      "collector",
+				},
+			})
+			g.Expect(err).NotTo(HaveOccurred())
+
+			startctx, startcancel := context.WithCancel(context.TODO())
+			defer startcancel()
+			go func() {
+				g.Expect(collector.Start(startctx)).To(Succeed())
+			}()
+
+			status := func() string {
+				s, _ := collector.Status(existingClusterName)
+				return s
+			}
+			g.Eventually(status, "2s", "0.2s").Should(Equal(tt.expectedStatus))
+
+			// The cluster changes, e.g. its address.
+			reconnected := makeValidFakeCluster(existingClusterName)
+			updates <- clustersmngr.ClusterListUpdate{
+				Added:   []cluster.Cluster{reconnected},
+				Removed: []cluster.Cluster{reconnected},
+			}
+
+			g.Eventually(func() int32 { return newcalls.Load() }, "2s", "0.2s").Should(Equal(int32(2)))
+			g.Eventually(status, "2s", "0.2s").Should(Equal(tt.expectedStatus))
+			g.Expect(stopcalls.Load()).To(Equal(tt.expectedStopCalls))
+			assertMetrics(g, ts, tt.expectedMetrics)
+		})
+	}
+}
+
 func Test_WatcherRetry(t *testing.T) {
 	g := NewGomegaWithT(t)
 	clustersManager := &clustersfakes.FakeSubscriber{}
@@ -551,3 +660,56 @@ func Test_makeImpersonateConfig(t *testing.T) {
 		})
 	}
 }
+
+func TestClusterWatcher_RestartWatchers(t *testing.T) {
+	g := NewGomegaWithT(t)
+	clustersManager := &clustersfakes.FakeSubscriber{}
+	sub := &clustersfakes.FakeSubscription{}
+	clustersManager.SubscribeReturns(sub)
+
+	existingClusterName := "test-cluster"
+	clustersManager.GetClustersReturns([]cluster.Cluster{makeValidFakeCluster(existingClusterName)})
+
+	var newcalls, stopcalls atomic.Int32
+	newWatcher := func(clusterName string, config *rest.Config) (Starter, error) {
+		newcalls.Add(1)
+		return &fakeWatcher{log: logr.Discard()}, nil
+	}
+
+	collector, err := newWatchingCollector(CollectorOpts{
+		Clusters:       clustersManager,
+		Log:            logr.Discard(),
+		NewWatcherFunc: newWatcher,
+		StopWatcherFunc: func(string) error {
+			stopcalls.Add(1)
+			return nil
+		},
+		ServiceAccount: ImpersonateServiceAccount{
+			Namespace: "flux-system",
+			Name:      "collector",
+		},
+	})
+	g.Expect(err).NotTo(HaveOccurred())
+
+	startctx, startcancel := context.WithCancel(context.TODO())
+	defer startcancel()
+	go func() {
+		g.Expect(collector.Start(startctx)).To(Succeed())
+	}()
+
+	g.Eventually(func() int32 { return newcalls.Load() }, "2s", "0.2s").Should(Equal(int32(1)))
+
+	collector.RestartWatchers()
+
+	// The cluster is watched again by a new watcher, without removing its records.
+	g.Eventually(func() int32 { return newcalls.Load() }, "2s", "0.2s").Should(Equal(int32(2)))
+	g.Eventually(func() string {
+		s, _ := collector.Status(existingClusterName)
+		return s
+	}, "2s", "0.2s").Should(Equal(ClusterWatchingStarted))
+	g.Consistently(func() string {
+		s, _ := collector.Status(existingClusterName)
+		return s
+	}, "1s", "0.2s").Should(Equal(ClusterWatchingStarted))
+	g.Expect(stopcalls.Load()).To(BeZero())
+}
@@ -0,0 +1,296 @@
+package configuration
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/go-logr/logr"
+	"github.com/google/cel-go/cel"
+	"github.com/weaveworks/weave-gitops-enterprise/pkg/query/configuration/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/util/jsonpath"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// customCategories are the categories that the kinds declared by ObjectKind resources can use.
+var customCategories = map[ObjectCategory]bool{
+	CategoryAutomation:       true,
+	CategorySource:           true,
+	CategoryGitopsSet:        true,
+	CategoryTemplate:         true,
+	CategoryClusterDiscovery: true,
+}
+
+// explorerStatuses are the statuses that status expressions can evaluate to.
+var explorerStatuses = map[ObjectStatus]bool{
+	Success:       true,
+	Failed:        true,
+	Reconciling:   true,
+	Suspended:     true,
+	PendingAction: true,
+}
+
+// NewCustomObjectKind returns the object kind declared by the spec of an ObjectKind resource.
+// Its objects are collected as unstructured, so the kind does not need to be known at build time.
+// The expressions of the kind that fail to evaluate are logged to log, once per expression.
+func NewCustomObjectKind(spec v1alpha1.ObjectKindSpec, log logr.Logger) (ObjectKind, error) {
+	if spec.Version == "" || spec.Kind == "" {
+		return ObjectKind{}, fmt.Errorf("version and kind are required")
+	}
+
+	category := ObjectCategory(spec.Category)
+	if !customCategories[category] {
+		return ObjectKind{}, fmt.Errorf("unsupported category %q", spec.Category)
+	}
+
+	gvk := schema.GroupVersionKind{Group: spec.Group, Version: spec.Version, Kind: spec.Kind}
+
+	kind := ObjectKind{
+		Gvk: gvk,
+		NewClientObjectFunc: func() client.Object {
+			obj := &unstructured.Unstructured{}
+			obj.SetGroupVersionKind(gvk)
+			return obj
+		},
+		// Unstructured objects need no type registered in the scheme.
+		AddToSchemeFunc: func(*runtime.Scheme) error {
+			return nil
+		},
+		GetConditionsFunc: unstructuredConditions,
+		GetSuspendedFunc:  unstructuredSuspended,
+		StatusFunc:        defaultStatusFunc,
+		MessageFunc:       defaultMessageFunc,
+		Labels:            spec.Labels,
+		Category:          category,
+	}
+
+	if len(spec.SearchFields) > 0 {
+		kind.SearchFields = map[string]string{}
+		for _, f := range spec.SearchFields {
+			if _, ok := kind.SearchFields[f.Name]; ok {
+				return ObjectKind{}, fmt.Errorf("search field %q is repeated", f.Name)
+			}
+			kind.SearchFields[f.Name] = f.JSONPath
+		}
+	}
+
+	if spec.Status != nil {
+		eval, err := compileExpression(spec.Status.Expression, log)
+		if err != nil {
+			return ObjectKind{}, fmt.Errorf("invalid status expression: %w", err)
+		}
+
+		for value, status := range spec.Status.Values {
+			if !explorerStatuses[ObjectStatus(status)] {
+				return ObjectKind{}, fmt.Errorf("invalid status for value %q: %q is not an explorer status", value, status)
+			}
+		}
+
+		values := spec.Status.Values
+		kind.StatusFunc = func(obj client.Object, _ ObjectKind) (ObjectStatus, error) {
+			value, err := eval(obj)
+			if err != nil {
+				return "", err
+			}
+
+			if mapped, ok := values[value]; ok {
+				return ObjectStatus(mapped), nil
+			}
+			if explorerStatuses[ObjectStatus(value)] {
+				return ObjectStatus(value), nil
+			}
+
+			return NoStatus, nil
+		}
+	}
+
+	if spec.Message != nil {
+		eval, err := compileExpression(*spec.Message, log)
+		if err != nil {
+			return ObjectKind{}, fmt.Errorf("invalid message expression: %w", err)
+		}
+
+		kind.MessageFunc = func(obj client.Object, _ ObjectKind) (string, error) {
+			return eval(obj)
+		}
+	}
+
+	if err := kind.Validate(); err != nil {
+		return ObjectKind{}, err
+	}
+
+	return kind, nil
+}
+
+// expressionFunc reads a value out of an object. Fields missing from the object read as empty.
+type expressionFunc func(obj client.Object) (string, error)
+
+func compileExpression(expr v1alpha1.Expression, log logr.Logger) (expressionFunc, error) {
+	switch {
+	case expr.CEL != "" && expr.JSONPath != "":
+		return nil, fmt.Errorf("only one of cel and jsonPath can be set")
+	case expr.CEL != "":
+		return compileCEL(expr.CEL, log)
+	case expr.JSONPath != "":
+		return compileJSONPath(expr.JSONPath)
+	default:
+		return nil, fmt.Errorf("one of cel and jsonPath is required")
+	}
+}
+
+func compileCEL(expr string, log logr.Logger) (expressionFunc, error) {
+	env, err := cel.NewEnv(cel.Variable("self", cel.DynType))
+	if err != nil {
+		return nil, fmt.Errorf("cannot create cel environment: %w", err)
+	}
+
+	ast, issues := env.Compile(expr)
+	if issues != nil && issues.Err() != nil {
+		return nil, issues.Err()
+	}
+
+	program, err := env.Program(ast)
+	if err != nil {
+		return nil, err
+	}
+
+	failed := &sync.Once{}
+
+	return func(obj client.Object) (string, error) {
+		content, err := toUnstructured(obj)
+		if err != nil {
+			return "", err
+		}
+
+		out, _, err := program.Eval(map[string]interface{}{"self": content})
+		if err != nil {
+			// Evaluation fails on the fields the object has not got yet, like its status before it is reconciled.
+			// Other errors are down to the expression, so they are logged, but the objects are still collected.
+			if !isMissingField(err) {
+				failed.Do(func() {
+					log.Error(err, "cannot evaluate cel expression, objects are collected without its value", "expression", expr)
+				})
+			}
+			return "", nil
+		}
+
+		if s, ok := out.Value().(string); ok {
+			return s, nil
+		}
+
+		return fmt.Sprint(out.Value()), nil
+	}, nil
+}
+
+// isMissingField tells whether a cel evaluation failed on a field the object has not got.
+func isMissingField(err error) bool {
+	return strings.HasPrefix(err.Error(), "no such key") || strings.HasPrefix(err.Error(), "no such attribute")
+}
+
+func compileJSONPath(expr string) (expressionFunc, error) {
+	jp := jsonpath.New("expression").AllowMissingKeys(true)
+	if err := jp.Parse(expr); err != nil {
+		return nil, err
+	}
+
+	return func(obj client.Object) (string, error) {
+		content, err := toUnstructured(obj)
+		if err != nil {
+			return "", err
+		}
+
+		buf := &bytes.Buffer{}
+		if err := jp.Execute(buf, content); err != nil {
+			return "", fmt.Errorf("cannot evaluate jsonpath: %w", err)
+		}
+
+		return buf.String(), nil
+	}, nil
+}
+
+func toUnstructured(obj client.Object) (map[string]interface{}, error) {
+	if u, ok := obj.(*unstructured.Unstructured); ok {
+		return u.Object, nil
+	}
+
+	return runtime.DefaultUnstructuredConverter.ToUnstructured(obj)
+}
+
+// unstructuredConditions reads the conditions of an object that follows the Kubernetes API conventions.
+func unstructuredConditions(obj client.Object) ([]metav1.Condition, error) {
+	content, err := toUnstructured(obj)
+	if err != nil {
+		return nil, err
+	}
+
+	raw, found, err := unstructured.NestedSlice(content, "status", "conditions")
+	if err != nil || !found {
+		return nil, err
+	}
+
+	conditions := []metav1.Condition{}
+	for _, c := range raw {
+		m, ok := c.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		condition := metav1.Condition{}
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(m, &condition); err != nil {
+			return nil, fmt.Errorf("cannot read condition: %w", err)
+		}
+		conditions = append(conditions, condition)
+	}
+
+	return conditions, nil
+}
+
+func unstructuredSuspended(obj client.Object) (bool, error) {
+	content, err := toUnstructured(obj)
+	if err != nil {
+		return false, err
+	}
+
+	suspended, _, err := unstructured.NestedBool(content, "spec", "suspend")
+	if err != nil {
+		return false, nil
+	}
+
+	return suspended, nil
+}
+
+// MergeObjectKinds adds the custom kinds to the built-in ones. Custom kinds for a GroupVersionKind
+// that is already collected are skipped and returned, so that they can be reported.
+func MergeObjectKinds(builtin []ObjectKind, custom map[string]ObjectKind) ([]ObjectKind, []string) {
+	result := append([]ObjectKind{}, builtin...)
+
+	seen := map[schema.GroupVersionKind]bool{}
+	for _, k := range builtin {
+		seen[k.Gvk] = true
+	}
+
+	names := []string{}
+	for name := range custom {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	skipped := []string{}
+	for _, name := range names {
+		k := custom[name]
+		if seen[k.Gvk] {
+			skipped = append(skipped, name)
+			continue
+		}
+		seen[k.Gvk] = true
+		result = append(result, k)
+	}
+
+	return result, skipped
+}
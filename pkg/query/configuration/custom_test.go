@@ -0,0 +1,293 @@
+package configuration
+
+import (
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/go-logr/logr/funcr"
+	. "github.com/onsi/gomega"
+	"github.com/weaveworks/weave-gitops-enterprise/pkg/query/configuration/v1alpha1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func newRollout(phase string, conditions ...map[string]interface{}) *unstructured.Unstructured {
+	obj := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "argoproj.io/v1alpha1",
+		"kind":       "Rollout",
+		"metadata": map[string]interface{}{
+			"name":      "rollout",
+			"namespace": "default",
+		},
+		"spec": map[string]interface{}{},
+	}}
+
+	status := map[string]interface{}{}
+	if phase != "" {
+		status["phase"] = phase
+		status["message"] = "rollout is " + phase
+	}
+	if len(conditions) > 0 {
+		list := []interface{}{}
+		for _, c := range conditions {
+			list = append(list, c)
+		}
+		status["conditions"] = list
+	}
+	if len(status) > 0 {
+		obj.Object["status"] = status
+	}
+
+	return obj
+}
+
+func TestNewCustomObjectKind(t *testing.T) {
+	spec := v1alpha1.ObjectKindSpec{
+		Group:    "argoproj.io",
+		Version:  "v1alpha1",
+		Kind:     "Rollout",
+		Category: "automation",
+		Labels:   []string{"team"},
+	}
+
+	t.Run("creates unstructured objects of the kind", func(t *testing.T) {
+		g := NewWithT(t)
+
+		kind, err := NewCustomObjectKind(spec, logr.Discard())
+		g.Expect(err).NotTo(HaveOccurred())
+		g.Expect(kind.Gvk.String()).To(Equal("argoproj.io/v1alpha1, Kind=Rollout"))
+		g.Expect(kind.Category).To(Equal(CategoryAutomation))
+		g.Expect(kind.Labels).To(Equal([]string{"team"}))
+		g.Expect(kind.NewClientObjectFunc().GetObjectKind().GroupVersionKind()).To(Equal(kind.Gvk))
+	})
+
+	t.Run("follows the Ready condition by default", func(t *testing.T) {
+		g := NewWithT(t)
+
+		kind, err := NewCustomObjectKind(spec, logr.Discard())
+		g.Expect(err).NotTo(HaveOccurred())
+
+		obj := newRollout("", map[string]interface{}{
+			"type":               "Ready",
+			"status":             "False",
+			"reason":             "Failed",
+			"message":            "rollout aborted",
+			"lastTransitionTime": "2023-01-01T00:00:00Z",
+		})
+
+		status, err := kind.StatusFunc(obj, kind)
+		g.Expect(err).NotTo(HaveOccurred())
+		g.Expect(status).To(Equal(Failed))
+
+		message, err := kind.MessageFunc(obj, kind)
+		g.Expect(err).NotTo(HaveOccurred())
+		g.Expect(message).To(Equal("rollout aborted"))
+	})
+
+	t.Run("reads the status and message with jsonpath", func(t *testing.T) {
+		g := NewWithT(t)
+
+		s := spec
+		s.Status = &v1alpha1.StatusExpression{
+			Expression: v1alpha1.Expression{JSONPath: "{.status.phase}"},
+			Values:     map[string]string{"Healthy": "Success", "Degraded": "Failed"},
+		}
+		s.Message = &v1alpha1.Expression{JSONPath: "{.status.message}"}
+
+		kind, err := NewCustomObjectKind(s, logr.Discard())
+		g.Expect(err).NotTo(HaveOccurred())
+
+		for phase, want := range map[string]ObjectStatus{
+			"Healthy":     Success,
+			"Degraded":    Failed,
+			"Reconciling": Reconciling,
+			"Paused":      NoStatus,
+			"":            NoStatus,
+		} {
+			status, err := kind.StatusFunc(newRollout(phase), kind)
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(status).To(Equal(want), phase)
+		}
+
+		message, err := kind.MessageFunc(newRollout("Healthy"), kind)
+		g.Expect(err).NotTo(HaveOccurred())
+		g.Expect(message).To(Equal("rollout is Healthy"))
+	})
+
+	t.Run("reads the status and message with cel", func(t *testing.T) {
+		g := NewWithT(t)
+
+		s := spec
+		s.Status = &v1alpha1.StatusExpression{
+			Expression: v1alpha1.Expression{CEL: `self.status.phase == "Healthy" ? "Success" : "Failed"`},
+		}
+		s.Message = &v1alpha1.Expression{CEL: `self.metadata.name + ": " + self.status.message`}
+
+		kind, err := NewCustomObjectKind(s, logr.Discard())
+		g.Expect(err).NotTo(HaveOccurred())
+
+		status, err := kind.StatusFunc(newRollout("Healthy"), kind)
+		g.Expect(err).NotTo(HaveOccurred())
+		g.Expect(status).To(Equal(Success))
+
+		status, err = kind.StatusFunc(newRollout("Degraded"), kind)
+		g.Expect(err).NotTo(HaveOccurred())
+		g.Expect(status).To(Equal(Failed))
+
+		// Objects that have no status yet show no status.
+		status, err = kind.StatusFunc(newRollout(""), kind)
+		g.Expect(err).NotTo(HaveOccurred())
+		g.Expect(status).To(Equal(NoStatus))
+
+		message, err := kind.MessageFunc(newRollout("Healthy"), kind)
+		g.Expect(err).NotTo(HaveOccurred())
+		g.Expect(message).To(Equal("rollout: rollout is Healthy"))
+	})
+
+	t.Run("logs the cel expressions that fail once", func(t *testing.T) {
+		g := NewWithT(t)
+
+		logged := []string{}
+		log := funcr.New(func(prefix, args string) {
+			logged = append(logged, args)
+		}, funcr.Options{})
+
+		s := spec
+		s.Status = &v1alpha1.StatusExpression{
+			Expression: v1alpha1.Expression{CEL: `self.status.phase + 1`},
+		}
+
+		kind, err := NewCustomObjectKind(s, log)
+		g.Expect(err).NotTo(HaveOccurred())
+
+		// Objects that have no status yet are not a failure of the expression.
+		status, err := kind.StatusFunc(newRollout(""), kind)
+		g.Expect(err).NotTo(HaveOccurred())
+		g.Expect(status).To(Equal(NoStatus))
+		g.Expect(logged).To(BeEmpty())
+
+		for i := 0; i < 2; i++ {
+			status, err := kind.StatusFunc(newRollout("Healthy"), kind)
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(status).To(Equal(NoStatus))
+		}
+		g.Expect(logged).To(HaveLen(1))
+		g.Expect(logged[0]).To(ContainSubstring("cannot evaluate cel expression"))
+		g.Expect(logged[0]).To(ContainSubstring("self.status.phase + 1"))
+	})
+
+	t.Run("reads the search fields with jsonpath", func(t *testing.T) {
+		g := NewWithT(t)
+
+		s := spec
+		s.SearchFields = []v1alpha1.SearchField{
+			{Name: "phase", JSONPath: "{.status.phase}"},
+			{Name: "strategy", JSONPath: "{.spec.strategy.canary.stableService}"},
+		}
+
+		kind, err := NewCustomObjectKind(s, logr.Discard())
+		g.Expect(err).NotTo(HaveOccurred())
+
+		fields, err := kind.SearchFieldValues(newRollout("Healthy"))
+		g.Expect(err).NotTo(HaveOccurred())
+		// Fields the object has not got are left out.
+		g.Expect(fields).To(Equal(map[string]string{"phase": "Healthy"}))
+	})
+
+	t.Run("rejects invalid specs", func(t *testing.T) {
+		for name, tt := range map[string]struct {
+			update  func(s *v1alpha1.ObjectKindSpec)
+			wantErr string
+		}{
+			"missing kind": {
+				update:  func(s *v1alpha1.ObjectKindSpec) { s.Kind = "" },
+				wantErr: "version and kind are required",
+			},
+			"unsupported category": {
+				update:  func(s *v1alpha1.ObjectKindSpec) { s.Category = "rbac" },
+				wantErr: `unsupported category "rbac"`,
+			},
+			"invalid cel": {
+				update: func(s *v1alpha1.ObjectKindSpec) {
+					s.Status = &v1alpha1.StatusExpression{Expression: v1alpha1.Expression{CEL: "self.status.phase =="}}
+				},
+				wantErr: "invalid status expression",
+			},
+			"invalid jsonpath": {
+				update: func(s *v1alpha1.ObjectKindSpec) {
+					s.Message = &v1alpha1.Expression{JSONPath: "{.status.message"}
+				},
+				wantErr: "invalid message expression",
+			},
+			"both expressions": {
+				update: func(s *v1alpha1.ObjectKindSpec) {
+					s.Message = &v1alpha1.Expression{CEL: "self.status.message", JSONPath: "{.status.message}"}
+				},
+				wantErr: "only one of cel and jsonPath can be set",
+			},
+			"unknown status": {
+				update: func(s *v1alpha1.ObjectKindSpec) {
+					s.Status = &v1alpha1.StatusExpression{
+						Expression: v1alpha1.Expression{JSONPath: "{.status.phase}"},
+						Values:     map[string]string{"Healthy": "Good"},
+					}
+				},
+				wantErr: `"Good" is not an explorer status`,
+			},
+			"invalid search field name": {
+				update: func(s *v1alpha1.ObjectKindSpec) {
+					s.SearchFields = []v1alpha1.SearchField{{Name: "spec.path", JSONPath: "{.spec.path}"}}
+				},
+				wantErr: `invalid search field name "spec.path"`,
+			},
+			"invalid search field jsonpath": {
+				update: func(s *v1alpha1.ObjectKindSpec) {
+					s.SearchFields = []v1alpha1.SearchField{{Name: "path", JSONPath: "{.spec.path"}}
+				},
+				wantErr: `invalid jsonpath for search field "path"`,
+			},
+			"repeated search field": {
+				update: func(s *v1alpha1.ObjectKindSpec) {
+					s.SearchFields = []v1alpha1.SearchField{{Name: "path", JSONPath: "{.spec.path}"}, {Name: "path", JSONPath: "{.spec.url}"}}
+				},
+				wantErr: `search field "path" is repeated`,
+			},
+		} {
+			t.Run(name, func(t *testing.T) {
+				g := NewWithT(t)
+
+				s := spec
+				tt.update(&s)
+
+				_, err := NewCustomObjectKind(s, logr.Discard())
+				g.Expect(err).To(MatchError(ContainSubstring(tt.wantErr)))
+			})
+		}
+	})
+}
+
+func TestMergeObjectKinds(t *testing.T) {
+	g := NewWithT(t)
+
+	rollout, err := NewCustomObjectKind(v1alpha1.ObjectKindSpec{Group: "argoproj.io", Version: "v1alpha1", Kind: "Rollout", Category: "automation"}, logr.Discard())
+	g.Expect(err).NotTo(HaveOccurred())
+
+	helmRelease, err := NewCustomObjectKind(v1alpha1.ObjectKindSpec{
+		Group:    HelmReleaseObjectKind.Gvk.Group,
+		Version:  HelmReleaseObjectKind.Gvk.Version,
+		Kind:     HelmReleaseObjectKind.Gvk.Kind,
+		Category: "automation",
+	}, logr.Discard())
+	g.Expect(err).NotTo(HaveOccurred())
+
+	kinds, skipped := MergeObjectKinds([]ObjectKind{HelmReleaseObjectKind}, map[string]ObjectKind{
+		"rollouts":     rollout,
+		"helmreleases": helmRelease,
+		"duplicate":    rollout,
+	})
+
+	g.Expect(kinds).To(HaveLen(2))
+	g.Expect(kinds[0].Gvk).To(Equal(HelmReleaseObjectKind.Gvk))
+	g.Expect(kinds[1].Gvk).To(Equal(rollout.Gvk))
+	// The duplicate is the first by name, so the rollouts resource is skipped instead.
+	g.Expect(skipped).To(Equal([]string{"helmreleases", "rollouts"}))
+}
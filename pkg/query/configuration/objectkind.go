@@ -1,7 +1,12 @@
 package configuration
 
 import (
+	"bytes"
+	"errors"
 	"fmt"
+	"regexp"
+	"strings"
+	"sync"
 	"time"
 
 	sourcev1 "github.com/fluxcd/source-controller/api/v1"
@@ -18,6 +23,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/util/jsonpath"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
@@ -65,6 +71,9 @@ type ObjectKind struct {
 	// HumanReadableLabelKeys is a map of label keys to human readable names. It allows to customise the label names in the UI.
 	// Values should be dash case: template-type, some-value, etc.
 	HumanReadableLabelKeys map[string]string
+	// SearchFields maps the names of the fields to index as full text, queried as `fields.<name>`, to the JSONPath
+	// templates that read them out of the objects. For example, the chart of a HelmRelease as `fields.chart`.
+	SearchFields map[string]string
 }
 
 type ObjectStatus string
@@ -105,9 +114,70 @@ func (o ObjectKind) Validate() error {
 		return fmt.Errorf("missing category")
 	}
 
+	for name, path := range o.SearchFields {
+		if !searchFieldNameRegexp.MatchString(name) {
+			return fmt.Errorf("invalid search field name %q", name)
+		}
+		if _, err := compileJSONPath(path); err != nil {
+			return fmt.Errorf("invalid jsonpath for search field %q: %w", name, err)
+		}
+	}
+
 	return nil
 }
 
+var searchFieldNameRegexp = regexp.MustCompile(`^[a-zA-Z][a-zA-Z0-9_-]*$`)
+
+// searchFieldPaths holds the parsed JSONPath templates of the search fields of every kind,
+// so that they are parsed once rather than for every object. A JSONPath cannot be executed
+// concurrently, so they are read under the lock.
+var searchFieldPaths = struct {
+	sync.Mutex
+	paths map[string]*jsonpath.JSONPath
+}{paths: map[string]*jsonpath.JSONPath{}}
+
+// SearchFieldValues reads the search fields of an object. The fields the object has not got are left out,
+// as are the fields that cannot be read: the values of the others are returned along with the error.
+func (o ObjectKind) SearchFieldValues(obj client.Object) (map[string]string, error) {
+	values := map[string]string{}
+	if len(o.SearchFields) == 0 {
+		return values, nil
+	}
+
+	content, err := toUnstructured(obj)
+	if err != nil {
+		return values, err
+	}
+
+	searchFieldPaths.Lock()
+	defer searchFieldPaths.Unlock()
+
+	errs := []error{}
+	for name, path := range o.SearchFields {
+		jp, ok := searchFieldPaths.paths[path]
+		if !ok {
+			jp = jsonpath.New(name).AllowMissingKeys(true)
+			if err := jp.Parse(path); err != nil {
+				errs = append(errs, fmt.Errorf("invalid jsonpath for search field %q: %w", name, err))
+				continue
+			}
+			searchFieldPaths.paths[path] = jp
+		}
+
+		buf := &bytes.Buffer{}
+		if err := jp.Execute(buf, content); err != nil {
+			errs = append(errs, fmt.Errorf("cannot read search field %q: %w", name, err))
+			continue
+		}
+
+		if value := strings.TrimSpace(buf.String()); value != "" {
+			values[name] = value
+		}
+	}
+
+	return values, errors.Join(errs...)
+}
+
 var (
 	HelmReleaseObjectKind = ObjectKind{
 		Gvk: helmv2beta1.GroupVersion.WithKind(helmv2beta1.HelmReleaseKind),
@@ -132,6 +202,12 @@ var (
 		StatusFunc:  defaultStatusFunc,
 		MessageFunc: defaultMessageFunc,
 		Category:    CategoryAutomation,
+		SearchFields: map[string]string{
+			"chart":    "{.spec.chart.spec.chart}",
+			"version":  "{.spec.chart.spec.version}",
+			"source":   "{.spec.chart.spec.sourceRef.name}",
+			"revision": "{.status.lastAppliedRevision}",
+		},
 	}
 
 	KustomizationObjectKind = ObjectKind{
@@ -157,6 +233,11 @@ var (
 		StatusFunc:  defaultStatusFunc,
 		MessageFunc: defaultMessageFunc,
 		Category:    CategoryAutomation,
+		SearchFields: map[string]string{
+			"path":     "{.spec.path}",
+			"source":   "{.spec.sourceRef.name}",
+			"revision": "{.status.lastAppliedRevision}",
+		},
 	}
 
 	HelmRepositoryObjectKind = ObjectKind{
@@ -182,6 +263,9 @@ var (
 		StatusFunc:  defaultStatusFunc,
 		MessageFunc: defaultMessageFunc,
 		Category:    CategorySource,
+		SearchFields: map[string]string{
+			"url": "{.spec.url}",
+		},
 	}
 
 	HelmChartObjectKind = ObjectKind{
@@ -207,6 +291,12 @@ var (
 		StatusFunc:  defaultStatusFunc,
 		MessageFunc: defaultMessageFunc,
 		Category:    CategorySource,
+		SearchFields: map[string]string{
+			"chart":    "{.spec.chart}",
+			"version":  "{.spec.version}",
+			"source":   "{.spec.sourceRef.name}",
+			"revision": "{.status.artifact.revision}",
+		},
 	}
 
 	GitRepositoryObjectKind = ObjectKind{
@@ -232,6 +322,11 @@ var (
 		StatusFunc:  defaultStatusFunc,
 		MessageFunc: defaultMessageFunc,
 		Category:    CategorySource,
+		SearchFields: map[string]string{
+			"url":      "{.spec.url}",
+			"branch":   "{.spec.ref.branch}",
+			"revision": "{.status.artifact.revision}",
+		},
 	}
 
 	OCIRepositoryObjectKind = ObjectKind{
@@ -257,6 +352,11 @@ var (
 		StatusFunc:  defaultStatusFunc,
 		MessageFunc: defaultMessageFunc,
 		Category:    CategorySource,
+		SearchFields: map[string]string{
+			"url":      "{.spec.url}",
+			"tag":      "{.spec.ref.tag}",
+			"revision": "{.status.artifact.revision}",
+		},
 	}
 
 	BucketObjectKind = ObjectKind{
@@ -282,6 +382,11 @@ var (
 		StatusFunc:  defaultStatusFunc,
 		MessageFunc: defaultMessageFunc,
 		Category:    CategorySource,
+		SearchFields: map[string]string{
+			"bucket":   "{.spec.bucketName}",
+			"endpoint": "{.spec.endpoint}",
+			"revision": "{.status.artifact.revision}",
+		},
 	}
 
 	RoleObjectKind = ObjectKind{
@@ -32,6 +32,63 @@ func TestObjectsKinds(t *testing.T) {
 	})
 }
 
+func TestObjectKind_SearchFieldValues(t *testing.T) {
+	g := NewWithT(t)
+
+	hr := &v2beta1.HelmRelease{
+		Spec: v2beta1.HelmReleaseSpec{
+			Chart: v2beta1.HelmChartTemplate{
+				Spec: v2beta1.HelmChartTemplateSpec{
+					Chart:   "ingress-nginx",
+					Version: "4.7.1",
+					SourceRef: v2beta1.CrossNamespaceObjectReference{
+						Kind: "HelmRepository",
+						Name: "ingress-nginx",
+					},
+				},
+			},
+		},
+	}
+
+	fields, err := HelmReleaseObjectKind.SearchFieldValues(hr)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(fields).To(Equal(map[string]string{
+		"chart":   "ingress-nginx",
+		"version": "4.7.1",
+		"source":  "ingress-nginx",
+	}))
+
+	ks := &kustomizev1.Kustomization{
+		Spec: kustomizev1.KustomizationSpec{Path: "./apps/prod"},
+		Status: kustomizev1.KustomizationStatus{
+			LastAppliedRevision: "main@sha1:5b2c4d0",
+		},
+	}
+
+	fields, err = KustomizationObjectKind.SearchFieldValues(ks)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(fields).To(Equal(map[string]string{
+		"path":     "./apps/prod",
+		"revision": "main@sha1:5b2c4d0",
+	}))
+
+	t.Run("leaves out the fields that cannot be read", func(t *testing.T) {
+		g := NewWithT(t)
+
+		kind := HelmReleaseObjectKind
+		kind.SearchFields = map[string]string{
+			"chart":  "{.spec.chart.spec.chart}",
+			"broken": "{.spec.chart.spec.chart[0]}",
+		}
+
+		fields, err := kind.SearchFieldValues(hr)
+		g.Expect(err).To(MatchError(ContainSubstring(`cannot read search field "broken"`)))
+		g.Expect(fields).To(Equal(map[string]string{
+			"chart": "ingress-nginx",
+		}))
+	})
+}
+
 func TestObjectKind_Validate(t *testing.T) {
 	g := NewWithT(t)
 
@@ -96,6 +153,18 @@ func TestObjectKind_Validate(t *testing.T) {
 		}
 		g.Expect(kind.Validate().Error()).To(Equal("missing message func"))
 	})
+
+	t.Run("should return error if a search field jsonpath is invalid", func(t *testing.T) {
+		kind := HelmReleaseObjectKind
+		kind.SearchFields = map[string]string{"chart": "{.spec.chart"}
+		g.Expect(kind.Validate()).To(MatchError(ContainSubstring(`invalid jsonpath for search field "chart"`)))
+	})
+
+	t.Run("should validate the default kinds", func(t *testing.T) {
+		for _, kind := range SupportedObjectKinds {
+			g.Expect(kind.Validate()).To(Succeed(), kind.String())
+		}
+	})
 }
 
 func TestStatusAndMessage(t *testing.T) {
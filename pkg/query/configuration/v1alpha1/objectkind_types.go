@@ -0,0 +1,96 @@
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ObjectKindSpec declares a kind of object for explorer to collect from every cluster.
+type ObjectKindSpec struct {
+	// Group is the API group of the kind, empty for the core group.
+	// +optional
+	Group string `json:"group,omitempty"`
+	// Version is the API version of the kind to watch.
+	// +required
+	Version string `json:"version"`
+	// Kind is the kind to collect.
+	// +required
+	Kind string `json:"kind"`
+	// Category groups the objects of the kind in the UI.
+	// +kubebuilder:validation:Enum=automation;source;gitopsset;template;clusterdiscovery
+	// +required
+	Category string `json:"category"`
+	// Labels are the labels of the objects to index, so that they can be queried as `labels.<key>`.
+	// +optional
+	Labels []string `json:"labels,omitempty"`
+	// Status is how the status of the objects is worked out.
+	// By default, it follows the Ready condition and `spec.suspend` like Flux objects.
+	// +optional
+	Status *StatusExpression `json:"status,omitempty"`
+	// Message is how the message of the objects is read.
+	// By default, it is the message of the Ready condition.
+	// +optional
+	Message *Expression `json:"message,omitempty"`
+	// SearchFields are the fields of the objects to index, so that they can be queried as `fields.<name>`.
+	// +optional
+	SearchFields []SearchField `json:"searchFields,omitempty"`
+}
+
+// SearchField indexes a value read out of the objects of a kind as full text.
+type SearchField struct {
+	// Name is how the field is queried, as `fields.<name>`.
+	// +kubebuilder:validation:Pattern=`^[a-zA-Z][a-zA-Z0-9_-]*$`
+	// +required
+	Name string `json:"name"`
+	// JSONPath is a kubectl JSONPath template, for example `{.spec.chart.spec.chart}`.
+	// +required
+	JSONPath string `json:"jsonPath"`
+}
+
+// Expression reads a value out of an object. Exactly one of CEL and JSONPath must be set.
+type Expression struct {
+	// CEL is a CEL expression evaluated with the object as `self`, for example
+	// `self.status.phase == "Healthy" ? "Success" : "Failed"`.
+	// +optional
+	CEL string `json:"cel,omitempty"`
+	// JSONPath is a kubectl JSONPath template, for example `{.status.phase}`.
+	// +optional
+	JSONPath string `json:"jsonPath,omitempty"`
+}
+
+// StatusExpression reads the status of an object.
+type StatusExpression struct {
+	Expression `json:",inline"`
+	// Values maps the values read to one of the explorer statuses:
+	// Success, Failed, Reconciling, Suspended or PendingAction.
+	// Values that are explorer statuses already need no mapping. Any other value shows no status.
+	// +optional
+	Values map[string]string `json:"values,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:scope=Cluster
+// +kubebuilder:printcolumn:name="Group",type=string,JSONPath=`.spec.group`
+// +kubebuilder:printcolumn:name="Version",type=string,JSONPath=`.spec.version`
+// +kubebuilder:printcolumn:name="Kind",type=string,JSONPath=`.spec.kind`
+// +kubebuilder:printcolumn:name="Category",type=string,JSONPath=`.spec.category`
+
+// ObjectKind is the Schema for the objectkinds API
+type ObjectKind struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec ObjectKindSpec `json:"spec,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// ObjectKindList contains a list of ObjectKind
+type ObjectKindList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ObjectKind `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&ObjectKind{}, &ObjectKindList{})
+}
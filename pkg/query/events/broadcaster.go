@@ -0,0 +1,117 @@
+package events
+
+import (
+	"errors"
+	"sync"
+
+	"github.com/go-logr/logr"
+	"github.com/weaveworks/weave-gitops-enterprise/pkg/query/internal/models"
+)
+
+// subscriberBufferSize is the number of batches a subscriber can fall behind
+// before it is dropped.
+const subscriberBufferSize = 100
+
+// ErrSubscriberDropped is returned to subscribers that did not keep up with the published events.
+// They have missed events, so they need to query again and subscribe again.
+var ErrSubscriberDropped = errors.New("subscriber dropped for falling behind")
+
+// Publisher is notified of the changes written to the store.
+type Publisher interface {
+	Publish(events []models.ObjectEvent)
+}
+
+// Subscriber hands out the changes written to the store.
+type Subscriber interface {
+	Subscribe() *Subscription
+}
+
+// Subscription receives the batches of events published after it was created, in order.
+type Subscription struct {
+	events chan []models.ObjectEvent
+	err    error
+	cancel func()
+}
+
+// Events returns the channel the batches of events are sent on.
+// It is closed when the subscription is cancelled or dropped.
+func (s *Subscription) Events() <-chan []models.ObjectEvent {
+	return s.events
+}
+
+// Err returns why the events channel was closed, nil if the subscription was cancelled.
+func (s *Subscription) Err() error {
+	return s.err
+}
+
+// Cancel stops the subscription. It is safe to call more than once.
+func (s *Subscription) Cancel() {
+	s.cancel()
+}
+
+// Broadcaster fans out the published events to every subscription.
+type Broadcaster struct {
+	log         logr.Logger
+	mu          sync.Mutex
+	subscribers map[*Subscription]bool
+}
+
+func NewBroadcaster(log logr.Logger) *Broadcaster {
+	return &Broadcaster{
+		log:         log.WithName("events-broadcaster"),
+		subscribers: map[*Subscription]bool{},
+	}
+}
+
+func (b *Broadcaster) Subscribe() *Subscription {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	sub := &Subscription{
+		events: make(chan []models.ObjectEvent, subscriberBufferSize),
+	}
+	sub.cancel = func() {
+		b.remove(sub, nil)
+	}
+
+	b.subscribers[sub] = true
+
+	return sub
+}
+
+// Publish sends the events to every subscription without blocking. Subscriptions
+// whose buffer is full are dropped, rather than holding up the collectors.
+func (b *Broadcaster) Publish(events []models.ObjectEvent) {
+	if len(events) == 0 {
+		return
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for sub := range b.subscribers {
+		select {
+		case sub.events <- events:
+		default:
+			b.log.Info("dropping subscriber that fell behind")
+			b.removeLocked(sub, ErrSubscriberDropped)
+		}
+	}
+}
+
+func (b *Broadcaster) remove(sub *Subscription, err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.removeLocked(sub, err)
+}
+
+func (b *Broadcaster) removeLocked(sub *Subscription, err error) {
+	if !b.subscribers[sub] {
+		return
+	}
+
+	delete(b.subscribers, sub)
+	sub.err = err
+	close(sub.events)
+}
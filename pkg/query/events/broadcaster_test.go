@@ -0,0 +1,60 @@
+package events
+
+import (
+	"testing"
+
+	"github.com/go-logr/logr"
+	. "github.com/onsi/gomega"
+	"github.com/weaveworks/weave-gitops-enterprise/pkg/query/internal/models"
+)
+
+func TestBroadcaster(t *testing.T) {
+	g := NewWithT(t)
+
+	b := NewBroadcaster(logr.Discard())
+
+	first := b.Subscribe()
+	second := b.Subscribe()
+
+	changes := []models.ObjectEvent{{Type: models.ObjectEventAdded, Object: models.Object{Name: "podinfo"}}}
+	b.Publish(changes)
+
+	g.Expect(first.Events()).To(Receive(Equal(changes)))
+	g.Expect(second.Events()).To(Receive(Equal(changes)))
+
+	// Cancelled subscriptions stop receiving events.
+	first.Cancel()
+	first.Cancel()
+	b.Publish(changes)
+
+	g.Expect(first.Events()).To(BeClosed())
+	g.Expect(first.Err()).To(BeNil())
+	g.Expect(second.Events()).To(Receive(Equal(changes)))
+
+	// Nothing is sent for empty batches.
+	b.Publish(nil)
+	g.Expect(second.Events()).NotTo(Receive())
+}
+
+func TestBroadcaster_DropsSlowSubscribers(t *testing.T) {
+	g := NewWithT(t)
+
+	b := NewBroadcaster(logr.Discard())
+	slow := b.Subscribe()
+
+	changes := []models.ObjectEvent{{Type: models.ObjectEventDeleted, Object: models.Object{Name: "podinfo"}}}
+	for i := 0; i <= subscriberBufferSize; i++ {
+		b.Publish(changes)
+	}
+
+	received := 0
+	for range slow.Events() {
+		received++
+	}
+
+	g.Expect(received).To(Equal(subscriberBufferSize))
+	g.Expect(slow.Err()).To(MatchError(ErrSubscriberDropped))
+
+	// Cancelling a dropped subscription is a no-op.
+	slow.Cancel()
+}
@@ -0,0 +1,123 @@
+package query
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/weaveworks/weave-gitops/pkg/server/auth"
+
+	"github.com/weaveworks/weave-gitops-enterprise/pkg/query/internal/models"
+)
+
+var (
+	// ErrInvalidObjectID is returned when explaining access to an object whose ID cannot be parsed.
+	ErrInvalidObjectID = errors.New("invalid object id")
+	// ErrImpersonationNotAllowed is returned when explaining the access of another principal
+	// without being allowed to impersonate it.
+	ErrImpersonationNotAllowed = errors.New("not allowed to impersonate")
+)
+
+// AccessExplainer explains the decisions of an Authorizer. Access cannot be explained
+// unless the Authorizer of the query service implements it.
+type AccessExplainer interface {
+	ExplainAccess(roles []models.Role, rolebindings []models.RoleBinding, principal *auth.UserPrincipal, obj models.Object) models.AccessExplanation
+	CanImpersonate(roles []models.Role, rolebindings []models.RoleBinding, principal *auth.UserPrincipal, cluster string, target *auth.UserPrincipal) (bool, error)
+}
+
+func (q *qs) ExplainAccess(ctx context.Context, id string, subject *auth.UserPrincipal) (models.AccessExplanation, error) {
+	principal := auth.Principal(ctx)
+	if principal == nil {
+		return models.AccessExplanation{}, fmt.Errorf("principal not found")
+	}
+
+	explainer, ok := q.authorizer.(AccessExplainer)
+	if !ok {
+		return models.AccessExplanation{}, fmt.Errorf("explaining access is not supported")
+	}
+
+	obj, err := parseObjectID(id)
+	if err != nil {
+		return models.AccessExplanation{}, err
+	}
+
+	roles, err := q.r.GetRoles(ctx)
+	if err != nil {
+		return models.AccessExplanation{}, fmt.Errorf("error fetching access rules from the store: %w", err)
+	}
+	bindings, err := q.r.GetRoleBindings(ctx)
+	if err != nil {
+		return models.AccessExplanation{}, fmt.Errorf("error fetching access rules from the store: %w", err)
+	}
+
+	target := principal
+	if subject != nil && !samePrincipal(subject, principal) {
+		// Only those who could impersonate the subject on the cluster of the object
+		// get to see what it can access there.
+		ok, err := explainer.CanImpersonate(roles, bindings, principal, obj.Cluster, subject)
+		if err != nil {
+			return models.AccessExplanation{}, fmt.Errorf("error checking impersonation: %w", err)
+		}
+		if !ok {
+			return models.AccessExplanation{}, fmt.Errorf("%w: %s", ErrImpersonationNotAllowed, subject.String())
+		}
+		target = subject
+	}
+
+	explanation := explainer.ExplainAccess(roles, bindings, target, obj)
+
+	q.debug.Info("access explained", "object", id, "principal", principal.ID, "subject", target.ID, "allowed", explanation.Allowed)
+	return explanation, nil
+}
+
+// parseObjectID reads the cluster, namespace, group, version, kind and name of an object from its ID.
+// The object does not have to exist, so that explaining access does not disclose whether it does.
+// The fields are read from the right, as the names of leaf clusters are of the form namespace/name.
+func parseObjectID(id string) (models.Object, error) {
+	parts := strings.Split(id, "/")
+	n := len(parts)
+
+	switch {
+	case n > 5:
+		return models.Object{
+			Cluster:    strings.Join(parts[:n-5], "/"),
+			Namespace:  parts[n-5],
+			APIGroup:   parts[n-4],
+			APIVersion: parts[n-3],
+			Kind:       parts[n-2],
+			Name:       parts[n-1],
+		}, nil
+	case n == 5:
+		// Objects without a version have IDs without it.
+		return models.Object{
+			Cluster:   parts[0],
+			Namespace: parts[1],
+			APIGroup:  parts[2],
+			Kind:      parts[3],
+			Name:      parts[4],
+		}, nil
+	}
+
+	return models.Object{}, fmt.Errorf("%w: %q is not of the form cluster/namespace/group/version/kind/name", ErrInvalidObjectID, id)
+}
+
+func samePrincipal(a, b *auth.UserPrincipal) bool {
+	if a.ID != b.ID || len(a.Groups) != len(b.Groups) {
+		return false
+	}
+
+	groupsA := append([]string{}, a.Groups...)
+	groupsB := append([]string{}, b.Groups...)
+	sort.Strings(groupsA)
+	sort.Strings(groupsB)
+
+	for i := range groupsA {
+		if groupsA[i] != groupsB[i] {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,128 @@
+package query
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/go-logr/logr"
+	. "github.com/onsi/gomega"
+	"github.com/weaveworks/weave-gitops/pkg/kube"
+	"github.com/weaveworks/weave-gitops/pkg/server/auth"
+
+	"github.com/weaveworks/weave-gitops-enterprise/pkg/query/internal/models"
+	"github.com/weaveworks/weave-gitops-enterprise/pkg/query/rbac"
+	"github.com/weaveworks/weave-gitops-enterprise/pkg/query/store"
+)
+
+func TestParseObjectID(t *testing.T) {
+	g := NewWithT(t)
+
+	obj := models.Object{
+		Cluster:    "management",
+		Namespace:  "flux-system",
+		APIGroup:   "helm.toolkit.fluxcd.io",
+		APIVersion: "v2beta1",
+		Kind:       "HelmRelease",
+		Name:       "podinfo",
+	}
+
+	parsed, err := parseObjectID(obj.GetID())
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(parsed).To(Equal(obj))
+
+	namespace := models.Object{Cluster: "management", APIVersion: "v1", Kind: "Namespace", Name: "flux-system"}
+	parsed, err = parseObjectID(namespace.GetID())
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(parsed).To(Equal(namespace))
+
+	leaf := obj
+	leaf.Cluster = "flux-system/leaf"
+	parsed, err = parseObjectID(leaf.GetID())
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(parsed).To(Equal(leaf))
+
+	_, err = parseObjectID("management/podinfo")
+	g.Expect(err).To(MatchError(ErrInvalidObjectID))
+}
+
+func TestExplainAccess(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	dir, err := os.MkdirTemp("", "test")
+	g.Expect(err).NotTo(HaveOccurred())
+
+	db, err := store.CreateSQLiteDB(dir)
+	g.Expect(err).NotTo(HaveOccurred())
+
+	s, err := store.NewSQLiteStore(db, logr.Discard())
+	g.Expect(err).NotTo(HaveOccurred())
+
+	ctx := context.Background()
+	g.Expect(s.StoreRoles(ctx, []models.Role{
+		{
+			Cluster: "management", Kind: "ClusterRole", Name: "helm-reader",
+			PolicyRules: []models.PolicyRule{{APIGroups: "helm.toolkit.fluxcd.io", Resources: "helmreleases", Verbs: "list"}},
+		},
+		{
+			Cluster: "management", Kind: "ClusterRole", Name: "impersonator",
+			PolicyRules: []models.PolicyRule{{APIGroups: "", Resources: "users,groups", Verbs: "impersonate"}},
+		},
+	})).To(Succeed())
+	g.Expect(s.StoreRoleBindings(ctx, []models.RoleBinding{
+		{
+			Cluster: "management", Kind: "RoleBinding", Namespace: "apps", Name: "devs-read-helm",
+			RoleRefKind: "ClusterRole", RoleRefName: "helm-reader",
+			Subjects: []models.Subject{{Kind: "Group", Name: "devs"}},
+		},
+		{
+			Cluster: "management", Kind: "ClusterRoleBinding", Name: "admins-impersonate",
+			RoleRefKind: "ClusterRole", RoleRefName: "impersonator",
+			Subjects: []models.Subject{{Kind: "User", Name: "admin"}},
+		},
+	})).To(Succeed())
+
+	q := &qs{
+		log:        logr.Discard(),
+		debug:      logr.Discard(),
+		r:          s,
+		authorizer: rbac.NewAuthorizer(map[string]string{"HelmRelease": "helmreleases"}, kube.UserPrefixes{}),
+	}
+
+	id := "management/flux-system/helm.toolkit.fluxcd.io/v2beta1/HelmRelease/podinfo"
+	dev := &auth.UserPrincipal{ID: "dev", Groups: []string{"devs"}}
+	admin := auth.WithPrincipal(ctx, &auth.UserPrincipal{ID: "admin"})
+
+	t.Run("the access of the caller is explained", func(t *testing.T) {
+		g := NewWithT(t)
+
+		explanation, err := q.ExplainAccess(auth.WithPrincipal(ctx, dev), id, nil)
+		g.Expect(err).NotTo(HaveOccurred())
+		g.Expect(explanation.Allowed).To(BeFalse())
+		g.Expect(explanation.NearMisses).To(HaveLen(1))
+		g.Expect(explanation.NearMisses[0].Reason).To(Equal(models.NearMissWrongNamespace))
+
+		// Naming the caller itself is not impersonation.
+		_, err = q.ExplainAccess(auth.WithPrincipal(ctx, dev), id, &auth.UserPrincipal{ID: "dev", Groups: []string{"devs"}})
+		g.Expect(err).NotTo(HaveOccurred())
+	})
+
+	t.Run("admins explain the access of others", func(t *testing.T) {
+		g := NewWithT(t)
+
+		explanation, err := q.ExplainAccess(admin, id, dev)
+		g.Expect(err).NotTo(HaveOccurred())
+		g.Expect(explanation.NearMisses).To(HaveLen(1))
+		g.Expect(explanation.NearMisses[0].BindingName).To(Equal("devs-read-helm"))
+
+		_, err = q.ExplainAccess(auth.WithPrincipal(ctx, dev), id, &auth.UserPrincipal{ID: "admin"})
+		g.Expect(err).To(MatchError(ErrImpersonationNotAllowed))
+	})
+
+	t.Run("invalid IDs are not explained", func(t *testing.T) {
+		g := NewWithT(t)
+
+		_, err := q.ExplainAccess(admin, "podinfo", nil)
+		g.Expect(err).To(MatchError(ErrInvalidObjectID))
+	})
+}
@@ -0,0 +1,121 @@
+package query
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"k8s.io/client-go/util/jsonpath"
+
+	"github.com/weaveworks/weave-gitops-enterprise/pkg/query/internal/models"
+)
+
+// ErrInvalidColumn is returned when objects are exported with a column that is not supported.
+var ErrInvalidColumn = errors.New("invalid column")
+
+const unstructuredFieldPrefix = "unstructured."
+
+// exportBatchSize is the number of objects read from the index at a time while exporting.
+const exportBatchSize = 500
+
+// DefaultExportColumns are the columns exported when none are given.
+var DefaultExportColumns = []string{"cluster", "namespace", "apiGroup", "apiVersion", "kind", "name", "status", "message", "tenant"}
+
+// exportFields are the fields of an object that can be exported, besides its labels
+// as `labels.<key>` and the fields of the object itself as `unstructured.<path>`.
+var exportFields = map[string]func(models.Object) string{
+	"message": func(o models.Object) string { return o.Message },
+}
+
+func init() {
+	for field, get := range groupByFields {
+		exportFields[field] = get
+	}
+}
+
+// exportColumn reads the value of a column from an object.
+type exportColumn func(models.Object) (string, error)
+
+// newExportColumns returns how to read each of columns from an object, or the default columns if there are none.
+func newExportColumns(columns []string) ([]string, []exportColumn, error) {
+	if len(columns) == 0 {
+		columns = DefaultExportColumns
+	}
+
+	result := []exportColumn{}
+	seen := map[string]bool{}
+	for _, column := range columns {
+		if seen[column] {
+			return nil, nil, fmt.Errorf("%w: column %q is repeated", ErrInvalidColumn, column)
+		}
+		seen[column] = true
+
+		col, err := newExportColumn(column)
+		if err != nil {
+			return nil, nil, err
+		}
+		result = append(result, col)
+	}
+
+	return columns, result, nil
+}
+
+func newExportColumn(column string) (exportColumn, error) {
+	if get, ok := exportFields[column]; ok {
+		return func(o models.Object) (string, error) {
+			return get(o), nil
+		}, nil
+	}
+
+	if strings.HasPrefix(column, labelFieldPrefix) && len(column) > len(labelFieldPrefix) {
+		key := strings.TrimPrefix(column, labelFieldPrefix)
+		return func(o models.Object) (string, error) {
+			return objectLabels(o)[key], nil
+		}, nil
+	}
+
+	if strings.HasPrefix(column, unstructuredFieldPrefix) && len(column) > len(unstructuredFieldPrefix) {
+		path := strings.TrimPrefix(column, unstructuredFieldPrefix)
+		jp := jsonpath.New(column).AllowMissingKeys(true)
+		if err := jp.Parse(fmt.Sprintf("{.%s}", path)); err != nil {
+			return nil, fmt.Errorf("%w: column %q is not a valid path: %s", ErrInvalidColumn, column, err)
+		}
+
+		return func(o models.Object) (string, error) {
+			raw := o.Content()
+			if len(raw) == 0 {
+				return "", nil
+			}
+
+			content := map[string]interface{}{}
+			if err := json.Unmarshal(raw, &content); err != nil {
+				return "", fmt.Errorf("cannot read object %s: %w", o.GetID(), err)
+			}
+
+			buf := &bytes.Buffer{}
+			if err := jp.Execute(buf, content); err != nil {
+				return "", fmt.Errorf("cannot read column %q of object %s: %w", column, o.GetID(), err)
+			}
+
+			return buf.String(), nil
+		}, nil
+	}
+
+	return nil, fmt.Errorf("%w: column %q is not supported", ErrInvalidColumn, column)
+}
+
+// exportRow reads the values of columns from an object.
+func exportRow(obj models.Object, columns []exportColumn) ([]string, error) {
+	row := make([]string, 0, len(columns))
+	for _, col := range columns {
+		value, err := col(obj)
+		if err != nil {
+			return nil, err
+		}
+		row = append(row, value)
+	}
+
+	return row, nil
+}
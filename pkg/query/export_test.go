@@ -0,0 +1,182 @@
+package query
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/go-logr/logr"
+	. "github.com/onsi/gomega"
+	"github.com/weaveworks/weave-gitops-enterprise/pkg/query/internal/models"
+	"github.com/weaveworks/weave-gitops-enterprise/pkg/query/store"
+	"github.com/weaveworks/weave-gitops/pkg/server/auth"
+)
+
+func TestNewExportColumns(t *testing.T) {
+	tests := []struct {
+		name    string
+		columns []string
+		want    []string
+		wantErr string
+	}{
+		{
+			name:    "fields, labels and object fields",
+			columns: []string{"cluster", "message", "labels.team", "unstructured.spec.chart.spec.version"},
+			want:    []string{"cluster", "message", "labels.team", "unstructured.spec.chart.spec.version"},
+		},
+		{
+			name:    "no columns",
+			columns: nil,
+			want:    DefaultExportColumns,
+		},
+		{
+			name:    "unknown field",
+			columns: []string{"spec"},
+			wantErr: `invalid column: column "spec" is not supported`,
+		},
+		{
+			name:    "object field without path",
+			columns: []string{"unstructured."},
+			wantErr: `invalid column: column "unstructured." is not supported`,
+		},
+		{
+			name:    "invalid object field path",
+			columns: []string{"unstructured.spec[0"},
+			wantErr: `invalid column: column "unstructured.spec[0" is not a valid path`,
+		},
+		{
+			name:    "repeated column",
+			columns: []string{"name", "name"},
+			wantErr: `invalid column: column "name" is repeated`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			columns, _, err := newExportColumns(tt.columns)
+			if tt.wantErr == "" {
+				g.Expect(err).NotTo(HaveOccurred())
+				g.Expect(columns).To(Equal(tt.want))
+				return
+			}
+
+			g.Expect(err).To(MatchError(ErrInvalidColumn))
+			g.Expect(err.Error()).To(HavePrefix(tt.wantErr))
+		})
+	}
+}
+
+func TestExport(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	dir, err := os.MkdirTemp("", "test")
+	g.Expect(err).NotTo(HaveOccurred())
+
+	db, err := store.CreateSQLiteDB(dir)
+	g.Expect(err).NotTo(HaveOccurred())
+
+	s, err := store.NewSQLiteStore(db, logr.Discard())
+	g.Expect(err).NotTo(HaveOccurred())
+
+	idx, err := store.NewIndexer(s, dir, logr.Discard())
+	g.Expect(err).NotTo(HaveOccurred())
+
+	ctx := auth.WithPrincipal(context.Background(), &auth.UserPrincipal{
+		ID: "test",
+	})
+
+	release := func(cluster, name, version string) models.Object {
+		// Objects are stored as the collector writes them.
+		raw, err := json.Marshal(map[string]interface{}{
+			"Object": map[string]interface{}{
+				"metadata": map[string]interface{}{
+					"labels": map[string]string{"team": "blue"},
+				},
+				"spec": map[string]interface{}{
+					"chart": map[string]interface{}{
+						"spec": map[string]interface{}{"version": version},
+					},
+				},
+			},
+		})
+		g.Expect(err).NotTo(HaveOccurred())
+
+		return models.Object{
+			Cluster:      cluster,
+			Namespace:    "flux-system",
+			Name:         name,
+			Kind:         "HelmRelease",
+			APIGroup:     "helm.toolkit.fluxcd.io",
+			APIVersion:   "v2beta1",
+			Status:       "Success",
+			Unstructured: raw,
+		}
+	}
+
+	// More objects than fit in a batch, or in a page of results.
+	objects := []models.Object{}
+	for i := 0; i < exportBatchSize+100; i++ {
+		objects = append(objects, release("cluster-1", fmt.Sprintf("release-%04d", i), "1.0.0"))
+	}
+	objects = append(objects, release("cluster-2", "podinfo", "6.3.5"))
+	// The principal cannot see the objects of this cluster, so they must not be exported.
+	objects = append(objects, release("cluster-3", "podinfo", "6.3.5"))
+
+	g.Expect(idx.Add(context.Background(), objects)).To(Succeed())
+	g.Expect(store.SeedObjects(db, objects)).To(Succeed())
+
+	q := &qs{
+		log:   logr.Discard(),
+		debug: logr.Discard(),
+		r:     s,
+		index: idx,
+		authorizer: predicateAuthz{
+			predicate: func(obj models.Object) (bool, error) {
+				return obj.Cluster != "cluster-3", nil
+			},
+		},
+	}
+
+	t.Run("every object is exported", func(t *testing.T) {
+		g := NewWithT(t)
+
+		batches := 0
+		rows := [][]string{}
+		err := q.Export(ctx, &query{}, []string{"cluster", "name"}, func(batch [][]string) error {
+			batches++
+			rows = append(rows, batch...)
+			return nil
+		})
+		g.Expect(err).NotTo(HaveOccurred())
+		g.Expect(rows).To(HaveLen(exportBatchSize + 101))
+		g.Expect(batches).To(Equal(2))
+		g.Expect(rows).NotTo(ContainElement([]string{"cluster-3", "podinfo"}))
+	})
+
+	t.Run("labels and object fields are exported", func(t *testing.T) {
+		g := NewWithT(t)
+
+		rows := [][]string{}
+		err := q.Export(ctx, &query{query: "name:podinfo"}, []string{"cluster", "labels.team", "unstructured.spec.chart.spec.version", "unstructured.spec.missing"}, func(batch [][]string) error {
+			rows = append(rows, batch...)
+			return nil
+		})
+		g.Expect(err).NotTo(HaveOccurred())
+		g.Expect(rows).To(Equal([][]string{
+			{"cluster-2", "blue", "6.3.5", ""},
+		}))
+	})
+
+	t.Run("invalid columns are not exported", func(t *testing.T) {
+		g := NewWithT(t)
+
+		err := q.Export(ctx, &query{}, []string{"spec"}, func(batch [][]string) error {
+			return nil
+		})
+		g.Expect(err).To(MatchError(ErrInvalidColumn))
+	})
+}
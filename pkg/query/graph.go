@@ -0,0 +1,256 @@
+package query
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/weaveworks/weave-gitops/pkg/server/auth"
+
+	"github.com/weaveworks/weave-gitops-enterprise/pkg/query/internal/models"
+)
+
+// ErrInvalidGraphRequest is returned when a graph is requested with an unsupported depth or direction.
+var ErrInvalidGraphRequest = errors.New("invalid graph request")
+
+// GraphDirection tells which neighbours of an object a graph includes.
+type GraphDirection string
+
+const (
+	// GraphDirectionUpstream follows edges to the objects an object depends on, like its source.
+	GraphDirectionUpstream GraphDirection = "upstream"
+	// GraphDirectionDownstream follows edges to the objects that depend on an object, like the workloads it applies.
+	GraphDirectionDownstream GraphDirection = "downstream"
+	// GraphDirectionBoth follows edges both ways.
+	GraphDirectionBoth GraphDirection = "both"
+)
+
+const (
+	// DefaultGraphDepth is the depth of graphs requested with no depth.
+	DefaultGraphDepth = 1
+	// MaxGraphDepth is the deepest a graph can be requested.
+	MaxGraphDepth = 10
+)
+
+func (q *qs) GetObjectGraph(ctx context.Context, id string, depth int, direction GraphDirection) (models.ObjectGraph, error) {
+	principal := auth.Principal(ctx)
+	if principal == nil {
+		return models.ObjectGraph{}, fmt.Errorf("principal not found")
+	}
+
+	if depth == 0 {
+		depth = DefaultGraphDepth
+	}
+	if depth < 0 || depth > MaxGraphDepth {
+		return models.ObjectGraph{}, fmt.Errorf("%w: depth must be between 1 and %d", ErrInvalidGraphRequest, MaxGraphDepth)
+	}
+
+	if direction == "" {
+		direction = GraphDirectionBoth
+	}
+	if direction != GraphDirectionUpstream && direction != GraphDirectionDownstream && direction != GraphDirectionBoth {
+		return models.ObjectGraph{}, fmt.Errorf("%w: unsupported direction %q", ErrInvalidGraphRequest, direction)
+	}
+
+	iter, err := q.r.GetObjects(ctx, []string{id}, nil)
+	if err != nil {
+		return models.ObjectGraph{}, fmt.Errorf("error getting object from the store: %w", err)
+	}
+
+	defer iter.Close()
+
+	objects, err := iter.All()
+	if err != nil {
+		return models.ObjectGraph{}, fmt.Errorf("error reading object: %w", err)
+	}
+
+	if len(objects) == 0 {
+		return models.ObjectGraph{}, ErrObjectNotFound
+	}
+
+	authorized, tenantLookup, err := q.accessFor(ctx, principal)
+	if err != nil {
+		return models.ObjectGraph{}, err
+	}
+
+	// Objects the principal cannot see are reported as missing, so that their existence is not disclosed.
+	if ok, err := authorized(objects[0]); err != nil || !ok {
+		return models.ObjectGraph{}, ErrObjectNotFound
+	}
+
+	w := &graphWalker{
+		qs:           q,
+		authorized:   authorized,
+		tenantLookup: tenantLookup,
+		nodes:        map[string]bool{},
+		hidden:       map[string]bool{},
+		edges:        map[models.ObjectEdge]bool{},
+		graph: models.ObjectGraph{
+			Nodes: []models.ObjectGraphNode{},
+			Edges: []models.ObjectEdge{},
+		},
+	}
+
+	root := objects[0]
+	w.addNode(root.Reference(), &root, 0)
+
+	if direction != GraphDirectionDownstream {
+		if err := w.walk(ctx, root.Reference().String(), depth, -1); err != nil {
+			return models.ObjectGraph{}, err
+		}
+	}
+
+	if direction != GraphDirectionUpstream {
+		if err := w.walk(ctx, root.Reference().String(), depth, 1); err != nil {
+			return models.ObjectGraph{}, err
+		}
+	}
+
+	q.debug.Info("object graph processed", "id", id, "depth", depth, "direction", direction, "principal", principal.ID, "numNodes", len(w.graph.Nodes))
+	return w.graph, nil
+}
+
+// graphWalker builds the graph of an object, one level of neighbours at a time. The objects that the
+// principal cannot see are left out, and so are the objects only reachable through them.
+type graphWalker struct {
+	qs           *qs
+	authorized   func(models.Object) (bool, error)
+	tenantLookup map[string]string
+	graph        models.ObjectGraph
+	// nodes and hidden hold the references of the objects that are in the graph, and those left out.
+	nodes  map[string]bool
+	hidden map[string]bool
+	// edges holds the edges in the graph, with no ID or owner, as the same edge can be read from several objects.
+	edges map[models.ObjectEdge]bool
+}
+
+// walk follows the edges of the graph from the object with the given reference, downstream if step is positive.
+func (w *graphWalker) walk(ctx context.Context, from string, depth int, step int) error {
+	frontier := []string{from}
+
+	for level := 1; level <= depth && len(frontier) > 0; level++ {
+		inFrontier := map[string]bool{}
+		for _, ref := range frontier {
+			inFrontier[ref] = true
+		}
+
+		edges, err := w.qs.r.GetObjectEdges(ctx, frontier)
+		if err != nil {
+			return fmt.Errorf("error getting object edges: %w", err)
+		}
+
+		// The edges leaving the frontier in the direction of the walk, and the neighbours they lead to.
+		following := []models.ObjectEdge{}
+		neighbours := []models.ObjectReference{}
+		pending := map[string]bool{}
+		for _, e := range edges {
+			near, far := e.Upstream, e.Downstream
+			if step < 0 {
+				near, far = e.Downstream, e.Upstream
+			}
+			if !inFrontier[near] {
+				continue
+			}
+
+			following = append(following, e)
+			if w.nodes[far] || w.hidden[far] || pending[far] {
+				continue
+			}
+
+			ref, err := models.ParseObjectReference(far)
+			if err != nil {
+				w.qs.log.Error(err, "invalid object edge", "owner", e.Owner)
+				w.hidden[far] = true
+				continue
+			}
+			pending[far] = true
+			neighbours = append(neighbours, ref)
+		}
+
+		next, err := w.addNeighbours(ctx, neighbours, level*step)
+		if err != nil {
+			return err
+		}
+
+		for _, e := range following {
+			if w.nodes[e.Upstream] && w.nodes[e.Downstream] {
+				w.addEdge(e)
+			}
+		}
+
+		frontier = next
+	}
+
+	return nil
+}
+
+// addNeighbours adds the neighbours that the principal can see to the graph, and returns their references.
+func (w *graphWalker) addNeighbours(ctx context.Context, neighbours []models.ObjectReference, depth int) ([]string, error) {
+	stored, err := w.qs.r.GetObjectsByReference(ctx, neighbours)
+	if err != nil {
+		return nil, fmt.Errorf("error getting objects from the store: %w", err)
+	}
+
+	byReference := map[string]models.Object{}
+	for _, obj := range stored {
+		byReference[obj.Reference().String()] = obj
+	}
+
+	added := []string{}
+	for _, ref := range neighbours {
+		key := ref.String()
+
+		var object *models.Object
+		// Objects that are not collected are authorized as if they were, so that they are only shown to the
+		// principals that can list them.
+		candidate := models.Object{
+			Cluster:   ref.Cluster,
+			Namespace: ref.Namespace,
+			APIGroup:  ref.APIGroup,
+			Kind:      ref.Kind,
+			Name:      ref.Name,
+		}
+		if obj, ok := byReference[key]; ok {
+			object = &obj
+			candidate = obj
+		}
+
+		ok, err := w.authorized(candidate)
+		if err != nil || !ok {
+			w.hidden[key] = true
+			continue
+		}
+
+		w.addNode(ref, object, depth)
+		added = append(added, key)
+	}
+
+	return added, nil
+}
+
+func (w *graphWalker) addNode(ref models.ObjectReference, object *models.Object, depth int) {
+	if object != nil {
+		object.ID = object.GetID()
+		if tenant, ok := w.tenantLookup[fmt.Sprintf("%s/%s", object.Cluster, object.Namespace)]; ok {
+			object.Tenant = tenant
+		}
+	}
+
+	w.nodes[ref.String()] = true
+	w.graph.Nodes = append(w.graph.Nodes, models.ObjectGraphNode{
+		Reference: ref,
+		Object:    object,
+		Depth:     depth,
+	})
+}
+
+func (w *graphWalker) addEdge(e models.ObjectEdge) {
+	e.ID = 0
+	e.Owner = ""
+	if w.edges[e] {
+		return
+	}
+
+	w.edges[e] = true
+	w.graph.Edges = append(w.graph.Edges, e)
+}
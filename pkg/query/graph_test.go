@@ -0,0 +1,194 @@
+package query
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/go-logr/logr"
+	. "github.com/onsi/gomega"
+	"github.com/weaveworks/weave-gitops/pkg/server/auth"
+
+	"github.com/weaveworks/weave-gitops-enterprise/pkg/query/internal/models"
+	"github.com/weaveworks/weave-gitops-enterprise/pkg/query/store"
+)
+
+func TestGetObjectGraph(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	db, err := store.CreateSQLiteDB(t.TempDir())
+	g.Expect(err).NotTo(HaveOccurred())
+
+	s, err := store.NewSQLiteStore(db, logr.Discard())
+	g.Expect(err).NotTo(HaveOccurred())
+
+	ctx := auth.WithPrincipal(context.Background(), &auth.UserPrincipal{
+		ID: "test",
+	})
+
+	object := func(group, kind, name string, content map[string]interface{}) models.Object {
+		raw, err := json.Marshal(content)
+		g.Expect(err).NotTo(HaveOccurred())
+
+		return models.Object{
+			Cluster:      "management",
+			Namespace:    "flux-system",
+			APIGroup:     group,
+			APIVersion:   "v1",
+			Kind:         kind,
+			Name:         name,
+			Category:     "automation",
+			Unstructured: raw,
+		}
+	}
+
+	repo := object("source.toolkit.fluxcd.io", "GitRepository", "flux-system", map[string]interface{}{})
+	apps := object("kustomize.toolkit.fluxcd.io", "Kustomization", "apps", map[string]interface{}{
+		"spec": map[string]interface{}{
+			"sourceRef": map[string]interface{}{"kind": "GitRepository", "name": "flux-system"},
+		},
+		"status": map[string]interface{}{
+			"inventory": map[string]interface{}{
+				"entries": []interface{}{
+					map[string]interface{}{"id": "podinfo_podinfo_apps_Deployment", "v": "v1"},
+					map[string]interface{}{"id": "podinfo_podinfo__Secret", "v": "v1"},
+				},
+			},
+		},
+	})
+	infra := object("kustomize.toolkit.fluxcd.io", "Kustomization", "infra", map[string]interface{}{
+		"spec": map[string]interface{}{
+			"sourceRef": map[string]interface{}{"kind": "GitRepository", "name": "flux-system"},
+		},
+	})
+	release := object("helm.toolkit.fluxcd.io", "HelmRelease", "podinfo", map[string]interface{}{
+		"status": map[string]interface{}{"helmChart": "flux-system/flux-system-podinfo"},
+	})
+	chart := object("source.toolkit.fluxcd.io", "HelmChart", "flux-system-podinfo", map[string]interface{}{
+		"spec": map[string]interface{}{
+			"sourceRef": map[string]interface{}{"kind": "HelmRepository", "name": "podinfo"},
+		},
+	})
+	helmRepo := object("source.toolkit.fluxcd.io", "HelmRepository", "podinfo", map[string]interface{}{})
+
+	objects := []models.Object{repo, apps, infra, release, chart, helmRepo}
+	g.Expect(store.SeedObjects(db, objects)).To(Succeed())
+
+	owners := []string{}
+	edges := []models.ObjectEdge{}
+	for _, obj := range objects {
+		declared, err := models.NewObjectEdges(obj)
+		g.Expect(err).NotTo(HaveOccurred())
+		owners = append(owners, obj.GetID())
+		edges = append(edges, declared...)
+	}
+	g.Expect(s.StoreObjectEdges(context.Background(), owners, edges)).To(Succeed())
+
+	hidden := map[string]bool{"Secret": true}
+	q := &qs{
+		log:   logr.Discard(),
+		debug: logr.Discard(),
+		r:     s,
+		authorizer: predicateAuthz{
+			predicate: func(obj models.Object) (bool, error) {
+				return !hidden[obj.Kind] && !hidden[obj.Name], nil
+			},
+		},
+	}
+
+	nodes := func(graph models.ObjectGraph) map[string]int {
+		result := map[string]int{}
+		for _, n := range graph.Nodes {
+			result[n.Reference.Kind+"/"+n.Reference.Name] = n.Depth
+		}
+		return result
+	}
+
+	t.Run("follows a source to every object it affects", func(t *testing.T) {
+		g := NewWithT(t)
+
+		graph, err := q.GetObjectGraph(ctx, repo.GetID(), 2, GraphDirectionDownstream)
+		g.Expect(err).NotTo(HaveOccurred())
+		g.Expect(nodes(graph)).To(Equal(map[string]int{
+			"GitRepository/flux-system": 0,
+			"Kustomization/apps":        1,
+			"Kustomization/infra":       1,
+			"Deployment/podinfo":        2,
+		}))
+		g.Expect(graph.Edges).To(HaveLen(3))
+
+		// Objects that are not collected are only known by their reference.
+		for _, n := range graph.Nodes {
+			if n.Reference.Kind == "Deployment" {
+				g.Expect(n.Object).To(BeNil())
+			} else {
+				g.Expect(n.Object).NotTo(BeNil())
+			}
+		}
+	})
+
+	t.Run("stops at the requested depth", func(t *testing.T) {
+		g := NewWithT(t)
+
+		graph, err := q.GetObjectGraph(ctx, repo.GetID(), 0, "")
+		g.Expect(err).NotTo(HaveOccurred())
+		g.Expect(nodes(graph)).To(Equal(map[string]int{
+			"GitRepository/flux-system": 0,
+			"Kustomization/apps":        1,
+			"Kustomization/infra":       1,
+		}))
+	})
+
+	t.Run("follows a release to its chart and repository", func(t *testing.T) {
+		g := NewWithT(t)
+
+		graph, err := q.GetObjectGraph(ctx, release.GetID(), 3, GraphDirectionBoth)
+		g.Expect(err).NotTo(HaveOccurred())
+		g.Expect(nodes(graph)).To(Equal(map[string]int{
+			"HelmRelease/podinfo":           0,
+			"HelmChart/flux-system-podinfo": -1,
+			"HelmRepository/podinfo":        -2,
+		}))
+		g.Expect(graph.Edges).To(ConsistOf(
+			models.ObjectEdge{Cluster: "management", Upstream: chart.Reference().String(), Downstream: release.Reference().String(), Relation: models.EdgeRelationChart},
+			models.ObjectEdge{Cluster: "management", Upstream: helmRepo.Reference().String(), Downstream: chart.Reference().String(), Relation: models.EdgeRelationSource},
+		))
+	})
+
+	t.Run("leaves out the objects only reachable through hidden ones", func(t *testing.T) {
+		g := NewWithT(t)
+
+		hidden["apps"] = true
+		defer delete(hidden, "apps")
+
+		graph, err := q.GetObjectGraph(ctx, repo.GetID(), 2, GraphDirectionDownstream)
+		g.Expect(err).NotTo(HaveOccurred())
+		g.Expect(nodes(graph)).To(Equal(map[string]int{
+			"GitRepository/flux-system": 0,
+			"Kustomization/infra":       1,
+		}))
+	})
+
+	t.Run("hidden and missing objects are not found", func(t *testing.T) {
+		g := NewWithT(t)
+
+		hidden["flux-system"] = true
+		defer delete(hidden, "flux-system")
+
+		_, err := q.GetObjectGraph(ctx, repo.GetID(), 1, GraphDirectionBoth)
+		g.Expect(err).To(MatchError(ErrObjectNotFound))
+
+		_, err = q.GetObjectGraph(ctx, "management/flux-system/source.toolkit.fluxcd.io/v1/GitRepository/missing", 1, GraphDirectionBoth)
+		g.Expect(err).To(MatchError(ErrObjectNotFound))
+	})
+
+	t.Run("rejects unsupported depths and directions", func(t *testing.T) {
+		g := NewWithT(t)
+
+		_, err := q.GetObjectGraph(ctx, repo.GetID(), MaxGraphDepth+1, GraphDirectionBoth)
+		g.Expect(err).To(MatchError(ErrInvalidGraphRequest))
+
+		_, err = q.GetObjectGraph(ctx, repo.GetID(), 1, "sideways")
+		g.Expect(err).To(MatchError(ErrInvalidGraphRequest))
+	})
+}
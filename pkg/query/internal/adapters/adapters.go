@@ -5,6 +5,7 @@ import (
 
 	"github.com/weaveworks/weave-gitops-enterprise/pkg/query/internal/models"
 	v1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
@@ -44,12 +45,19 @@ func (c *cRoleAdapter) ToModel() models.Role {
 		})
 	}
 
+	var selectors []metav1.LabelSelector
+	if c.AggregationRule != nil {
+		selectors = c.AggregationRule.ClusterRoleSelectors
+	}
+
 	return models.Role{
-		Cluster:     c.ClusterName,
-		Namespace:   c.GetNamespace(),
-		Kind:        c.Kind,
-		Name:        c.Name,
-		PolicyRules: rules,
+		Cluster:              c.ClusterName,
+		Namespace:            c.GetNamespace(),
+		Kind:                 c.Kind,
+		Name:                 c.Name,
+		PolicyRules:          rules,
+		Labels:               c.GetLabels(),
+		AggregationSelectors: selectors,
 	}
 }
 
@@ -0,0 +1,39 @@
+package models
+
+// Reasons why a rule bound to a principal does not allow it to list an object,
+// when it is the only thing keeping the rule from allowing it.
+const (
+	NearMissWrongNamespace = "WrongNamespace"
+	NearMissMissingVerb    = "MissingVerb"
+	NearMissResourceName   = "ResourceName"
+)
+
+// AccessExplanation tells why a principal can or cannot list an object.
+// It is not stored in the database.
+type AccessExplanation struct {
+	Allowed bool
+	// Matches are the rules that allow the principal to list the object.
+	Matches []RuleMatch
+	// NearMisses are the rules bound to the principal that would allow it
+	// to list the object, but for a single reason.
+	NearMisses []RuleMatch
+}
+
+// RuleMatch is a policy rule along with the role it belongs to and the binding
+// that grants it to a principal.
+type RuleMatch struct {
+	BindingKind      string
+	BindingNamespace string
+	BindingName      string
+	RoleKind         string
+	RoleNamespace    string
+	RoleName         string
+	APIGroups        []string
+	Resources        []string
+	Verbs            []string
+	ResourceNames    []string
+	// Reason is one of the NearMiss constants for a near miss, and empty for a match.
+	Reason string
+	// Message describes the near miss to a person.
+	Message string
+}
@@ -0,0 +1,9 @@
+package models
+
+// AggregationBucket is the number of objects that share the same values for the fields
+// they were grouped by. Like AccessRule, it is not stored in the database.
+type AggregationBucket struct {
+	// Group holds the value of every field grouped by, by field name.
+	Group map[string]string
+	Count int64
+}
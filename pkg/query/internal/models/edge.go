@@ -0,0 +1,265 @@
+package models
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// ObjectReference identifies an object regardless of its API version, the way objects refer to each other.
+// Its string form, `<cluster>/<namespace>/<group>/<kind>/<name>`, is how edges refer to objects.
+type ObjectReference struct {
+	Cluster   string `json:"cluster"`
+	Namespace string `json:"namespace"`
+	APIGroup  string `json:"apiGroup"`
+	Kind      string `json:"kind"`
+	Name      string `json:"name"`
+}
+
+func (r ObjectReference) String() string {
+	return strings.Join([]string{r.Cluster, r.Namespace, r.APIGroup, r.Kind, r.Name}, "/")
+}
+
+// ParseObjectReference parses the string form of a reference. The fields are read from the right,
+// as the names of leaf clusters are of the form namespace/name.
+func ParseObjectReference(s string) (ObjectReference, error) {
+	parts := strings.Split(s, "/")
+	n := len(parts)
+	if n < 5 || parts[n-2] == "" || parts[n-1] == "" {
+		return ObjectReference{}, fmt.Errorf("invalid object reference %q", s)
+	}
+
+	cluster := strings.Join(parts[:n-4], "/")
+	if cluster == "" {
+		return ObjectReference{}, fmt.Errorf("invalid object reference %q", s)
+	}
+
+	return ObjectReference{
+		Cluster:   cluster,
+		Namespace: parts[n-4],
+		APIGroup:  parts[n-3],
+		Kind:      parts[n-2],
+		Name:      parts[n-1],
+	}, nil
+}
+
+// Reference returns the reference to the object.
+func (o Object) Reference() ObjectReference {
+	return ObjectReference{
+		Cluster:   o.Cluster,
+		Namespace: o.Namespace,
+		APIGroup:  o.APIGroup,
+		Kind:      o.Kind,
+		Name:      o.Name,
+	}
+}
+
+// EdgeRelation tells how the downstream object of an edge depends on its upstream object.
+type EdgeRelation string
+
+const (
+	// EdgeRelationSource is the source of a Kustomization or a HelmChart.
+	EdgeRelationSource EdgeRelation = "source"
+	// EdgeRelationChart is the HelmChart of a HelmRelease.
+	EdgeRelationChart EdgeRelation = "chart"
+	// EdgeRelationGenerated is an object generated by a GitOpsSet.
+	EdgeRelationGenerated EdgeRelation = "generated"
+	// EdgeRelationInventory is an object applied by a Kustomization.
+	EdgeRelationInventory EdgeRelation = "inventory"
+)
+
+// ObjectEdge relates two objects of a cluster: a failure of its upstream object affects its downstream object.
+// Edges are read from the objects that declare them, and are replaced whenever these change.
+type ObjectEdge struct {
+	ID      uint   `gorm:"primaryKey"`
+	Cluster string `json:"cluster" gorm:"type:text;index"`
+	// Owner is the ID of the object the edge was read from.
+	Owner      string       `json:"owner" gorm:"type:text;index"`
+	Upstream   string       `json:"upstream" gorm:"type:text;index"`
+	Downstream string       `json:"downstream" gorm:"type:text;index"`
+	Relation   EdgeRelation `json:"relation" gorm:"type:text"`
+}
+
+const (
+	fluxSourceGroup    = "source.toolkit.fluxcd.io"
+	fluxKustomizeGroup = "kustomize.toolkit.fluxcd.io"
+	fluxHelmGroup      = "helm.toolkit.fluxcd.io"
+	gitopsSetsGroup    = "templates.weave.works"
+)
+
+// edgeSource is the part of an object that edges are read from.
+type edgeSource struct {
+	Spec struct {
+		SourceRef *crossNamespaceReference `json:"sourceRef"`
+		Chart     *struct {
+			Spec struct {
+				SourceRef crossNamespaceReference `json:"sourceRef"`
+			} `json:"spec"`
+		} `json:"chart"`
+	} `json:"spec"`
+	Status struct {
+		HelmChart string `json:"helmChart"`
+		Inventory *struct {
+			Entries []struct {
+				ID string `json:"id"`
+			} `json:"entries"`
+		} `json:"inventory"`
+	} `json:"status"`
+}
+
+type crossNamespaceReference struct {
+	Kind      string `json:"kind"`
+	Name      string `json:"name"`
+	Namespace string `json:"namespace"`
+}
+
+// NewObjectEdges returns the edges that an object declares: the source of Kustomizations and HelmCharts,
+// the HelmChart of HelmReleases, and the objects in the inventory of Kustomizations and GitOpsSets.
+func NewObjectEdges(obj Object) ([]ObjectEdge, error) {
+	if len(obj.Unstructured) == 0 {
+		return nil, nil
+	}
+
+	var src edgeSource
+	if err := json.Unmarshal(obj.Content(), &src); err != nil {
+		return nil, fmt.Errorf("cannot read object %s: %w", obj.GetID(), err)
+	}
+
+	ref := obj.Reference()
+	edges := []ObjectEdge{}
+	add := func(upstream, downstream ObjectReference, relation EdgeRelation) {
+		edges = append(edges, ObjectEdge{
+			Cluster:    obj.Cluster,
+			Owner:      obj.GetID(),
+			Upstream:   upstream.String(),
+			Downstream: downstream.String(),
+			Relation:   relation,
+		})
+	}
+
+	switch {
+	case obj.APIGroup == fluxKustomizeGroup && obj.Kind == "Kustomization",
+		obj.APIGroup == fluxSourceGroup && obj.Kind == "HelmChart":
+		if s := src.Spec.SourceRef; s != nil && s.Kind != "" && s.Name != "" {
+			add(sourceReference(obj, *s), ref, EdgeRelationSource)
+		}
+	case obj.APIGroup == fluxHelmGroup && obj.Kind == "HelmRelease":
+		if chart, ok := helmChartReference(obj, src); ok {
+			add(chart, ref, EdgeRelationChart)
+		}
+	}
+
+	relation := EdgeRelationInventory
+	if obj.APIGroup == gitopsSetsGroup && obj.Kind == "GitOpsSet" {
+		relation = EdgeRelationGenerated
+	} else if obj.APIGroup != fluxKustomizeGroup {
+		return edges, nil
+	}
+
+	if src.Status.Inventory != nil {
+		for _, entry := range src.Status.Inventory.Entries {
+			entryRef, err := parseInventoryID(obj.Cluster, entry.ID)
+			if err != nil {
+				return nil, fmt.Errorf("cannot read inventory of object %s: %w", obj.GetID(), err)
+			}
+			add(ref, entryRef, relation)
+		}
+	}
+
+	return edges, nil
+}
+
+// sourceReference returns the Flux source referred to by an object. Sources are in the namespace of the object by default.
+func sourceReference(obj Object, s crossNamespaceReference) ObjectReference {
+	namespace := s.Namespace
+	if namespace == "" {
+		namespace = obj.Namespace
+	}
+
+	return ObjectReference{
+		Cluster:   obj.Cluster,
+		Namespace: namespace,
+		APIGroup:  fluxSourceGroup,
+		Kind:      s.Kind,
+		Name:      s.Name,
+	}
+}
+
+// helmChartReference returns the HelmChart of a HelmRelease. Until the chart is reported in its status,
+// it is the one that helm-controller creates: `<namespace>-<name>`, in the namespace of the chart source.
+func helmChartReference(obj Object, src edgeSource) (ObjectReference, bool) {
+	chart := ObjectReference{
+		Cluster:  obj.Cluster,
+		APIGroup: fluxSourceGroup,
+		Kind:     "HelmChart",
+	}
+
+	if namespace, name, ok := strings.Cut(src.Status.HelmChart, "/"); ok && namespace != "" && name != "" {
+		chart.Namespace = namespace
+		chart.Name = name
+		return chart, true
+	}
+
+	if src.Spec.Chart == nil {
+		return ObjectReference{}, false
+	}
+
+	chart.Namespace = src.Spec.Chart.Spec.SourceRef.Namespace
+	if chart.Namespace == "" {
+		chart.Namespace = obj.Namespace
+	}
+	chart.Name = obj.Namespace + "-" + obj.Name
+
+	return chart, true
+}
+
+// parseInventoryID parses the ID of an inventory entry, `<namespace>_<name>_<group>_<kind>`,
+// as written by Flux and GitOpsSets. Colons in names are written as double underscores.
+func parseInventoryID(cluster, id string) (ObjectReference, error) {
+	namespace, rest, ok := strings.Cut(id, "_")
+	if !ok {
+		return ObjectReference{}, fmt.Errorf("invalid inventory entry %q", id)
+	}
+
+	i := strings.LastIndex(rest, "_")
+	if i == -1 {
+		return ObjectReference{}, fmt.Errorf("invalid inventory entry %q", id)
+	}
+	kind := rest[i+1:]
+	rest = rest[:i]
+
+	i = strings.LastIndex(rest, "_")
+	if i == -1 {
+		return ObjectReference{}, fmt.Errorf("invalid inventory entry %q", id)
+	}
+	group := rest[i+1:]
+	name := strings.ReplaceAll(rest[:i], "__", ":")
+
+	if name == "" || kind == "" || strings.Contains(name, "_") {
+		return ObjectReference{}, fmt.Errorf("invalid inventory entry %q", id)
+	}
+
+	return ObjectReference{
+		Cluster:   cluster,
+		Namespace: namespace,
+		APIGroup:  group,
+		Kind:      kind,
+		Name:      name,
+	}, nil
+}
+
+// ObjectGraph is the neighbourhood of an object: the objects it depends on, and those that depend on it.
+type ObjectGraph struct {
+	Nodes []ObjectGraphNode `json:"nodes"`
+	Edges []ObjectEdge      `json:"edges"`
+}
+
+// ObjectGraphNode is an object of a graph. Objects that are not collected,
+// like the workloads applied by a Kustomization, are only known by their reference.
+type ObjectGraphNode struct {
+	Reference ObjectReference `json:"reference"`
+	// Object is the object, if it is collected.
+	Object *Object `json:"object,omitempty"`
+	// Depth is the number of edges between the node and the object the graph is for, negative upstream.
+	Depth int `json:"depth"`
+}
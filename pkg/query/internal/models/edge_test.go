@@ -0,0 +1,180 @@
+package models
+
+import (
+	"encoding/json"
+	"testing"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestNewObjectEdges(t *testing.T) {
+	object := func(group, kind, name string, content map[string]interface{}) Object {
+		raw, err := json.Marshal(content)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		return Object{
+			Cluster:      "management",
+			Namespace:    "flux-system",
+			APIGroup:     group,
+			APIVersion:   "v1",
+			Kind:         kind,
+			Name:         name,
+			Unstructured: raw,
+		}
+	}
+
+	tests := []struct {
+		name    string
+		object  Object
+		want    []ObjectEdge
+		wantErr string
+	}{
+		{
+			name: "kustomization source and inventory",
+			object: object("kustomize.toolkit.fluxcd.io", "Kustomization", "apps", map[string]interface{}{
+				"spec": map[string]interface{}{
+					"sourceRef": map[string]interface{}{"kind": "GitRepository", "name": "flux-system"},
+				},
+				"status": map[string]interface{}{
+					"inventory": map[string]interface{}{
+						"entries": []interface{}{
+							map[string]interface{}{"id": "podinfo_podinfo_apps_Deployment", "v": "v1"},
+							map[string]interface{}{"id": "_system__podinfo_rbac.authorization.k8s.io_ClusterRole", "v": "v1"},
+							map[string]interface{}{"id": "podinfo_config__ConfigMap", "v": "v1"},
+						},
+					},
+				},
+			}),
+			want: []ObjectEdge{
+				{Upstream: "management/flux-system/source.toolkit.fluxcd.io/GitRepository/flux-system", Relation: EdgeRelationSource},
+				{Downstream: "management/podinfo/apps/Deployment/podinfo", Relation: EdgeRelationInventory},
+				{Downstream: "management//rbac.authorization.k8s.io/ClusterRole/system:podinfo", Relation: EdgeRelationInventory},
+				{Downstream: "management/podinfo//ConfigMap/config", Relation: EdgeRelationInventory},
+			},
+		},
+		{
+			name: "helm release chart reported in its status",
+			object: object("helm.toolkit.fluxcd.io", "HelmRelease", "podinfo", map[string]interface{}{
+				"status": map[string]interface{}{"helmChart": "charts/flux-system-podinfo"},
+			}),
+			want: []ObjectEdge{
+				{Upstream: "management/charts/source.toolkit.fluxcd.io/HelmChart/flux-system-podinfo", Relation: EdgeRelationChart},
+			},
+		},
+		{
+			name: "helm release chart before it is reconciled",
+			object: object("helm.toolkit.fluxcd.io", "HelmRelease", "podinfo", map[string]interface{}{
+				"spec": map[string]interface{}{
+					"chart": map[string]interface{}{
+						"spec": map[string]interface{}{
+							"sourceRef": map[string]interface{}{"kind": "HelmRepository", "name": "podinfo", "namespace": "charts"},
+						},
+					},
+				},
+			}),
+			want: []ObjectEdge{
+				{Upstream: "management/charts/source.toolkit.fluxcd.io/HelmChart/flux-system-podinfo", Relation: EdgeRelationChart},
+			},
+		},
+		{
+			name: "helm chart source",
+			object: object("source.toolkit.fluxcd.io", "HelmChart", "flux-system-podinfo", map[string]interface{}{
+				"spec": map[string]interface{}{
+					"sourceRef": map[string]interface{}{"kind": "HelmRepository", "name": "podinfo"},
+				},
+			}),
+			want: []ObjectEdge{
+				{Upstream: "management/flux-system/source.toolkit.fluxcd.io/HelmRepository/podinfo", Relation: EdgeRelationSource},
+			},
+		},
+		{
+			name: "objects as collected",
+			object: object("source.toolkit.fluxcd.io", "HelmChart", "flux-system-podinfo", map[string]interface{}{
+				"Object": map[string]interface{}{
+					"spec": map[string]interface{}{
+						"sourceRef": map[string]interface{}{"kind": "HelmRepository", "name": "podinfo"},
+					},
+				},
+			}),
+			want: []ObjectEdge{
+				{Upstream: "management/flux-system/source.toolkit.fluxcd.io/HelmRepository/podinfo", Relation: EdgeRelationSource},
+			},
+		},
+		{
+			name: "gitopsset generated objects",
+			object: object("templates.weave.works", "GitOpsSet", "envs", map[string]interface{}{
+				"status": map[string]interface{}{
+					"inventory": map[string]interface{}{
+						"entries": []interface{}{
+							map[string]interface{}{"id": "flux-system_dev_kustomize.toolkit.fluxcd.io_Kustomization", "v": "v1"},
+						},
+					},
+				},
+			}),
+			want: []ObjectEdge{
+				{Downstream: "management/flux-system/kustomize.toolkit.fluxcd.io/Kustomization/dev", Relation: EdgeRelationGenerated},
+			},
+		},
+		{
+			name:   "objects of other kinds declare no edges",
+			object: object("source.toolkit.fluxcd.io", "GitRepository", "flux-system", map[string]interface{}{}),
+			want:   []ObjectEdge{},
+		},
+		{
+			name: "invalid inventory entry",
+			object: object("kustomize.toolkit.fluxcd.io", "Kustomization", "apps", map[string]interface{}{
+				"status": map[string]interface{}{
+					"inventory": map[string]interface{}{
+						"entries": []interface{}{map[string]interface{}{"id": "podinfo"}},
+					},
+				},
+			}),
+			wantErr: `invalid inventory entry "podinfo"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			edges, err := NewObjectEdges(tt.object)
+			if tt.wantErr != "" {
+				g.Expect(err).To(MatchError(ContainSubstring(tt.wantErr)))
+				return
+			}
+			g.Expect(err).NotTo(HaveOccurred())
+
+			// The object is the other end of every edge.
+			self := tt.object.Reference().String()
+			for i := range tt.want {
+				tt.want[i].Cluster = "management"
+				tt.want[i].Owner = tt.object.GetID()
+				if tt.want[i].Upstream == "" {
+					tt.want[i].Upstream = self
+				} else {
+					tt.want[i].Downstream = self
+				}
+			}
+			g.Expect(edges).To(Equal(tt.want))
+		})
+	}
+}
+
+func TestParseObjectReference(t *testing.T) {
+	g := NewWithT(t)
+
+	ref := ObjectReference{Cluster: "management", APIGroup: "rbac.authorization.k8s.io", Kind: "ClusterRole", Name: "system:podinfo"}
+	parsed, err := ParseObjectReference(ref.String())
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(parsed).To(Equal(ref))
+
+	leaf := ObjectReference{Cluster: "flux-system/leaf", Namespace: "flux-system", APIGroup: "source.toolkit.fluxcd.io", Kind: "GitRepository", Name: "flux-system"}
+	parsed, err = ParseObjectReference(leaf.String())
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(parsed).To(Equal(leaf))
+
+	_, err = ParseObjectReference("management/flux-system/GitRepository/flux-system")
+	g.Expect(err).To(HaveOccurred())
+}
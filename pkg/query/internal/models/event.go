@@ -0,0 +1,15 @@
+package models
+
+type ObjectEventType string
+
+const (
+	ObjectEventAdded    ObjectEventType = "added"
+	ObjectEventModified ObjectEventType = "modified"
+	ObjectEventDeleted  ObjectEventType = "deleted"
+)
+
+// ObjectEvent is a change to an object that has been written to the store.
+type ObjectEvent struct {
+	Type   ObjectEventType
+	Object Object
+}
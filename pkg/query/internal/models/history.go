@@ -0,0 +1,62 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// ObjectRevision is an entry in the history of an object, recorded when its status,
+// message or spec changes. Revisions are removed along with their object, and
+// expire with the retention policy of its kind.
+type ObjectRevision struct {
+	ID       uint   `gorm:"primaryKey"`
+	ObjectID string `json:"objectId" gorm:"type:text;index"`
+	Cluster  string `json:"cluster" gorm:"type:text;index"`
+	Status   string `json:"status" gorm:"type:text"`
+	Message  string `json:"message" gorm:"type:text"`
+	// Spec is the spec of the object. It is only recorded by the revisions that changed it.
+	Spec       json.RawMessage `json:"spec"`
+	RecordedAt time.Time       `json:"recordedAt" gorm:"index"`
+}
+
+// NewObjectRevision returns the revision that stored would need to record to become obj,
+// and whether there is anything to record. A nil stored means the object is new.
+func NewObjectRevision(stored *Object, obj Object, now time.Time) (ObjectRevision, bool) {
+	revision := ObjectRevision{
+		ObjectID:   obj.GetID(),
+		Cluster:    obj.Cluster,
+		Status:     obj.Status,
+		Message:    obj.Message,
+		RecordedAt: now,
+	}
+
+	spec := objectSpec(obj.Content())
+
+	if stored == nil {
+		revision.Spec = spec
+		return revision, true
+	}
+
+	specChanged := string(objectSpec(stored.Content())) != string(spec)
+	if specChanged {
+		revision.Spec = spec
+	}
+
+	changed := specChanged || stored.Status != obj.Status || stored.Message != obj.Message
+
+	return revision, changed
+}
+
+// objectSpec returns the spec of a raw object, or nil if it has none.
+func objectSpec(raw json.RawMessage) json.RawMessage {
+	if len(raw) == 0 {
+		return nil
+	}
+
+	fields := map[string]json.RawMessage{}
+	if err := json.Unmarshal(raw, &fields); err != nil {
+		return nil
+	}
+
+	return fields["spec"]
+}
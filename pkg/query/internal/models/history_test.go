@@ -0,0 +1,100 @@
+package models
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestNewObjectRevision(t *testing.T) {
+	now := time.Now()
+
+	stored := Object{
+		Cluster:      "cluster",
+		Name:         "name",
+		Namespace:    "namespace",
+		Kind:         "HelmRelease",
+		Status:       "Success",
+		Message:      "Release reconciliation succeeded",
+		Unstructured: json.RawMessage(`{"spec":{"interval":"1m"},"status":{"observedGeneration":1}}`),
+	}
+
+	tests := []struct {
+		name        string
+		stored      *Object
+		update      func(o *Object)
+		wantChanged bool
+		wantSpec    string
+	}{
+		{
+			name:        "new objects are recorded with their spec",
+			stored:      nil,
+			update:      func(o *Object) {},
+			wantChanged: true,
+			wantSpec:    `{"interval":"1m"}`,
+		},
+		{
+			name:        "unchanged objects are not recorded",
+			stored:      &stored,
+			update:      func(o *Object) {},
+			wantChanged: false,
+		},
+		{
+			name:   "changes outside the spec, status and message are not recorded",
+			stored: &stored,
+			update: func(o *Object) {
+				o.Unstructured = json.RawMessage(`{"spec":{"interval":"1m"},"status":{"observedGeneration":2}}`)
+			},
+			wantChanged: false,
+		},
+		{
+			name:   "status changes are recorded without the spec",
+			stored: &stored,
+			update: func(o *Object) {
+				o.Status = "Failed"
+			},
+			wantChanged: true,
+		},
+		{
+			name:   "spec changes are recorded with the spec",
+			stored: &stored,
+			update: func(o *Object) {
+				o.Unstructured = json.RawMessage(`{"spec":{"interval":"5m"}}`)
+			},
+			wantChanged: true,
+			wantSpec:    `{"interval":"5m"}`,
+		},
+		{
+			name:   "the spec is read from objects as collected",
+			stored: &stored,
+			update: func(o *Object) {
+				o.Unstructured = json.RawMessage(`{"Object":{"spec":{"interval":"5m"}}}`)
+			},
+			wantChanged: true,
+			wantSpec:    `{"interval":"5m"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			obj := stored
+			tt.update(&obj)
+
+			revision, changed := NewObjectRevision(tt.stored, obj, now)
+			g.Expect(changed).To(Equal(tt.wantChanged))
+			if !changed {
+				return
+			}
+
+			g.Expect(revision.ObjectID).To(Equal(obj.GetID()))
+			g.Expect(revision.Cluster).To(Equal(obj.Cluster))
+			g.Expect(revision.Status).To(Equal(obj.Status))
+			g.Expect(revision.RecordedAt).To(Equal(now))
+			g.Expect(string(revision.Spec)).To(Equal(tt.wantSpec))
+		})
+	}
+}
@@ -25,9 +25,10 @@ type Object struct {
 	Message             string                       `json:"message" gorm:"type:text"`
 	Category            configuration.ObjectCategory `json:"category" gorm:"type:text"`
 	KubernetesDeletedAt time.Time                    `json:"kubernetesDeletedAt"`
-	Unstructured        json.RawMessage              `json:"unstructured" gorm:"type:blob"`
+	Unstructured        json.RawMessage              `json:"unstructured"`
 	Tenant              string                       `json:"tenant" gorm:"type:text"`
 	Labels              map[string]string            `json:"labels" gorm:"-"`
+	Fields              map[string]string            `json:"fields" gorm:"serializer:json"`
 }
 
 func (o Object) Validate() error {
@@ -54,6 +55,23 @@ func (o Object) Validate() error {
 	return nil
 }
 
+// Content returns the Kubernetes object held by Unstructured. The collector stores
+// objects in their normalized form, which holds the object under "Object".
+func (o Object) Content() json.RawMessage {
+	if len(o.Unstructured) == 0 {
+		return nil
+	}
+
+	normalized := struct {
+		Object json.RawMessage `json:"Object"`
+	}{}
+	if err := json.Unmarshal(o.Unstructured, &normalized); err == nil && len(normalized.Object) > 0 {
+		return normalized.Object
+	}
+
+	return o.Unstructured
+}
+
 func (o *Object) GetID() string {
 	return fmt.Sprintf("%s/%s/%s/%s", o.Cluster, o.Namespace, o.GroupVersionKind(), o.Name)
 }
@@ -84,6 +102,7 @@ const (
 	TransactionTypeUpsert    TransactionType = "upsert"
 	TransactionTypeDelete    TransactionType = "delete"
 	TransactionTypeDeleteAll TransactionType = "deleteAll"
+	TransactionTypeSync      TransactionType = "sync"
 )
 
 //counterfeiter:generate . ObjectTransaction
@@ -94,6 +113,20 @@ type ObjectTransaction interface {
 	RetentionPolicy() configuration.RetentionPolicy
 }
 
+// SyncTransaction holds the IDs of the objects that exist in a cluster when a watcher lists them
+// after starting. It is used to remove the records of the objects deleted while the cluster was not watched.
+type SyncTransaction interface {
+	ObjectTransaction
+	// ObjectKinds returns the kinds listed.
+	ObjectKinds() []configuration.ObjectKind
+	// ObjectIDs returns the IDs of the objects listed.
+	ObjectIDs() map[string]bool
+	// ListedAt returns the time the objects were listed. Records updated after it are kept.
+	ListedAt() time.Time
+	// Done is called once the transaction has been processed.
+	Done(err error)
+}
+
 type NormalizedObject interface {
 	client.Object
 	// GetStatus returns the status of the object, as determined by the ObjectKind StatusFunc
@@ -104,6 +137,8 @@ type NormalizedObject interface {
 	GetCategory() (configuration.ObjectCategory, error)
 	// GetLabels returns the labels for the object
 	GetRelevantLabels() map[string]string
+	// GetSearchFields returns the search fields of the object, as determined by the ObjectKind SearchFields
+	GetSearchFields() (map[string]string, error)
 	// Raw returns the underlying client.Object
 	Raw() client.Object
 }
@@ -133,6 +168,10 @@ func (n defaultNormalizedObject) GetRelevantLabels() map[string]string {
 	return labels
 }
 
+func (n defaultNormalizedObject) GetSearchFields() (map[string]string, error) {
+	return n.config.SearchFieldValues(n.Object)
+}
+
 func (n defaultNormalizedObject) GetCategory() (configuration.ObjectCategory, error) {
 	if n.config.Category == "" {
 		return "", fmt.Errorf("category not found for object kind %q", n.config.Gvk.Kind)
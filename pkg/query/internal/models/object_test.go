@@ -116,3 +116,37 @@ func TestGetRelevantLabels(t *testing.T) {
 		})
 	}
 }
+
+func TestContent(t *testing.T) {
+	tests := []struct {
+		name         string
+		unstructured string
+		expected     string
+	}{
+		{
+			name:         "objects as collected",
+			unstructured: `{"Object":{"kind":"HelmRelease","spec":{"interval":"1m"}}}`,
+			expected:     `{"kind":"HelmRelease","spec":{"interval":"1m"}}`,
+		},
+		{
+			name:         "plain objects",
+			unstructured: `{"kind":"HelmRelease","spec":{"interval":"1m"}}`,
+			expected:     `{"kind":"HelmRelease","spec":{"interval":"1m"}}`,
+		},
+		{
+			name:         "no object",
+			unstructured: "",
+			expected:     "",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			obj := Object{Unstructured: []byte(tc.unstructured)}
+
+			if got := string(obj.Content()); got != tc.expected {
+				t.Errorf("expected %s, got %s", tc.expected, got)
+			}
+		})
+	}
+}
@@ -5,6 +5,8 @@ import (
 	"strings"
 
 	"gorm.io/gorm"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 )
 
 type Role struct {
@@ -15,6 +17,10 @@ type Role struct {
 	Kind        string       `gorm:"type:text"`
 	Name        string       `gorm:"type:text"`
 	PolicyRules []PolicyRule `gorm:"foreignKey:RoleID"`
+	// Labels are kept for cluster roles, so that aggregated cluster roles can select them.
+	Labels map[string]string `gorm:"serializer:json"`
+	// AggregationSelectors are the cluster role selectors of the aggregation rule of a cluster role.
+	AggregationSelectors []metav1.LabelSelector `gorm:"serializer:json"`
 }
 
 // PolicyRule is a rule that applies to a role.
@@ -65,13 +71,60 @@ func (o Role) Validate() error {
 		return fmt.Errorf("missing kind field")
 	}
 
-	if o.PolicyRules == nil || len(o.PolicyRules) == 0 {
+	// Aggregated cluster roles get their rules from the cluster roles they select.
+	if len(o.PolicyRules) == 0 && len(o.AggregationSelectors) == 0 {
 		return fmt.Errorf("missing policy rules")
 	}
 
 	return nil
 }
 
+// AggregatedRules returns the rules of the role, along with those of the cluster roles of the same
+// cluster that its aggregation rule selects, if any. This is what the cluster role aggregation
+// controller of Kubernetes sets the rules of the role to, so it does not matter whether the role
+// was collected before or after the controller got to it.
+func (o Role) AggregatedRules(roles []Role) ([]PolicyRule, error) {
+	if o.Kind != "ClusterRole" || len(o.AggregationSelectors) == 0 {
+		return o.PolicyRules, nil
+	}
+
+	rules := append([]PolicyRule{}, o.PolicyRules...)
+	for i := range o.AggregationSelectors {
+		selector, err := metav1.LabelSelectorAsSelector(&o.AggregationSelectors[i])
+		if err != nil {
+			return nil, fmt.Errorf("invalid aggregation rule of cluster role %s: %w", o.Name, err)
+		}
+
+		for _, role := range roles {
+			if role.Cluster != o.Cluster || role.Kind != "ClusterRole" || role.Name == o.Name {
+				continue
+			}
+			if !selector.Matches(labels.Set(role.Labels)) {
+				continue
+			}
+
+			for _, rule := range role.PolicyRules {
+				if !containsRule(rules, rule) {
+					rules = append(rules, rule)
+				}
+			}
+		}
+	}
+
+	return rules, nil
+}
+
+func containsRule(rules []PolicyRule, rule PolicyRule) bool {
+	for _, r := range rules {
+		if r.APIGroups == rule.APIGroups && r.Resources == rule.Resources &&
+			r.Verbs == rule.Verbs && r.ResourceNames == rule.ResourceNames {
+			return true
+		}
+	}
+
+	return false
+}
+
 // We join the arrays into a string because the database may not support arrays.
 // These helpers are used to ensure we always use the same separator.
 var separator = ","
@@ -0,0 +1,48 @@
+package models
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestRole_AggregatedRules(t *testing.T) {
+	g := NewWithT(t)
+
+	aggregateToView := map[string]string{"rbac.authorization.k8s.io/aggregate-to-view": "true"}
+	helmRule := PolicyRule{APIGroups: "helm.toolkit.fluxcd.io", Resources: "helmreleases", Verbs: "get,list,watch"}
+	kustomizeRule := PolicyRule{APIGroups: "kustomize.toolkit.fluxcd.io", Resources: "kustomizations", Verbs: "get,list,watch"}
+
+	view := Role{
+		Cluster: "management",
+		Kind:    "ClusterRole",
+		Name:    "view",
+		// Set by the aggregation controller already.
+		PolicyRules:          []PolicyRule{helmRule},
+		AggregationSelectors: []metav1.LabelSelector{{MatchLabels: aggregateToView}},
+	}
+
+	roles := []Role{
+		view,
+		{Cluster: "management", Kind: "ClusterRole", Name: "view-helm", Labels: aggregateToView, PolicyRules: []PolicyRule{helmRule}},
+		{Cluster: "management", Kind: "ClusterRole", Name: "view-kustomize", Labels: aggregateToView, PolicyRules: []PolicyRule{kustomizeRule}},
+		// Neither roles of other clusters nor roles without the label are aggregated.
+		{Cluster: "leaf", Kind: "ClusterRole", Name: "view-all", Labels: aggregateToView, PolicyRules: []PolicyRule{{APIGroups: "*", Resources: "*", Verbs: "*"}}},
+		{Cluster: "management", Kind: "ClusterRole", Name: "edit-helm", PolicyRules: []PolicyRule{{APIGroups: "helm.toolkit.fluxcd.io", Resources: "helmreleases", Verbs: "*"}}},
+	}
+
+	rules, err := view.AggregatedRules(roles)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(rules).To(Equal([]PolicyRule{helmRule, kustomizeRule}))
+
+	// Roles without an aggregation rule keep their rules.
+	rules, err = roles[1].AggregatedRules(roles)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(rules).To(Equal([]PolicyRule{helmRule}))
+
+	invalid := view
+	invalid.AggregationSelectors = []metav1.LabelSelector{{MatchExpressions: []metav1.LabelSelectorRequirement{{Key: "a", Operator: "Unknown"}}}}
+	_, err = invalid.AggregatedRules(roles)
+	g.Expect(err).To(HaveOccurred())
+}
@@ -0,0 +1,48 @@
+package models
+
+import (
+	"errors"
+	"time"
+)
+
+// SavedQuery is a named query that a user keeps to run again. It belongs to the user
+// that saved it, and can be shared with the members of a group.
+type SavedQuery struct {
+	ID    string `json:"id" gorm:"primaryKey;autoIncrement:false"`
+	Name  string `json:"name" gorm:"type:text"`
+	Owner string `json:"owner" gorm:"type:text;index"`
+	// SharedWith is the group whose members can see and run the query. It is private when empty.
+	SharedWith string    `json:"sharedWith" gorm:"type:text"`
+	Terms      string    `json:"terms" gorm:"type:text"`
+	Filters    []string  `json:"filters" gorm:"serializer:json"`
+	Query      string    `json:"query" gorm:"type:text"`
+	OrderBy    string    `json:"orderBy" gorm:"type:text"`
+	Descending bool      `json:"descending"`
+	Columns    []string  `json:"columns" gorm:"serializer:json"`
+	CreatedAt  time.Time `json:"createdAt"`
+}
+
+func (q SavedQuery) Validate() error {
+	if q.ID == "" {
+		return errors.New("missing id field")
+	}
+	if q.Name == "" {
+		return errors.New("missing name field")
+	}
+	if q.Owner == "" {
+		return errors.New("missing owner field")
+	}
+	return nil
+}
+
+func (q SavedQuery) GetTerms() string {
+	return q.Terms
+}
+
+func (q SavedQuery) GetFilters() []string {
+	return q.Filters
+}
+
+func (q SavedQuery) GetQuery() string {
+	return q.Query
+}
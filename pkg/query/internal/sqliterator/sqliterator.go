@@ -65,36 +65,37 @@ func (i *iterator) All() ([]models.Object, error) {
 	return objects, nil
 }
 
-func (i *iterator) Page(count int, offset int) ([]models.Object, error) {
-	var objects []models.Object
-
-	index := -1
-
-	for i.rows.Next() {
-		index++
-
-		if index < offset {
-			continue
-		}
+// Page reads rows until count of them were accepted by the filter.
+// Database rows cannot be resumed, so no page token is returned.
+func (i *iterator) Page(count int, filter func(models.Object) (bool, error)) ([]models.Object, string, error) {
+	objects := []models.Object{}
 
+	for (count == 0 || len(objects) < count) && i.rows.Next() {
 		var object models.Object
 
 		if err := i.result.ScanRows(i.rows, &object); err != nil {
-			return nil, fmt.Errorf("failed to scan rows: %w", err)
+			return nil, "", fmt.Errorf("failed to scan rows: %w", err)
 		}
 
-		objects = append(objects, object)
+		if filter != nil {
+			ok, err := filter(object)
+			if err != nil {
+				return nil, "", err
+			}
 
-		if index >= offset+count-1 {
-			break
+			if !ok {
+				continue
+			}
 		}
+
+		objects = append(objects, object)
 	}
 
 	if err := i.rows.Err(); err != nil {
-		return nil, fmt.Errorf("failed to get rows: %w", err)
+		return nil, "", fmt.Errorf("failed to get rows: %w", err)
 	}
 
-	return objects, nil
+	return objects, "", nil
 }
 
 func (i *iterator) Close() error {
@@ -0,0 +1,123 @@
+package objectkinds
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/go-logr/logr"
+	"github.com/weaveworks/weave-gitops-enterprise/pkg/query/configuration"
+	"github.com/weaveworks/weave-gitops-enterprise/pkg/query/configuration/v1alpha1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/rest"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// ApplyFunc changes the kinds that explorer collects.
+type ApplyFunc func(ctx context.Context, kinds []configuration.ObjectKind) error
+
+// Reconciler keeps the kinds that explorer collects in line with the ObjectKind resources
+// of the management cluster: the built-in kinds, plus the ones the resources declare.
+type Reconciler struct {
+	client  client.Client
+	builtin []configuration.ObjectKind
+	apply   ApplyFunc
+	log     logr.Logger
+	// applied identifies the resources the collected kinds were last worked out from.
+	applied string
+}
+
+func NewReconciler(c client.Client, builtin []configuration.ObjectKind, apply ApplyFunc, log logr.Logger) (*Reconciler, error) {
+	if c == nil {
+		return nil, fmt.Errorf("invalid client")
+	}
+	if apply == nil {
+		return nil, fmt.Errorf("apply func must be supplied")
+	}
+
+	return &Reconciler{
+		client:  c,
+		builtin: builtin,
+		apply:   apply,
+		log:     log.WithName("object-kinds-reconciler"),
+	}, nil
+}
+
+func (r *Reconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&v1alpha1.ObjectKind{}).
+		Complete(r)
+}
+
+// Reconcile works the collected kinds out from all the ObjectKind resources, whichever one changed.
+func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	list := &v1alpha1.ObjectKindList{}
+	if err := r.client.List(ctx, list); err != nil {
+		return ctrl.Result{}, fmt.Errorf("cannot list object kinds: %w", err)
+	}
+
+	custom := map[string]configuration.ObjectKind{}
+	versions := []string{}
+	for _, item := range list.Items {
+		kind, err := configuration.NewCustomObjectKind(item.Spec, r.log.WithValues("name", item.Name))
+		if err != nil {
+			// Invalid resources are skipped, so that they don't stop the others from being collected.
+			r.log.Error(err, "invalid object kind", "name", item.Name)
+			continue
+		}
+
+		custom[item.Name] = kind
+		versions = append(versions, fmt.Sprintf("%s@%d", item.Name, item.Generation))
+	}
+
+	sort.Strings(versions)
+	applied := strings.Join(versions, ",")
+	if applied == r.applied {
+		return ctrl.Result{}, nil
+	}
+
+	kinds, skipped := configuration.MergeObjectKinds(r.builtin, custom)
+	for _, name := range skipped {
+		r.log.Info("skipping object kind already collected", "name", name)
+	}
+
+	if err := r.apply(ctx, kinds); err != nil {
+		return ctrl.Result{}, fmt.Errorf("cannot apply object kinds: %w", err)
+	}
+
+	r.applied = applied
+	r.log.Info("object kinds applied", "custom", len(custom)-len(skipped))
+
+	return ctrl.Result{}, nil
+}
+
+// Start watches the ObjectKind resources of the cluster of cfg until the context is done.
+func Start(ctx context.Context, cfg *rest.Config, builtin []configuration.ObjectKind, apply ApplyFunc, log logr.Logger) error {
+	scheme := runtime.NewScheme()
+	if err := v1alpha1.AddToScheme(scheme); err != nil {
+		return fmt.Errorf("cannot create runtime scheme: %w", err)
+	}
+
+	mgr, err := ctrl.NewManager(cfg, ctrl.Options{
+		Scheme:             scheme,
+		Logger:             log,
+		LeaderElection:     false,
+		MetricsBindAddress: "0",
+	})
+	if err != nil {
+		return fmt.Errorf("cannot create controller manager: %w", err)
+	}
+
+	rec, err := NewReconciler(mgr.GetClient(), builtin, apply, log)
+	if err != nil {
+		return err
+	}
+
+	if err := rec.SetupWithManager(mgr); err != nil {
+		return fmt.Errorf("cannot setup reconciler: %w", err)
+	}
+
+	return mgr.Start(ctx)
+}
@@ -0,0 +1,79 @@
+package objectkinds
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-logr/logr"
+	. "github.com/onsi/gomega"
+	"github.com/weaveworks/weave-gitops-enterprise/pkg/query/configuration"
+	"github.com/weaveworks/weave-gitops-enterprise/pkg/query/configuration/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestReconcile(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+
+	scheme := runtime.NewScheme()
+	g.Expect(v1alpha1.AddToScheme(scheme)).To(Succeed())
+
+	rollouts := &v1alpha1.ObjectKind{
+		ObjectMeta: metav1.ObjectMeta{Name: "rollouts", Generation: 1},
+		Spec: v1alpha1.ObjectKindSpec{
+			Group:    "argoproj.io",
+			Version:  "v1alpha1",
+			Kind:     "Rollout",
+			Category: "automation",
+		},
+	}
+	invalid := &v1alpha1.ObjectKind{
+		ObjectMeta: metav1.ObjectMeta{Name: "invalid", Generation: 1},
+		Spec: v1alpha1.ObjectKindSpec{
+			Version:  "v1",
+			Kind:     "Claim",
+			Category: "automation",
+			Status: &v1alpha1.StatusExpression{
+				Expression: v1alpha1.Expression{CEL: "self.status.ready =="},
+			},
+		},
+	}
+
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(rollouts, invalid).Build()
+
+	applied := [][]configuration.ObjectKind{}
+	apply := func(ctx context.Context, kinds []configuration.ObjectKind) error {
+		applied = append(applied, kinds)
+		return nil
+	}
+
+	builtin := []configuration.ObjectKind{configuration.HelmReleaseObjectKind}
+
+	r, err := NewReconciler(c, builtin, apply, logr.Discard())
+	g.Expect(err).NotTo(HaveOccurred())
+
+	// The invalid resource is skipped.
+	_, err = r.Reconcile(ctx, ctrl.Request{})
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(applied).To(HaveLen(1))
+	g.Expect(applied[0]).To(HaveLen(2))
+	g.Expect(applied[0][0].Gvk).To(Equal(configuration.HelmReleaseObjectKind.Gvk))
+	g.Expect(applied[0][1].Gvk.Kind).To(Equal("Rollout"))
+
+	// Nothing changed, so the kinds are not applied again.
+	_, err = r.Reconcile(ctx, ctrl.Request{})
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(applied).To(HaveLen(1))
+
+	// Removing the resource goes back to the built-in kinds.
+	g.Expect(c.Delete(ctx, rollouts)).To(Succeed())
+
+	_, err = r.Reconcile(ctx, ctrl.Request{})
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(applied).To(HaveLen(2))
+	g.Expect(applied[1]).To(HaveLen(1))
+	g.Expect(applied[1][0].Gvk).To(Equal(configuration.HelmReleaseObjectKind.Gvk))
+}
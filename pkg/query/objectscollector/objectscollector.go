@@ -1,45 +1,71 @@
 package objectscollector
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
+	"sync"
 	"time"
 
 	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/client-go/rest"
 
 	"github.com/weaveworks/weave-gitops-enterprise/pkg/query/collector"
 	"github.com/weaveworks/weave-gitops-enterprise/pkg/query/collector/clusters"
 	"github.com/weaveworks/weave-gitops-enterprise/pkg/query/configuration"
+	"github.com/weaveworks/weave-gitops-enterprise/pkg/query/events"
 	"github.com/weaveworks/weave-gitops-enterprise/pkg/query/internal/models"
 	"github.com/weaveworks/weave-gitops-enterprise/pkg/query/store"
 	"github.com/weaveworks/weave-gitops/core/logger"
 )
 
-func NewObjectsCollector(w store.Store, idx store.IndexWriter, mgr clusters.Subscriber, sa collector.ImpersonateServiceAccount, kinds []configuration.ObjectKind, log logr.Logger) (collector.Collector, error) {
+// ObjectsCollector writes the objects of every cluster to the store and the index.
+// The kinds it collects can be changed while it runs.
+type ObjectsCollector struct {
+	collector.Collector
+
+	store     store.Store
+	idx       store.IndexWriter
+	publisher events.Publisher
+	log       logr.Logger
+
+	kindsMu sync.Mutex
+	kinds   []configuration.ObjectKind
+}
+
+// NewObjectsCollector creates a collector that writes the objects of every cluster to the store and the index.
+// The changes written are published to the publisher, if one is given.
+func NewObjectsCollector(w store.Store, idx store.IndexWriter, publisher events.Publisher, mgr clusters.Subscriber, sa collector.ImpersonateServiceAccount, kinds []configuration.ObjectKind, log logr.Logger) (*ObjectsCollector, error) {
 	incoming := make(chan []models.ObjectTransaction)
 	go func() {
 		for tx := range incoming {
-			if err := processRecords(tx, w, idx, log); err != nil {
+			if err := processRecords(tx, w, idx, publisher, log); err != nil {
 				log.Error(err, "could not process records")
 			}
 		}
 	}()
 
-	for _, k := range kinds {
-		if err := k.Validate(); err != nil {
-			return nil, fmt.Errorf("invalid object kind: %w", err)
-		}
+	if err := validateObjectKinds(kinds); err != nil {
+		return nil, err
+	}
+
+	oc := &ObjectsCollector{
+		store:     w,
+		idx:       idx,
+		publisher: publisher,
+		log:       log,
+		kinds:     kinds,
 	}
 
 	newWatcher := func(clusterName string, config *rest.Config) (collector.Starter, error) {
-		return collector.NewWatcher(clusterName, config, kinds, incoming, log)
+		return collector.NewSyncingWatcher(clusterName, config, oc.ObjectKinds(), incoming, log)
 	}
 
 	deleteWatcher := func(clusterName string) error {
 		tx := collector.NewDeleteAllTransaction(clusterName)
-		return processRecords([]models.ObjectTransaction{tx}, w, idx, log)
+		return processRecords([]models.ObjectTransaction{tx}, w, idx, publisher, log)
 	}
 
 	opts := collector.CollectorOpts{
@@ -55,15 +81,108 @@ func NewObjectsCollector(w store.Store, idx store.IndexWriter, mgr clusters.Subs
 	if err != nil {
 		return nil, fmt.Errorf("cannot create collector: %store", err)
 	}
+	oc.Collector = col
+
+	return oc, nil
+}
+
+func validateObjectKinds(kinds []configuration.ObjectKind) error {
+	for _, k := range kinds {
+		if err := k.Validate(); err != nil {
+			return fmt.Errorf("invalid object kind: %w", err)
+		}
+	}
+	return nil
+}
+
+// ObjectKinds returns the kinds collected.
+func (c *ObjectsCollector) ObjectKinds() []configuration.ObjectKind {
+	c.kindsMu.Lock()
+	defer c.kindsMu.Unlock()
+
+	return c.kinds
+}
+
+// SetObjectKinds changes the kinds collected. The watchers of every cluster are restarted
+// to watch the new kinds, and the objects of the kinds no longer collected are removed.
+func (c *ObjectsCollector) SetObjectKinds(ctx context.Context, kinds []configuration.ObjectKind) error {
+	if err := validateObjectKinds(kinds); err != nil {
+		return err
+	}
+
+	c.kindsMu.Lock()
+	previous := c.kinds
+	c.kinds = kinds
+	c.kindsMu.Unlock()
+
+	c.RestartWatchers()
+
+	kept := map[schema.GroupVersionKind]bool{}
+	for _, k := range kinds {
+		kept[k.Gvk] = true
+	}
+
+	removed := map[schema.GroupVersionKind]bool{}
+	for _, k := range previous {
+		if !kept[k.Gvk] {
+			removed[k.Gvk] = true
+		}
+	}
+
+	if len(removed) == 0 {
+		return nil
+	}
 
-	return col, nil
+	return removeObjectsOfKinds(ctx, c.store, c.idx, c.publisher, removed)
 }
 
-func processRecords(objectTransactions []models.ObjectTransaction, store store.Store, idx store.IndexWriter, log logr.Logger) error {
+// removeObjectsOfKinds removes the stored objects whose GroupVersionKind is one of kinds.
+func removeObjectsOfKinds(ctx context.Context, s store.Store, idx store.IndexWriter, publisher events.Publisher, kinds map[schema.GroupVersionKind]bool) error {
+	selector := store.ObjectSelector{}
+	for gvk := range kinds {
+		selector.Kinds = append(selector.Kinds, gvk)
+	}
+
+	iter, err := s.GetObjectsBySelector(ctx, selector)
+	if err != nil {
+		return fmt.Errorf("failed to get stored objects: %w", err)
+	}
+
+	remove, err := iter.All()
+	if err != nil {
+		return fmt.Errorf("failed to get stored objects: %w", err)
+	}
+
+	changes := []models.ObjectEvent{}
+	for _, obj := range remove {
+		changes = append(changes, models.ObjectEvent{Type: models.ObjectEventDeleted, Object: obj})
+	}
+
+	if len(remove) == 0 {
+		return nil
+	}
+
+	if err := s.DeleteObjects(ctx, remove); err != nil {
+		return fmt.Errorf("failed to delete objects: %w", err)
+	}
+
+	if err := idx.Remove(ctx, remove); err != nil {
+		return fmt.Errorf("failed to delete objects from index: %w", err)
+	}
+
+	if publisher != nil {
+		publisher.Publish(changes)
+	}
+
+	return nil
+}
+
+func processRecords(objectTransactions []models.ObjectTransaction, store store.Store, idx store.IndexWriter, publisher events.Publisher, log logr.Logger) error {
 	ctx := context.Background()
 	upsert := []models.Object{}
 	delete := []models.Object{}
 	deleteAll := []string{} //holds the cluster names to delete all resources
+	syncs := []models.SyncTransaction{}
 	debug := log.V(logger.LogLevelDebug)
 
 	for _, objTx := range objectTransactions {
@@ -72,6 +191,13 @@ func processRecords(objectTransactions []models.ObjectTransaction, store store.S
 			deleteAll = append(deleteAll, objTx.ClusterName())
 			continue
 		}
+		// Nor do sync txs
+		if objTx.TransactionType() == models.TransactionTypeSync {
+			if syncTx, ok := objTx.(models.SyncTransaction); ok {
+				syncs = append(syncs, syncTx)
+			}
+			continue
+		}
 		gvk := objTx.Object().GetObjectKind().GroupVersionKind()
 
 		o := objTx.Object()
@@ -107,6 +233,12 @@ func processRecords(objectTransactions []models.ObjectTransaction, store store.S
 			continue
 		}
 
+		// The object is kept without the search fields that cannot be read.
+		fields, err := o.GetSearchFields()
+		if err != nil {
+			log.Error(err, fmt.Sprintf("failed to get search fields from object %s/%s/%s", objTx.ClusterName(), o.GetNamespace(), o.GetName()))
+		}
+
 		object := models.Object{
 			Cluster:             objTx.ClusterName(),
 			Name:                objTx.Object().GetName(),
@@ -120,6 +252,7 @@ func processRecords(objectTransactions []models.ObjectTransaction, store store.S
 			KubernetesDeletedAt: modelTs,
 			Unstructured:        raw,
 			Labels:              o.GetRelevantLabels(),
+			Fields:              fields,
 		}
 
 		if objTx.TransactionType() == models.TransactionTypeDelete {
@@ -138,17 +271,66 @@ func processRecords(objectTransactions []models.ObjectTransaction, store store.S
 		upsert = append(upsert, object)
 	}
 
+	changes := []models.ObjectEvent{}
+
+	for _, syncTx := range syncs {
+		deleted, err := syncObjects(ctx, syncTx, store, idx)
+		syncTx.Done(err)
+		if err != nil {
+			return fmt.Errorf("failed to sync objects of cluster %q: %w", syncTx.ClusterName(), err)
+		}
+		changes = append(changes, deleted...)
+		debug.Info("objects synced", "cluster", syncTx.ClusterName(), "delete", len(deleted))
+	}
+
+	stored := map[string]models.Object{}
+	changed := upsert
 	if len(upsert) > 0 {
-		if err := store.StoreObjects(ctx, upsert); err != nil {
+		var err error
+		stored, err = storedObjects(ctx, store, upsert)
+		if err != nil {
+			return err
+		}
+
+		// Watchers reconcile every object when they start, so most of
+		// the objects of a cluster that is watched again are unchanged.
+		changed = changedObjects(stored, upsert)
+	}
+
+	if len(changed) > 0 {
+
+		if publisher != nil {
+			changes = append(changes, upsertEvents(stored, changed)...)
+		}
+
+		if err := store.StoreObjects(ctx, changed); err != nil {
 			return fmt.Errorf("failed to store objects: %w", err)
 		}
 
+		if err := store.StoreObjectRevisions(ctx, objectRevisions(stored, changed, time.Now())); err != nil {
+			return fmt.Errorf("failed to store object history: %w", err)
+		}
+
+		owners, edges := objectEdges(changed, log)
+		if err := store.StoreObjectEdges(ctx, owners, edges); err != nil {
+			return fmt.Errorf("failed to store object edges: %w", err)
+		}
+	}
+
+	// Unchanged objects are indexed all the same: the index is created anew when the
+	// process starts, while the store may be kept, and it holds the labels the store does not.
+	if len(upsert) > 0 {
 		if err := idx.Add(ctx, upsert); err != nil {
 			return fmt.Errorf("failed to index objects: %w", err)
 		}
 	}
 
 	if len(delete) > 0 {
+		for _, obj := range delete {
+			obj.ID = obj.GetID()
+			changes = append(changes, models.ObjectEvent{Type: models.ObjectEventDeleted, Object: obj})
+		}
+
 		if err := store.DeleteObjects(ctx, delete); err != nil {
 			return fmt.Errorf("failed to delete objects: %w", err)
 		}
@@ -159,6 +341,14 @@ func processRecords(objectTransactions []models.ObjectTransaction, store store.S
 	}
 
 	if len(deleteAll) > 0 {
+		if publisher != nil {
+			deleted, err := deleteAllEvents(ctx, store, deleteAll)
+			if err != nil {
+				return err
+			}
+			changes = append(changes, deleted...)
+		}
+
 		if err := store.DeleteAllObjects(ctx, deleteAll); err != nil {
 			return fmt.Errorf("failed to delete all objects: %w", err)
 		}
@@ -170,6 +360,213 @@ func processRecords(objectTransactions []models.ObjectTransaction, store store.S
 		}
 	}
 
-	debug.Info("objects processed", "upsert", len(upsert), "delete", len(delete), "deleteAll", len(deleteAll))
+	if publisher != nil {
+		publisher.Publish(changes)
+	}
+
+	debug.Info("objects processed", "upsert", len(upsert), "changed", len(changed), "delete", len(delete), "deleteAll", len(deleteAll))
 	return nil
 }
+
+// storedObjects returns the objects as they are in the store before they are upserted, by ID.
+func storedObjects(ctx context.Context, s store.StoreReader, upsert []models.Object) (map[string]models.Object, error) {
+	ids := []string{}
+	for _, obj := range upsert {
+		ids = append(ids, obj.GetID())
+	}
+
+	iter, err := s.GetObjects(ctx, ids, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get stored objects: %w", err)
+	}
+
+	all, err := iter.All()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get stored objects: %w", err)
+	}
+
+	result := map[string]models.Object{}
+	for _, obj := range all {
+		result[obj.ID] = obj
+	}
+
+	return result, nil
+}
+
+// changedObjects returns the objects to upsert that differ from their previous version,
+// which is the one stored, or the one upserted before in the same batch.
+func changedObjects(stored map[string]models.Object, upsert []models.Object) []models.Object {
+	previous := previousObjects(stored)
+
+	result := []models.Object{}
+	for _, obj := range upsert {
+		s, ok := previous[obj.GetID()]
+		previous[obj.GetID()] = obj
+		if ok && s.Status == obj.Status && s.Message == obj.Message && s.Category == obj.Category &&
+			s.KubernetesDeletedAt.Equal(obj.KubernetesDeletedAt) && bytes.Equal(s.Unstructured, obj.Unstructured) &&
+			equalFields(s.Fields, obj.Fields) {
+			continue
+		}
+		result = append(result, obj)
+	}
+
+	return result
+}
+
+// previousObjects returns a copy of the stored objects, for a batch to be compared with
+// as it is upserted. The same object can be updated more than once within a batch.
+func previousObjects(stored map[string]models.Object) map[string]models.Object {
+	result := make(map[string]models.Object, len(stored))
+	for id, obj := range stored {
+		result[id] = obj
+	}
+
+	return result
+}
+
+// equalFields tells whether two objects have the same search fields. The fields of
+// a kind can change while its objects do not.
+func equalFields(a, b map[string]string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	for k, v := range a {
+		if w, ok := b[k]; !ok || v != w {
+			return false
+		}
+	}
+
+	return true
+}
+
+// syncObjects deletes the stored objects of a cluster that were not listed by a sync transaction,
+// and returns the events for them. The objects of kinds with a retention policy are left for the
+// cleaner to remove when they expire, as are the objects stored after the listing.
+func syncObjects(ctx context.Context, syncTx models.SyncTransaction, s store.Store, idx store.IndexWriter) ([]models.ObjectEvent, error) {
+	selector := store.ObjectSelector{Clusters: []string{syncTx.ClusterName()}}
+	for _, k := range syncTx.ObjectKinds() {
+		if k.RetentionPolicy == configuration.NoRetentionPolicy {
+			selector.Kinds = append(selector.Kinds, k.Gvk)
+		}
+	}
+
+	changes := []models.ObjectEvent{}
+	if len(selector.Kinds) == 0 {
+		return changes, nil
+	}
+
+	iter, err := s.GetObjectsBySelector(ctx, selector)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get stored objects: %w", err)
+	}
+
+	all, err := iter.All()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get stored objects: %w", err)
+	}
+
+	ids := syncTx.ObjectIDs()
+	remove := []models.Object{}
+	for _, obj := range all {
+		if ids[obj.GetID()] || obj.UpdatedAt.After(syncTx.ListedAt()) {
+			continue
+		}
+		remove = append(remove, obj)
+		changes = append(changes, models.ObjectEvent{Type: models.ObjectEventDeleted, Object: obj})
+	}
+
+	if len(remove) == 0 {
+		return changes, nil
+	}
+
+	if err := s.DeleteObjects(ctx, remove); err != nil {
+		return nil, fmt.Errorf("failed to delete objects: %w", err)
+	}
+
+	if err := idx.Remove(ctx, remove); err != nil {
+		return nil, fmt.Errorf("failed to delete objects from index: %w", err)
+	}
+
+	return changes, nil
+}
+
+// upsertEvents tells apart the objects that are new to the store from the ones that are modified.
+func upsertEvents(stored map[string]models.Object, upsert []models.Object) []models.ObjectEvent {
+	previous := previousObjects(stored)
+
+	result := []models.ObjectEvent{}
+	for _, obj := range upsert {
+		obj.ID = obj.GetID()
+
+		eventType := models.ObjectEventAdded
+		if _, ok := previous[obj.ID]; ok {
+			eventType = models.ObjectEventModified
+		}
+		previous[obj.ID] = obj
+
+		result = append(result, models.ObjectEvent{Type: eventType, Object: obj})
+	}
+
+	return result
+}
+
+// objectRevisions returns the history to record for the objects whose status, message or spec changed,
+// in the order they changed. Objects updated more than once within a batch get a revision for each change.
+func objectRevisions(stored map[string]models.Object, upsert []models.Object, now time.Time) []models.ObjectRevision {
+	previous := previousObjects(stored)
+
+	result := []models.ObjectRevision{}
+	for _, obj := range upsert {
+		var prev *models.Object
+		if s, ok := previous[obj.GetID()]; ok {
+			prev = &s
+		}
+		previous[obj.GetID()] = obj
+
+		if revision, changed := models.NewObjectRevision(prev, obj, now); changed {
+			result = append(result, revision)
+		}
+	}
+
+	return result
+}
+
+// objectEdges returns the IDs of the objects and the edges read from them. Objects whose
+// edges cannot be read are left with no edges, rather than failing the whole batch.
+func objectEdges(upsert []models.Object, log logr.Logger) ([]string, []models.ObjectEdge) {
+	owners := []string{}
+	result := []models.ObjectEdge{}
+	for _, obj := range upsert {
+		owners = append(owners, obj.GetID())
+
+		edges, err := models.NewObjectEdges(obj)
+		if err != nil {
+			log.Error(err, "failed to read object edges", "object", obj.GetID())
+			continue
+		}
+		result = append(result, edges...)
+	}
+
+	return owners, result
+}
+
+// deleteAllEvents lists the stored objects of the clusters that are about to be removed.
+func deleteAllEvents(ctx context.Context, s store.StoreReader, clusters []string) ([]models.ObjectEvent, error) {
+	iter, err := s.GetObjectsBySelector(ctx, store.ObjectSelector{Clusters: clusters})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get stored objects: %w", err)
+	}
+
+	stored, err := iter.All()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get stored objects: %w", err)
+	}
+
+	result := []models.ObjectEvent{}
+	for _, obj := range stored {
+		result = append(result, models.ObjectEvent{Type: models.ObjectEventDeleted, Object: obj})
+	}
+
+	return result, nil
+}
@@ -1,6 +1,9 @@
 package objectscollector
 
 import (
+	"context"
+	"encoding/json"
+	"fmt"
 	"testing"
 	"time"
 
@@ -10,11 +13,15 @@ import (
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
 	. "github.com/onsi/gomega"
+	"github.com/weaveworks/weave-gitops-enterprise/pkg/query/collector"
 	"github.com/weaveworks/weave-gitops-enterprise/pkg/query/configuration"
+	"github.com/weaveworks/weave-gitops-enterprise/pkg/query/events"
 	"github.com/weaveworks/weave-gitops-enterprise/pkg/query/internal/models"
+	"github.com/weaveworks/weave-gitops-enterprise/pkg/query/store"
 	"github.com/weaveworks/weave-gitops-enterprise/pkg/query/store/storefakes"
 	"github.com/weaveworks/weave-gitops-enterprise/pkg/query/utils/testutils"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 )
 
 func TestObjectsCollector_defaultProcessRecords(t *testing.T) {
@@ -65,9 +72,10 @@ func TestObjectsCollector_defaultProcessRecords(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			fakeStore := &storefakes.FakeStore{}
+			fakeStore.GetObjectsReturns(&storefakes.FakeIterator{}, nil)
 			fakeIndex := &storefakes.FakeIndexWriter{}
 
-			err := processRecords(tt.objectRecords, fakeStore, fakeIndex, log)
+			err := processRecords(tt.objectRecords, fakeStore, fakeIndex, nil, log)
 			if tt.errPattern != "" {
 				g.Expect(err).To(MatchError(MatchRegexp(tt.errPattern)))
 				return
@@ -109,7 +117,7 @@ func TestObjectsCollector_removeAll(t *testing.T) {
 		},
 	}
 
-	err := processRecords(tx, fakeStore, fakeIndex, log)
+	err := processRecords(tx, fakeStore, fakeIndex, nil, log)
 	g.Expect(err).To(BeNil())
 
 	g.Expect(fakeStore.DeleteAllObjectsCallCount()).To(Equal(1))
@@ -119,10 +127,343 @@ func TestObjectsCollector_removeAll(t *testing.T) {
 
 }
 
+func TestObjectsCollector_publishesEvents(t *testing.T) {
+	g := NewWithT(t)
+	log := logr.Discard()
+	fakeStore := &storefakes.FakeStore{}
+	fakeIndex := &storefakes.FakeIndexWriter{}
+
+	clusterName := "anyCluster"
+
+	existing := models.Object{Cluster: clusterName, Name: "existingHelmRelease", Namespace: "namespace", Kind: "HelmRelease", APIVersion: "v2beta1"}
+	existing.ID = existing.GetID()
+
+	// The modified release is already stored, the created one is not.
+	storedIter := &storefakes.FakeIterator{}
+	storedIter.AllReturns([]models.Object{existing}, nil)
+	fakeStore.GetObjectsReturns(storedIter, nil)
+
+	// The removed cluster holds one object.
+	removed := models.Object{Cluster: "removedCluster", Name: "removedHelmRelease", Namespace: "namespace", Kind: "HelmRelease"}
+	removedIter := &storefakes.FakeIterator{}
+	removedIter.AllReturns([]models.Object{removed}, nil)
+	fakeStore.GetObjectsBySelectorReturns(removedIter, nil)
+
+	tx := []models.ObjectTransaction{
+		testutils.NewObjectTransaction(clusterName, testutils.NewHelmRelease("createdHelmRelease", "namespace"), models.TransactionTypeUpsert),
+		testutils.NewObjectTransaction(clusterName, testutils.NewHelmRelease("existingHelmRelease", "namespace"), models.TransactionTypeUpsert),
+		testutils.NewObjectTransaction(clusterName, testutils.NewHelmRelease("deletedHelmRelease", "namespace"), models.TransactionTypeDelete),
+		testutils.NewObjectTransaction("removedCluster", nil, models.TransactionTypeDeleteAll),
+	}
+
+	broadcaster := events.NewBroadcaster(log)
+	sub := broadcaster.Subscribe()
+	defer sub.Cancel()
+
+	g.Expect(processRecords(tx, fakeStore, fakeIndex, broadcaster, log)).To(Succeed())
+
+	var published []models.ObjectEvent
+	g.Expect(sub.Events()).To(Receive(&published))
+
+	got := []string{}
+	for _, e := range published {
+		got = append(got, fmt.Sprintf("%s %s", e.Type, e.Object.Name))
+	}
+
+	g.Expect(got).To(Equal([]string{
+		"added createdHelmRelease",
+		"modified existingHelmRelease",
+		"deleted deletedHelmRelease",
+		"deleted removedHelmRelease",
+	}))
+
+	_, selector := fakeStore.GetObjectsBySelectorArgsForCall(0)
+	g.Expect(selector).To(Equal(store.ObjectSelector{Clusters: []string{"removedCluster"}}))
+}
+
+func TestObjectsCollector_recordsHistory(t *testing.T) {
+	g := NewWithT(t)
+	log := logr.Discard()
+	fakeStore := &storefakes.FakeStore{}
+	fakeIndex := &storefakes.FakeIndexWriter{}
+
+	clusterName := "anyCluster"
+
+	tx := []models.ObjectTransaction{
+		testutils.NewObjectTransaction(clusterName, testutils.NewHelmRelease("createdHelmRelease", "namespace"), models.TransactionTypeUpsert),
+		testutils.NewObjectTransaction(clusterName, testutils.NewHelmRelease("unchangedHelmRelease", "namespace"), models.TransactionTypeUpsert),
+		testutils.NewObjectTransaction(clusterName, testutils.NewHelmRelease("failedHelmRelease", "namespace"), models.TransactionTypeUpsert),
+	}
+
+	// Store the objects as collected, except for the status of the release that changed since.
+	fakeStore.GetObjectsReturns(&storefakes.FakeIterator{}, nil)
+	g.Expect(processRecords(tx[1:], fakeStore, fakeIndex, nil, log)).To(Succeed())
+	_, stored := fakeStore.StoreObjectsArgsForCall(0)
+	for i := range stored {
+		stored[i].ID = stored[i].GetID()
+	}
+	stored[1].Status = "Progressing"
+
+	storedIter := &storefakes.FakeIterator{}
+	storedIter.AllReturns(stored, nil)
+	fakeStore.GetObjectsReturns(storedIter, nil)
+
+	g.Expect(processRecords(tx, fakeStore, fakeIndex, nil, log)).To(Succeed())
+
+	g.Expect(fakeStore.StoreObjectRevisionsCallCount()).To(Equal(2))
+	_, revisions := fakeStore.StoreObjectRevisionsArgsForCall(1)
+
+	recorded := []string{}
+	for _, r := range revisions {
+		recorded = append(recorded, r.ObjectID)
+	}
+
+	g.Expect(recorded).To(Equal([]string{
+		"anyCluster/namespace//v2beta1/HelmRelease/createdHelmRelease",
+		"anyCluster/namespace//v2beta1/HelmRelease/failedHelmRelease",
+	}))
+}
+
+func TestObjectsCollector_recordsHistoryOfObjectsUpdatedInABatch(t *testing.T) {
+	g := NewWithT(t)
+	log := logr.Discard()
+	fakeStore := &storefakes.FakeStore{}
+	fakeIndex := &storefakes.FakeIndexWriter{}
+	fakeStore.GetObjectsReturns(&storefakes.FakeIterator{}, nil)
+
+	clusterName := "anyCluster"
+
+	release := func(version string) models.ObjectTransaction {
+		return testutils.NewObjectTransaction(clusterName, testutils.NewHelmRelease("helmRelease", "namespace", func(hr *v2beta1.HelmRelease) {
+			hr.Spec.Chart.Spec.Version = version
+		}), models.TransactionTypeUpsert)
+	}
+
+	// The release is updated twice, then back to its first version, before the batch is processed.
+	tx := []models.ObjectTransaction{release("1.0.0"), release("2.0.0"), release("2.0.0"), release("1.0.0")}
+
+	g.Expect(processRecords(tx, fakeStore, fakeIndex, nil, log)).To(Succeed())
+
+	g.Expect(fakeStore.StoreObjectRevisionsCallCount()).To(Equal(1))
+	_, revisions := fakeStore.StoreObjectRevisionsArgsForCall(0)
+
+	versions := []string{}
+	for _, r := range revisions {
+		spec := struct {
+			Chart struct {
+				Spec struct {
+					Version string `json:"version"`
+				} `json:"spec"`
+			} `json:"chart"`
+		}{}
+		g.Expect(json.Unmarshal(r.Spec, &spec)).To(Succeed())
+		versions = append(versions, spec.Chart.Spec.Version)
+	}
+	// Each change is recorded once, in the order it was made.
+	g.Expect(versions).To(Equal([]string{"1.0.0", "2.0.0", "1.0.0"}))
+
+	// The last version of the release is the one stored.
+	_, upserted := fakeStore.StoreObjectsArgsForCall(0)
+	g.Expect(upserted).To(HaveLen(3))
+	g.Expect(string(upserted[2].Content())).To(ContainSubstring(`"version":"1.0.0"`))
+}
+
+func TestObjectsCollector_skipsUnchangedObjects(t *testing.T) {
+	g := NewWithT(t)
+	log := logr.Discard()
+	fakeStore := &storefakes.FakeStore{}
+	fakeIndex := &storefakes.FakeIndexWriter{}
+
+	clusterName := "anyCluster"
+
+	tx := []models.ObjectTransaction{
+		testutils.NewObjectTransaction(clusterName, testutils.NewHelmRelease("unchangedHelmRelease", "namespace"), models.TransactionTypeUpsert),
+		testutils.NewObjectTransaction(clusterName, testutils.NewHelmRelease("failedHelmRelease", "namespace"), models.TransactionTypeUpsert),
+	}
+
+	fakeStore.GetObjectsReturns(&storefakes.FakeIterator{}, nil)
+	g.Expect(processRecords(tx, fakeStore, fakeIndex, nil, log)).To(Succeed())
+	_, stored := fakeStore.StoreObjectsArgsForCall(0)
+	for i := range stored {
+		stored[i].ID = stored[i].GetID()
+	}
+	stored[1].Status = "Progressing"
+
+	storedIter := &storefakes.FakeIterator{}
+	storedIter.AllReturns(stored, nil)
+	fakeStore.GetObjectsReturns(storedIter, nil)
+
+	// The objects are reconciled again, as when a cluster is watched again.
+	g.Expect(processRecords(tx, fakeStore, fakeIndex, nil, log)).To(Succeed())
+
+	g.Expect(fakeStore.StoreObjectsCallCount()).To(Equal(2))
+	_, upserted := fakeStore.StoreObjectsArgsForCall(1)
+	g.Expect(upserted).To(HaveLen(1))
+	g.Expect(upserted[0].Name).To(Equal("failedHelmRelease"))
+
+	// Every object is indexed, as the index does not outlive the process like the store may.
+	g.Expect(fakeIndex.AddCallCount()).To(Equal(2))
+	_, indexed := fakeIndex.AddArgsForCall(1)
+	g.Expect(indexed).To(HaveLen(2))
+
+	// Nothing is written to the store when no object changed.
+	g.Expect(processRecords(tx[:1], fakeStore, fakeIndex, nil, log)).To(Succeed())
+	g.Expect(fakeStore.StoreObjectsCallCount()).To(Equal(2))
+	g.Expect(fakeStore.StoreObjectRevisionsCallCount()).To(Equal(2))
+	g.Expect(fakeIndex.AddCallCount()).To(Equal(3))
+	_, indexed = fakeIndex.AddArgsForCall(2)
+	g.Expect(indexed).To(HaveLen(1))
+	g.Expect(indexed[0].Labels).To(Equal(stored[0].Labels))
+}
+
+func TestObjectsCollector_searchFields(t *testing.T) {
+	g := NewWithT(t)
+	log := logr.Discard()
+	fakeStore := &storefakes.FakeStore{}
+	fakeIndex := &storefakes.FakeIndexWriter{}
+
+	tx := []models.ObjectTransaction{
+		testutils.NewObjectTransaction("anyCluster", testutils.NewHelmRelease("ingress", "namespace", func(hr *v2beta1.HelmRelease) {
+			hr.Spec.Chart.Spec.Chart = "ingress-nginx"
+		}), models.TransactionTypeUpsert),
+	}
+
+	fakeStore.GetObjectsReturns(&storefakes.FakeIterator{}, nil)
+	g.Expect(processRecords(tx, fakeStore, fakeIndex, nil, log)).To(Succeed())
+	_, stored := fakeStore.StoreObjectsArgsForCall(0)
+	g.Expect(stored).To(HaveLen(1))
+	g.Expect(stored[0].Fields).To(Equal(map[string]string{"chart": "ingress-nginx"}))
+
+	_, indexed := fakeIndex.AddArgsForCall(0)
+	g.Expect(indexed[0].Fields).To(Equal(stored[0].Fields))
+
+	// Objects stored before their kind had search fields are written again.
+	stored[0].ID = stored[0].GetID()
+	stored[0].Fields = nil
+	storedIter := &storefakes.FakeIterator{}
+	storedIter.AllReturns(stored, nil)
+	fakeStore.GetObjectsReturns(storedIter, nil)
+
+	g.Expect(processRecords(tx, fakeStore, fakeIndex, nil, log)).To(Succeed())
+	g.Expect(fakeStore.StoreObjectsCallCount()).To(Equal(2))
+}
+
+func TestObjectsCollector_searchFieldsThatCannotBeRead(t *testing.T) {
+	g := NewWithT(t)
+	log := logr.Discard()
+	fakeStore := &storefakes.FakeStore{}
+	fakeIndex := &storefakes.FakeIndexWriter{}
+
+	defer func(fields map[string]string) {
+		configuration.HelmReleaseObjectKind.SearchFields = fields
+	}(configuration.HelmReleaseObjectKind.SearchFields)
+	configuration.HelmReleaseObjectKind.SearchFields = map[string]string{
+		"chart":  "{.spec.chart.spec.chart}",
+		"broken": "{.spec.chart.spec.chart[0]}",
+	}
+
+	ingress := testutils.NewHelmRelease("ingress", "namespace", func(hr *v2beta1.HelmRelease) {
+		hr.Spec.Chart.Spec.Chart = "ingress-nginx"
+	})
+
+	tx := []models.ObjectTransaction{
+		testutils.NewObjectTransaction("anyCluster", ingress, models.TransactionTypeUpsert),
+		testutils.NewObjectTransaction("anyCluster", testutils.NewHelmRelease("removed", "namespace"), models.TransactionTypeDelete),
+	}
+
+	fakeStore.GetObjectsReturns(&storefakes.FakeIterator{}, nil)
+	g.Expect(processRecords(tx, fakeStore, fakeIndex, nil, log)).To(Succeed())
+
+	// The objects are kept without the fields that cannot be read.
+	_, stored := fakeStore.StoreObjectsArgsForCall(0)
+	g.Expect(stored).To(HaveLen(1))
+	g.Expect(stored[0].Fields).To(Equal(map[string]string{"chart": "ingress-nginx"}))
+
+	g.Expect(fakeStore.DeleteObjectsCallCount()).To(Equal(1))
+	_, deleted := fakeStore.DeleteObjectsArgsForCall(0)
+	g.Expect(deleted).To(HaveLen(1))
+	g.Expect(deleted[0].Name).To(Equal("removed"))
+}
+
+func TestObjectsCollector_syncsCluster(t *testing.T) {
+	g := NewWithT(t)
+	log := logr.Discard()
+	fakeStore := &storefakes.FakeStore{}
+	fakeIndex := &storefakes.FakeIndexWriter{}
+
+	clusterName := "anyCluster"
+	listedAt := time.Now()
+
+	newObject := func(cluster, name string, kind configuration.ObjectKind, updatedAt time.Time) models.Object {
+		obj := models.Object{
+			Cluster:    cluster,
+			Namespace:  "namespace",
+			APIGroup:   kind.Gvk.Group,
+			APIVersion: kind.Gvk.Version,
+			Kind:       kind.Gvk.Kind,
+			Name:       name,
+		}
+		obj.ID = obj.GetID()
+		obj.UpdatedAt = updatedAt
+		return obj
+	}
+
+	listed := newObject(clusterName, "listedHelmRelease", configuration.HelmReleaseObjectKind, listedAt.Add(-time.Hour))
+	stale := newObject(clusterName, "staleHelmRelease", configuration.HelmReleaseObjectKind, listedAt.Add(-time.Hour))
+	storedAfterListing := newObject(clusterName, "newHelmRelease", configuration.HelmReleaseObjectKind, listedAt.Add(time.Second))
+
+	// The store only returns the objects of the cluster and kinds selected.
+	selectedIter := &storefakes.FakeIterator{}
+	selectedIter.AllReturns([]models.Object{listed, stale, storedAfterListing}, nil)
+	fakeStore.GetObjectsBySelectorReturns(selectedIter, nil)
+
+	var (
+		done   bool
+		result error
+	)
+	syncTx := collector.NewSyncTransaction(clusterName,
+		[]configuration.ObjectKind{configuration.HelmReleaseObjectKind, configuration.PolicyAgentAuditEventObjectKind},
+		map[string]bool{listed.ID: true},
+		listedAt,
+		func(err error) {
+			done = true
+			result = err
+		})
+
+	broadcaster := events.NewBroadcaster(log)
+	sub := broadcaster.Subscribe()
+	defer sub.Cancel()
+
+	g.Expect(processRecords([]models.ObjectTransaction{syncTx}, fakeStore, fakeIndex, broadcaster, log)).To(Succeed())
+
+	g.Expect(done).To(BeTrue())
+	g.Expect(result).NotTo(HaveOccurred())
+
+	// Only the stale object is removed; nothing is removed for the whole cluster.
+	g.Expect(fakeStore.DeleteObjectsCallCount()).To(Equal(1))
+	_, deleted := fakeStore.DeleteObjectsArgsForCall(0)
+	g.Expect(deleted).To(Equal([]models.Object{stale}))
+	g.Expect(fakeIndex.RemoveCallCount()).To(Equal(1))
+	g.Expect(fakeStore.DeleteAllObjectsCallCount()).To(BeZero())
+	g.Expect(fakeStore.StoreObjectsCallCount()).To(BeZero())
+
+	// Kinds with a retention policy are left for the cleaner.
+	_, selector := fakeStore.GetObjectsBySelectorArgsForCall(0)
+	g.Expect(selector).To(Equal(store.ObjectSelector{
+		Clusters: []string{clusterName},
+		Kinds:    []schema.GroupVersionKind{configuration.HelmReleaseObjectKind.Gvk},
+	}))
+
+	var published []models.ObjectEvent
+	g.Expect(sub.Events()).To(Receive(&published))
+	g.Expect(published).To(Equal([]models.ObjectEvent{{Type: models.ObjectEventDeleted, Object: stale}}))
+}
+
 func TestObjectsCollector_retention(t *testing.T) {
 	g := NewWithT(t)
 	log := logr.Discard()
 	fakeStore := &storefakes.FakeStore{}
+	fakeStore.GetObjectsReturns(&storefakes.FakeIterator{}, nil)
 	fakeIndex := &storefakes.FakeIndexWriter{}
 
 	//setup data
@@ -172,7 +513,7 @@ func TestObjectsCollector_retention(t *testing.T) {
 		},
 	}
 
-	err := processRecords(tx, fakeStore, fakeIndex, log)
+	err := processRecords(tx, fakeStore, fakeIndex, nil, log)
 	g.Expect(err).To(BeNil())
 
 	// Remove the expired object.
@@ -209,3 +550,36 @@ func (t *transaction) TransactionType() models.TransactionType {
 func (t *transaction) RetentionPolicy() configuration.RetentionPolicy {
 	return t.retentionPolicy
 }
+
+func TestObjectsCollector_removeObjectsOfKinds(t *testing.T) {
+	g := NewWithT(t)
+	fakeStore := &storefakes.FakeStore{}
+	fakeIndex := &storefakes.FakeIndexWriter{}
+
+	gvk := configuration.HelmReleaseObjectKind.Gvk
+
+	release := models.Object{Cluster: "anyCluster", Name: "release", Namespace: "namespace", APIGroup: gvk.Group, APIVersion: gvk.Version, Kind: gvk.Kind}
+
+	selectedIter := &storefakes.FakeIterator{}
+	selectedIter.AllReturns([]models.Object{release}, nil)
+	fakeStore.GetObjectsBySelectorReturns(selectedIter, nil)
+
+	broadcaster := events.NewBroadcaster(logr.Discard())
+	sub := broadcaster.Subscribe()
+	defer sub.Cancel()
+
+	g.Expect(removeObjectsOfKinds(context.Background(), fakeStore, fakeIndex, broadcaster, map[schema.GroupVersionKind]bool{gvk: true})).To(Succeed())
+
+	_, selector := fakeStore.GetObjectsBySelectorArgsForCall(0)
+	g.Expect(selector).To(Equal(store.ObjectSelector{Kinds: []schema.GroupVersionKind{gvk}}))
+
+	_, deleted := fakeStore.DeleteObjectsArgsForCall(0)
+	g.Expect(deleted).To(Equal([]models.Object{release}))
+
+	_, removed := fakeIndex.RemoveArgsForCall(0)
+	g.Expect(removed).To(Equal([]models.Object{release}))
+
+	var published []models.ObjectEvent
+	g.Expect(sub.Events()).To(Receive(&published))
+	g.Expect(published).To(Equal([]models.ObjectEvent{{Type: models.ObjectEventDeleted, Object: release}}))
+}
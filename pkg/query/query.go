@@ -2,12 +2,14 @@ package query
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
 	"github.com/weaveworks/weave-gitops/core/logger"
 
 	"github.com/go-logr/logr"
 	"github.com/weaveworks/weave-gitops-enterprise/pkg/query/configuration"
+	"github.com/weaveworks/weave-gitops-enterprise/pkg/query/events"
 	"github.com/weaveworks/weave-gitops-enterprise/pkg/query/internal/models"
 	store "github.com/weaveworks/weave-gitops-enterprise/pkg/query/store"
 	"github.com/weaveworks/weave-gitops/pkg/server/auth"
@@ -15,11 +17,51 @@ import (
 
 // QueryService is an all-in-one service that handles managing a collector, writing to the store, and responding to queries
 type QueryService interface {
-	RunQuery(ctx context.Context, q store.Query, opts store.QueryOption) ([]models.Object, error)
-	ListFacets(ctx context.Context, cat configuration.ObjectCategory) (store.Facets, error)
+	// RunQuery returns the objects matching the query that the principal of the context is allowed to see,
+	// and a token to fetch the next page with, which is empty on the last page.
+	RunQuery(ctx context.Context, q store.Query, opts store.QueryOption) ([]models.Object, string, error)
+	// WatchQuery sends the changes to the objects matching the query that the principal of the context
+	// is allowed to see, until the context is done or send fails.
+	WatchQuery(ctx context.Context, q store.Query, send func(models.ObjectEvent) error) error
+	// Aggregate counts the objects matching the query that the principal of the context is allowed to see,
+	// grouped by the values of the given fields. ErrInvalidGroupBy is returned for unsupported fields.
+	Aggregate(ctx context.Context, q store.Query, groupBy []string) ([]models.AggregationBucket, error)
+	// Export sends the values of the columns of every object matching the query that the principal of the context
+	// is allowed to see, a batch of rows at a time, until there are none left or send fails. Unlike RunQuery, results
+	// are not limited to a page. DefaultExportColumns are exported if no columns are given, and ErrInvalidColumn
+	// is returned for unsupported columns.
+	Export(ctx context.Context, q store.Query, columns []string, send func(rows [][]string) error) error
+	// GetObjectHistory returns the revisions of an object that the principal of the context is allowed to see,
+	// oldest first. ErrObjectNotFound is returned for objects that do not exist or that the principal cannot see.
+	GetObjectHistory(ctx context.Context, id string) ([]models.ObjectRevision, error)
+	// GetObjectGraph returns the objects related to the object with the given ID, up to depth edges away in the
+	// given direction, leaving out those the principal of the context is not allowed to see. ErrObjectNotFound is
+	// returned for objects that do not exist or that the principal cannot see, and ErrInvalidGraphRequest for
+	// unsupported depths and directions.
+	GetObjectGraph(ctx context.Context, id string, depth int, direction GraphDirection) (models.ObjectGraph, error)
+	// ListFacets returns the values of the common fields of the objects, and of the labels of the given kinds
+	// that are in the category.
+	ListFacets(ctx context.Context, cat configuration.ObjectCategory, kinds []configuration.ObjectKind) (store.Facets, error)
 	GetAccessRules(ctx context.Context) ([]models.AccessRule, error)
+	// ExplainAccess tells whether the subject can list the object with the given ID, and which of its rules allow it,
+	// or come closest to allowing it. The subject is the principal of the context if nil, and can only be another
+	// principal if the principal of the context can impersonate it. ErrImpersonationNotAllowed is returned otherwise.
+	ExplainAccess(ctx context.Context, id string, subject *auth.UserPrincipal) (models.AccessExplanation, error)
+	// ListSavedQueries returns the queries saved by the principal of the context, and those shared with its groups.
+	ListSavedQueries(ctx context.Context) ([]models.SavedQuery, error)
+	// GetSavedQuery returns a saved query that the principal of the context can see. Running it applies the
+	// access of the principal running it, rather than that of its owner. ErrSavedQueryNotFound is returned otherwise.
+	GetSavedQuery(ctx context.Context, id string) (models.SavedQuery, error)
+	// CreateSavedQuery saves a query for the principal of the context, optionally shared with one of its groups.
+	// The ID, owner and creation time of the query are set when it is saved.
+	CreateSavedQuery(ctx context.Context, saved models.SavedQuery) (models.SavedQuery, error)
+	// DeleteSavedQuery deletes a query saved by the principal of the context.
+	DeleteSavedQuery(ctx context.Context, id string) error
 }
 
+// ErrObjectNotFound is returned for objects that do not exist, or that the principal is not allowed to see.
+var ErrObjectNotFound = errors.New("object not found")
+
 // Authorizer creates an authorization predicate when given a cluster name.
 type Authorizer interface {
 	ObjectAuthorizer(roles []models.Role, rolebindings []models.RoleBinding, principal *auth.UserPrincipal, cluster string) func(models.Object) (bool, error)
@@ -30,6 +72,10 @@ type QueryServiceOpts struct {
 	StoreReader store.StoreReader
 	IndexReader store.IndexReader
 	Authorizer  Authorizer
+	// Events is where WatchQuery gets the changes from. Queries cannot be watched without it.
+	Events events.Subscriber
+	// StoreWriter is where saved queries are written to. Queries cannot be saved without it.
+	StoreWriter store.StoreWriter
 }
 
 func (o QueryServiceOpts) Validate() error {
@@ -60,6 +106,8 @@ func NewQueryService(opts QueryServiceOpts) (QueryService, error) {
 		r:          opts.StoreReader,
 		index:      opts.IndexReader,
 		authorizer: opts.Authorizer,
+		events:     opts.Events,
+		w:          opts.StoreWriter,
 	}, nil
 }
 
@@ -69,67 +117,300 @@ type qs struct {
 	r          store.StoreReader
 	index      store.IndexReader
 	authorizer Authorizer
+	events     events.Subscriber
+	w          store.StoreWriter
 }
 
-func (q *qs) RunQuery(ctx context.Context, query store.Query, opts store.QueryOption) ([]models.Object, error) {
+func (q *qs) RunQuery(ctx context.Context, query store.Query, opts store.QueryOption) ([]models.Object, string, error) {
 	principal := auth.Principal(ctx)
 	if principal == nil {
-		return nil, fmt.Errorf("principal not found")
+		return nil, "", fmt.Errorf("principal not found")
 	}
-	q.debug.Info("query received", "filters", query.GetFilters(), "terms", query.GetTerms(), "principal", principal.ID)
+	q.debug.Info("query received", "filters", query.GetFilters(), "terms", query.GetTerms(), "query", query.GetQuery(), "principal", principal.ID)
 
-	roles, err := q.r.GetRoles(ctx)
+	authorized, tenantLookup, err := q.accessFor(ctx, principal)
 	if err != nil {
-		return nil, fmt.Errorf("error fetching access rules from the store: %w", err)
+		return nil, "", err
 	}
-	bindings, err := q.r.GetRoleBindings(ctx)
+
+	iter, err := q.index.Search(ctx, query, opts)
 	if err != nil {
-		return nil, fmt.Errorf("error fetching access rules from the store: %w", err)
+		return nil, "", fmt.Errorf("error getting objects from indexer: %w", err)
 	}
 
-	tenants, err := q.r.GetTenants(ctx)
+	defer iter.Close()
+
+	var limit int32
+
+	if opts != nil {
+		limit = opts.GetLimit()
+	}
+
+	// If Limit is 0, all objects are returned.
+	result, next, err := iter.Page(int(limit), authorized)
 	if err != nil {
-		return nil, fmt.Errorf("error fetching tenants from the store: %w", err)
+		return nil, "", fmt.Errorf("error reading objects: %w", err)
 	}
 
-	tenantLookup := createTenantLookup(tenants)
+	for i, obj := range result {
+		tenantID := fmt.Sprintf("%s/%s", obj.Cluster, obj.Namespace)
+		if tenantName, ok := tenantLookup[tenantID]; ok {
+			result[i].Tenant = tenantName
+		}
+	}
 
-	iter, err := q.index.Search(ctx, query, opts)
+	q.debug.Info("query processed", "query", query, "principal", principal.ID, "numResult", len(result))
+	return result, next, nil
+}
+
+func (q *qs) Aggregate(ctx context.Context, query store.Query, groupBy []string) ([]models.AggregationBucket, error) {
+	principal := auth.Principal(ctx)
+	if principal == nil {
+		return nil, fmt.Errorf("principal not found")
+	}
+
+	if err := validateGroupBy(groupBy); err != nil {
+		return nil, err
+	}
+
+	q.debug.Info("aggregation received", "filters", query.GetFilters(), "terms", query.GetTerms(), "query", query.GetQuery(), "groupBy", groupBy, "principal", principal.ID)
+
+	authorized, tenantLookup, err := q.accessFor(ctx, principal)
+	if err != nil {
+		return nil, err
+	}
+
+	iter, err := q.index.Search(ctx, query, nil)
 	if err != nil {
 		return nil, fmt.Errorf("error getting objects from indexer: %w", err)
 	}
 
 	defer iter.Close()
 
-	result := []models.Object{}
+	// Counts are taken after authorization, so that they only include the objects the principal can list.
+	agg := newAggregator(groupBy)
+	total := 0
+	for {
+		// Objects are read a batch at a time, so that the whole result is not held in memory.
+		objects, next, err := iter.Page(aggregationBatchSize, authorized)
+		if err != nil {
+			return nil, fmt.Errorf("error reading objects: %w", err)
+		}
 
-	var limit int32
+		for i, obj := range objects {
+			tenantID := fmt.Sprintf("%s/%s", obj.Cluster, obj.Namespace)
+			if tenantName, ok := tenantLookup[tenantID]; ok {
+				objects[i].Tenant = tenantName
+			}
+		}
 
-	if opts != nil {
-		limit = opts.GetLimit()
+		agg.add(objects)
+		total += len(objects)
+
+		if next == "" || len(objects) == 0 {
+			break
+		}
 	}
 
-	// keep track of any cluster authorize predicate we might need again
-	perClusterAllowed := map[string](func(models.Object) (bool, error)){}
+	buckets := agg.result()
+
+	q.debug.Info("aggregation processed", "principal", principal.ID, "numObjects", total, "numBuckets", len(buckets))
+	return buckets, nil
+}
+
+func (q *qs) Export(ctx context.Context, query store.Query, columns []string, send func(rows [][]string) error) error {
+	principal := auth.Principal(ctx)
+	if principal == nil {
+		return fmt.Errorf("principal not found")
+	}
+
+	_, exportColumns, err := newExportColumns(columns)
+	if err != nil {
+		return err
+	}
+
+	q.debug.Info("export received", "filters", query.GetFilters(), "terms", query.GetTerms(), "query", query.GetQuery(), "columns", columns, "principal", principal.ID)
+
+	authorized, tenantLookup, err := q.accessFor(ctx, principal)
+	if err != nil {
+		return err
+	}
+
+	iter, err := q.index.Search(ctx, query, nil)
+	if err != nil {
+		return fmt.Errorf("error getting objects from indexer: %w", err)
+	}
+
+	defer iter.Close()
+
+	total := 0
+	for {
+		// Objects are read a batch at a time, so that the whole result is not held in memory.
+		objects, next, err := iter.Page(exportBatchSize, authorized)
+		if err != nil {
+			return fmt.Errorf("error reading objects: %w", err)
+		}
+
+		rows := make([][]string, 0, len(objects))
+		for _, obj := range objects {
+			tenantID := fmt.Sprintf("%s/%s", obj.Cluster, obj.Namespace)
+			if tenantName, ok := tenantLookup[tenantID]; ok {
+				obj.Tenant = tenantName
+			}
+
+			row, err := exportRow(obj, exportColumns)
+			if err != nil {
+				return err
+			}
+			rows = append(rows, row)
+		}
 
-	for iter.Next() {
-		if limit > 0 && len(result) == int(limit) {
-			// Limit is set in the query and reached.
-			// If Limit is 0, all objects are returned.
+		if len(rows) > 0 {
+			if err := send(rows); err != nil {
+				return err
+			}
+			total += len(rows)
+		}
+
+		if next == "" || len(objects) == 0 {
 			break
 		}
+	}
 
-		obj, err := iter.Row()
-		if err != nil {
-			q.log.Error(err, "error getting row from iterator")
+	q.debug.Info("export processed", "principal", principal.ID, "numResult", total)
+	return nil
+}
+
+func (q *qs) WatchQuery(ctx context.Context, query store.Query, send func(models.ObjectEvent) error) error {
+	principal := auth.Principal(ctx)
+	if principal == nil {
+		return fmt.Errorf("principal not found")
+	}
+
+	if q.events == nil {
+		return fmt.Errorf("watching queries is not enabled")
+	}
+
+	// Report syntax errors before waiting for the first change.
+	if expr := query.GetQuery(); expr != "" {
+		if _, err := store.ParseQuery(expr); err != nil {
+			return err
+		}
+	}
+
+	q.debug.Info("watch started", "filters", query.GetFilters(), "terms", query.GetTerms(), "query", query.GetQuery(), "principal", principal.ID)
+
+	sub := q.events.Subscribe()
+	defer sub.Cancel()
+
+	// The objects sent so far that still match the query,
+	// to tell the watcher when a modified object no longer does.
+	matching := map[string]bool{}
+
+	for {
+		select {
+		case <-ctx.Done():
+			q.debug.Info("watch stopped", "principal", principal.ID)
+			return nil
+		case changes, ok := <-sub.Events():
+			if !ok {
+				return sub.Err()
+			}
+
+			if err := q.sendChanges(ctx, principal, query, changes, matching, send); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func (q *qs) sendChanges(ctx context.Context, principal *auth.UserPrincipal, query store.Query, changes []models.ObjectEvent, matching map[string]bool, send func(models.ObjectEvent) error) error {
+	objects := []models.Object{}
+	for _, e := range changes {
+		objects = append(objects, e.Object)
+	}
+
+	// Deleted objects are gone from the index already, so the changes are matched on their own.
+	idx, err := store.NewMemoryIndex(objects, q.log)
+	if err != nil {
+		return err
+	}
+	defer idx.Close()
+
+	matches, err := idx.Matches(query)
+	if err != nil {
+		return err
+	}
+
+	var (
+		authorized   func(models.Object) (bool, error)
+		tenantLookup map[string]string
+	)
+
+	for _, e := range changes {
+		id := e.Object.GetID()
+
+		if !matches[id] {
+			if e.Type != models.ObjectEventModified || !matching[id] {
+				continue
+			}
+
+			// The object left the results.
+			e.Type = models.ObjectEventDeleted
+		}
+
+		// Access rules are only loaded for the batches that have something to send.
+		if authorized == nil {
+			authorized, tenantLookup, err = q.accessFor(ctx, principal)
+			if err != nil {
+				return err
+			}
+		}
+
+		if ok, _ := authorized(e.Object); !ok {
 			continue
 		}
 
-		tenantID := fmt.Sprintf("%s/%s", obj.Cluster, obj.Namespace)
+		if e.Type == models.ObjectEventDeleted {
+			delete(matching, id)
+		} else {
+			matching[id] = true
+		}
+
+		tenantID := fmt.Sprintf("%s/%s", e.Object.Cluster, e.Object.Namespace)
 		if tenantName, ok := tenantLookup[tenantID]; ok {
-			obj.Tenant = tenantName
+			e.Object.Tenant = tenantName
 		}
 
+		if err := send(e); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// accessFor loads the access rules and tenants from the store, and returns a predicate that tells
+// whether the principal can see an object, along with the tenants by cluster and namespace.
+func (q *qs) accessFor(ctx context.Context, principal *auth.UserPrincipal) (func(models.Object) (bool, error), map[string]string, error) {
+	roles, err := q.r.GetRoles(ctx)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error fetching access rules from the store: %w", err)
+	}
+	bindings, err := q.r.GetRoleBindings(ctx)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error fetching access rules from the store: %w", err)
+	}
+
+	tenants, err := q.r.GetTenants(ctx)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error fetching tenants from the store: %w", err)
+	}
+
+	// keep track of any cluster authorize predicate we might need again
+	perClusterAllowed := map[string](func(models.Object) (bool, error)){}
+
+	authorized := func(obj models.Object) (bool, error) {
 		cluster := obj.Cluster
 		allow, ok := perClusterAllowed[cluster]
 		if !ok {
@@ -137,30 +418,64 @@ func (q *qs) RunQuery(ctx context.Context, query store.Query, opts store.QueryOp
 			perClusterAllowed[cluster] = allow
 		}
 
-		ok, err = allow(obj)
+		ok, err := allow(obj)
 		if err != nil {
 			q.log.Error(err, "error checking access")
-			continue
+			return false, nil
 		}
 
-		if ok {
-			result = append(result, obj)
-		} else {
+		if !ok {
 			//unauthorised is logged for debugging
 			q.debug.Info("unauthorised access", "principal", principal.ID, "object", obj.ID)
 		}
+
+		return ok, nil
 	}
 
-	q.debug.Info("query processed", "query", query, "principal", principal.ID, "numResult", len(result))
-	return result, nil
+	return authorized, createTenantLookup(tenants), nil
+}
+
+func (q *qs) GetObjectHistory(ctx context.Context, id string) ([]models.ObjectRevision, error) {
+	principal := auth.Principal(ctx)
+	if principal == nil {
+		return nil, fmt.Errorf("principal not found")
+	}
+
+	iter, err := q.r.GetObjects(ctx, []string{id}, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error getting object from the store: %w", err)
+	}
+
+	defer iter.Close()
+
+	objects, err := iter.All()
+	if err != nil {
+		return nil, fmt.Errorf("error reading object: %w", err)
+	}
+
+	if len(objects) == 0 {
+		return nil, ErrObjectNotFound
+	}
+
+	authorized, _, err := q.accessFor(ctx, principal)
+	if err != nil {
+		return nil, err
+	}
+
+	// Objects the principal cannot see are reported as missing, so that their existence is not disclosed.
+	if ok, err := authorized(objects[0]); err != nil || !ok {
+		return nil, ErrObjectNotFound
+	}
+
+	return q.r.GetObjectHistory(ctx, id)
 }
 
 func (q *qs) GetAccessRules(ctx context.Context) ([]models.AccessRule, error) {
 	return q.r.GetAccessRules(ctx)
 }
 
-func (q *qs) ListFacets(ctx context.Context, cat configuration.ObjectCategory) (store.Facets, error) {
-	return q.index.ListFacets(ctx, cat)
+func (q *qs) ListFacets(ctx context.Context, cat configuration.ObjectCategory, kinds []configuration.ObjectKind) (store.Facets, error) {
+	return q.index.ListFacets(ctx, cat, kinds)
 }
 
 func createTenantLookup(tenants []models.Tenant) map[string]string {
@@ -3,9 +3,11 @@ package query
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
 	"testing"
+	"time"
 
 	"github.com/go-logr/logr"
 	"github.com/google/go-cmp/cmp"
@@ -438,6 +440,48 @@ func TestRunQuery(t *testing.T) {
 			opts:  &query{orderBy: "name", descending: true, filters: []string{"category:automation"}},
 			want:  []string{"kube-prometheus-stack"},
 		},
+		{
+			name:    "structured query with or and not",
+			objects: structuredQueryObjects,
+			query:   &query{query: "kind:HelmRelease AND (status:Failed OR status:Stalled) AND NOT namespace:flux-system"},
+			opts:    &query{orderBy: "name"},
+			want:    []string{"podinfo"},
+		},
+		{
+			name:    "structured query with prefix",
+			objects: structuredQueryObjects,
+			query:   &query{query: "name:pod*"},
+			opts:    &query{orderBy: "name"},
+			want:    []string{"podinfo", "podinfo-system"},
+		},
+		{
+			name:    "structured query with regex",
+			objects: structuredQueryObjects,
+			query:   &query{query: "name~/.*-system/"},
+			opts:    &query{orderBy: "name"},
+			want:    []string{"podinfo-system"},
+		},
+		{
+			name:    "structured query with label selector",
+			objects: structuredQueryObjects,
+			query:   &query{query: "labels.team in (platform, payments)"},
+			opts:    &query{orderBy: "name"},
+			want:    []string{"ingress", "podinfo"},
+		},
+		{
+			name:    "structured query with time range",
+			objects: structuredQueryObjects,
+			query:   &query{query: "kubernetesDeletedAt>=2023-06-01"},
+			opts:    &query{orderBy: "name"},
+			want:    []string{"ingress"},
+		},
+		{
+			name:    "structured query combined with filters",
+			objects: structuredQueryObjects,
+			query:   &query{query: "status!=Failed", filters: []string{"kind:HelmRelease"}},
+			opts:    &query{orderBy: "name"},
+			want:    []string{"podinfo-system"},
+		},
 	}
 
 	for _, tt := range tests {
@@ -478,7 +522,7 @@ func TestRunQuery(t *testing.T) {
 				},
 			})
 
-			got, err := q.RunQuery(ctx, tt.query, tt.opts)
+			got, _, err := q.RunQuery(ctx, tt.query, tt.opts)
 			g.Expect(err).NotTo(HaveOccurred())
 
 			names := []string{}
@@ -493,6 +537,76 @@ func TestRunQuery(t *testing.T) {
 
 }
 
+var structuredQueryObjects = []models.Object{
+	{
+		Cluster:    "management",
+		Name:       "podinfo",
+		Namespace:  "default",
+		Kind:       "HelmRelease",
+		APIGroup:   "helm.toolkit.fluxcd.io",
+		APIVersion: "v2beta1",
+		Status:     "Failed",
+		Labels:     map[string]string{"team": "platform"},
+	},
+	{
+		Cluster:    "management",
+		Name:       "podinfo-system",
+		Namespace:  "flux-system",
+		Kind:       "HelmRelease",
+		APIGroup:   "helm.toolkit.fluxcd.io",
+		APIVersion: "v2beta1",
+		Status:     "Stalled",
+		Labels:     map[string]string{"team": "security"},
+	},
+	{
+		Cluster:             "management",
+		Name:                "ingress",
+		Namespace:           "default",
+		Kind:                "Kustomization",
+		APIGroup:            "kustomize.toolkit.fluxcd.io",
+		APIVersion:          "v1",
+		Status:              "Failed",
+		Labels:              map[string]string{"team": "payments"},
+		KubernetesDeletedAt: time.Date(2023, 7, 1, 0, 0, 0, 0, time.UTC),
+	},
+}
+
+func TestRunQuery_InvalidStructuredQuery(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	dir, err := os.MkdirTemp("", "test")
+	g.Expect(err).NotTo(HaveOccurred())
+
+	db, err := store.CreateSQLiteDB(dir)
+	g.Expect(err).NotTo(HaveOccurred())
+
+	s, err := store.NewSQLiteStore(db, logr.Discard())
+	g.Expect(err).NotTo(HaveOccurred())
+
+	idxDir, err := os.MkdirTemp("", "indexer-test")
+	g.Expect(err).NotTo(HaveOccurred())
+
+	idx, err := store.NewIndexer(s, idxDir, logr.Discard())
+	g.Expect(err).NotTo(HaveOccurred())
+
+	q := &qs{
+		log:        logr.Discard(),
+		debug:      logr.Discard(),
+		r:          s,
+		index:      idx,
+		authorizer: allowAll,
+	}
+
+	ctx := auth.WithPrincipal(context.Background(), &auth.UserPrincipal{ID: "test"})
+
+	_, _, err = q.RunQuery(ctx, &query{query: "kind:HelmRelease AND (status:Failed"}, nil)
+	g.Expect(err).To(HaveOccurred())
+
+	var parseErr *store.ParseError
+	g.Expect(errors.As(err, &parseErr)).To(BeTrue())
+	g.Expect(parseErr.Position).To(Equal(21))
+}
+
 // TestRunQuery_ErrorScenarios injects errors to ensure that querying is tolerant to errors where possible.
 func TestRunQuery_ErrorScenarios(t *testing.T) {
 	t.Run("should be tolerant to inconsistency between indexer and datastore", func(t *testing.T) {
@@ -552,7 +666,7 @@ func TestRunQuery_ErrorScenarios(t *testing.T) {
 			},
 		})
 
-		got, err := q.RunQuery(ctx, &query{terms: ""}, nil)
+		got, _, err := q.RunQuery(ctx, &query{terms: ""}, nil)
 		g.Expect(err).NotTo(HaveOccurred())
 
 		names := []string{}
@@ -657,11 +771,49 @@ func TestQueryIteration(t *testing.T) {
 		limit: 3,
 	}
 
-	got, err := q.RunQuery(ctx, qy, qy)
+	got, next, err := q.RunQuery(ctx, qy, qy)
 	g.Expect(err).NotTo(HaveOccurred())
 
 	g.Expect(got).To(HaveLen(3))
 	g.Expect(got).To(HaveEach(HaveField("Namespace", "namespace-a")), "all be in namespace-a")
+	g.Expect(next).To(BeEmpty(), "the only object left is unauthorized")
+
+	t.Run("pages resume after the last authorized object", func(t *testing.T) {
+		g := NewGomegaWithT(t)
+
+		qy := &query{
+			limit:   1,
+			orderBy: "name",
+		}
+
+		names := []string{}
+		for pages := 1; ; pages++ {
+			g.Expect(pages).To(BeNumerically("<=", 3))
+
+			got, next, err := q.RunQuery(ctx, qy, qy)
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(got).To(HaveLen(1))
+
+			names = append(names, got[0].Name)
+
+			if next == "" {
+				break
+			}
+
+			qy.pageToken = next
+		}
+
+		g.Expect(names).To(Equal([]string{"obj-1", "obj-3", "obj-4"}))
+	})
+
+	t.Run("invalid page token", func(t *testing.T) {
+		g := NewGomegaWithT(t)
+
+		qy := &query{pageToken: "invalid"}
+
+		_, _, err := q.RunQuery(ctx, qy, qy)
+		g.Expect(errors.Is(err, store.ErrInvalidPageToken)).To(BeTrue())
+	})
 }
 
 func TestQueryOrdering_Realistic(t *testing.T) {
@@ -712,7 +864,7 @@ func TestQueryOrdering_Realistic(t *testing.T) {
 			orderBy: "name",
 		}
 
-		got, err := q.RunQuery(ctx, qy, qy)
+		got, _, err := q.RunQuery(ctx, qy, qy)
 		g.Expect(err).NotTo(HaveOccurred())
 
 		expected := []string{
@@ -746,7 +898,7 @@ func TestQueryOrdering_Realistic(t *testing.T) {
 			terms: "flux-system",
 		}
 
-		got, err := q.RunQuery(ctx, qy, qy)
+		got, _, err := q.RunQuery(ctx, qy, qy)
 		g.Expect(err).NotTo(HaveOccurred())
 
 		expected := []string{
@@ -831,7 +983,7 @@ func TestQuery_Tenants(t *testing.T) {
 		authorizer: allowAll,
 	}
 
-	res, err := q.RunQuery(ctx, &query{terms: "", descending: true}, nil)
+	res, _, err := q.RunQuery(ctx, &query{terms: "", descending: true}, nil)
 	g.Expect(err).NotTo(HaveOccurred())
 
 	expected := []models.Object{
@@ -875,10 +1027,12 @@ func TestQuery_Tenants(t *testing.T) {
 type query struct {
 	terms      string
 	filters    []string
+	query      string
 	offset     int32
 	limit      int32
 	orderBy    string
 	descending bool
+	pageToken  string
 }
 
 func (q *query) GetTerms() string {
@@ -889,6 +1043,10 @@ func (q *query) GetFilters() []string {
 	return q.filters
 }
 
+func (q *query) GetQuery() string {
+	return q.query
+}
+
 func (q *query) GetOffset() int32 {
 	return q.offset
 }
@@ -905,7 +1063,69 @@ func (q *query) GetDescending() bool {
 	return q.descending
 }
 
+func (q *query) GetPageToken() string {
+	return q.pageToken
+}
+
 func toUnstructured(obj client.Object) json.RawMessage {
 	data, _ := json.Marshal(obj)
 	return data
 }
+
+func TestGetObjectHistory(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	dir, err := os.MkdirTemp("", "test")
+	g.Expect(err).NotTo(HaveOccurred())
+
+	db, err := store.CreateSQLiteDB(dir)
+	g.Expect(err).NotTo(HaveOccurred())
+
+	s, err := store.NewSQLiteStore(db, logr.Discard())
+	g.Expect(err).NotTo(HaveOccurred())
+
+	ctx := auth.WithPrincipal(context.Background(), &auth.UserPrincipal{
+		ID: "test",
+	})
+
+	visible := models.Object{
+		Cluster:    "test-cluster-1",
+		Name:       "visible",
+		Namespace:  "namespace-a",
+		Kind:       "Deployment",
+		APIGroup:   "apps",
+		APIVersion: "v1",
+	}
+	hidden := visible
+	hidden.Name = "hidden"
+
+	g.Expect(store.SeedObjects(db, []models.Object{visible, hidden})).To(Succeed())
+	g.Expect(s.StoreObjectRevisions(context.Background(), []models.ObjectRevision{
+		{ObjectID: visible.GetID(), Status: "Progressing", RecordedAt: time.Now().Add(-time.Minute)},
+		{ObjectID: visible.GetID(), Status: "Success", RecordedAt: time.Now()},
+		{ObjectID: hidden.GetID(), Status: "Success", RecordedAt: time.Now()},
+	})).To(Succeed())
+
+	q := &qs{
+		log:   logr.Discard(),
+		debug: logr.Discard(),
+		r:     s,
+		authorizer: predicateAuthz{
+			predicate: func(obj models.Object) (bool, error) {
+				return obj.Name != hidden.Name, nil
+			},
+		},
+	}
+
+	history, err := q.GetObjectHistory(ctx, visible.GetID())
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(history).To(HaveLen(2))
+	g.Expect(history[0].Status).To(Equal("Progressing"))
+	g.Expect(history[1].Status).To(Equal("Success"))
+
+	_, err = q.GetObjectHistory(ctx, hidden.GetID())
+	g.Expect(err).To(MatchError(ErrObjectNotFound))
+
+	_, err = q.GetObjectHistory(ctx, "test-cluster-1/namespace-a/apps/v1/Deployment/missing")
+	g.Expect(err).To(MatchError(ErrObjectNotFound))
+}
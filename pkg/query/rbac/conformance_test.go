@@ -0,0 +1,278 @@
+//go:build integration
+
+package rbac_test
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	"github.com/weaveworks/weave-gitops/pkg/kube"
+	"github.com/weaveworks/weave-gitops/pkg/server/auth"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/kubernetes/pkg/controller/clusterroleaggregation"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/envtest"
+
+	"github.com/weaveworks/weave-gitops-enterprise/pkg/query/internal/adapters"
+	"github.com/weaveworks/weave-gitops-enterprise/pkg/query/internal/models"
+	"github.com/weaveworks/weave-gitops-enterprise/pkg/query/rbac"
+)
+
+const conformanceCluster = "envtest"
+
+var conformancePrefixes = kube.UserPrefixes{UsernamePrefix: "oidc:", GroupsPrefix: "oidc-group:"}
+
+// TestConformance checks that the explorer authorizes objects as Kubernetes would, by comparing
+// its decisions with SubjectAccessReviews for every combination of principal and object.
+// The cluster role aggregation controller, which envtest does not run, is run alongside it.
+func TestConformance(t *testing.T) {
+	g := NewWithT(t)
+
+	cmdOut, err := exec.Command("git", "rev-parse", "--show-toplevel").Output()
+	g.Expect(err).NotTo(HaveOccurred())
+	os.Setenv("KUBEBUILDER_ASSETS", fmt.Sprintf("%s/tools/bin/envtest", strings.TrimSpace(string(cmdOut))))
+
+	testEnv := &envtest.Environment{}
+	cfg, err := testEnv.Start()
+	g.Expect(err).NotTo(HaveOccurred())
+	defer func() {
+		g.Expect(testEnv.Stop()).To(Succeed())
+	}()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	clientset, err := kubernetes.NewForConfig(cfg)
+	g.Expect(err).NotTo(HaveOccurred())
+
+	factory := informers.NewSharedInformerFactory(clientset, 0)
+	aggregation := clusterroleaggregation.NewClusterRoleAggregation(factory.Rbac().V1().ClusterRoles(), clientset.RbacV1())
+	factory.Start(ctx.Done())
+	go aggregation.Run(ctx, 1)
+
+	for _, obj := range conformanceFixtures() {
+		g.Expect(createObject(ctx, clientset, obj)).To(Succeed())
+	}
+
+	// Wait for the aggregated cluster roles to get their rules, both built-in and not.
+	for _, name := range []string{"view", "explorer-view"} {
+		g.Eventually(func() int {
+			role, err := clientset.RbacV1().ClusterRoles().Get(ctx, name, metav1.GetOptions{})
+			if err != nil {
+				return 0
+			}
+			return len(role.Rules)
+		}, 10*time.Second, 100*time.Millisecond).ShouldNot(BeZero(), name)
+	}
+
+	roles, bindings := collectRBAC(ctx, g, clientset)
+	kindToResource := map[string]string{
+		"HelmRelease":   "helmreleases",
+		"Kustomization": "kustomizations",
+		"ConfigMap":     "configmaps",
+	}
+	authz := rbac.NewAuthorizer(kindToResource, conformancePrefixes)
+
+	principals := []*auth.UserPrincipal{
+		auth.NewUserPrincipal(auth.ID("alice"), auth.Groups([]string{"devs"})),
+		auth.NewUserPrincipal(auth.ID("bob")),
+		auth.NewUserPrincipal(auth.ID("carol"), auth.Groups([]string{"ops"})),
+	}
+
+	objects := []models.Object{}
+	for _, namespace := range []string{"apps", "other"} {
+		for _, name := range []string{"podinfo", "nginx"} {
+			objects = append(objects,
+				models.Object{Cluster: conformanceCluster, Namespace: namespace, APIGroup: "helm.toolkit.fluxcd.io", APIVersion: "v2beta1", Kind: "HelmRelease", Name: name},
+				models.Object{Cluster: conformanceCluster, Namespace: namespace, APIGroup: "kustomize.toolkit.fluxcd.io", APIVersion: "v1", Kind: "Kustomization", Name: name},
+				models.Object{Cluster: conformanceCluster, Namespace: namespace, APIVersion: "v1", Kind: "ConfigMap", Name: name},
+			)
+		}
+	}
+
+	allowedCount := 0
+	for _, principal := range principals {
+		allowed := authz.ObjectAuthorizer(roles, bindings, principal, conformanceCluster)
+
+		for _, obj := range objects {
+			want := subjectAccessReview(ctx, g, clientset, principal, obj, kindToResource[obj.Kind])
+			got, err := allowed(obj)
+			g.Expect(err).NotTo(HaveOccurred())
+
+			if got != want {
+				t.Errorf("%s listing %s: explorer allowed=%t, Kubernetes allowed=%t", principal.ID, obj.GetID(), got, want)
+			}
+			if want {
+				allowedCount++
+			}
+		}
+	}
+
+	// Make sure the suite is not passing by denying everything.
+	g.Expect(allowedCount).NotTo(BeZero())
+}
+
+func conformanceFixtures() []client.Object {
+	aggregateToExplorerView := map[string]string{"explorer.weave.works/aggregate-to-view": "true"}
+
+	return []client.Object{
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "apps"}},
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "other"}},
+		// An aggregated cluster role, bound to a prefixed group in a namespace.
+		&rbacv1.ClusterRole{
+			ObjectMeta: metav1.ObjectMeta{Name: "explorer-view"},
+			AggregationRule: &rbacv1.AggregationRule{
+				ClusterRoleSelectors: []metav1.LabelSelector{{MatchLabels: aggregateToExplorerView}},
+			},
+		},
+		&rbacv1.ClusterRole{
+			ObjectMeta: metav1.ObjectMeta{Name: "explorer-view-helm", Labels: aggregateToExplorerView},
+			Rules: []rbacv1.PolicyRule{{
+				APIGroups: []string{"helm.toolkit.fluxcd.io"}, Resources: []string{"helmreleases"}, Verbs: []string{"get", "list", "watch"},
+			}},
+		},
+		&rbacv1.RoleBinding{
+			ObjectMeta: metav1.ObjectMeta{Name: "devs-explorer-view", Namespace: "apps"},
+			RoleRef:    rbacv1.RoleRef{APIGroup: rbacv1.GroupName, Kind: "ClusterRole", Name: "explorer-view"},
+			Subjects:   []rbacv1.Subject{{APIGroup: rbacv1.GroupName, Kind: rbacv1.GroupKind, Name: "oidc-group:devs"}},
+		},
+		// The built-in view cluster role, which aggregates the core resources.
+		&rbacv1.RoleBinding{
+			ObjectMeta: metav1.ObjectMeta{Name: "devs-view", Namespace: "other"},
+			RoleRef:    rbacv1.RoleRef{APIGroup: rbacv1.GroupName, Kind: "ClusterRole", Name: "view"},
+			Subjects:   []rbacv1.Subject{{APIGroup: rbacv1.GroupName, Kind: rbacv1.GroupKind, Name: "oidc-group:devs"}},
+		},
+		// A prefixed user, with a role restricted by name and one missing the list verb.
+		&rbacv1.Role{
+			ObjectMeta: metav1.ObjectMeta{Name: "podinfo-reader", Namespace: "apps"},
+			Rules: []rbacv1.PolicyRule{{
+				APIGroups: []string{"kustomize.toolkit.fluxcd.io"}, Resources: []string{"kustomizations"}, Verbs: []string{"list"}, ResourceNames: []string{"podinfo"},
+			}},
+		},
+		&rbacv1.RoleBinding{
+			ObjectMeta: metav1.ObjectMeta{Name: "bob-podinfo-reader", Namespace: "apps"},
+			RoleRef:    rbacv1.RoleRef{APIGroup: rbacv1.GroupName, Kind: "Role", Name: "podinfo-reader"},
+			Subjects:   []rbacv1.Subject{{APIGroup: rbacv1.GroupName, Kind: rbacv1.UserKind, Name: "oidc:bob"}},
+		},
+		&rbacv1.ClusterRole{
+			ObjectMeta: metav1.ObjectMeta{Name: "helm-getter"},
+			Rules: []rbacv1.PolicyRule{{
+				APIGroups: []string{"helm.toolkit.fluxcd.io"}, Resources: []string{"helmreleases"}, Verbs: []string{"get"},
+			}},
+		},
+		&rbacv1.ClusterRoleBinding{
+			ObjectMeta: metav1.ObjectMeta{Name: "bob-helm-getter"},
+			RoleRef:    rbacv1.RoleRef{APIGroup: rbacv1.GroupName, Kind: "ClusterRole", Name: "helm-getter"},
+			Subjects:   []rbacv1.Subject{{APIGroup: rbacv1.GroupName, Kind: rbacv1.UserKind, Name: "oidc:bob"}},
+		},
+		// A cluster-wide binding to an unprefixed group, which principals never match.
+		&rbacv1.ClusterRoleBinding{
+			ObjectMeta: metav1.ObjectMeta{Name: "ops-explorer-view"},
+			RoleRef:    rbacv1.RoleRef{APIGroup: rbacv1.GroupName, Kind: "ClusterRole", Name: "explorer-view"},
+			Subjects:   []rbacv1.Subject{{APIGroup: rbacv1.GroupName, Kind: rbacv1.GroupKind, Name: "ops"}},
+		},
+	}
+}
+
+func createObject(ctx context.Context, clientset kubernetes.Interface, obj client.Object) error {
+	var err error
+	switch o := obj.(type) {
+	case *corev1.Namespace:
+		_, err = clientset.CoreV1().Namespaces().Create(ctx, o, metav1.CreateOptions{})
+	case *rbacv1.ClusterRole:
+		_, err = clientset.RbacV1().ClusterRoles().Create(ctx, o, metav1.CreateOptions{})
+	case *rbacv1.ClusterRoleBinding:
+		_, err = clientset.RbacV1().ClusterRoleBindings().Create(ctx, o, metav1.CreateOptions{})
+	case *rbacv1.Role:
+		_, err = clientset.RbacV1().Roles(o.Namespace).Create(ctx, o, metav1.CreateOptions{})
+	case *rbacv1.RoleBinding:
+		_, err = clientset.RbacV1().RoleBindings(o.Namespace).Create(ctx, o, metav1.CreateOptions{})
+	default:
+		err = fmt.Errorf("unsupported fixture %T", obj)
+	}
+	return err
+}
+
+// collectRBAC reads the roles and bindings of the cluster through the adapters of the role collector.
+func collectRBAC(ctx context.Context, g *WithT, clientset kubernetes.Interface) ([]models.Role, []models.RoleBinding) {
+	roles := []models.Role{}
+	bindings := []models.RoleBinding{}
+
+	clusterRoles, err := clientset.RbacV1().ClusterRoles().List(ctx, metav1.ListOptions{})
+	g.Expect(err).NotTo(HaveOccurred())
+	for i := range clusterRoles.Items {
+		role := &clusterRoles.Items[i]
+		role.Kind = "ClusterRole"
+		adapter, err := adapters.NewRoleAdapter(conformanceCluster, "ClusterRole", role)
+		g.Expect(err).NotTo(HaveOccurred())
+		roles = append(roles, adapter.ToModel())
+	}
+
+	namespacedRoles, err := clientset.RbacV1().Roles("").List(ctx, metav1.ListOptions{})
+	g.Expect(err).NotTo(HaveOccurred())
+	for i := range namespacedRoles.Items {
+		role := &namespacedRoles.Items[i]
+		role.Kind = "Role"
+		adapter, err := adapters.NewRoleAdapter(conformanceCluster, "Role", role)
+		g.Expect(err).NotTo(HaveOccurred())
+		roles = append(roles, adapter.ToModel())
+	}
+
+	clusterRoleBindings, err := clientset.RbacV1().ClusterRoleBindings().List(ctx, metav1.ListOptions{})
+	g.Expect(err).NotTo(HaveOccurred())
+	for i := range clusterRoleBindings.Items {
+		binding := &clusterRoleBindings.Items[i]
+		binding.Kind = "ClusterRoleBinding"
+		adapter, err := adapters.NewBindingAdapter(conformanceCluster, binding)
+		g.Expect(err).NotTo(HaveOccurred())
+		bindings = append(bindings, adapter.ToModel())
+	}
+
+	roleBindings, err := clientset.RbacV1().RoleBindings("").List(ctx, metav1.ListOptions{})
+	g.Expect(err).NotTo(HaveOccurred())
+	for i := range roleBindings.Items {
+		binding := &roleBindings.Items[i]
+		binding.Kind = "RoleBinding"
+		adapter, err := adapters.NewBindingAdapter(conformanceCluster, binding)
+		g.Expect(err).NotTo(HaveOccurred())
+		bindings = append(bindings, adapter.ToModel())
+	}
+
+	return roles, bindings
+}
+
+// subjectAccessReview asks Kubernetes whether the principal, as it is impersonated, can list the object.
+func subjectAccessReview(ctx context.Context, g *WithT, clientset kubernetes.Interface, principal *auth.UserPrincipal, obj models.Object, resource string) bool {
+	groups := []string{}
+	for _, group := range principal.Groups {
+		groups = append(groups, conformancePrefixes.GroupsPrefix+group)
+	}
+
+	review, err := clientset.AuthorizationV1().SubjectAccessReviews().Create(ctx, &authorizationv1.SubjectAccessReview{
+		Spec: authorizationv1.SubjectAccessReviewSpec{
+			User:   conformancePrefixes.UsernamePrefix + principal.ID,
+			Groups: groups,
+			ResourceAttributes: &authorizationv1.ResourceAttributes{
+				Namespace: obj.Namespace,
+				Verb:      "list",
+				Group:     obj.APIGroup,
+				Resource:  resource,
+				Name:      obj.Name,
+			},
+		},
+	}, metav1.CreateOptions{})
+	g.Expect(err).NotTo(HaveOccurred())
+
+	return review.Status.Allowed
+}
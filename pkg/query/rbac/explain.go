@@ -0,0 +1,227 @@
+package rbac
+
+import (
+	"fmt"
+
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apiserver/pkg/authentication/serviceaccount"
+	"k8s.io/apiserver/pkg/authorization/authorizer"
+	rbacv1helpers "k8s.io/kubernetes/pkg/apis/rbac/v1"
+	rbacvalidation "k8s.io/kubernetes/pkg/registry/rbac/validation"
+	rbacauth "k8s.io/kubernetes/plugin/pkg/auth/authorizer/rbac"
+
+	"github.com/weaveworks/weave-gitops/pkg/server/auth"
+
+	"github.com/weaveworks/weave-gitops-enterprise/pkg/query/internal/models"
+)
+
+// ExplainAccess tells whether the principal can list the object, along with
+// the rules that allow it, or those that come closest to allowing it.
+//
+// Unlike ObjectAuthorizer, this goes through every binding of the principal
+// on the cluster of the object, including those in other namespaces, so it is
+// meant for one object at a time.
+func (authz *Authorizer) ExplainAccess(roles []models.Role, rolebindings []models.RoleBinding, principal *auth.UserPrincipal, obj models.Object) models.AccessExplanation {
+	principal = authz.prefixed(principal)
+	explanation := models.AccessExplanation{
+		Matches:    []models.RuleMatch{},
+		NearMisses: []models.RuleMatch{},
+	}
+
+	getlist := &clusterRBACGetLister{
+		cluster:      obj.Cluster,
+		roles:        roles,
+		rolebindings: rolebindings,
+	}
+	getlist.init()
+	request := objectAsAttributes{user: principal, kindToResource: authz.resources(), object: obj}
+
+	for i := range rolebindings {
+		binding := &rolebindings[i]
+		if binding.Cluster != obj.Cluster || !bindingAppliesTo(binding, principal) {
+			continue
+		}
+
+		roleNamespace, rules, ok := bindingRules(getlist, binding)
+		if !ok {
+			// Bindings to roles that do not exist grant nothing.
+			continue
+		}
+
+		for j := range rules {
+			reasons := explainRule(request, binding, &rules[j])
+			if len(reasons) > 1 {
+				continue
+			}
+
+			match := models.RuleMatch{
+				BindingKind:      binding.Kind,
+				BindingNamespace: binding.Namespace,
+				BindingName:      binding.Name,
+				RoleKind:         binding.RoleRefKind,
+				RoleNamespace:    roleNamespace,
+				RoleName:         binding.RoleRefName,
+				APIGroups:        rules[j].APIGroups,
+				Resources:        rules[j].Resources,
+				Verbs:            rules[j].Verbs,
+				ResourceNames:    rules[j].ResourceNames,
+			}
+
+			if len(reasons) == 0 {
+				explanation.Matches = append(explanation.Matches, match)
+				continue
+			}
+
+			match.Reason = reasons[0].reason
+			match.Message = reasons[0].message
+			explanation.NearMisses = append(explanation.NearMisses, match)
+		}
+	}
+
+	// The same rules as those ObjectAuthorizer resolves for the namespace of the object.
+	explanation.Allowed = len(explanation.Matches) > 0
+
+	return explanation
+}
+
+// CanImpersonate tells whether the principal can impersonate the user and
+// groups of target on the cluster, as Kubernetes would for a request
+// with Impersonate-User and Impersonate-Group headers.
+func (authz *Authorizer) CanImpersonate(roles []models.Role, rolebindings []models.RoleBinding, principal *auth.UserPrincipal, cluster string, target *auth.UserPrincipal) (bool, error) {
+	principal = authz.prefixed(principal)
+	target = authz.prefixed(target)
+	getlist := &clusterRBACGetLister{
+		cluster:      cluster,
+		roles:        roles,
+		rolebindings: rolebindings,
+	}
+	getlist.init()
+	resolver := rbacvalidation.NewDefaultRuleResolver(
+		rbacvalidation.RoleGetter(getlist),
+		rbacvalidation.RoleBindingLister(getlist),
+		rbacvalidation.ClusterRoleGetter(getlist),
+		rbacvalidation.ClusterRoleBindingLister(getlist))
+
+	// Impersonation is cluster-wide, so only cluster role bindings count.
+	rules, err := resolver.RulesFor((*principalAsInfo)(principal), "")
+	if err != nil {
+		return false, err
+	}
+
+	impersonate := func(resource, name string) bool {
+		return rbacauth.RulesAllow(authorizer.AttributesRecord{
+			User:            (*principalAsInfo)(principal),
+			Verb:            "impersonate",
+			Resource:        resource,
+			Name:            name,
+			ResourceRequest: true,
+		}, rules...)
+	}
+
+	if target.ID != "" && !impersonate("users", target.ID) {
+		return false, nil
+	}
+
+	for _, group := range target.Groups {
+		if !impersonate("groups", group) {
+			return false, nil
+		}
+	}
+
+	return true, nil
+}
+
+// bindingRules returns the namespace and rules of the role a binding refers to,
+// or false if the role is not found.
+func bindingRules(getlist *clusterRBACGetLister, binding *models.RoleBinding) (string, []rbacv1.PolicyRule, bool) {
+	if binding.RoleRefKind == "ClusterRole" {
+		role, err := getlist.GetClusterRole(binding.RoleRefName)
+		if err != nil {
+			return "", nil, false
+		}
+		return "", role.Rules, true
+	}
+
+	role, err := getlist.GetRole(binding.Namespace, binding.RoleRefName)
+	if err != nil {
+		return "", nil, false
+	}
+	return binding.Namespace, role.Rules, true
+}
+
+// bindingAppliesTo tells whether any of the subjects of a binding is the principal,
+// one of its groups, or the service account it stands for.
+func bindingAppliesTo(binding *models.RoleBinding, principal *auth.UserPrincipal) bool {
+	for _, subject := range binding.Subjects {
+		switch subject.Kind {
+		case rbacv1.UserKind:
+			if subject.Name == principal.ID {
+				return true
+			}
+		case rbacv1.GroupKind:
+			for _, group := range principal.Groups {
+				if subject.Name == group {
+					return true
+				}
+			}
+		case rbacv1.ServiceAccountKind:
+			namespace := subject.Namespace
+			if namespace == "" && binding.Kind == "RoleBinding" {
+				namespace = binding.Namespace
+			}
+			if serviceaccount.MatchesUsername(namespace, subject.Name, principal.ID) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+type nearMiss struct {
+	reason  string
+	message string
+}
+
+// explainRule returns why a rule granted by a binding does not allow the request, if it does not.
+// Rules for other API groups or resources are not related to the request, and get two
+// reasons so that they are not taken for near misses.
+func explainRule(req objectAsAttributes, binding *models.RoleBinding, rule *rbacv1.PolicyRule) []nearMiss {
+	unrelated := []nearMiss{{}, {}}
+
+	if !rbacv1helpers.APIGroupMatches(rule, req.GetAPIGroup()) {
+		return unrelated
+	}
+	if !rbacv1helpers.ResourceMatches(rule, req.GetResource(), req.GetSubresource()) {
+		return unrelated
+	}
+
+	reasons := []nearMiss{}
+
+	if binding.Kind == "RoleBinding" && binding.Namespace != req.GetNamespace() {
+		message := fmt.Sprintf("the binding grants access in namespace %q only", binding.Namespace)
+		if req.GetNamespace() == "" {
+			message = fmt.Sprintf("the binding grants access in namespace %q only, and the object is not namespaced", binding.Namespace)
+		}
+		reasons = append(reasons, nearMiss{
+			reason:  models.NearMissWrongNamespace,
+			message: message,
+		})
+	}
+
+	if !rbacv1helpers.VerbMatches(rule, req.GetVerb()) {
+		reasons = append(reasons, nearMiss{
+			reason:  models.NearMissMissingVerb,
+			message: fmt.Sprintf("the rule does not grant the %q verb", req.GetVerb()),
+		})
+	}
+
+	if !rbacv1helpers.ResourceNameMatches(rule, req.GetName()) {
+		reasons = append(reasons, nearMiss{
+			reason:  models.NearMissResourceName,
+			message: fmt.Sprintf("the rule only grants access to the resource names %v", rule.ResourceNames),
+		})
+	}
+
+	return reasons
+}
@@ -0,0 +1,130 @@
+package rbac
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"github.com/weaveworks/weave-gitops/pkg/kube"
+	"github.com/weaveworks/weave-gitops/pkg/server/auth"
+
+	"github.com/weaveworks/weave-gitops-enterprise/pkg/query/internal/models"
+)
+
+func explainFixtures() ([]models.Role, []models.RoleBinding) {
+	role := func(kind, namespace, name string, rules ...models.PolicyRule) models.Role {
+		return models.Role{Cluster: "management", Kind: kind, Namespace: namespace, Name: name, PolicyRules: rules}
+	}
+	rule := func(verbs, resources, names string) models.PolicyRule {
+		return models.PolicyRule{APIGroups: "helm.toolkit.fluxcd.io", Resources: resources, Verbs: verbs, ResourceNames: names}
+	}
+	binding := func(kind, namespace, name, roleKind, roleName string, subjects ...models.Subject) models.RoleBinding {
+		return models.RoleBinding{Cluster: "management", Kind: kind, Namespace: namespace, Name: name, RoleRefKind: roleKind, RoleRefName: roleName, Subjects: subjects}
+	}
+	group := models.Subject{Kind: "Group", Name: "devs"}
+
+	roles := []models.Role{
+		role("ClusterRole", "", "helm-reader", rule("get,list,watch", "helmreleases", "")),
+		role("Role", "apps", "helm-getter", rule("get", "helmreleases", "")),
+		role("Role", "apps", "podinfo-reader", rule("list", "helmreleases", "podinfo")),
+		role("Role", "apps", "kustomization-reader", rule("list", "kustomizations", "")),
+		role("ClusterRole", "", "impersonator", models.PolicyRule{APIGroups: "", Resources: "users,groups", Verbs: "impersonate"}),
+	}
+	bindings := []models.RoleBinding{
+		binding("RoleBinding", "other", "read-helm-in-other", "ClusterRole", "helm-reader", group),
+		binding("RoleBinding", "apps", "get-helm", "Role", "helm-getter", group),
+		binding("RoleBinding", "apps", "read-podinfo", "Role", "podinfo-reader", group),
+		binding("RoleBinding", "apps", "read-kustomizations", "Role", "kustomization-reader", group),
+		binding("RoleBinding", "apps", "read-missing", "Role", "missing", group),
+		binding("ClusterRoleBinding", "", "admins-read-helm", "ClusterRole", "helm-reader", models.Subject{Kind: "User", Name: "admin"}),
+		binding("ClusterRoleBinding", "", "admins-impersonate", "ClusterRole", "impersonator", models.Subject{Kind: "User", Name: "admin"}),
+	}
+
+	return roles, bindings
+}
+
+func TestExplainAccess(t *testing.T) {
+	roles, bindings := explainFixtures()
+	authz := NewAuthorizer(map[string]string{"HelmRelease": "helmreleases", "Kustomization": "kustomizations"}, kube.UserPrefixes{})
+
+	obj := models.Object{
+		Cluster:    "management",
+		Namespace:  "apps",
+		APIGroup:   "helm.toolkit.fluxcd.io",
+		APIVersion: "v2beta1",
+		Kind:       "HelmRelease",
+		Name:       "nginx",
+	}
+
+	t.Run("near misses are explained", func(t *testing.T) {
+		g := NewWithT(t)
+
+		dev := auth.NewUserPrincipal(auth.ID("dev"), auth.Groups([]string{"devs"}))
+		explanation := authz.ExplainAccess(roles, bindings, dev, obj)
+
+		allowed, _ := authz.ObjectAuthorizer(roles, bindings, dev, obj.Cluster)(obj)
+		g.Expect(explanation.Allowed).To(Equal(allowed))
+		g.Expect(explanation.Allowed).To(BeFalse())
+		g.Expect(explanation.Matches).To(BeEmpty())
+
+		reasons := map[string]string{}
+		for _, m := range explanation.NearMisses {
+			reasons[m.BindingName] = m.Reason
+		}
+		g.Expect(reasons).To(Equal(map[string]string{
+			"read-helm-in-other": models.NearMissWrongNamespace,
+			"get-helm":           models.NearMissMissingVerb,
+			"read-podinfo":       models.NearMissResourceName,
+		}))
+	})
+
+	t.Run("matches are explained", func(t *testing.T) {
+		g := NewWithT(t)
+
+		admin := auth.NewUserPrincipal(auth.ID("admin"))
+		explanation := authz.ExplainAccess(roles, bindings, admin, obj)
+
+		g.Expect(explanation.Allowed).To(BeTrue())
+		g.Expect(explanation.Matches).To(HaveLen(1))
+		g.Expect(explanation.Matches[0].BindingName).To(Equal("admins-read-helm"))
+		g.Expect(explanation.Matches[0].RoleName).To(Equal("helm-reader"))
+		g.Expect(explanation.Matches[0].Verbs).To(Equal([]string{"get", "list", "watch"}))
+		g.Expect(explanation.NearMisses).To(BeEmpty())
+	})
+
+	t.Run("named objects are allowed by resource names", func(t *testing.T) {
+		g := NewWithT(t)
+
+		podinfo := obj
+		podinfo.Name = "podinfo"
+
+		dev := auth.NewUserPrincipal(auth.ID("dev"), auth.Groups([]string{"devs"}))
+		explanation := authz.ExplainAccess(roles, bindings, dev, podinfo)
+
+		g.Expect(explanation.Allowed).To(BeTrue())
+		g.Expect(explanation.Matches).To(HaveLen(1))
+		g.Expect(explanation.Matches[0].BindingName).To(Equal("read-podinfo"))
+	})
+}
+
+func TestCanImpersonate(t *testing.T) {
+	g := NewWithT(t)
+
+	roles, bindings := explainFixtures()
+	authz := NewAuthorizer(map[string]string{}, kube.UserPrefixes{})
+
+	admin := auth.NewUserPrincipal(auth.ID("admin"))
+	dev := auth.NewUserPrincipal(auth.ID("dev"), auth.Groups([]string{"devs"}))
+
+	ok, err := authz.CanImpersonate(roles, bindings, admin, "management", dev)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(ok).To(BeTrue())
+
+	// Impersonation is allowed per cluster.
+	ok, err = authz.CanImpersonate(roles, bindings, admin, "leaf", dev)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(ok).To(BeFalse())
+
+	ok, err = authz.CanImpersonate(roles, bindings, dev, "management", admin)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(ok).To(BeFalse())
+}
@@ -23,6 +23,7 @@ package rbac
 import (
 	"errors"
 	"fmt"
+	"sync"
 
 	rbacv1 "k8s.io/api/rbac/v1"
 	k8suser "k8s.io/apiserver/pkg/authentication/user"
@@ -31,26 +32,49 @@ import (
 	rbacvalidation "k8s.io/kubernetes/pkg/registry/rbac/validation"
 	rbacauth "k8s.io/kubernetes/plugin/pkg/auth/authorizer/rbac"
 
+	"github.com/weaveworks/weave-gitops/pkg/kube"
 	"github.com/weaveworks/weave-gitops/pkg/server/auth"
 
 	"github.com/weaveworks/weave-gitops-enterprise/pkg/query/internal/models"
 )
 
 // NewAuthorizer constructs an authorizer with the things that are
-// known statically.
-func NewAuthorizer(kindToResource map[string]string) *Authorizer {
+// known statically. The user prefixes are those that are added to
+// the user and groups of principals when impersonating them, so they
+// are what the subjects of bindings name.
+func NewAuthorizer(kindToResource map[string]string, userPrefixes kube.UserPrefixes) *Authorizer {
 	return &Authorizer{
 		kindToResource: kindToResource,
+		userPrefixes:   userPrefixes,
 	}
 }
 
 type Authorizer struct {
+	mu             sync.RWMutex
 	kindToResource map[string]string
+	userPrefixes   kube.UserPrefixes
+}
+
+// SetKindToResource replaces the map of object kinds to resources, as when kinds are
+// added to the ones collected. The predicates already constructed keep the previous map.
+func (authz *Authorizer) SetKindToResource(kindToResource map[string]string) {
+	authz.mu.Lock()
+	defer authz.mu.Unlock()
+
+	authz.kindToResource = kindToResource
+}
+
+func (authz *Authorizer) resources() map[string]string {
+	authz.mu.RLock()
+	defer authz.mu.RUnlock()
+
+	return authz.kindToResource
 }
 
 // ObjectAuthorizer constructs an authorization predicate given the
 // roles and rolebindings, for the particular cluster and principal.
 func (authz *Authorizer) ObjectAuthorizer(roles []models.Role, rolebindings []models.RoleBinding, principal *auth.UserPrincipal, cluster string) func(models.Object) (bool, error) {
+	principal = authz.prefixed(principal)
 	getlist := &clusterRBACGetLister{
 		cluster:      cluster,
 		roles:        roles,
@@ -62,7 +86,7 @@ func (authz *Authorizer) ObjectAuthorizer(roles []models.Role, rolebindings []mo
 		rbacvalidation.RoleBindingLister(getlist),
 		rbacvalidation.ClusterRoleGetter(getlist),
 		rbacvalidation.ClusterRoleBindingLister(getlist))
-	request := &objectAsAttributes{user: principal, kindToResource: authz.kindToResource}
+	request := &objectAsAttributes{user: principal, kindToResource: authz.resources()}
 	return func(obj models.Object) (bool, error) {
 		request.object = obj
 		rules, err := resolver.RulesFor(request.GetUser(), obj.Namespace)
@@ -76,6 +100,22 @@ func (authz *Authorizer) ObjectAuthorizer(roles []models.Role, rolebindings []mo
 	}
 }
 
+// prefixed returns the principal as Kubernetes sees it when it is
+// impersonated, following kube.ConfigWithPrincipal: principals with
+// a token are not impersonated, so their names are not prefixed.
+func (authz *Authorizer) prefixed(principal *auth.UserPrincipal) *auth.UserPrincipal {
+	if principal.Token() != "" || (authz.userPrefixes.UsernamePrefix == "" && authz.userPrefixes.GroupsPrefix == "") {
+		return principal
+	}
+
+	groups := make([]string, len(principal.Groups))
+	for i, group := range principal.Groups {
+		groups[i] = authz.userPrefixes.GroupsPrefix + group
+	}
+
+	return auth.NewUserPrincipal(auth.ID(authz.userPrefixes.UsernamePrefix+principal.ID), auth.Groups(groups))
+}
+
 // This is a copy of RuleAllows in
 // https://github.com/kubernetes/kubernetes/blob/master/plugin/pkg/auth/authorizer/rbac/rbac.go#L178,
 // with added printlns. Replace rbacauth.RuleAllows above if you want
@@ -259,7 +299,7 @@ func (c *clusterRBACGetLister) GetClusterRole(name string) (*rbacv1.ClusterRole,
 		if ind == -1 {
 			return nil, notfound
 		}
-		return makeClusterRole(&c.roles[ind]), nil
+		return c.makeClusterRole(&c.roles[ind])
 	}
 
 	for i := c.next; i < len(c.roles); i++ {
@@ -267,7 +307,7 @@ func (c *clusterRBACGetLister) GetClusterRole(name string) (*rbacv1.ClusterRole,
 		if c.roles[i].Cluster == c.cluster {
 			c.fileRole(i)
 			if c.roles[i].Kind == "ClusterRole" && c.roles[i].Name == name {
-				return makeClusterRole(&c.roles[i]), nil
+				return c.makeClusterRole(&c.roles[i])
 			}
 		}
 	}
@@ -312,12 +352,20 @@ func (c *clusterRBACGetLister) ListRoleBindings(namespace string) ([]*rbacv1.Rol
 // These are essentially undoing the transformation done in
 // internal/models/adapters/.
 
-func makeClusterRole(role *models.Role) *rbacv1.ClusterRole {
+// makeClusterRole includes the rules of the cluster roles selected by
+// the aggregation rule of the role, if it has one. Those are looked up
+// every time, as aggregated roles are few and usually bound only once.
+func (c *clusterRBACGetLister) makeClusterRole(role *models.Role) (*rbacv1.ClusterRole, error) {
+	rules, err := role.AggregatedRules(c.roles)
+	if err != nil {
+		return nil, err
+	}
+
 	r := &rbacv1.ClusterRole{
-		Rules: makePolicyRules(role.PolicyRules),
+		Rules: makePolicyRules(rules),
 	}
 	r.SetName(role.Name)
-	return r
+	return r, nil
 }
 
 func makeRole(role *models.Role) *rbacv1.Role {
@@ -332,8 +380,14 @@ func makeRole(role *models.Role) *rbacv1.Role {
 func makePolicyRules(rules []models.PolicyRule) []rbacv1.PolicyRule {
 	rs := make([]rbacv1.PolicyRule, len(rules))
 	for i := range rules {
+		apiGroups := models.SplitRuleData(rules[i].APIGroups)
+		if apiGroups == nil {
+			// The core group is the empty string, which is joined to an empty string too.
+			// A rule cannot have no API groups, so this is the core group.
+			apiGroups = []string{""}
+		}
 		rs[i] = rbacv1.PolicyRule{
-			APIGroups:     models.SplitRuleData(rules[i].APIGroups),
+			APIGroups:     apiGroups,
 			Resources:     models.SplitRuleData(rules[i].Resources),
 			Verbs:         models.SplitRuleData(rules[i].Verbs),
 			ResourceNames: models.SplitRuleData(rules[i].ResourceNames),
@@ -4,7 +4,11 @@ import (
 	"math/rand"
 	"testing"
 
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
 	"github.com/weaveworks/weave-gitops-enterprise/pkg/query/internal/models"
+	"github.com/weaveworks/weave-gitops/pkg/kube"
 	"github.com/weaveworks/weave-gitops/pkg/server/auth"
 )
 
@@ -246,7 +250,7 @@ func benchmark_RBAC(b *testing.B, numObjects int) {
 
 	for i := 0; i < b.N; i++ {
 		// this is constructed once and supplied to the service
-		authz := NewAuthorizer(kindToResource)
+		authz := NewAuthorizer(kindToResource, kube.UserPrefixes{})
 
 		count := 0
 		// this mimics what the query service does for a request; once
@@ -283,3 +287,136 @@ func Benchmark_RBAC_50perCluster(b *testing.B) {
 func Benchmark_RBAC_ltOnePerCluster(b *testing.B) {
 	benchmark_RBAC(b, 10)
 }
+
+func TestObjectAuthorizer_AggregatedClusterRoles(t *testing.T) {
+	g := NewWithT(t)
+
+	aggregateToView := map[string]string{"rbac.authorization.k8s.io/aggregate-to-view": "true"}
+	roles := []models.Role{
+		{
+			// The aggregation controller has not set the rules yet.
+			Cluster: "management", Kind: "ClusterRole", Name: "view",
+			AggregationSelectors: []metav1.LabelSelector{{MatchLabels: aggregateToView}},
+		},
+		{
+			Cluster: "management", Kind: "ClusterRole", Name: "view-helm", Labels: aggregateToView,
+			PolicyRules: []models.PolicyRule{{APIGroups: "helm.toolkit.fluxcd.io", Resources: "helmreleases", Verbs: "list"}},
+		},
+	}
+	bindings := []models.RoleBinding{{
+		Cluster: "management", Kind: "RoleBinding", Namespace: "apps", Name: "devs-view",
+		RoleRefKind: "ClusterRole", RoleRefName: "view",
+		Subjects: []models.Subject{{Kind: "Group", Name: "devs"}},
+	}}
+
+	authz := NewAuthorizer(map[string]string{"HelmRelease": "helmreleases"}, kube.UserPrefixes{})
+	dev := auth.NewUserPrincipal(auth.ID("dev"), auth.Groups([]string{"devs"}))
+	allowed := authz.ObjectAuthorizer(roles, bindings, dev, "management")
+
+	ok, err := allowed(models.Object{Cluster: "management", Namespace: "apps", APIGroup: "helm.toolkit.fluxcd.io", Kind: "HelmRelease", Name: "podinfo"})
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(ok).To(BeTrue())
+
+	ok, err = allowed(models.Object{Cluster: "management", Namespace: "other", APIGroup: "helm.toolkit.fluxcd.io", Kind: "HelmRelease", Name: "podinfo"})
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(ok).To(BeFalse())
+}
+
+func TestObjectAuthorizer_UserPrefixes(t *testing.T) {
+	roles := []models.Role{{
+		Cluster: "management", Kind: "ClusterRole", Name: "helm-reader",
+		PolicyRules: []models.PolicyRule{{APIGroups: "helm.toolkit.fluxcd.io", Resources: "helmreleases", Verbs: "list"}},
+	}}
+	obj := models.Object{Cluster: "management", Namespace: "apps", APIGroup: "helm.toolkit.fluxcd.io", Kind: "HelmRelease", Name: "podinfo"}
+	prefixes := kube.UserPrefixes{UsernamePrefix: "oidc:", GroupsPrefix: "oidc-group:"}
+
+	tests := []struct {
+		name      string
+		subject   models.Subject
+		principal *auth.UserPrincipal
+		prefixes  kube.UserPrefixes
+		allowed   bool
+	}{
+		{
+			name:      "prefixed user",
+			subject:   models.Subject{Kind: "User", Name: "oidc:alice"},
+			principal: auth.NewUserPrincipal(auth.ID("alice")),
+			prefixes:  prefixes,
+			allowed:   true,
+		},
+		{
+			name:      "prefixed group",
+			subject:   models.Subject{Kind: "Group", Name: "oidc-group:devs"},
+			principal: auth.NewUserPrincipal(auth.ID("alice"), auth.Groups([]string{"devs"})),
+			prefixes:  prefixes,
+			allowed:   true,
+		},
+		{
+			name:      "unprefixed subject",
+			subject:   models.Subject{Kind: "User", Name: "alice"},
+			principal: auth.NewUserPrincipal(auth.ID("alice")),
+			prefixes:  prefixes,
+			allowed:   false,
+		},
+		{
+			name:      "no prefixes",
+			subject:   models.Subject{Kind: "User", Name: "alice"},
+			principal: auth.NewUserPrincipal(auth.ID("alice")),
+			allowed:   true,
+		},
+		{
+			// Principals with a token are not impersonated, so no prefix is added.
+			name:      "token",
+			subject:   models.Subject{Kind: "User", Name: "alice"},
+			principal: auth.NewUserPrincipal(auth.ID("alice"), auth.Token("token")),
+			prefixes:  prefixes,
+			allowed:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			bindings := []models.RoleBinding{{
+				Cluster: "management", Kind: "ClusterRoleBinding", Name: "read-helm",
+				RoleRefKind: "ClusterRole", RoleRefName: "helm-reader",
+				Subjects: []models.Subject{tt.subject},
+			}}
+
+			authz := NewAuthorizer(map[string]string{"HelmRelease": "helmreleases"}, tt.prefixes)
+			ok, err := authz.ObjectAuthorizer(roles, bindings, tt.principal, "management")(obj)
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(ok).To(Equal(tt.allowed))
+		})
+	}
+}
+
+func TestObjectAuthorizer_SetKindToResource(t *testing.T) {
+	g := NewWithT(t)
+
+	roles := []models.Role{{
+		Cluster: "management", Kind: "ClusterRole", Name: "rollout-reader",
+		PolicyRules: []models.PolicyRule{{APIGroups: "argoproj.io", Resources: "rollouts", Verbs: "list"}},
+	}}
+	bindings := []models.RoleBinding{{
+		Cluster: "management", Kind: "ClusterRoleBinding", Name: "devs-rollouts",
+		RoleRefKind: "ClusterRole", RoleRefName: "rollout-reader",
+		Subjects: []models.Subject{{Kind: "Group", Name: "devs"}},
+	}}
+	obj := models.Object{Cluster: "management", Namespace: "apps", APIGroup: "argoproj.io", Kind: "Rollout", Name: "podinfo"}
+	dev := auth.NewUserPrincipal(auth.ID("dev"), auth.Groups([]string{"devs"}))
+
+	// The kind was not served when the map was created.
+	authz := NewAuthorizer(map[string]string{"HelmRelease": "helmreleases"}, kube.UserPrefixes{})
+
+	ok, err := authz.ObjectAuthorizer(roles, bindings, dev, "management")(obj)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(ok).To(BeFalse())
+
+	authz.SetKindToResource(map[string]string{"HelmRelease": "helmreleases", "Rollout": "rollouts"})
+
+	ok, err = authz.ObjectAuthorizer(roles, bindings, dev, "management")(obj)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(ok).To(BeTrue())
+}
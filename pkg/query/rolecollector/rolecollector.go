@@ -85,15 +85,14 @@ func processRecords(objectTransactions []models.ObjectTransaction, store store.S
 				continue
 			}
 
-			// Explorer should support aggregated clusteroles.
-			// Related issue: https://github.com/weaveworks/weave-gitops-enterprise/issues/3443
-			if len(role.GetRules()) == 0 {
-				// Certain roles have no policy rules for some reason.
-				// Possibly related to the rbac.authorization.k8s.io/aggregate-to-gitops-reader label?
+			model := role.ToModel()
+			// Aggregated cluster roles may have no rules of their own until the aggregation controller
+			// sets them, but they are kept so that their bindings grant the rules of the roles they select.
+			if len(model.PolicyRules) == 0 && len(model.AggregationSelectors) == 0 {
 				continue
 			}
 
-			roles = append(roles, role.ToModel())
+			roles = append(roles, model)
 		}
 
 		if kind == "ClusterRoleBinding" || kind == "RoleBinding" {
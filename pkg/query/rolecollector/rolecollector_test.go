@@ -98,3 +98,34 @@ func TestRoleCollector_defaultProcessRecords(t *testing.T) {
 	}
 
 }
+
+func TestRoleCollector_aggregatedClusterRoles(t *testing.T) {
+	g := NewWithT(t)
+	fakeStore := &storefakes.FakeStore{}
+
+	aggregated := testutils.NewClusterRole("view", false, func(cr *rbacv1.ClusterRole) {
+		cr.AggregationRule = &rbacv1.AggregationRule{
+			ClusterRoleSelectors: []metav1.LabelSelector{{
+				MatchLabels: map[string]string{"rbac.authorization.k8s.io/aggregate-to-view": "true"},
+			}},
+		}
+	})
+	selected := testutils.NewClusterRole("view-kustomizations", true, func(cr *rbacv1.ClusterRole) {
+		cr.Labels = map[string]string{"rbac.authorization.k8s.io/aggregate-to-view": "true"}
+	})
+
+	err := processRecords([]models.ObjectTransaction{
+		testutils.NewObjectTransaction("anyCluster", aggregated, models.TransactionTypeUpsert),
+		testutils.NewObjectTransaction("anyCluster", selected, models.TransactionTypeUpsert),
+		// Cluster roles without rules that do not aggregate others grant nothing.
+		testutils.NewObjectTransaction("anyCluster", testutils.NewClusterRole("empty", false), models.TransactionTypeUpsert),
+	}, fakeStore, logr.Discard())
+	g.Expect(err).NotTo(HaveOccurred())
+
+	g.Expect(fakeStore.StoreRolesCallCount()).To(Equal(1))
+	_, roles := fakeStore.StoreRolesArgsForCall(0)
+	g.Expect(roles).To(HaveLen(2))
+	g.Expect(roles[0].Name).To(Equal("view"))
+	g.Expect(roles[0].AggregationSelectors).To(Equal(aggregated.AggregationRule.ClusterRoleSelectors))
+	g.Expect(roles[1].Labels).To(Equal(selected.Labels))
+}
@@ -0,0 +1,180 @@
+package query
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/weaveworks/weave-gitops/pkg/server/auth"
+
+	"github.com/weaveworks/weave-gitops-enterprise/pkg/query/internal/models"
+	"github.com/weaveworks/weave-gitops-enterprise/pkg/query/store"
+)
+
+var (
+	// ErrSavedQueryNotFound is returned for saved queries that do not exist, or that the principal cannot see.
+	ErrSavedQueryNotFound = errors.New("saved query not found")
+	// ErrSavedQueryExists is returned when saving a query with the name of another query of the same user.
+	ErrSavedQueryExists = errors.New("saved query already exists")
+	// ErrInvalidSavedQuery is returned when saving a query that cannot be run.
+	ErrInvalidSavedQuery = errors.New("invalid saved query")
+	// ErrSavedQueryNotOwned is returned when deleting a query saved by another user.
+	ErrSavedQueryNotOwned = errors.New("saved query is owned by another user")
+)
+
+func (q *qs) ListSavedQueries(ctx context.Context) ([]models.SavedQuery, error) {
+	principal := auth.Principal(ctx)
+	if principal == nil {
+		return nil, fmt.Errorf("principal not found")
+	}
+
+	all, err := q.r.GetSavedQueries(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error getting saved queries: %w", err)
+	}
+
+	result := []models.SavedQuery{}
+	for _, saved := range all {
+		if savedQueryVisibleTo(saved, principal) {
+			result = append(result, saved)
+		}
+	}
+
+	return result, nil
+}
+
+func (q *qs) GetSavedQuery(ctx context.Context, id string) (models.SavedQuery, error) {
+	principal := auth.Principal(ctx)
+	if principal == nil {
+		return models.SavedQuery{}, fmt.Errorf("principal not found")
+	}
+
+	return q.visibleSavedQuery(ctx, principal, id)
+}
+
+func (q *qs) CreateSavedQuery(ctx context.Context, saved models.SavedQuery) (models.SavedQuery, error) {
+	principal := auth.Principal(ctx)
+	if principal == nil {
+		return models.SavedQuery{}, fmt.Errorf("principal not found")
+	}
+
+	if q.w == nil {
+		return models.SavedQuery{}, fmt.Errorf("saving queries is not enabled")
+	}
+
+	if err := validateSavedQuery(saved, principal); err != nil {
+		return models.SavedQuery{}, err
+	}
+
+	all, err := q.r.GetSavedQueries(ctx)
+	if err != nil {
+		return models.SavedQuery{}, fmt.Errorf("error getting saved queries: %w", err)
+	}
+
+	for _, existing := range all {
+		if existing.Owner == principal.ID && existing.Name == saved.Name {
+			return models.SavedQuery{}, fmt.Errorf("%w: %q", ErrSavedQueryExists, saved.Name)
+		}
+	}
+
+	saved.ID = uuid.NewString()
+	saved.Owner = principal.ID
+	saved.CreatedAt = time.Now().UTC()
+
+	if err := q.w.StoreSavedQuery(ctx, saved); err != nil {
+		return models.SavedQuery{}, fmt.Errorf("error saving query: %w", err)
+	}
+
+	q.debug.Info("query saved", "id", saved.ID, "name", saved.Name, "sharedWith", saved.SharedWith, "principal", principal.ID)
+	return saved, nil
+}
+
+func (q *qs) DeleteSavedQuery(ctx context.Context, id string) error {
+	principal := auth.Principal(ctx)
+	if principal == nil {
+		return fmt.Errorf("principal not found")
+	}
+
+	if q.w == nil {
+		return fmt.Errorf("saving queries is not enabled")
+	}
+
+	saved, err := q.visibleSavedQuery(ctx, principal, id)
+	if err != nil {
+		return err
+	}
+
+	// The members of the group a query is shared with can run it, but only its owner can delete it.
+	if saved.Owner != principal.ID {
+		return ErrSavedQueryNotOwned
+	}
+
+	if err := q.w.DeleteSavedQuery(ctx, id); err != nil {
+		return fmt.Errorf("error deleting saved query: %w", err)
+	}
+
+	q.debug.Info("saved query deleted", "id", id, "principal", principal.ID)
+	return nil
+}
+
+func (q *qs) visibleSavedQuery(ctx context.Context, principal *auth.UserPrincipal, id string) (models.SavedQuery, error) {
+	all, err := q.r.GetSavedQueries(ctx)
+	if err != nil {
+		return models.SavedQuery{}, fmt.Errorf("error getting saved queries: %w", err)
+	}
+
+	for _, saved := range all {
+		if saved.ID == id && savedQueryVisibleTo(saved, principal) {
+			return saved, nil
+		}
+	}
+
+	return models.SavedQuery{}, ErrSavedQueryNotFound
+}
+
+// savedQueryVisibleTo tells whether the principal saved the query, or is a member of the group it is shared with.
+func savedQueryVisibleTo(saved models.SavedQuery, principal *auth.UserPrincipal) bool {
+	if saved.Owner == principal.ID {
+		return true
+	}
+
+	return saved.SharedWith != "" && memberOf(principal, saved.SharedWith)
+}
+
+func memberOf(principal *auth.UserPrincipal, group string) bool {
+	for _, g := range principal.Groups {
+		if g == group {
+			return true
+		}
+	}
+
+	return false
+}
+
+// validateSavedQuery checks that a query can be run before it is saved, and that
+// it is only shared with a group the principal is a member of.
+func validateSavedQuery(saved models.SavedQuery, principal *auth.UserPrincipal) error {
+	if saved.Name == "" {
+		return fmt.Errorf("%w: name is required", ErrInvalidSavedQuery)
+	}
+
+	if expr := saved.Query; expr != "" {
+		if _, err := store.ParseQuery(expr); err != nil {
+			return err
+		}
+	}
+
+	if len(saved.Columns) > 0 {
+		if _, _, err := newExportColumns(saved.Columns); err != nil {
+			return err
+		}
+	}
+
+	if saved.SharedWith != "" && !memberOf(principal, saved.SharedWith) {
+		return fmt.Errorf("%w: cannot share with group %q, which the user is not a member of", ErrInvalidSavedQuery, saved.SharedWith)
+	}
+
+	return nil
+}
@@ -0,0 +1,145 @@
+package query
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/go-logr/logr"
+	. "github.com/onsi/gomega"
+	"github.com/weaveworks/weave-gitops-enterprise/pkg/query/internal/models"
+	"github.com/weaveworks/weave-gitops-enterprise/pkg/query/store"
+	"github.com/weaveworks/weave-gitops/pkg/server/auth"
+)
+
+// clusterAuthz lets each principal see the objects of its own cluster only.
+type clusterAuthz map[string]string
+
+func (c clusterAuthz) ObjectAuthorizer(_ []models.Role, _ []models.RoleBinding, principal *auth.UserPrincipal, _ string) func(models.Object) (bool, error) {
+	return func(obj models.Object) (bool, error) {
+		return c[principal.ID] == obj.Cluster, nil
+	}
+}
+
+func TestSavedQueries(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	dir, err := os.MkdirTemp("", "test")
+	g.Expect(err).NotTo(HaveOccurred())
+
+	db, err := store.CreateSQLiteDB(dir)
+	g.Expect(err).NotTo(HaveOccurred())
+
+	s, err := store.NewSQLiteStore(db, logr.Discard())
+	g.Expect(err).NotTo(HaveOccurred())
+
+	idx, err := store.NewIndexer(s, dir, logr.Discard())
+	g.Expect(err).NotTo(HaveOccurred())
+
+	objects := []models.Object{
+		{Cluster: "cluster-a", Namespace: "flux-system", Name: "podinfo", Kind: "HelmRelease", APIGroup: "helm.toolkit.fluxcd.io", APIVersion: "v2beta1", Status: "Failed"},
+		{Cluster: "cluster-b", Namespace: "flux-system", Name: "podinfo", Kind: "HelmRelease", APIGroup: "helm.toolkit.fluxcd.io", APIVersion: "v2beta1", Status: "Failed"},
+		{Cluster: "cluster-b", Namespace: "flux-system", Name: "nginx", Kind: "HelmRelease", APIGroup: "helm.toolkit.fluxcd.io", APIVersion: "v2beta1", Status: "Success"},
+	}
+	g.Expect(idx.Add(context.Background(), objects)).To(Succeed())
+	g.Expect(store.SeedObjects(db, objects)).To(Succeed())
+
+	q := &qs{
+		log:   logr.Discard(),
+		debug: logr.Discard(),
+		r:     s,
+		w:     s,
+		index: idx,
+		authorizer: clusterAuthz{
+			"alice": "cluster-a",
+			"bob":   "cluster-b",
+		},
+	}
+
+	alice := auth.WithPrincipal(context.Background(), &auth.UserPrincipal{ID: "alice", Groups: []string{"team-a"}})
+	bob := auth.WithPrincipal(context.Background(), &auth.UserPrincipal{ID: "bob", Groups: []string{"team-a"}})
+	carol := auth.WithPrincipal(context.Background(), &auth.UserPrincipal{ID: "carol", Groups: []string{"team-b"}})
+
+	failing, err := q.CreateSavedQuery(alice, models.SavedQuery{
+		Name:       "failing releases",
+		SharedWith: "team-a",
+		Query:      "kind:HelmRelease AND status:Failed",
+		Columns:    []string{"cluster", "name"},
+	})
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(failing.ID).NotTo(BeEmpty())
+	g.Expect(failing.Owner).To(Equal("alice"))
+
+	private, err := q.CreateSavedQuery(alice, models.SavedQuery{Name: "everything"})
+	g.Expect(err).NotTo(HaveOccurred())
+
+	t.Run("queries are visible to their owner and the group they are shared with", func(t *testing.T) {
+		g := NewWithT(t)
+
+		saved, err := q.ListSavedQueries(alice)
+		g.Expect(err).NotTo(HaveOccurred())
+		g.Expect(saved).To(HaveLen(2))
+
+		saved, err = q.ListSavedQueries(bob)
+		g.Expect(err).NotTo(HaveOccurred())
+		g.Expect(saved).To(HaveLen(1))
+		g.Expect(saved[0].ID).To(Equal(failing.ID))
+
+		saved, err = q.ListSavedQueries(carol)
+		g.Expect(err).NotTo(HaveOccurred())
+		g.Expect(saved).To(BeEmpty())
+
+		_, err = q.GetSavedQuery(bob, private.ID)
+		g.Expect(err).To(MatchError(ErrSavedQueryNotFound))
+	})
+
+	t.Run("running a shared query applies the access of the caller", func(t *testing.T) {
+		g := NewWithT(t)
+
+		saved, err := q.GetSavedQuery(bob, failing.ID)
+		g.Expect(err).NotTo(HaveOccurred())
+
+		objs, _, err := q.RunQuery(bob, saved, &query{})
+		g.Expect(err).NotTo(HaveOccurred())
+		g.Expect(objs).To(HaveLen(1))
+		g.Expect(objs[0].Cluster).To(Equal("cluster-b"))
+		g.Expect(objs[0].Name).To(Equal("podinfo"))
+
+		objs, _, err = q.RunQuery(alice, saved, &query{})
+		g.Expect(err).NotTo(HaveOccurred())
+		g.Expect(objs).To(HaveLen(1))
+		g.Expect(objs[0].Cluster).To(Equal("cluster-a"))
+	})
+
+	t.Run("invalid queries are not saved", func(t *testing.T) {
+		g := NewWithT(t)
+
+		_, err := q.CreateSavedQuery(alice, models.SavedQuery{Name: "failing releases"})
+		g.Expect(err).To(MatchError(ErrSavedQueryExists))
+
+		_, err = q.CreateSavedQuery(alice, models.SavedQuery{})
+		g.Expect(err).To(MatchError(ErrInvalidSavedQuery))
+
+		_, err = q.CreateSavedQuery(alice, models.SavedQuery{Name: "shared", SharedWith: "team-b"})
+		g.Expect(err).To(MatchError(ErrInvalidSavedQuery))
+
+		_, err = q.CreateSavedQuery(alice, models.SavedQuery{Name: "columns", Columns: []string{"spec"}})
+		g.Expect(err).To(MatchError(ErrInvalidColumn))
+
+		var parseErr *store.ParseError
+		_, err = q.CreateSavedQuery(alice, models.SavedQuery{Name: "query", Query: "status:"})
+		g.Expect(err).To(BeAssignableToTypeOf(parseErr))
+	})
+
+	t.Run("only the owner deletes a query", func(t *testing.T) {
+		g := NewWithT(t)
+
+		g.Expect(q.DeleteSavedQuery(bob, failing.ID)).To(MatchError(ErrSavedQueryNotOwned))
+		g.Expect(q.DeleteSavedQuery(carol, failing.ID)).To(MatchError(ErrSavedQueryNotFound))
+		g.Expect(q.DeleteSavedQuery(alice, failing.ID)).To(Succeed())
+
+		saved, err := q.ListSavedQueries(bob)
+		g.Expect(err).NotTo(HaveOccurred())
+		g.Expect(saved).To(BeEmpty())
+	})
+}
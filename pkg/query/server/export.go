@@ -0,0 +1,204 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	pb "github.com/weaveworks/weave-gitops-enterprise/pkg/api/query"
+	"github.com/weaveworks/weave-gitops-enterprise/pkg/query"
+	"github.com/weaveworks/weave-gitops-enterprise/pkg/query/store"
+	"google.golang.org/genproto/googleapis/api/httpbody"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	grpcStatus "google.golang.org/grpc/status"
+)
+
+const exportQueryPath = "/v1/query/export"
+
+const (
+	exportFormatCSV    = "csv"
+	exportFormatNDJSON = "ndjson"
+)
+
+var exportContentTypes = map[string]string{
+	exportFormatCSV:    "text/csv",
+	exportFormatNDJSON: "application/x-ndjson",
+}
+
+func (s *server) ExportQuery(msg *pb.ExportQueryRequest, stream pb.Query_ExportQueryServer) error {
+	msg, err := s.savedQueryExportRequest(stream.Context(), msg)
+	if err != nil {
+		return err
+	}
+
+	format := msg.Format
+	if format == "" {
+		format = exportFormatCSV
+	}
+
+	contentType, ok := exportContentTypes[format]
+	if !ok {
+		return grpcStatus.Errorf(codes.InvalidArgument, "unsupported export format %q", msg.Format)
+	}
+
+	columns := msg.Columns
+	if len(columns) == 0 {
+		columns = query.DefaultExportColumns
+	}
+
+	sent := false
+	send := func(data []byte) error {
+		sent = true
+		return stream.Send(&httpbody.HttpBody{
+			ContentType: contentType,
+			Data:        data,
+		})
+	}
+
+	err = s.qs.Export(stream.Context(), msg, msg.Columns, func(rows [][]string) error {
+		data, err := encodeExportRows(format, columns, rows, !sent)
+		if err != nil {
+			return err
+		}
+		return send(data)
+	})
+	if err != nil {
+		var parseErr *store.ParseError
+		if errors.As(err, &parseErr) {
+			return invalidQueryError(parseErr)
+		}
+		if errors.Is(err, query.ErrInvalidColumn) {
+			return grpcStatus.Error(codes.InvalidArgument, err.Error())
+		}
+		return fmt.Errorf("failed to export query: %w", err)
+	}
+
+	// Nothing matched, but the response still tells its format, and CSV has a header.
+	if !sent {
+		data, err := encodeExportRows(format, columns, nil, true)
+		if err != nil {
+			return fmt.Errorf("failed to export query: %w", err)
+		}
+		return send(data)
+	}
+
+	return nil
+}
+
+// encodeExportRows encodes rows as CSV, preceded by the column names if header is true,
+// or as NDJSON, one object per row with the columns as keys.
+func encodeExportRows(format string, columns []string, rows [][]string, header bool) ([]byte, error) {
+	buf := &bytes.Buffer{}
+
+	switch format {
+	case exportFormatCSV:
+		w := csv.NewWriter(buf)
+		if header {
+			if err := w.Write(columns); err != nil {
+				return nil, err
+			}
+		}
+		if err := w.WriteAll(rows); err != nil {
+			return nil, err
+		}
+	case exportFormatNDJSON:
+		for _, row := range rows {
+			// The keys are written in the order of the columns, rather than sorted as for a map.
+			buf.WriteByte('{')
+			for i, column := range columns {
+				if i > 0 {
+					buf.WriteByte(',')
+				}
+				key, err := json.Marshal(column)
+				if err != nil {
+					return nil, err
+				}
+				value, err := json.Marshal(row[i])
+				if err != nil {
+					return nil, err
+				}
+				buf.Write(key)
+				buf.WriteByte(':')
+				buf.Write(value)
+			}
+			buf.WriteString("}\n")
+		}
+	default:
+		return nil, fmt.Errorf("unsupported export format %q", format)
+	}
+
+	return buf.Bytes(), nil
+}
+
+// exportQueryHandler serves ExportQuery on the gateway mux. The in-process gateway handlers
+// do not support streaming, and the gateway would add a delimiter after each message, so the
+// bodies of the stream are written to the response as they are.
+func exportQueryHandler(mux *runtime.ServeMux, srv pb.QueryServer) runtime.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request, pathParams map[string]string) {
+		ctx, cancel := context.WithCancel(r.Context())
+		defer cancel()
+
+		inbound, outbound := runtime.MarshalerForRequest(mux, r)
+
+		ctx, err := runtime.AnnotateIncomingContext(ctx, mux, r, "/query.v1.Query/ExportQuery", runtime.WithHTTPPathPattern(exportQueryPath))
+		if err != nil {
+			runtime.HTTPError(ctx, mux, outbound, w, r, err)
+			return
+		}
+
+		req := &pb.ExportQueryRequest{}
+		if err := inbound.NewDecoder(r.Body).Decode(req); err != nil && err != io.EOF {
+			runtime.HTTPError(ctx, mux, outbound, w, r, grpcStatus.Errorf(codes.InvalidArgument, "%v", err))
+			return
+		}
+
+		stream := &exportQueryStream{
+			ctx: ctx,
+			w:   w,
+		}
+
+		if err := srv.ExportQuery(req, stream); err != nil && !stream.started {
+			runtime.HTTPError(ctx, mux, outbound, w, r, err)
+		}
+		// Once the response has started, an error can only cut it short.
+	}
+}
+
+// exportQueryStream writes the responses of ExportQuery to an HTTP response.
+// Only Send and Context are used by ExportQuery.
+type exportQueryStream struct {
+	grpc.ServerStream
+
+	ctx     context.Context
+	w       http.ResponseWriter
+	started bool
+}
+
+func (s *exportQueryStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *exportQueryStream) Send(body *httpbody.HttpBody) error {
+	if !s.started {
+		s.w.Header().Set("Content-Type", body.GetContentType())
+		s.w.WriteHeader(http.StatusOK)
+		s.started = true
+	}
+
+	if _, err := s.w.Write(body.GetData()); err != nil {
+		return err
+	}
+
+	if f, ok := s.w.(http.Flusher); ok {
+		f.Flush()
+	}
+
+	return s.ctx.Err()
+}
@@ -0,0 +1,53 @@
+package server
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestEncodeExportRows(t *testing.T) {
+	columns := []string{"name", "message", "labels.team"}
+	rows := [][]string{
+		{"podinfo", "install retries exhausted, \"upgrade\" failed", "blue"},
+		{"flux-system", "", ""},
+	}
+
+	tests := []struct {
+		name   string
+		format string
+		header bool
+		want   string
+	}{
+		{
+			name:   "csv with header",
+			format: exportFormatCSV,
+			header: true,
+			want: "name,message,labels.team\n" +
+				"podinfo,\"install retries exhausted, \"\"upgrade\"\" failed\",blue\n" +
+				"flux-system,,\n",
+		},
+		{
+			name:   "csv without header",
+			format: exportFormatCSV,
+			want: "podinfo,\"install retries exhausted, \"\"upgrade\"\" failed\",blue\n" +
+				"flux-system,,\n",
+		},
+		{
+			name:   "ndjson keeps the order of the columns",
+			format: exportFormatNDJSON,
+			want: `{"name":"podinfo","message":"install retries exhausted, \"upgrade\" failed","labels.team":"blue"}` + "\n" +
+				`{"name":"flux-system","message":"","labels.team":""}` + "\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			data, err := encodeExportRows(tt.format, columns, rows, tt.header)
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(string(data)).To(Equal(tt.want))
+		})
+	}
+}
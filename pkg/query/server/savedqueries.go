@@ -0,0 +1,147 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	pb "github.com/weaveworks/weave-gitops-enterprise/pkg/api/query"
+	"github.com/weaveworks/weave-gitops-enterprise/pkg/query"
+	"github.com/weaveworks/weave-gitops-enterprise/pkg/query/internal/models"
+	"github.com/weaveworks/weave-gitops-enterprise/pkg/query/store"
+	"google.golang.org/grpc/codes"
+	grpcStatus "google.golang.org/grpc/status"
+)
+
+func (s *server) ListSavedQueries(ctx context.Context, msg *pb.ListSavedQueriesRequest) (*pb.ListSavedQueriesResponse, error) {
+	saved, err := s.qs.ListSavedQueries(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list saved queries: %w", err)
+	}
+
+	pbSaved := []*pb.SavedQuery{}
+	for _, q := range saved {
+		pbSaved = append(pbSaved, convertToPbSavedQuery(q))
+	}
+
+	return &pb.ListSavedQueriesResponse{
+		SavedQueries: pbSaved,
+	}, nil
+}
+
+func (s *server) CreateSavedQuery(ctx context.Context, msg *pb.CreateSavedQueryRequest) (*pb.CreateSavedQueryResponse, error) {
+	saved, err := s.qs.CreateSavedQuery(ctx, models.SavedQuery{
+		Name:       msg.Name,
+		SharedWith: msg.SharedWith,
+		Terms:      msg.Terms,
+		Filters:    msg.Filters,
+		Query:      msg.Query,
+		OrderBy:    msg.OrderBy,
+		Descending: msg.Descending,
+		Columns:    msg.Columns,
+	})
+	if err != nil {
+		return nil, savedQueryError("failed to save query", err)
+	}
+
+	return &pb.CreateSavedQueryResponse{
+		SavedQuery: convertToPbSavedQuery(saved),
+	}, nil
+}
+
+func (s *server) DeleteSavedQuery(ctx context.Context, msg *pb.DeleteSavedQueryRequest) (*pb.DeleteSavedQueryResponse, error) {
+	if msg.Id == "" {
+		return nil, grpcStatus.Error(codes.InvalidArgument, "id is required")
+	}
+
+	if err := s.qs.DeleteSavedQuery(ctx, msg.Id); err != nil {
+		return nil, savedQueryError("failed to delete saved query", err)
+	}
+
+	return &pb.DeleteSavedQueryResponse{}, nil
+}
+
+// savedQueryRequest replaces the query of a request with the saved query it refers to, if any.
+// The saved query is run with the access of the caller, like any other query.
+func (s *server) savedQueryRequest(ctx context.Context, msg *pb.DoQueryRequest) (*pb.DoQueryRequest, error) {
+	if msg.SavedQueryId == "" {
+		return msg, nil
+	}
+
+	saved, err := s.qs.GetSavedQuery(ctx, msg.SavedQueryId)
+	if err != nil {
+		return nil, savedQueryError("failed to get saved query", err)
+	}
+
+	return &pb.DoQueryRequest{
+		Terms:      saved.Terms,
+		Filters:    saved.Filters,
+		Query:      saved.Query,
+		OrderBy:    saved.OrderBy,
+		Descending: saved.Descending,
+		Offset:     msg.Offset,
+		Limit:      msg.Limit,
+		PageToken:  msg.PageToken,
+	}, nil
+}
+
+// savedQueryExportRequest replaces the query of an export request with the saved query it refers to, if any.
+func (s *server) savedQueryExportRequest(ctx context.Context, msg *pb.ExportQueryRequest) (*pb.ExportQueryRequest, error) {
+	if msg.SavedQueryId == "" {
+		return msg, nil
+	}
+
+	saved, err := s.qs.GetSavedQuery(ctx, msg.SavedQueryId)
+	if err != nil {
+		return nil, savedQueryError("failed to get saved query", err)
+	}
+
+	columns := msg.Columns
+	if len(columns) == 0 {
+		columns = saved.Columns
+	}
+
+	return &pb.ExportQueryRequest{
+		Terms:   saved.Terms,
+		Filters: saved.Filters,
+		Query:   saved.Query,
+		Format:  msg.Format,
+		Columns: columns,
+	}, nil
+}
+
+// savedQueryError maps the errors of saved queries to statuses.
+func savedQueryError(msg string, err error) error {
+	var parseErr *store.ParseError
+	switch {
+	case errors.As(err, &parseErr):
+		return invalidQueryError(parseErr)
+	case errors.Is(err, query.ErrSavedQueryNotFound):
+		return grpcStatus.Error(codes.NotFound, err.Error())
+	case errors.Is(err, query.ErrSavedQueryExists):
+		return grpcStatus.Error(codes.AlreadyExists, err.Error())
+	case errors.Is(err, query.ErrInvalidSavedQuery), errors.Is(err, query.ErrInvalidColumn):
+		return grpcStatus.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, query.ErrSavedQueryNotOwned):
+		return grpcStatus.Error(codes.PermissionDenied, err.Error())
+	}
+
+	return fmt.Errorf("%s: %w", msg, err)
+}
+
+func convertToPbSavedQuery(q models.SavedQuery) *pb.SavedQuery {
+	return &pb.SavedQuery{
+		Id:         q.ID,
+		Name:       q.Name,
+		Owner:      q.Owner,
+		SharedWith: q.SharedWith,
+		Terms:      q.Terms,
+		Filters:    q.Filters,
+		Query:      q.Query,
+		OrderBy:    q.OrderBy,
+		Descending: q.Descending,
+		Columns:    q.Columns,
+		CreatedAt:  q.CreatedAt.UTC().Format(time.RFC3339),
+	}
+}
@@ -2,15 +2,22 @@ package server
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"net/http"
 	"os"
+	"strings"
 	"time"
 
+	"github.com/weaveworks/weave-gitops-enterprise/pkg/query/alerting"
 	"github.com/weaveworks/weave-gitops-enterprise/pkg/query/cleaner"
 	"github.com/weaveworks/weave-gitops-enterprise/pkg/query/configuration"
+	"github.com/weaveworks/weave-gitops-enterprise/pkg/query/events"
 	"github.com/weaveworks/weave-gitops-enterprise/pkg/query/tenantscollector"
 	"github.com/weaveworks/weave-gitops/core/logger"
+	"github.com/weaveworks/weave-gitops/pkg/kube"
 	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/rest"
 
 	"github.com/go-logr/logr"
 	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
@@ -23,11 +30,15 @@ import (
 	"github.com/weaveworks/weave-gitops-enterprise/pkg/query/internal/models"
 	"github.com/weaveworks/weave-gitops-enterprise/pkg/query/rolecollector"
 
+	"github.com/weaveworks/weave-gitops-enterprise/pkg/query/objectkinds"
 	"github.com/weaveworks/weave-gitops-enterprise/pkg/query/objectscollector"
 	"github.com/weaveworks/weave-gitops-enterprise/pkg/query/rbac"
 	store "github.com/weaveworks/weave-gitops-enterprise/pkg/query/store"
 	"github.com/weaveworks/weave-gitops/core/clustersmngr"
 	"github.com/weaveworks/weave-gitops/pkg/server/auth"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	grpcStatus "google.golang.org/grpc/status"
 )
 
 type server struct {
@@ -41,6 +52,10 @@ type server struct {
 	cleaner          cleaner.ObjectCleaner
 	enabledFor       []string
 	clustersManager  clustersmngr.ClustersManager
+	userPrefixes     kube.UserPrefixes
+	// objectKinds returns the kinds of the objects collected, which change
+	// with the ObjectKind resources of the management cluster.
+	objectKinds func() []configuration.ObjectKind
 }
 
 func (s *server) StopCollection() error {
@@ -66,26 +81,124 @@ type ServerOpts struct {
 	// required to watch clusters
 	ClustersManager clustersmngr.ClustersManager
 	SkipCollection  bool
-	StoreType       string
+	// StoreType is the storage backend, sqlite by default
+	StoreType string
+	// StoreURI is the connection string for backends that are not sqlite
+	StoreURI string
 	// required to map GVRs to GVKs for authz purporses
 	DiscoveryClient     discovery.DiscoveryInterface
 	ObjectKinds         []configuration.ObjectKind
 	ServiceAccount      collector.ImpersonateServiceAccount
 	EnableObjectCleaner bool
 	EnabledFor          []string
+	// ManagementConfig is used to watch the ObjectKind resources of the management cluster,
+	// for the kinds they declare to be collected besides ObjectKinds. Optional.
+	ManagementConfig *rest.Config
+	// UserPrefixes are added to the user and groups of principals when impersonating them,
+	// so they are needed to match principals with the subjects of role bindings.
+	UserPrefixes kube.UserPrefixes
+	// Alerting declares the rules to raise alerts for, and where to send them. Optional. The replicas
+	// elect the one evaluating the rules with a lease in the management cluster, so it requires ManagementConfig.
+	Alerting *alerting.Config
 }
 
 func (s *server) DoQuery(ctx context.Context, msg *pb.DoQueryRequest) (*pb.DoQueryResponse, error) {
-	objs, err := s.qs.RunQuery(ctx, msg, msg)
+	msg, err := s.savedQueryRequest(ctx, msg)
 	if err != nil {
+		return nil, err
+	}
+
+	objs, next, err := s.qs.RunQuery(ctx, msg, msg)
+	if err != nil {
+		var parseErr *store.ParseError
+		if errors.As(err, &parseErr) {
+			return nil, invalidQueryError(parseErr)
+		}
+		if errors.Is(err, store.ErrInvalidPageToken) {
+			return nil, grpcStatus.Error(codes.InvalidArgument, err.Error())
+		}
 		return nil, fmt.Errorf("failed to run query: %w", err)
 	}
 
 	return &pb.DoQueryResponse{
-		Objects: convertToPbObject(objs),
+		Objects:       convertToPbObject(objs),
+		NextPageToken: next,
+	}, nil
+}
+
+// invalidQueryError reports a structured query syntax error as an InvalidArgument status,
+// with the location of the error attached as a field violation on `query`.
+func invalidQueryError(parseErr *store.ParseError) error {
+	st := grpcStatus.New(codes.InvalidArgument, parseErr.Error())
+
+	detailed, err := st.WithDetails(&errdetails.BadRequest{
+		FieldViolations: []*errdetails.BadRequest_FieldViolation{{
+			Field:       "query",
+			Description: parseErr.Error(),
+		}},
+	})
+	if err != nil {
+		return st.Err()
+	}
+
+	return detailed.Err()
+}
+
+func (s *server) AggregateQuery(ctx context.Context, msg *pb.AggregateQueryRequest) (*pb.AggregateQueryResponse, error) {
+	buckets, err := s.qs.Aggregate(ctx, msg, msg.GroupBy)
+	if err != nil {
+		var parseErr *store.ParseError
+		if errors.As(err, &parseErr) {
+			return nil, invalidQueryError(parseErr)
+		}
+		if errors.Is(err, query.ErrInvalidGroupBy) {
+			return nil, grpcStatus.Error(codes.InvalidArgument, err.Error())
+		}
+		return nil, fmt.Errorf("failed to aggregate query: %w", err)
+	}
+
+	return &pb.AggregateQueryResponse{
+		Buckets: convertToPbAggregationBucket(buckets),
 	}, nil
 }
 
+func (s *server) GetObjectHistory(ctx context.Context, msg *pb.GetObjectHistoryRequest) (*pb.GetObjectHistoryResponse, error) {
+	if msg.Id == "" {
+		return nil, grpcStatus.Error(codes.InvalidArgument, "id is required")
+	}
+
+	revisions, err := s.qs.GetObjectHistory(ctx, msg.Id)
+	if err != nil {
+		if errors.Is(err, query.ErrObjectNotFound) {
+			return nil, grpcStatus.Errorf(codes.NotFound, "object %q not found", msg.Id)
+		}
+		return nil, fmt.Errorf("failed to get object history: %w", err)
+	}
+
+	return &pb.GetObjectHistoryResponse{
+		Revisions: convertToPbObjectRevision(revisions),
+	}, nil
+}
+
+func (s *server) GetObjectGraph(ctx context.Context, msg *pb.GetObjectGraphRequest) (*pb.GetObjectGraphResponse, error) {
+	if msg.Id == "" {
+		return nil, grpcStatus.Error(codes.InvalidArgument, "id is required")
+	}
+
+	graph, err := s.qs.GetObjectGraph(ctx, msg.Id, int(msg.Depth), query.GraphDirection(msg.Direction))
+	if err != nil {
+		if errors.Is(err, query.ErrObjectNotFound) {
+			return nil, grpcStatus.Errorf(codes.NotFound, "object %q not found", msg.Id)
+		}
+		if errors.Is(err, query.ErrInvalidGraphRequest) {
+			return nil, grpcStatus.Error(codes.InvalidArgument, err.Error())
+		}
+		return nil, fmt.Errorf("failed to get object graph: %w", err)
+	}
+
+	return convertToPbObjectGraph(graph), nil
+}
+
 func (s *server) DebugGetAccessRules(ctx context.Context, msg *pb.DebugGetAccessRulesRequest) (*pb.DebugGetAccessRulesResponse, error) {
 	rules, err := s.qs.GetAccessRules(ctx)
 	if err != nil {
@@ -94,21 +207,51 @@ func (s *server) DebugGetAccessRules(ctx context.Context, msg *pb.DebugGetAccess
 
 	user := auth.Principal(ctx)
 
-	matching := accesschecker.RelevantRulesForUser(user, rules)
+	matching := accesschecker.RelevantRulesForUser(user, rules, s.userPrefixes)
 	return &pb.DebugGetAccessRulesResponse{
 		Rules: convertToPbAccessRule(matching),
 	}, nil
 }
 
+func (s *server) ExplainAccess(ctx context.Context, msg *pb.ExplainAccessRequest) (*pb.ExplainAccessResponse, error) {
+	if msg.Id == "" {
+		return nil, grpcStatus.Error(codes.InvalidArgument, "id is required")
+	}
+
+	var subject *auth.UserPrincipal
+	if msg.User != "" || len(msg.Groups) > 0 {
+		subject = auth.NewUserPrincipal(auth.ID(msg.User), auth.Groups(msg.Groups))
+	}
+
+	explanation, err := s.qs.ExplainAccess(ctx, msg.Id, subject)
+	if err != nil {
+		if errors.Is(err, query.ErrInvalidObjectID) {
+			return nil, grpcStatus.Error(codes.InvalidArgument, err.Error())
+		}
+		if errors.Is(err, query.ErrImpersonationNotAllowed) {
+			return nil, grpcStatus.Error(codes.PermissionDenied, err.Error())
+		}
+		return nil, fmt.Errorf("failed to explain access: %w", err)
+	}
+
+	return &pb.ExplainAccessResponse{
+		Allowed:    explanation.Allowed,
+		Matches:    convertToPbRuleMatch(explanation.Matches),
+		NearMisses: convertToPbRuleMatch(explanation.NearMisses),
+	}, nil
+}
+
 func (s *server) ListFacets(ctx context.Context, msg *pb.ListFacetsRequest) (*pb.ListFacetsResponse, error) {
-	facets, err := s.qs.ListFacets(ctx, configuration.ObjectCategory(msg.Category))
+	kinds := s.objectKinds()
+
+	facets, err := s.qs.ListFacets(ctx, configuration.ObjectCategory(msg.Category), kinds)
 	if err != nil {
 		return nil, fmt.Errorf("failed to list facets: %w", err)
 	}
 
 	humanReadableLabelKeys := map[string]string{}
 
-	for _, objectKind := range configuration.SupportedObjectKinds {
+	for _, objectKind := range kinds {
 		for _, label := range objectKind.Labels {
 			if objectKind.HumanReadableLabelKeys != nil {
 				// Substitute human readable label keys for label with dot notation: labels.<label>
@@ -183,6 +326,9 @@ func (so *ServerOpts) Validate() error {
 	if so.ServiceAccount.Namespace == "" {
 		return fmt.Errorf("service account namespace cannot be empty")
 	}
+	if so.Alerting != nil && so.ManagementConfig == nil {
+		return fmt.Errorf("management config cannot be nil when alerting is enabled")
+	}
 	return nil
 }
 
@@ -193,12 +339,30 @@ func NewServer(opts ServerOpts) (_ pb.QueryServer, _ func() error, retErr error)
 
 	debug := opts.Logger.WithName("query-server").V(logger.LogLevelDebug)
 
-	dbDir, err := os.MkdirTemp("", "db")
-	if err != nil {
-		return nil, nil, err
+	backend := store.StorageBackend(opts.StoreType)
+	storeURI := opts.StoreURI
+
+	if backend == "" {
+		backend = store.StorageBackendSQLite
 	}
 
-	s, err := store.NewStore(store.StorageBackendSQLite, dbDir, opts.Logger)
+	if opts.Alerting != nil && backend != store.StorageBackendPostgres {
+		if rules := opts.Alerting.SavedQueryRules(); len(rules) > 0 {
+			return nil, nil, fmt.Errorf("alerting rules %s reference saved queries, which are lost on restart unless the store is postgres: declare their queries instead", strings.Join(rules, ", "))
+		}
+	}
+
+	if backend == store.StorageBackendSQLite {
+		dbDir, err := os.MkdirTemp("", "db")
+		if err != nil {
+			return nil, nil, err
+		}
+		storeURI = dbDir
+
+		opts.Logger.Info("explorer store is not persistent: saved queries are lost on restart, use the postgres backend to keep them")
+	}
+
+	s, err := store.NewStore(backend, storeURI, opts.Logger)
 	if err != nil {
 		return nil, nil, fmt.Errorf("cannot create store:%w", err)
 	}
@@ -208,7 +372,7 @@ func NewServer(opts ServerOpts) (_ pb.QueryServer, _ func() error, retErr error)
 		return nil, nil, fmt.Errorf("cannot create resources map:%w", err)
 	}
 
-	authz := rbac.NewAuthorizer(kindToResourceMap)
+	authz := rbac.NewAuthorizer(kindToResourceMap, opts.UserPrefixes)
 
 	idxDir, err := os.MkdirTemp("", "index")
 	if err != nil {
@@ -220,11 +384,25 @@ func NewServer(opts ServerOpts) (_ pb.QueryServer, _ func() error, retErr error)
 		return nil, nil, fmt.Errorf("cannot create indexer: %w", err)
 	}
 
+	// The index lives on local disk, so it starts empty even when the store
+	// already holds objects collected before a restart or by another replica.
+	if backend != store.StorageBackendSQLite {
+		if err := indexStoredObjects(context.Background(), s, idx); err != nil {
+			return nil, nil, fmt.Errorf("cannot index stored objects: %w", err)
+		}
+		debug.Info("stored objects indexed")
+	}
+
+	// The objects collector publishes the changes it writes, for queries to be watched.
+	broadcaster := events.NewBroadcaster(opts.Logger)
+
 	qs, err := query.NewQueryService(query.QueryServiceOpts{
 		Log:         opts.Logger,
 		StoreReader: s,
 		IndexReader: idx,
 		Authorizer:  authz,
+		Events:      broadcaster,
+		StoreWriter: s,
 	})
 	if err != nil {
 		return nil, nil, fmt.Errorf("failed to create query service: %w", err)
@@ -235,6 +413,8 @@ func NewServer(opts ServerOpts) (_ pb.QueryServer, _ func() error, retErr error)
 		qs:              qs,
 		enabledFor:      opts.EnabledFor,
 		clustersManager: opts.ClustersManager,
+		userPrefixes:    opts.UserPrefixes,
+		objectKinds:     staticObjectKinds(opts.ObjectKinds),
 	}
 
 	if !opts.SkipCollection {
@@ -254,7 +434,7 @@ func NewServer(opts ServerOpts) (_ pb.QueryServer, _ func() error, retErr error)
 			return nil, nil, fmt.Errorf("failed to create access rules collector: %w", err)
 		}
 
-		objsCollector, err := objectscollector.NewObjectsCollector(s, idx, clusters.MakeSubscriber(opts.ClustersManager), opts.ServiceAccount, opts.ObjectKinds, opts.Logger)
+		objsCollector, err := objectscollector.NewObjectsCollector(s, idx, broadcaster, clusters.MakeSubscriber(opts.ClustersManager), opts.ServiceAccount, opts.ObjectKinds, opts.Logger)
 		if err != nil {
 			return nil, nil, fmt.Errorf("failed to create applications collector: %w", err)
 		}
@@ -288,7 +468,7 @@ func NewServer(opts ServerOpts) (_ pb.QueryServer, _ func() error, retErr error)
 				Log:      opts.Logger,
 				Index:    idx,
 				Interval: 1 * time.Hour,
-				Config:   opts.ObjectKinds,
+				Config:   objsCollector.ObjectKinds(),
 			})
 
 			if err != nil {
@@ -302,8 +482,63 @@ func NewServer(opts ServerOpts) (_ pb.QueryServer, _ func() error, retErr error)
 			serv.cleaner = oc
 		}
 
+		if opts.ManagementConfig != nil {
+			go func() {
+				setObjectKinds := func(ctx context.Context, kinds []configuration.ObjectKind) error {
+					if err := objsCollector.SetObjectKinds(ctx, kinds); err != nil {
+						return err
+					}
+
+					// The objects of the kinds collected expire with their retention policy.
+					if serv.cleaner != nil {
+						serv.cleaner.SetObjectKinds(kinds)
+					}
+
+					// The kinds added may be of resources that were not served when the server started.
+					kindToResourceMap, err := createKindToResourceMap(opts.DiscoveryClient)
+					if err != nil {
+						opts.Logger.Error(err, "cannot update resources map")
+						return nil
+					}
+					authz.SetKindToResource(kindToResourceMap)
+
+					return nil
+				}
+
+				if err := objectkinds.Start(ctx, opts.ManagementConfig, opts.ObjectKinds, setObjectKinds, opts.Logger); err != nil {
+					opts.Logger.Error(err, "object kinds watcher failed")
+				}
+			}()
+		}
+
+		if opts.Alerting != nil {
+			sinks, err := opts.Alerting.NewSinks()
+			if err != nil {
+				return nil, nil, fmt.Errorf("failed to create alerting sinks: %w", err)
+			}
+
+			evaluator, err := alerting.NewEvaluator(alerting.EvaluatorOpts{
+				Log:      opts.Logger,
+				Store:    s,
+				Index:    idx,
+				Interval: opts.Alerting.Interval.Duration,
+				Rules:    opts.Alerting.Rules,
+				Sinks:    sinks,
+			})
+			if err != nil {
+				return nil, nil, fmt.Errorf("failed to create alerting evaluator: %w", err)
+			}
+
+			go func() {
+				if err := evaluator.StartWithLeaderElection(ctx, opts.ManagementConfig); err != nil {
+					opts.Logger.Error(err, "alerting evaluator failed")
+				}
+			}()
+		}
+
 		serv.arc = rulesCollector
 		serv.objs = objsCollector
+		serv.objectKinds = objsCollector.ObjectKinds
 		serv.cancelCollection = cancel
 
 		debug.Info("collectors started")
@@ -312,13 +547,49 @@ func NewServer(opts ServerOpts) (_ pb.QueryServer, _ func() error, retErr error)
 	return serv, serv.StopCollection, nil
 }
 
+// staticObjectKinds returns the kinds of the objects when they are not collected by this server,
+// which are the supported kinds unless others are given.
+func staticObjectKinds(kinds []configuration.ObjectKind) func() []configuration.ObjectKind {
+	if len(kinds) == 0 {
+		kinds = configuration.SupportedObjectKinds
+	}
+
+	return func() []configuration.ObjectKind {
+		return kinds
+	}
+}
+
+func indexStoredObjects(ctx context.Context, s store.StoreReader, idx store.IndexWriter) error {
+	iter, err := s.GetAllObjects(ctx)
+	if err != nil {
+		return fmt.Errorf("cannot get stored objects: %w", err)
+	}
+
+	objs, err := iter.All()
+	if err != nil {
+		return fmt.Errorf("cannot read stored objects: %w", err)
+	}
+
+	return idx.Add(ctx, objs)
+}
+
 func Hydrate(ctx context.Context, mux *runtime.ServeMux, opts ServerOpts) (func() error, error) {
 	s, stop, err := NewServer(opts)
 	if err != nil {
 		return nil, err
 	}
 
-	return stop, pb.RegisterQueryHandlerServer(ctx, mux, s)
+	if err := pb.RegisterQueryHandlerServer(ctx, mux, s); err != nil {
+		return stop, err
+	}
+
+	// Registered after the generated handlers so that they take precedence over
+	// the in-process streaming handlers, which do not support streaming.
+	if err := mux.HandlePath(http.MethodPost, watchQueryPath, watchQueryHandler(mux, s)); err != nil {
+		return stop, err
+	}
+
+	return stop, mux.HandlePath(http.MethodPost, exportQueryPath, exportQueryHandler(mux, s))
 }
 
 func convertToPbObject(obj []models.Object) []*pb.Object {
@@ -345,6 +616,67 @@ func convertToPbObject(obj []models.Object) []*pb.Object {
 	return pbObjects
 }
 
+func convertToPbAggregationBucket(buckets []models.AggregationBucket) []*pb.AggregationBucket {
+	pbBuckets := []*pb.AggregationBucket{}
+
+	for _, b := range buckets {
+		pbBuckets = append(pbBuckets, &pb.AggregationBucket{
+			Group: b.Group,
+			Count: b.Count,
+		})
+	}
+
+	return pbBuckets
+}
+
+func convertToPbObjectRevision(revisions []models.ObjectRevision) []*pb.ObjectRevision {
+	pbRevisions := []*pb.ObjectRevision{}
+
+	for _, r := range revisions {
+		pbRevisions = append(pbRevisions, &pb.ObjectRevision{
+			Status:     r.Status,
+			Message:    r.Message,
+			Spec:       string(r.Spec),
+			RecordedAt: r.RecordedAt.UTC().Format(time.RFC3339),
+		})
+	}
+
+	return pbRevisions
+}
+
+func convertToPbObjectGraph(graph models.ObjectGraph) *pb.GetObjectGraphResponse {
+	response := &pb.GetObjectGraphResponse{
+		Nodes: []*pb.GraphNode{},
+		Edges: []*pb.GraphEdge{},
+	}
+
+	for _, n := range graph.Nodes {
+		node := &pb.GraphNode{
+			Ref:       n.Reference.String(),
+			Cluster:   n.Reference.Cluster,
+			Namespace: n.Reference.Namespace,
+			ApiGroup:  n.Reference.APIGroup,
+			Kind:      n.Reference.Kind,
+			Name:      n.Reference.Name,
+			Depth:     int32(n.Depth),
+		}
+		if n.Object != nil {
+			node.Object = convertToPbObject([]models.Object{*n.Object})[0]
+		}
+		response.Nodes = append(response.Nodes, node)
+	}
+
+	for _, e := range graph.Edges {
+		response.Edges = append(response.Edges, &pb.GraphEdge{
+			Upstream:   e.Upstream,
+			Downstream: e.Downstream,
+			Relation:   string(e.Relation),
+		})
+	}
+
+	return response
+}
+
 func convertToPbAccessRule(rules []models.AccessRule) []*pb.AccessRule {
 	pbRules := []*pb.AccessRule{}
 
@@ -373,6 +705,29 @@ func convertToPbAccessRule(rules []models.AccessRule) []*pb.AccessRule {
 	return pbRules
 }
 
+func convertToPbRuleMatch(matches []models.RuleMatch) []*pb.RuleMatch {
+	pbMatches := []*pb.RuleMatch{}
+
+	for _, m := range matches {
+		pbMatches = append(pbMatches, &pb.RuleMatch{
+			BindingKind:      m.BindingKind,
+			BindingNamespace: m.BindingNamespace,
+			BindingName:      m.BindingName,
+			RoleKind:         m.RoleKind,
+			RoleNamespace:    m.RoleNamespace,
+			RoleName:         m.RoleName,
+			ApiGroups:        m.APIGroups,
+			Resources:        m.Resources,
+			Verbs:            m.Verbs,
+			ResourceNames:    m.ResourceNames,
+			Reason:           m.Reason,
+			Message:          m.Message,
+		})
+	}
+
+	return pbMatches
+}
+
 func convertToPbFacet(facets store.Facets) []*pb.Facet {
 	pbFacets := []*pb.Facet{}
 
@@ -2,19 +2,32 @@ package server
 
 import (
 	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
 	"testing"
 
 	"github.com/go-logr/logr"
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
 	. "github.com/onsi/gomega"
 	"github.com/weaveworks/weave-gitops-enterprise/internal/grpctesting"
 	pb "github.com/weaveworks/weave-gitops-enterprise/pkg/api/query"
+	"github.com/weaveworks/weave-gitops-enterprise/pkg/query/alerting"
 	"github.com/weaveworks/weave-gitops-enterprise/pkg/query/collector"
 	"github.com/weaveworks/weave-gitops-enterprise/pkg/query/configuration"
+	"github.com/weaveworks/weave-gitops-enterprise/pkg/query/configuration/v1alpha1"
+	"github.com/weaveworks/weave-gitops-enterprise/pkg/query/objectscollector"
 	"github.com/weaveworks/weave-gitops/core/clustersmngr"
 	"github.com/weaveworks/weave-gitops/core/clustersmngr/clustersmngrfakes"
+	"github.com/weaveworks/weave-gitops/pkg/server/auth"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	grpcStatus "google.golang.org/grpc/status"
 	fakediscovery "k8s.io/client-go/discovery/fake"
 	fakeclientset "k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/rest"
 )
 
 func TestNewServer(t *testing.T) {
@@ -59,6 +72,39 @@ func TestNewServer(t *testing.T) {
 			},
 			errPattern: "",
 		},
+		{
+			name: "cannot alert on saved queries without the postgres store",
+			options: ServerOpts{
+				Logger:      logr.Discard(),
+				ObjectKinds: configuration.SupportedObjectKinds,
+				ServiceAccount: collector.ImpersonateServiceAccount{
+					Name:      "collector",
+					Namespace: "flux-system",
+				},
+				DiscoveryClient:  fakeDiscovery,
+				ClustersManager:  clustersManager,
+				ManagementConfig: &rest.Config{},
+				Alerting: &alerting.Config{
+					Rules: []alerting.Rule{{Name: "failed-releases", SavedQuery: "releases"}},
+				},
+			},
+			errPattern: "alerting rules failed-releases reference saved queries",
+		},
+		{
+			name: "cannot alert without the management config",
+			options: ServerOpts{
+				Logger:      logr.Discard(),
+				ObjectKinds: configuration.SupportedObjectKinds,
+				ServiceAccount: collector.ImpersonateServiceAccount{
+					Name:      "collector",
+					Namespace: "flux-system",
+				},
+				DiscoveryClient: fakeDiscovery,
+				ClustersManager: clustersManager,
+				Alerting:        &alerting.Config{},
+			},
+			errPattern: "management config cannot be nil when alerting is enabled",
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -81,6 +127,53 @@ func TestNewServer(t *testing.T) {
 
 }
 
+func TestObjectKinds(t *testing.T) {
+	g := NewWithT(t)
+
+	clustersManager := &clustersmngrfakes.FakeClustersManager{}
+	cmw := clustersmngr.ClustersWatcher{
+		Updates: make(chan clustersmngr.ClusterListUpdate),
+	}
+	clustersManager.SubscribeReturns(&cmw)
+
+	client := fakeclientset.NewSimpleClientset()
+	fakeDiscovery, _ := client.Discovery().(*fakediscovery.FakeDiscovery)
+
+	opts := ServerOpts{
+		Logger:      logr.Discard(),
+		ObjectKinds: configuration.SupportedObjectKinds,
+		ServiceAccount: collector.ImpersonateServiceAccount{
+			Name:      "collector",
+			Namespace: "flux-system",
+		},
+		DiscoveryClient:     fakeDiscovery,
+		ClustersManager:     clustersManager,
+		EnableObjectCleaner: true,
+	}
+
+	srv, _, err := NewServer(opts)
+	g.Expect(err).To(BeNil())
+
+	s := srv.(*server)
+	g.Expect(s.objectKinds()).To(HaveLen(len(configuration.SupportedObjectKinds)))
+
+	custom, err := configuration.NewCustomObjectKind(v1alpha1.ObjectKindSpec{
+		Group:    "cert-manager.io",
+		Version:  "v1",
+		Kind:     "Certificate",
+		Category: string(configuration.CategorySource),
+		Labels:   []string{"cert-manager.io/issuer"},
+	}, logr.Discard())
+	g.Expect(err).NotTo(HaveOccurred())
+
+	kinds := append([]configuration.ObjectKind{custom}, configuration.SupportedObjectKinds...)
+	g.Expect(s.objs.(*objectscollector.ObjectsCollector).SetObjectKinds(context.Background(), kinds)).To(Succeed())
+
+	// The facets are listed for the kinds the collector collects, custom ones included.
+	g.Expect(s.objectKinds()).To(HaveLen(len(kinds)))
+	g.Expect(s.objectKinds()[0].Gvk).To(Equal(custom.Gvk))
+}
+
 func TestListEnabledComponents(t *testing.T) {
 	g := NewWithT(t)
 
@@ -172,3 +265,294 @@ func TestListEnabledComponents_Invalid(t *testing.T) {
 
 	g.Expect(res.Components).NotTo(ContainElement(pb.EnabledComponent_applications))
 }
+
+func TestDoQuery_InvalidQuery(t *testing.T) {
+	g := NewWithT(t)
+
+	clustersManager := &clustersmngrfakes.FakeClustersManager{}
+	client := fakeclientset.NewSimpleClientset()
+	fakeDiscovery, _ := client.Discovery().(*fakediscovery.FakeDiscovery)
+
+	opts := ServerOpts{
+		Logger:      logr.Discard(),
+		ObjectKinds: configuration.SupportedObjectKinds,
+		ServiceAccount: collector.ImpersonateServiceAccount{
+			Name:      "collector",
+			Namespace: "flux-system",
+		},
+		DiscoveryClient: fakeDiscovery,
+		ClustersManager: clustersManager,
+		SkipCollection:  true,
+	}
+
+	srv, stop, err := NewServer(opts)
+	g.Expect(err).To(BeNil())
+	defer func() {
+		g.Expect(stop()).To(Succeed())
+	}()
+
+	ctx := auth.WithPrincipal(context.Background(), &auth.UserPrincipal{ID: "test"})
+
+	_, err = srv.DoQuery(ctx, &pb.DoQueryRequest{Query: "kind:HelmRelease AND (status:Failed"})
+	g.Expect(err).To(HaveOccurred())
+
+	st, ok := grpcStatus.FromError(err)
+	g.Expect(ok).To(BeTrue())
+	g.Expect(st.Code()).To(Equal(codes.InvalidArgument))
+	g.Expect(st.Message()).To(Equal("invalid query at column 22: missing ')' for this '('"))
+
+	g.Expect(st.Details()).To(HaveLen(1))
+	badRequest, ok := st.Details()[0].(*errdetails.BadRequest)
+	g.Expect(ok).To(BeTrue())
+	g.Expect(badRequest.FieldViolations[0].Field).To(Equal("query"))
+
+	_, err = srv.DoQuery(ctx, &pb.DoQueryRequest{PageToken: "invalid"})
+	g.Expect(grpcStatus.Code(err)).To(Equal(codes.InvalidArgument))
+}
+
+func TestExplainAccess(t *testing.T) {
+	g := NewWithT(t)
+
+	client := fakeclientset.NewSimpleClientset()
+	fakeDiscovery, _ := client.Discovery().(*fakediscovery.FakeDiscovery)
+
+	opts := ServerOpts{
+		Logger:      logr.Discard(),
+		ObjectKinds: configuration.SupportedObjectKinds,
+		ServiceAccount: collector.ImpersonateServiceAccount{
+			Name:      "collector",
+			Namespace: "flux-system",
+		},
+		DiscoveryClient: fakeDiscovery,
+		ClustersManager: &clustersmngrfakes.FakeClustersManager{},
+		SkipCollection:  true,
+	}
+
+	srv, stop, err := NewServer(opts)
+	g.Expect(err).To(BeNil())
+	defer func() {
+		g.Expect(stop()).To(Succeed())
+	}()
+
+	ctx := auth.WithPrincipal(context.Background(), &auth.UserPrincipal{ID: "test"})
+	id := "management/flux-system/helm.toolkit.fluxcd.io/v2beta1/HelmRelease/podinfo"
+
+	res, err := srv.ExplainAccess(ctx, &pb.ExplainAccessRequest{Id: id})
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(res.Allowed).To(BeFalse())
+	g.Expect(res.Matches).To(BeEmpty())
+
+	_, err = srv.ExplainAccess(ctx, &pb.ExplainAccessRequest{Id: id, User: "other"})
+	g.Expect(grpcStatus.Code(err)).To(Equal(codes.PermissionDenied))
+
+	_, err = srv.ExplainAccess(ctx, &pb.ExplainAccessRequest{Id: "podinfo"})
+	g.Expect(grpcStatus.Code(err)).To(Equal(codes.InvalidArgument))
+
+	_, err = srv.ExplainAccess(ctx, &pb.ExplainAccessRequest{})
+	g.Expect(grpcStatus.Code(err)).To(Equal(codes.InvalidArgument))
+}
+
+func TestSavedQueries(t *testing.T) {
+	g := NewWithT(t)
+
+	client := fakeclientset.NewSimpleClientset()
+	fakeDiscovery, _ := client.Discovery().(*fakediscovery.FakeDiscovery)
+
+	opts := ServerOpts{
+		Logger:      logr.Discard(),
+		ObjectKinds: configuration.SupportedObjectKinds,
+		ServiceAccount: collector.ImpersonateServiceAccount{
+			Name:      "collector",
+			Namespace: "flux-system",
+		},
+		DiscoveryClient: fakeDiscovery,
+		ClustersManager: &clustersmngrfakes.FakeClustersManager{},
+		SkipCollection:  true,
+	}
+
+	srv, stop, err := NewServer(opts)
+	g.Expect(err).To(BeNil())
+	defer func() {
+		g.Expect(stop()).To(Succeed())
+	}()
+
+	ctx := auth.WithPrincipal(context.Background(), &auth.UserPrincipal{ID: "test", Groups: []string{"team-a"}})
+	other := auth.WithPrincipal(context.Background(), &auth.UserPrincipal{ID: "other", Groups: []string{"team-a"}})
+
+	created, err := srv.CreateSavedQuery(ctx, &pb.CreateSavedQueryRequest{
+		Name:       "failing releases",
+		SharedWith: "team-a",
+		Query:      "kind:HelmRelease AND status:Failed",
+		Columns:    []string{"cluster", "name"},
+	})
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(created.SavedQuery.Owner).To(Equal("test"))
+	g.Expect(created.SavedQuery.CreatedAt).NotTo(BeEmpty())
+
+	list, err := srv.ListSavedQueries(other, &pb.ListSavedQueriesRequest{})
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(list.SavedQueries).To(HaveLen(1))
+	g.Expect(list.SavedQueries[0].Id).To(Equal(created.SavedQuery.Id))
+
+	_, err = srv.DoQuery(other, &pb.DoQueryRequest{SavedQueryId: created.SavedQuery.Id})
+	g.Expect(err).NotTo(HaveOccurred())
+
+	_, err = srv.DoQuery(ctx, &pb.DoQueryRequest{SavedQueryId: "unknown"})
+	g.Expect(grpcStatus.Code(err)).To(Equal(codes.NotFound))
+
+	_, err = srv.CreateSavedQuery(ctx, &pb.CreateSavedQueryRequest{Name: "failing releases"})
+	g.Expect(grpcStatus.Code(err)).To(Equal(codes.AlreadyExists))
+
+	_, err = srv.CreateSavedQuery(ctx, &pb.CreateSavedQueryRequest{Name: "invalid", Query: "kind:HelmRelease AND (status:Failed"})
+	g.Expect(grpcStatus.Code(err)).To(Equal(codes.InvalidArgument))
+
+	_, err = srv.DeleteSavedQuery(other, &pb.DeleteSavedQueryRequest{Id: created.SavedQuery.Id})
+	g.Expect(grpcStatus.Code(err)).To(Equal(codes.PermissionDenied))
+
+	_, err = srv.DeleteSavedQuery(ctx, &pb.DeleteSavedQueryRequest{Id: created.SavedQuery.Id})
+	g.Expect(err).NotTo(HaveOccurred())
+
+	list, err = srv.ListSavedQueries(ctx, &pb.ListSavedQueriesRequest{})
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(list.SavedQueries).To(BeEmpty())
+}
+
+func TestHydrate_WatchQuery(t *testing.T) {
+	g := NewWithT(t)
+
+	client := fakeclientset.NewSimpleClientset()
+	fakeDiscovery, _ := client.Discovery().(*fakediscovery.FakeDiscovery)
+
+	mux := runtime.NewServeMux()
+
+	stop, err := Hydrate(context.Background(), mux, ServerOpts{
+		Logger:      logr.Discard(),
+		ObjectKinds: configuration.SupportedObjectKinds,
+		ServiceAccount: collector.ImpersonateServiceAccount{
+			Name:      "collector",
+			Namespace: "flux-system",
+		},
+		DiscoveryClient: fakeDiscovery,
+		ClustersManager: &clustersmngrfakes.FakeClustersManager{},
+		SkipCollection:  true,
+	})
+	g.Expect(err).NotTo(HaveOccurred())
+	defer func() {
+		g.Expect(stop()).To(Succeed())
+	}()
+
+	withPrincipal := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mux.ServeHTTP(w, r.WithContext(auth.WithPrincipal(r.Context(), &auth.UserPrincipal{ID: "test"})))
+	})
+
+	ts := httptest.NewServer(withPrincipal)
+	defer ts.Close()
+
+	// The watch is served by the streaming handler rather than the in-process one,
+	// which would answer that streaming is not implemented.
+	res, err := http.Post(ts.URL+"/v1/query/watch", "application/json", strings.NewReader(`{"query": "kind:("}`))
+	g.Expect(err).NotTo(HaveOccurred())
+	defer res.Body.Close()
+
+	g.Expect(res.StatusCode).To(Equal(http.StatusBadRequest))
+
+	body, err := io.ReadAll(res.Body)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(string(body)).To(ContainSubstring("invalid query at column 6"))
+}
+
+func TestHydrate_ExportQuery(t *testing.T) {
+	g := NewWithT(t)
+
+	client := fakeclientset.NewSimpleClientset()
+	fakeDiscovery, _ := client.Discovery().(*fakediscovery.FakeDiscovery)
+
+	mux := runtime.NewServeMux()
+
+	stop, err := Hydrate(context.Background(), mux, ServerOpts{
+		Logger:      logr.Discard(),
+		ObjectKinds: configuration.SupportedObjectKinds,
+		ServiceAccount: collector.ImpersonateServiceAccount{
+			Name:      "collector",
+			Namespace: "flux-system",
+		},
+		DiscoveryClient: fakeDiscovery,
+		ClustersManager: &clustersmngrfakes.FakeClustersManager{},
+		SkipCollection:  true,
+	})
+	g.Expect(err).NotTo(HaveOccurred())
+	defer func() {
+		g.Expect(stop()).To(Succeed())
+	}()
+
+	withPrincipal := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mux.ServeHTTP(w, r.WithContext(auth.WithPrincipal(r.Context(), &auth.UserPrincipal{ID: "test"})))
+	})
+
+	ts := httptest.NewServer(withPrincipal)
+	defer ts.Close()
+
+	tests := []struct {
+		name            string
+		request         string
+		wantStatus      int
+		wantContentType string
+		wantBody        string
+	}{
+		{
+			name:            "csv has a header even without objects",
+			request:         `{"columns": ["cluster", "name", "unstructured.spec.chart.spec.version"]}`,
+			wantStatus:      http.StatusOK,
+			wantContentType: "text/csv",
+			wantBody:        "cluster,name,unstructured.spec.chart.spec.version\n",
+		},
+		{
+			name:            "ndjson",
+			request:         `{"format": "ndjson"}`,
+			wantStatus:      http.StatusOK,
+			wantContentType: "application/x-ndjson",
+			wantBody:        "",
+		},
+		{
+			name:       "unsupported format",
+			request:    `{"format": "xml"}`,
+			wantStatus: http.StatusBadRequest,
+			wantBody:   `unsupported export format`,
+		},
+		{
+			name:       "unsupported column",
+			request:    `{"columns": ["spec"]}`,
+			wantStatus: http.StatusBadRequest,
+			wantBody:   `column \"spec\" is not supported`,
+		},
+		{
+			name:       "invalid query",
+			request:    `{"query": "kind:("}`,
+			wantStatus: http.StatusBadRequest,
+			wantBody:   "invalid query at column 6",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			res, err := http.Post(ts.URL+"/v1/query/export", "application/json", strings.NewReader(tt.request))
+			g.Expect(err).NotTo(HaveOccurred())
+			defer res.Body.Close()
+
+			g.Expect(res.StatusCode).To(Equal(tt.wantStatus))
+
+			body, err := io.ReadAll(res.Body)
+			g.Expect(err).NotTo(HaveOccurred())
+
+			if tt.wantStatus != http.StatusOK {
+				g.Expect(string(body)).To(ContainSubstring(tt.wantBody))
+				return
+			}
+
+			g.Expect(res.Header.Get("Content-Type")).To(Equal(tt.wantContentType))
+			g.Expect(string(body)).To(Equal(tt.wantBody))
+		})
+	}
+}
@@ -0,0 +1,114 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	pb "github.com/weaveworks/weave-gitops-enterprise/pkg/api/query"
+	"github.com/weaveworks/weave-gitops-enterprise/pkg/query/events"
+	"github.com/weaveworks/weave-gitops-enterprise/pkg/query/internal/models"
+	"github.com/weaveworks/weave-gitops-enterprise/pkg/query/store"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	grpcStatus "google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+const watchQueryPath = "/v1/query/watch"
+
+func (s *server) WatchQuery(msg *pb.WatchQueryRequest, stream pb.Query_WatchQueryServer) error {
+	err := s.qs.WatchQuery(stream.Context(), msg, func(e models.ObjectEvent) error {
+		return stream.Send(&pb.WatchQueryResponse{
+			Type:   string(e.Type),
+			Object: convertToPbObject([]models.Object{e.Object})[0],
+		})
+	})
+	if err != nil {
+		var parseErr *store.ParseError
+		if errors.As(err, &parseErr) {
+			return invalidQueryError(parseErr)
+		}
+		if errors.Is(err, events.ErrSubscriberDropped) {
+			// The client missed changes, so it has to query again before watching again.
+			return grpcStatus.Error(codes.Aborted, err.Error())
+		}
+		return fmt.Errorf("failed to watch query: %w", err)
+	}
+
+	return nil
+}
+
+// watchQueryHandler serves WatchQuery on the gateway mux. The in-process gateway handlers
+// do not support streaming, so the stream is forwarded the same way the gateway proxies
+// a gRPC stream: one JSON message per line.
+func watchQueryHandler(mux *runtime.ServeMux, srv pb.QueryServer) runtime.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request, pathParams map[string]string) {
+		ctx, cancel := context.WithCancel(r.Context())
+		defer cancel()
+
+		inbound, outbound := runtime.MarshalerForRequest(mux, r)
+
+		ctx, err := runtime.AnnotateIncomingContext(ctx, mux, r, "/query.v1.Query/WatchQuery", runtime.WithHTTPPathPattern(watchQueryPath))
+		if err != nil {
+			runtime.HTTPError(ctx, mux, outbound, w, r, err)
+			return
+		}
+
+		req := &pb.WatchQueryRequest{}
+		if err := inbound.NewDecoder(r.Body).Decode(req); err != nil && err != io.EOF {
+			runtime.HTTPError(ctx, mux, outbound, w, r, grpcStatus.Errorf(codes.InvalidArgument, "%v", err))
+			return
+		}
+
+		stream := &watchQueryStream{
+			ctx:       ctx,
+			responses: make(chan *pb.WatchQueryResponse),
+		}
+
+		done := make(chan error, 1)
+		go func() {
+			done <- srv.WatchQuery(req, stream)
+		}()
+
+		recv := func() (proto.Message, error) {
+			select {
+			case resp := <-stream.responses:
+				return resp, nil
+			case err := <-done:
+				if err == nil {
+					return nil, io.EOF
+				}
+				return nil, err
+			}
+		}
+
+		ctx = runtime.NewServerMetadataContext(ctx, runtime.ServerMetadata{})
+		runtime.ForwardResponseStream(ctx, mux, outbound, w, r, recv, mux.GetForwardResponseOptions()...)
+	}
+}
+
+// watchQueryStream hands the responses of WatchQuery over to the HTTP handler.
+// Only Send and Context are used by WatchQuery.
+type watchQueryStream struct {
+	grpc.ServerStream
+
+	ctx       context.Context
+	responses chan *pb.WatchQueryResponse
+}
+
+func (s *watchQueryStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *watchQueryStream) Send(resp *pb.WatchQueryResponse) error {
+	select {
+	case s.responses <- resp:
+		return nil
+	case <-s.ctx.Done():
+		return s.ctx.Err()
+	}
+}
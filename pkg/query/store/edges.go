@@ -0,0 +1,74 @@
+package store
+
+import (
+	"fmt"
+
+	"github.com/weaveworks/weave-gitops-enterprise/pkg/query/internal/models"
+	"gorm.io/gorm"
+)
+
+// The edges between objects are plain SQL that both backends share.
+
+func storeObjectEdges(db *gorm.DB, owners []string, edges []models.ObjectEdge) error {
+	if len(owners) == 0 && len(edges) == 0 {
+		return nil
+	}
+
+	return db.Transaction(func(tx *gorm.DB) error {
+		if len(owners) > 0 {
+			if err := tx.Where("owner IN ?", owners).Delete(&models.ObjectEdge{}).Error; err != nil {
+				return fmt.Errorf("failed to delete object edges: %w", err)
+			}
+		}
+
+		if len(edges) > 0 {
+			if err := tx.Create(&edges).Error; err != nil {
+				return fmt.Errorf("failed to store object edges: %w", err)
+			}
+		}
+
+		return nil
+	})
+}
+
+func getObjectEdges(db *gorm.DB, references []string) ([]models.ObjectEdge, error) {
+	edges := []models.ObjectEdge{}
+	if len(references) == 0 {
+		return edges, nil
+	}
+
+	result := db.Where("upstream IN ? OR downstream IN ?", references, references).Order("id").Find(&edges)
+	if result.Error != nil {
+		return nil, fmt.Errorf("failed to get object edges: %w", result.Error)
+	}
+
+	return edges, nil
+}
+
+// objectReferenceBatchSize is the number of references looked up by a single statement.
+const objectReferenceBatchSize = 100
+
+func getObjectsByReference(db *gorm.DB, references []models.ObjectReference) ([]models.Object, error) {
+	objects := []models.Object{}
+
+	for start := 0; start < len(references); start += objectReferenceBatchSize {
+		end := start + objectReferenceBatchSize
+		if end > len(references) {
+			end = len(references)
+		}
+
+		query := db.Model(&models.Object{})
+		for _, ref := range references[start:end] {
+			query = query.Or("cluster = ? AND namespace = ? AND api_group = ? AND kind = ? AND name = ?",
+				ref.Cluster, ref.Namespace, ref.APIGroup, ref.Kind, ref.Name)
+		}
+
+		batch := []models.Object{}
+		if result := query.Find(&batch); result.Error != nil {
+			return nil, fmt.Errorf("failed to get objects: %w", result.Error)
+		}
+		objects = append(objects, batch...)
+	}
+
+	return objects, nil
+}
@@ -0,0 +1,68 @@
+package store
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/weaveworks/weave-gitops-enterprise/pkg/query/internal/models"
+	"gorm.io/gorm"
+)
+
+// The history of objects is plain SQL that both backends share.
+
+func storeObjectRevisions(db *gorm.DB, revisions []models.ObjectRevision) error {
+	if len(revisions) == 0 {
+		return nil
+	}
+
+	return db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&revisions).Error; err != nil {
+			return fmt.Errorf("failed to store object revisions: %w", err)
+		}
+
+		pruned := map[string]bool{}
+		for _, revision := range revisions {
+			if pruned[revision.ObjectID] {
+				continue
+			}
+			pruned[revision.ObjectID] = true
+
+			latest := tx.Model(&models.ObjectRevision{}).
+				Select("id").
+				Where("object_id = ?", revision.ObjectID).
+				Order("id DESC").
+				Limit(MaxObjectRevisions)
+
+			result := tx.Where("object_id = ? AND id NOT IN (?)", revision.ObjectID, latest).Delete(&models.ObjectRevision{})
+			if result.Error != nil {
+				return fmt.Errorf("failed to prune object revisions: %w", result.Error)
+			}
+		}
+
+		return nil
+	})
+}
+
+func deleteObjectRevisions(db *gorm.DB, objectIDs []string, before time.Time) error {
+	if len(objectIDs) == 0 {
+		return nil
+	}
+
+	result := db.Where("object_id IN ? AND recorded_at < ?", objectIDs, before).Delete(&models.ObjectRevision{})
+	if result.Error != nil {
+		return fmt.Errorf("failed to delete object revisions: %w", result.Error)
+	}
+
+	return nil
+}
+
+func getObjectHistory(db *gorm.DB, objectID string) ([]models.ObjectRevision, error) {
+	revisions := []models.ObjectRevision{}
+
+	result := db.Where("object_id = ?", objectID).Order("id").Find(&revisions)
+	if result.Error != nil {
+		return nil, fmt.Errorf("failed to get object history: %w", result.Error)
+	}
+
+	return revisions, nil
+}
@@ -5,16 +5,19 @@ import (
 	"encoding/json"
 	"fmt"
 	"path/filepath"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
 
+	"github.com/blevesearch/bleve/v2/analysis/analyzer/standard"
 	"github.com/blevesearch/bleve/v2/mapping"
 	"github.com/weaveworks/weave-gitops-enterprise/pkg/query/configuration"
 	"github.com/weaveworks/weave-gitops-enterprise/pkg/query/store/metrics"
 
 	bleve "github.com/blevesearch/bleve/v2"
 	"github.com/blevesearch/bleve/v2/search"
+	blevequery "github.com/blevesearch/bleve/v2/search/query"
 	"github.com/go-logr/logr"
 	"github.com/weaveworks/weave-gitops-enterprise/pkg/query/internal/models"
 )
@@ -48,7 +51,8 @@ type IndexReader interface {
 	Search(ctx context.Context, query Query, opts QueryOption) (Iterator, error)
 	// ListFacets returns a map of facets and their values.
 	// Facets can be used to build a filtering UI or to see what values are available for a given field.
-	ListFacets(ctx context.Context, category configuration.ObjectCategory) (Facets, error)
+	// The labels of the given kinds that are in the category are added as facets.
+	ListFacets(ctx context.Context, category configuration.ObjectCategory, kinds []configuration.ObjectKind) (Facets, error)
 }
 
 var indexFile = "index.db"
@@ -56,11 +60,8 @@ var commonFields = []string{"cluster", "namespace", "kind"}
 
 func NewIndexer(s Store, path string, log logr.Logger) (Indexer, error) {
 	idxFileLocation := filepath.Join(path, indexFile)
-	mapping := bleve.NewIndexMapping()
-
-	addFieldMappings(mapping, commonFields)
 
-	index, err := bleve.New(idxFileLocation, mapping)
+	index, err := bleve.New(idxFileLocation, newIndexMapping())
 	if err != nil {
 		return nil, fmt.Errorf("failed to create indexer: %w", err)
 	}
@@ -74,6 +75,14 @@ func NewIndexer(s Store, path string, log logr.Logger) (Indexer, error) {
 
 var facetSuffix = ".facet"
 
+func newIndexMapping() *mapping.IndexMappingImpl {
+	mapping := bleve.NewIndexMapping()
+
+	addFieldMappings(mapping, commonFields)
+
+	return mapping
+}
+
 func addFieldMappings(index *mapping.IndexMappingImpl, fields []string) {
 	objMapping := bleve.NewDocumentMapping()
 
@@ -85,6 +94,20 @@ func addFieldMappings(index *mapping.IndexMappingImpl, fields []string) {
 	unstructuredMapping := bleve.NewDocumentDisabledMapping()
 	objMapping.AddSubDocumentMapping("unstructured", unstructuredMapping)
 
+	// The text of the object is kept out of `_all` for the same reason,
+	// and matched separately by the terms of a search.
+	contentMapping := bleve.NewTextFieldMapping()
+	contentMapping.Analyzer = standard.Name
+	contentMapping.Store = false
+	contentMapping.IncludeInAll = false
+	objMapping.AddFieldMappingsAt("content", contentMapping)
+
+	// The search fields of each kind are indexed as full text, so they can be queried
+	// as `fields.<name>`, like `fields.chart:ingress-nginx` or `fields.path:"apps/prod"`.
+	searchFieldsMapping := bleve.NewDocumentMapping()
+	searchFieldsMapping.DefaultAnalyzer = standard.Name
+	objMapping.AddSubDocumentMapping("fields", searchFieldsMapping)
+
 	for _, field := range commonFields {
 		// This mapping allows us to do query-string queries on the field.
 		// For example, we can do `cluster:foo` to get all objects in the `foo` cluster.
@@ -110,40 +133,74 @@ type bleveIndexer struct {
 	log   logr.Logger
 }
 
-// We want the index to contain our raw JSON objects,
-// but adding the JSON to the index ID messes up the facets.
-// So we prepend the ID with a prefix, and then store the JSON under that ID.
-const unstructuredSuffix = "_unstructured"
+// indexedObject is the document an object is indexed as. The text of the Kubernetes object
+// is indexed along with it, so that terms match what is in the object, while every object
+// is still a single document to page through.
+type indexedObject struct {
+	models.Object
+	Content string `json:"content"`
+}
 
 func (i *bleveIndexer) Add(ctx context.Context, objects []models.Object) (err error) {
 	// metrics
 	metrics.IndexerAddInflightRequests(metrics.AddAction, 1)
 	defer recordIndexerMetrics(metrics.AddAction, time.Now(), err)
 
-	batch := i.idx.NewBatch()
+	return addObjects(i.idx, objects, i.log)
+}
+
+func addObjects(idx bleve.Index, objects []models.Object, log logr.Logger) error {
+	batch := idx.NewBatch()
 
 	for _, obj := range objects {
-		if err := batch.Index(obj.GetID(), obj); err != nil {
-			i.log.Error(err, "failed to index object", "object", obj.GetID())
+		doc := indexedObject{Object: obj}
+
+		if obj.Unstructured != nil {
+			content, err := objectText(obj.Unstructured)
+			if err != nil {
+				log.Error(err, "failed to unmarshal object", "object", obj.GetID())
+			}
+
+			doc.Content = content
+		}
+
+		if err := batch.Index(obj.GetID(), doc); err != nil {
+			log.Error(err, "failed to index object", "object", obj.GetID())
 			continue
 		}
+	}
 
-		if obj.Unstructured != nil {
-			var data interface{}
+	return idx.Batch(batch)
+}
 
-			if err := json.Unmarshal(obj.Unstructured, &data); err != nil {
-				i.log.Error(err, "failed to unmarshal object", "object", obj.GetID())
-				continue
-			}
+// objectText returns the string values of a JSON document, separated by spaces.
+func objectText(data []byte) (string, error) {
+	var v interface{}
 
-			if err := batch.Index(obj.GetID()+unstructuredSuffix, data); err != nil {
-				i.log.Error(err, "failed to index unstructured object", "object", obj.GetID())
-				continue
+	if err := json.Unmarshal(data, &v); err != nil {
+		return "", err
+	}
+
+	values := []string{}
+
+	var walk func(v interface{})
+	walk = func(v interface{}) {
+		switch v := v.(type) {
+		case string:
+			values = append(values, v)
+		case []interface{}:
+			for _, item := range v {
+				walk(item)
+			}
+		case map[string]interface{}:
+			for _, item := range v {
+				walk(item)
 			}
 		}
 	}
+	walk(v)
 
-	return i.idx.Batch(batch)
+	return strings.Join(values, " "), nil
 }
 
 func (i *bleveIndexer) Remove(ctx context.Context, objects []models.Object) (err error) {
@@ -187,105 +244,120 @@ func (i *bleveIndexer) Search(ctx context.Context, q Query, opts QueryOption) (i
 	metrics.IndexerAddInflightRequests(metrics.SearchAction, 1)
 	defer recordIndexerMetrics(metrics.SearchAction, time.Now(), err)
 
-	// Match all by default.
-	// Conjunction queries will return results that match all the sub-queries.
-	query := bleve.NewConjunctionQuery(bleve.NewMatchAllQuery())
-
-	terms := q.GetTerms()
-
-	if terms != "" {
-		tq := bleve.NewTermQuery(terms)
-		query.AddQuery(tq)
+	query, err := buildSearchQuery(q)
+	if err != nil {
+		return nil, err
 	}
 
-	filters := q.GetFilters()
+	req := bleve.NewSearchRequest(query)
 
-	if len(filters) > 0 {
-		// Prepend a `+` to each filter to make it a required term.
-		// This gives us the AND between categories, and OR within categories.
-		str := "+"
-		str += strings.Join(q.GetFilters(), " +")
+	// We cannot search for .GetLimit() results, because we are filtering out objects AFTER the search
+	// with our RBAC rules, so there may be fewer objects left than the limit.
+	// Instead the query iterator searches for the next batch of results whenever it runs out of them,
+	// and handles limiting the page size.
+	req.Size = searchBatchSize
 
-		qs := bleve.NewQueryStringQuery(str)
+	orders := search.SortOrder{}
 
-		query.AddQuery(qs)
+	if opts != nil && opts.GetOrderBy() != "" {
+		orders = append(orders, &search.SortField{
+			Field: opts.GetOrderBy(),
+			Type:  search.SortFieldAsString,
+			// Desc behaves oddly in bleve. Setting .Desc to `true` will reverse the default order (descending).
+			Desc: opts.GetDescending(),
+		})
+	} else {
+		// We order by score here so that we can get the most relevant results first.
+		orders = append(orders, &search.SortScore{
+			Desc: true,
+		})
 	}
 
-	req := bleve.NewSearchRequest(query)
+	// Ties are broken by ID, so that the order is stable and pages can be resumed from a row.
+	orders = append(orders, &search.SortDocID{})
 
-	count, err := i.idx.DocCount()
-	if err != nil {
-		return nil, fmt.Errorf("failed to get document count: %w", err)
-	}
+	req.SortByCustom(orders)
 
-	// We return all results, because we are filtering out objects AFTER the search with our RBAC rules.
-	// There will be cases where we return a slice of objects based on .GetLimit(), but those
-	// objects will be filtered out by the accesschecker.
-	// The query iterator will handle limiting the page size.
-	req.Size = int(count)
-
-	orders := search.SortOrder{}
+	fingerprint := queryFingerprint(q, opts)
 
 	if opts != nil {
-		sort := opts.GetOrderBy()
-		if sort != "" {
-			sf := &search.SortField{
-				Field: sort,
-				Type:  search.SortFieldAsString,
-				// Desc behaves oddly in bleve. Setting .Desc to `true` will reverse the default order (descending).
-				Desc: opts.GetDescending(),
+		if token := opts.GetPageToken(); token != "" {
+			after, err := decodePageToken(token, fingerprint)
+			if err != nil {
+				return nil, err
 			}
 
-			orders = append(orders, sf)
-		}
+			if len(after) != len(orders) {
+				return nil, ErrInvalidPageToken
+			}
 
-		if opts.GetOffset() > 0 {
+			req.SetSearchAfter(after)
+		} else if opts.GetOffset() > 0 {
 			req.From = int(opts.GetOffset())
 		}
 	}
 
-	// We order by score here so that we can get the most relevant results first.
-	orders = append(orders, &search.SortScore{
-		Desc: true,
-	})
-
-	req.SortByCustom(orders)
-
 	searchResults, err := i.idx.Search(req)
 	if err != nil {
 		return nil, fmt.Errorf("failed to search for objects: %w", err)
 	}
 
-	// Strip the `_unstructured` suffix from the ID so we can get the object from the store.
-	for i, hit := range searchResults.Hits {
-		if strings.Contains(hit.ID, unstructuredSuffix) {
-			hit.ID = strings.Replace(hit.ID, "_unstructured", "", 1)
-		}
+	iter := &indexerIterator{
+		idx:         i.idx,
+		req:         req,
+		hits:        searchResults.Hits,
+		more:        len(searchResults.Hits) == req.Size,
+		orders:      orders,
+		s:           i.store,
+		mu:          sync.Mutex{},
+		index:       -1,
+		opts:        opts,
+		fingerprint: fingerprint,
+	}
 
-		searchResults.Hits[i] = hit
+	return iter, nil
+}
+
+// buildSearchQuery combines the terms, filters and structured query of q into a single bleve query.
+func buildSearchQuery(q Query) (*blevequery.ConjunctionQuery, error) {
+	// Match all by default.
+	// Conjunction queries will return results that match all the sub-queries.
+	query := bleve.NewConjunctionQuery(bleve.NewMatchAllQuery())
+
+	terms := q.GetTerms()
+
+	if terms != "" {
+		// Terms match the fields of the object, or its content.
+		tq := bleve.NewTermQuery(terms)
+		cq := bleve.NewTermQuery(terms)
+		cq.SetField("content")
+
+		query.AddQuery(bleve.NewDisjunctionQuery(tq, cq))
 	}
 
-	// Ensure hits are unique
-	seen := map[string]bool{}
-	uniqueHits := []*search.DocumentMatch{}
-	for _, hit := range searchResults.Hits {
-		if _, ok := seen[hit.ID]; !ok {
-			uniqueHits = append(uniqueHits, hit)
-			seen[hit.ID] = true
-		}
+	filters := q.GetFilters()
+
+	if len(filters) > 0 {
+		// Prepend a `+` to each filter to make it a required term.
+		// This gives us the AND between categories, and OR within categories.
+		str := "+"
+		str += strings.Join(q.GetFilters(), " +")
+
+		qs := bleve.NewQueryStringQuery(str)
+
+		query.AddQuery(qs)
 	}
 
-	searchResults.Hits = uniqueHits
+	if expr := q.GetQuery(); expr != "" {
+		sq, err := ParseQuery(expr)
+		if err != nil {
+			return nil, err
+		}
 
-	iter := &indexerIterator{
-		result: searchResults,
-		s:      i.store,
-		mu:     sync.Mutex{},
-		index:  -1,
-		opts:   opts,
+		query.AddQuery(sq)
 	}
 
-	return iter, nil
+	return query, nil
 }
 
 func recordIndexerMetrics(action string, start time.Time, err error) {
@@ -297,7 +369,7 @@ func recordIndexerMetrics(action string, start time.Time, err error) {
 	metrics.IndexerSetLatency(action, metrics.SuccessLabel, time.Since(start))
 }
 
-func (i *bleveIndexer) ListFacets(ctx context.Context, category configuration.ObjectCategory) (fcs Facets, err error) {
+func (i *bleveIndexer) ListFacets(ctx context.Context, category configuration.ObjectCategory, kinds []configuration.ObjectKind) (fcs Facets, err error) {
 	// metrics
 	metrics.IndexerAddInflightRequests(metrics.ListFacetsAction, 1)
 	defer recordIndexerMetrics(metrics.ListFacetsAction, time.Now(), err)
@@ -305,7 +377,7 @@ func (i *bleveIndexer) ListFacets(ctx context.Context, category configuration.Ob
 	query := bleve.NewMatchAllQuery()
 	req := bleve.NewSearchRequest(query)
 
-	addDefaultFacets(req, category)
+	addDefaultFacets(req, category, kinds)
 
 	searchResults, err := i.idx.Search(req)
 	if err != nil {
@@ -331,14 +403,14 @@ func (i *bleveIndexer) ListFacets(ctx context.Context, category configuration.Ob
 
 // addDefaultFacets adds a set of defaault facets to facets search requests. Default facets are comprised of a set
 // of common fields like cluster and set of objectkind specific fields like labels
-func addDefaultFacets(req *bleve.SearchRequest, cat configuration.ObjectCategory) {
+func addDefaultFacets(req *bleve.SearchRequest, cat configuration.ObjectCategory, kinds []configuration.ObjectKind) {
 	// adding facets for common fields
 	for _, f := range commonFields {
 		req.AddFacet(f, bleve.NewFacetRequest(f+facetSuffix, 100))
 	}
 
 	// adding facets for labels
-	for _, objectKind := range configuration.SupportedObjectKinds {
+	for _, objectKind := range kinds {
 		if cat != "" && objectKind.Category != cat {
 			continue
 		}
@@ -349,27 +421,54 @@ func addDefaultFacets(req *bleve.SearchRequest, cat configuration.ObjectCategory
 	}
 }
 
+// searchBatchSize is the number of hits the iterator searches for at once.
+var searchBatchSize = 1000
+
+// pageBatchSize is the number of objects Page loads from the store at once.
+const pageBatchSize = 100
+
 type indexerIterator struct {
-	result *bleve.SearchResult
-	mu     sync.Mutex
-	index  int
-	s      Store
-	opts   QueryOption
+	idx bleve.Index
+	req *bleve.SearchRequest
+	// hits holds the current batch of hits, and more whether there may be hits after it.
+	hits        []*search.DocumentMatch
+	more        bool
+	err         error
+	mu          sync.Mutex
+	index       int
+	s           Store
+	opts        QueryOption
+	orders      search.SortOrder
+	fingerprint string
+	// loaded holds the objects of the hits Page is about to read, by ID,
+	// for the hits before loadedUntil.
+	loaded      map[string]models.Object
+	loadedUntil int
 }
 
+// Next moves to the next hit. If the next batch of hits cannot be searched for,
+// it returns false and the error is returned by Row.
 func (i *indexerIterator) Next() bool {
 	i.mu.Lock()
 	defer i.mu.Unlock()
 
-	i.index++
-	return i.index < len(i.result.Hits)
+	ok, err := i.advance()
+	if err != nil {
+		i.err = err
+	}
+
+	return ok
 }
 
 func (i *indexerIterator) Row() (models.Object, error) {
 	i.mu.Lock()
 	defer i.mu.Unlock()
 
-	result := i.result.Hits[i.index]
+	if i.err != nil {
+		return models.Object{}, i.err
+	}
+
+	result := i.hits[i.index]
 
 	id := result.ID
 
@@ -382,8 +481,17 @@ func (i *indexerIterator) All() ([]models.Object, error) {
 
 	ids := []string{}
 
-	for _, hit := range i.result.Hits {
-		ids = append(ids, hit.ID)
+	for {
+		ok, err := i.advance()
+		if err != nil {
+			return nil, err
+		}
+
+		if !ok {
+			break
+		}
+
+		ids = append(ids, i.hits[i.index].ID)
 	}
 
 	iter, err := i.s.GetObjects(context.Background(), ids, i.opts)
@@ -394,32 +502,156 @@ func (i *indexerIterator) All() ([]models.Object, error) {
 	return iter.All()
 }
 
-func (i *indexerIterator) Page(count int, offset int) ([]models.Object, error) {
+// Page reads hits in order, loading their objects from the store in batches.
+// Once the page is full it looks ahead for one more accepted row, so that a token is
+// only returned when there is a next page. The token points at the last row read before
+// that one: resuming from it skips every rejected row without reading it again.
+func (i *indexerIterator) Page(count int, filter RowFilter) ([]models.Object, string, error) {
 	i.mu.Lock()
 	defer i.mu.Unlock()
 
-	ids := []string{}
+	objects := []models.Object{}
+	// The sort key of the last row read.
+	var last []string
+
+	for {
+		ok, err := i.advance()
+		if err != nil {
+			return nil, "", err
+		}
+
+		if !ok {
+			break
+		}
 
-	numHits := i.result.Hits.Len()
-	upper := offset + count
-	if upper > numHits {
-		upper = numHits
+		key := sortKey(i.orders, i.hits[i.index])
+
+		obj, ok, err := i.load(i.index)
+		if err != nil {
+			return nil, "", err
+		}
+
+		if !ok {
+			// The object was removed from the store since it was indexed.
+			last = key
+			continue
+		}
+
+		if filter != nil {
+			ok, err := filter(obj)
+			if err != nil {
+				return nil, "", err
+			}
+
+			if !ok {
+				last = key
+				continue
+			}
+		}
+
+		if count > 0 && len(objects) == count {
+			// Leave the row for the next page.
+			i.index--
+
+			token, err := encodePageToken(i.fingerprint, last)
+			if err != nil {
+				return nil, "", err
+			}
+
+			return objects, token, nil
+		}
+
+		objects = append(objects, obj)
+		last = key
 	}
 
-	for index := offset; index < upper; index++ {
-		ids = append(ids, i.result.Hits[index].ID)
+	return objects, "", nil
+}
+
+// advance moves to the next hit, searching for the next batch of hits
+// after the current one when it runs out of them.
+func (i *indexerIterator) advance() (bool, error) {
+	if i.index+1 < len(i.hits) {
+		i.index++
+		return true, nil
 	}
 
-	if len(ids) == 0 {
-		return []models.Object{}, nil
+	if !i.more || len(i.hits) == 0 {
+		return false, nil
 	}
 
-	iter, err := i.s.GetObjects(context.Background(), ids, i.opts)
+	i.req.From = 0
+	i.req.SetSearchAfter(sortKey(i.orders, i.hits[len(i.hits)-1]))
+
+	result, err := i.idx.Search(i.req)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get objects: %w", err)
+		return false, fmt.Errorf("failed to search for objects: %w", err)
 	}
 
-	return iter.All()
+	i.hits = result.Hits
+	i.more = len(result.Hits) == i.req.Size
+	i.index = -1
+	i.loaded = nil
+	i.loadedUntil = 0
+
+	if len(i.hits) == 0 {
+		return false, nil
+	}
+
+	i.index++
+	return true, nil
+}
+
+// sortKey returns the key to resume a search right after hit.
+// Bleve only keeps a placeholder for the score in the sort values of a hit,
+// so the score is written out for the next search to compare its hits with.
+func sortKey(orders search.SortOrder, hit *search.DocumentMatch) []string {
+	key := make([]string, len(hit.Sort))
+	copy(key, hit.Sort)
+
+	for pos, order := range orders {
+		if order.RequiresScoring() {
+			key[pos] = strconv.FormatFloat(hit.Score, 'g', -1, 64)
+		}
+	}
+
+	return key
+}
+
+// load returns the object of the hit at index, fetching it and the hits after it
+// from the store if they have not been loaded yet.
+func (i *indexerIterator) load(index int) (models.Object, bool, error) {
+	if index >= i.loadedUntil {
+		end := index + pageBatchSize
+		if end > len(i.hits) {
+			end = len(i.hits)
+		}
+
+		ids := []string{}
+		for _, hit := range i.hits[index:end] {
+			ids = append(ids, hit.ID)
+		}
+
+		iter, err := i.s.GetObjects(context.Background(), ids, nil)
+		if err != nil {
+			return models.Object{}, false, fmt.Errorf("failed to get objects: %w", err)
+		}
+
+		objects, err := iter.All()
+		if err != nil {
+			return models.Object{}, false, fmt.Errorf("failed to get objects: %w", err)
+		}
+
+		i.loaded = map[string]models.Object{}
+		for _, obj := range objects {
+			i.loaded[obj.ID] = obj
+		}
+		i.loadedUntil = index + len(ids)
+	}
+
+	obj, ok := i.loaded[i.hits[index].ID]
+
+	return obj, ok, nil
 }
 
 func (i *indexerIterator) Close() error {
@@ -18,6 +18,7 @@ import (
 	bleve "github.com/blevesearch/bleve/v2"
 	gapiv1 "github.com/weaveworks/templates-controller/apis/gitops/v1alpha2"
 	"github.com/weaveworks/weave-gitops-enterprise/pkg/query/internal/models"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 )
 
 func TestIndexer_Metrics(t *testing.T) {
@@ -120,7 +121,7 @@ func TestIndexer_Metrics(t *testing.T) {
 	})
 
 	t.Run("should have ListFacets instrumented", func(t *testing.T) {
-		_, err := idx.ListFacets(context.Background(), configuration.CategoryAutomation)
+		_, err := idx.ListFacets(context.Background(), configuration.CategoryAutomation, configuration.SupportedObjectKinds)
 		g.Expect(err).NotTo(HaveOccurred())
 
 		wantMetrics := []string{
@@ -317,37 +318,47 @@ func TestIndexer_RemoveByQueryWithPagination(t *testing.T) {
 			g.Expect(err).NotTo(HaveOccurred())
 
 			// Ensure things got written to the index.
-			// Iterate through all pages without an initial offset.
+			// Iterate through all pages of a single search.
 			iter, err := idx.Search(context.Background(), query{}, nil)
 			g.Expect(err).NotTo(HaveOccurred())
 
-			pageObjects, err := iter.Page(3, 0)
+			pageObjects, next, err := iter.Page(3, nil)
 			g.Expect(err).NotTo(HaveOccurred())
 			g.Expect(len(pageObjects)).To(Equal(3))
+			g.Expect(next).NotTo(BeEmpty())
 
-			pageObjects, err = iter.Page(3, 3)
+			pageObjects, next, err = iter.Page(3, nil)
 			g.Expect(err).NotTo(HaveOccurred())
 			g.Expect(len(pageObjects)).To(Equal(3))
+			g.Expect(next).NotTo(BeEmpty())
 
-			pageObjects, err = iter.Page(3, 6)
+			pageObjects, next, err = iter.Page(3, nil)
 			g.Expect(err).NotTo(HaveOccurred())
 			g.Expect(len(pageObjects)).To(Equal(1))
+			g.Expect(next).To(BeEmpty())
 
-			// Iterate through all pages with an initial offset.
-			iter, err = idx.Search(context.Background(), query{}, nil)
-			g.Expect(err).NotTo(HaveOccurred())
+			// Iterate through all pages by resuming a new search from the previous page token.
+			names := []string{}
+			opts := &queryOption{orderBy: "name"}
+			for {
+				iter, err = idx.Search(context.Background(), query{}, opts)
+				g.Expect(err).NotTo(HaveOccurred())
 
-			pageObjects, err = iter.Page(2, 2)
-			g.Expect(err).NotTo(HaveOccurred())
-			g.Expect(len(pageObjects)).To(Equal(2))
+				pageObjects, next, err = iter.Page(2, nil)
+				g.Expect(err).NotTo(HaveOccurred())
 
-			pageObjects, err = iter.Page(2, 4)
-			g.Expect(err).NotTo(HaveOccurred())
-			g.Expect(len(pageObjects)).To(Equal(2))
+				for _, obj := range pageObjects {
+					names = append(names, obj.Name)
+				}
 
-			pageObjects, err = iter.Page(2, 6)
-			g.Expect(err).NotTo(HaveOccurred())
-			g.Expect(len(pageObjects)).To(Equal(1))
+				if next == "" {
+					break
+				}
+
+				opts.pageToken = next
+			}
+
+			g.Expect(names).To(Equal([]string{"name-1", "name-2", "name-3", "name-4", "name-5", "name-6", "name-7"}))
 
 			// Check that required objects were removed.
 			err = idx.RemoveByQuery(context.Background(), tt.query)
@@ -359,7 +370,7 @@ func TestIndexer_RemoveByQueryWithPagination(t *testing.T) {
 			all, err := iter.All()
 			g.Expect(err).NotTo(HaveOccurred())
 
-			names := []string{}
+			names = []string{}
 			for _, obj := range all {
 				names = append(names, obj.Name)
 			}
@@ -370,6 +381,237 @@ func TestIndexer_RemoveByQueryWithPagination(t *testing.T) {
 	}
 }
 
+func TestIndexer_SearchWithPageToken(t *testing.T) {
+	g := NewWithT(t)
+
+	s, err := NewStore(StorageBackendSQLite, t.TempDir(), logr.Discard())
+	g.Expect(err).NotTo(HaveOccurred())
+
+	idx, err := NewIndexer(s, t.TempDir(), logr.Discard())
+	g.Expect(err).NotTo(HaveOccurred())
+	// The index must be closed before its directory is removed.
+	defer func() {
+		g.Expect(idx.(*bleveIndexer).idx.Close()).To(Succeed())
+	}()
+
+	objects := []models.Object{}
+	for _, name := range []string{"a", "b", "c", "d", "e"} {
+		objects = append(objects, models.Object{
+			Cluster:    "management",
+			Kind:       "Namespace",
+			Name:       name,
+			Namespace:  "anyNamespace",
+			APIGroup:   "anyGroup",
+			APIVersion: "anyVersion",
+			Category:   "automation",
+		})
+	}
+
+	g.Expect(idx.Add(context.Background(), objects)).To(Succeed())
+	g.Expect(s.StoreObjects(context.Background(), objects)).To(Succeed())
+
+	hideB := func(obj models.Object) (bool, error) {
+		return obj.Name != "b", nil
+	}
+
+	iter, err := idx.Search(context.Background(), query{}, &queryOption{orderBy: "name"})
+	g.Expect(err).NotTo(HaveOccurred())
+
+	page, next, err := iter.Page(1, hideB)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(page).To(HaveLen(1))
+	g.Expect(page[0].Name).To(Equal("a"))
+
+	t.Run("resumes after the rows the filter rejected", func(t *testing.T) {
+		g := NewWithT(t)
+
+		// "b" is rejected while looking for the next page, so the token points past it.
+		token, err := decodePageToken(next, queryFingerprint(query{}, &queryOption{orderBy: "name"}))
+		g.Expect(err).NotTo(HaveOccurred())
+		g.Expect(token[0]).To(Equal("b"))
+
+		iter, err := idx.Search(context.Background(), query{}, &queryOption{orderBy: "name", pageToken: next})
+		g.Expect(err).NotTo(HaveOccurred())
+
+		page, next, err := iter.Page(10, hideB)
+		g.Expect(err).NotTo(HaveOccurred())
+		g.Expect(next).To(BeEmpty())
+
+		names := []string{}
+		for _, obj := range page {
+			names = append(names, obj.Name)
+		}
+		g.Expect(names).To(Equal([]string{"c", "d", "e"}))
+	})
+
+	t.Run("resumes the results ordered by score", func(t *testing.T) {
+		g := NewWithT(t)
+
+		// "c" matches both sides of the query, so it scores higher than the others.
+		q := structuredQuery("kind:Namespace OR name:c")
+
+		names := []string{}
+		token := ""
+		for {
+			iter, err := idx.Search(context.Background(), q, &queryOption{pageToken: token})
+			g.Expect(err).NotTo(HaveOccurred())
+
+			page, next, err := iter.Page(2, hideB)
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(page).NotTo(BeEmpty())
+
+			for _, obj := range page {
+				names = append(names, obj.Name)
+			}
+
+			if next == "" {
+				break
+			}
+			token = next
+		}
+
+		g.Expect(names).To(HaveLen(4))
+		g.Expect(names[0]).To(Equal("c"))
+		g.Expect(names).To(ConsistOf("a", "c", "d", "e"))
+	})
+
+	t.Run("searches for the hits in batches", func(t *testing.T) {
+		g := NewWithT(t)
+
+		defer func(size int) {
+			searchBatchSize = size
+		}(searchBatchSize)
+		searchBatchSize = 2
+
+		iter, err := idx.Search(context.Background(), query{}, &queryOption{orderBy: "name"})
+		g.Expect(err).NotTo(HaveOccurred())
+
+		page, next, err := iter.Page(3, hideB)
+		g.Expect(err).NotTo(HaveOccurred())
+		g.Expect(next).NotTo(BeEmpty())
+
+		names := []string{}
+		for _, obj := range page {
+			names = append(names, obj.Name)
+		}
+		g.Expect(names).To(Equal([]string{"a", "c", "d"}))
+
+		iter, err = idx.Search(context.Background(), query{}, &queryOption{orderBy: "name", pageToken: next})
+		g.Expect(err).NotTo(HaveOccurred())
+
+		all, err := iter.All()
+		g.Expect(err).NotTo(HaveOccurred())
+		g.Expect(all).To(HaveLen(1))
+		g.Expect(all[0].Name).To(Equal("e"))
+	})
+
+	t.Run("is not affected by objects added before the page", func(t *testing.T) {
+		g := NewWithT(t)
+
+		added := objects[0]
+		added.Name = "aa"
+		g.Expect(idx.Add(context.Background(), []models.Object{added})).To(Succeed())
+		g.Expect(s.StoreObjects(context.Background(), []models.Object{added})).To(Succeed())
+
+		iter, err := idx.Search(context.Background(), query{}, &queryOption{orderBy: "name", pageToken: next})
+		g.Expect(err).NotTo(HaveOccurred())
+
+		page, _, err := iter.Page(1, hideB)
+		g.Expect(err).NotTo(HaveOccurred())
+		g.Expect(page).To(HaveLen(1))
+		g.Expect(page[0].Name).To(Equal("c"))
+	})
+
+	t.Run("rejects tokens of another query", func(t *testing.T) {
+		g := NewWithT(t)
+
+		_, err := idx.Search(context.Background(), query{}, &queryOption{orderBy: "kind", pageToken: next})
+		g.Expect(err).To(MatchError(ErrInvalidPageToken))
+
+		_, err = idx.Search(context.Background(), query{}, &queryOption{pageToken: "not-a-token"})
+		g.Expect(err).To(MatchError(ErrInvalidPageToken))
+	})
+}
+
+func TestIndexer_SearchWithPageToken_Unstructured(t *testing.T) {
+	g := NewWithT(t)
+
+	s, err := NewStore(StorageBackendSQLite, t.TempDir(), logr.Discard())
+	g.Expect(err).NotTo(HaveOccurred())
+
+	idx, err := NewIndexer(s, t.TempDir(), logr.Discard())
+	g.Expect(err).NotTo(HaveOccurred())
+	defer func() {
+		g.Expect(idx.(*bleveIndexer).idx.Close()).To(Succeed())
+	}()
+
+	objects := []models.Object{}
+	for _, name := range []string{"a", "b", "c"} {
+		objects = append(objects, models.Object{
+			Cluster:      "management",
+			Kind:         "ConfigMap",
+			Name:         name,
+			Namespace:    "default",
+			APIGroup:     "",
+			APIVersion:   "v1",
+			Category:     "automation",
+			Unstructured: []byte(`{"kind":"ConfigMap","metadata":{"name":"` + name + `"},"data":{"image":"podinfo"}}`),
+		})
+	}
+
+	g.Expect(idx.Add(context.Background(), objects)).To(Succeed())
+	g.Expect(s.StoreObjects(context.Background(), objects)).To(Succeed())
+
+	tests := []struct {
+		name  string
+		query Query
+		opts  *queryOption
+	}{
+		{
+			name:  "ordered by name",
+			query: query{},
+			opts:  &queryOption{orderBy: "name"},
+		},
+		{
+			name:  "ordered by score",
+			query: query{},
+			opts:  &queryOption{},
+		},
+		{
+			name: "matching the content of the objects",
+			// The terms are only found in the objects, not in their fields.
+			query: termsQuery("podinfo"),
+			opts:  &queryOption{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			names := []string{}
+			for {
+				iter, err := idx.Search(context.Background(), tt.query, tt.opts)
+				g.Expect(err).NotTo(HaveOccurred())
+
+				page, next, err := iter.Page(1, nil)
+				g.Expect(err).NotTo(HaveOccurred())
+
+				for _, obj := range page {
+					names = append(names, obj.Name)
+				}
+
+				if next == "" {
+					break
+				}
+				tt.opts.pageToken = next
+			}
+
+			g.Expect(names).To(ConsistOf("a", "b", "c"))
+		})
+	}
+}
+
 type query struct{}
 
 func (q query) GetTerms() string {
@@ -380,6 +622,115 @@ func (q query) GetFilters() []string {
 	return []string{}
 }
 
+func (q query) GetQuery() string {
+	return ""
+}
+
+// structuredQuery is a Query with only a structured query expression.
+type structuredQuery string
+
+func (q structuredQuery) GetTerms() string {
+	return ""
+}
+
+func (q structuredQuery) GetFilters() []string {
+	return []string{}
+}
+
+func (q structuredQuery) GetQuery() string {
+	return string(q)
+}
+
+// termsQuery is a Query with only terms.
+type termsQuery string
+
+func (q termsQuery) GetTerms() string {
+	return string(q)
+}
+
+func (q termsQuery) GetFilters() []string {
+	return []string{}
+}
+
+func (q termsQuery) GetQuery() string {
+	return ""
+}
+
+func TestIndexer_SearchFields(t *testing.T) {
+	g := NewWithT(t)
+
+	s, err := NewStore(StorageBackendSQLite, t.TempDir(), logr.Discard())
+	g.Expect(err).NotTo(HaveOccurred())
+
+	idx, err := NewIndexer(s, t.TempDir(), logr.Discard())
+	g.Expect(err).NotTo(HaveOccurred())
+	defer func() {
+		g.Expect(idx.(*bleveIndexer).idx.Close()).To(Succeed())
+	}()
+
+	object := func(kind, name string, fields map[string]string) models.Object {
+		return models.Object{
+			Cluster:    "management",
+			Namespace:  "flux-system",
+			APIGroup:   "anyGroup",
+			APIVersion: "anyVersion",
+			Kind:       kind,
+			Name:       name,
+			Category:   "automation",
+			Fields:     fields,
+		}
+	}
+
+	objects := []models.Object{
+		object("HelmRelease", "ingress", map[string]string{"chart": "ingress-nginx", "version": "4.7.1"}),
+		object("HelmRelease", "podinfo", map[string]string{"chart": "podinfo", "version": "6.3.5"}),
+		object("Kustomization", "prod", map[string]string{"path": "./clusters/apps/prod/eu"}),
+		object("Kustomization", "staging", map[string]string{"path": "./clusters/apps/staging"}),
+		object("Kustomization", "no-path", nil),
+	}
+
+	g.Expect(idx.Add(context.Background(), objects)).To(Succeed())
+	g.Expect(s.StoreObjects(context.Background(), objects)).To(Succeed())
+
+	tests := []struct {
+		query string
+		want  []string
+	}{
+		{query: "fields.chart:ingress-nginx", want: []string{"ingress"}},
+		{query: `kind:Kustomization AND fields.path:"apps/prod"`, want: []string{"prod"}},
+		{query: "fields.path:apps", want: []string{"prod", "staging"}},
+		{query: "fields.chart:ingr*", want: []string{"ingress"}},
+		{query: "kind:Kustomization AND NOT fields.path:staging", want: []string{"no-path", "prod"}},
+		{query: "fields.chart:nginx-ingress", want: []string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			g := NewWithT(t)
+
+			iter, err := idx.Search(context.Background(), structuredQuery(tt.query), &queryOption{orderBy: "name"})
+			g.Expect(err).NotTo(HaveOccurred())
+
+			found, err := iter.All()
+			g.Expect(err).NotTo(HaveOccurred())
+
+			names := []string{}
+			for _, obj := range found {
+				names = append(names, obj.Name)
+			}
+			g.Expect(names).To(Equal(tt.want))
+		})
+	}
+
+	t.Run("are kept by the store", func(t *testing.T) {
+		g := NewWithT(t)
+
+		stored, err := s.GetObjectByID(context.Background(), objects[0].GetID())
+		g.Expect(err).NotTo(HaveOccurred())
+		g.Expect(stored.Fields).To(Equal(map[string]string{"chart": "ingress-nginx", "version": "4.7.1"}))
+	})
+}
+
 func TestListFacets(t *testing.T) {
 	g := NewGomegaWithT(t)
 
@@ -391,6 +742,7 @@ func TestListFacets(t *testing.T) {
 		objects           []models.Object
 		expected          Facets
 		requestedCategory configuration.ObjectCategory
+		kinds             []configuration.ObjectKind
 	}{
 		{
 			name: "adds default facets",
@@ -494,6 +846,34 @@ func TestListFacets(t *testing.T) {
 			},
 			requestedCategory: configuration.CategoryAutomation,
 		},
+		{
+			name: "adds facets from labels of custom kinds",
+			objects: []models.Object{
+				{
+					Cluster:    "management",
+					Kind:       "Certificate",
+					Name:       "tls",
+					Category:   configuration.CategorySource,
+					Namespace:  "ns-1",
+					APIGroup:   "cert-manager.io",
+					APIVersion: "v1",
+					Labels: map[string]string{
+						"cert-manager.io/issuer": "letsencrypt",
+					},
+				},
+			},
+			expected: Facets{
+				"cluster":                       []string{"management"},
+				"namespace":                     []string{"ns-1"},
+				"kind":                          []string{"Certificate"},
+				"labels.cert-manager.io/issuer": []string{"letsencrypt"},
+			},
+			kinds: append([]configuration.ObjectKind{{
+				Gvk:      schema.GroupVersionKind{Group: "cert-manager.io", Version: "v1", Kind: "Certificate"},
+				Category: configuration.CategorySource,
+				Labels:   []string{"cert-manager.io/issuer"},
+			}}, configuration.SupportedObjectKinds...),
+		},
 	}
 
 	for _, tt := range tests {
@@ -511,7 +891,12 @@ func TestListFacets(t *testing.T) {
 				g.Expect(err).NotTo(HaveOccurred())
 			}()
 
-			facets, err := idx.ListFacets(context.Background(), tt.requestedCategory)
+			kinds := tt.kinds
+			if kinds == nil {
+				kinds = configuration.SupportedObjectKinds
+			}
+
+			facets, err := idx.ListFacets(context.Background(), tt.requestedCategory, kinds)
 
 			diff := cmp.Diff(tt.expected, facets)
 
@@ -521,3 +906,28 @@ func TestListFacets(t *testing.T) {
 		})
 	}
 }
+
+type queryOption struct {
+	orderBy   string
+	pageToken string
+}
+
+func (o *queryOption) GetLimit() int32 {
+	return 0
+}
+
+func (o *queryOption) GetOffset() int32 {
+	return 0
+}
+
+func (o *queryOption) GetOrderBy() string {
+	return o.orderBy
+}
+
+func (o *queryOption) GetDescending() bool {
+	return false
+}
+
+func (o *queryOption) GetPageToken() string {
+	return o.pageToken
+}
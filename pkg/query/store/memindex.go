@@ -0,0 +1,57 @@
+package store
+
+import (
+	"fmt"
+
+	bleve "github.com/blevesearch/bleve/v2"
+	"github.com/go-logr/logr"
+	"github.com/weaveworks/weave-gitops-enterprise/pkg/query/internal/models"
+)
+
+// MemoryIndex indexes a handful of objects in memory, with the same mapping as the
+// indexer, to tell which of them match a query without searching the whole index.
+// It is used to match changes that may already be gone from the index, such as deletions.
+type MemoryIndex struct {
+	idx   bleve.Index
+	count int
+}
+
+func NewMemoryIndex(objects []models.Object, log logr.Logger) (*MemoryIndex, error) {
+	index, err := bleve.NewMemOnly(newIndexMapping())
+	if err != nil {
+		return nil, fmt.Errorf("failed to create memory index: %w", err)
+	}
+
+	if err := addObjects(index, objects, log); err != nil {
+		return nil, fmt.Errorf("failed to index objects: %w", err)
+	}
+
+	return &MemoryIndex{idx: index, count: len(objects)}, nil
+}
+
+// Matches returns the IDs of the objects that match the query.
+func (m *MemoryIndex) Matches(q Query) (map[string]bool, error) {
+	query, err := buildSearchQuery(q)
+	if err != nil {
+		return nil, err
+	}
+
+	req := bleve.NewSearchRequest(query)
+	req.Size = m.count
+
+	result, err := m.idx.Search(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search memory index: %w", err)
+	}
+
+	matches := map[string]bool{}
+	for _, hit := range result.Hits {
+		matches[hit.ID] = true
+	}
+
+	return matches, nil
+}
+
+func (m *MemoryIndex) Close() error {
+	return m.idx.Close()
+}
@@ -22,12 +22,22 @@ const (
 	DeleteRoleBindingsAction    = "DeleteRoleBindings"
 	DeleteAllRoleBindingsAction = "DeleteAllRoleBindings"
 	DeleteTenantsAction         = "DeleteTenants"
+	StoreObjectRevisionsAction  = "StoreObjectRevisions"
+	DeleteObjectRevisionsAction = "DeleteObjectRevisions"
+	StoreSavedQueryAction       = "StoreSavedQuery"
+	DeleteSavedQueryAction      = "DeleteSavedQuery"
+	StoreObjectEdgesAction      = "StoreObjectEdges"
 	GetObjectsAction            = "GetObjects"
 	GetObjectByIdAction         = "GetObjectByID"
 	GetRolesAction              = "GetRoles"
 	GetRoleBindingsAction       = "GetRoleBindings"
 	GetAccessRulesAction        = "GetAccessRules"
 	GetTenantsAction            = "GetTenants"
+	GetObjectHistoryAction      = "GetObjectHistory"
+	GetSavedQueriesAction       = "GetSavedQueries"
+	GetObjectEdgesAction        = "GetObjectEdges"
+	GetObjectsByReferenceAction = "GetObjectsByReference"
+	GetObjectsBySelectorAction  = "GetObjectsBySelector"
 
 	// indexer actions
 	AddAction           = "Add"
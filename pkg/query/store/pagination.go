@@ -0,0 +1,66 @@
+package store
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrInvalidPageToken is returned when a page token cannot be decoded,
+// or was issued for a different query than the one it is used with.
+var ErrInvalidPageToken = errors.New("invalid page token")
+
+// pageToken is the decoded form of the opaque token handed out to resume a search.
+// After holds the sort key of the last row read, so the next page starts right after it
+// no matter how many rows were added or removed before it in the meantime.
+type pageToken struct {
+	Query string   `json:"q"`
+	After []string `json:"a"`
+}
+
+func encodePageToken(fingerprint string, after []string) (string, error) {
+	data, err := json.Marshal(pageToken{Query: fingerprint, After: after})
+	if err != nil {
+		return "", fmt.Errorf("failed to encode page token: %w", err)
+	}
+
+	return base64.RawURLEncoding.EncodeToString(data), nil
+}
+
+// decodePageToken returns the sort key a search has to resume after.
+// The token must have been issued for a query with the same fingerprint.
+func decodePageToken(token string, fingerprint string) ([]string, error) {
+	data, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, ErrInvalidPageToken
+	}
+
+	pt := pageToken{}
+	if err := json.Unmarshal(data, &pt); err != nil {
+		return nil, ErrInvalidPageToken
+	}
+
+	if pt.Query != fingerprint || len(pt.After) == 0 {
+		return nil, ErrInvalidPageToken
+	}
+
+	return pt.After, nil
+}
+
+// queryFingerprint identifies the results and the order of a search,
+// so that a page token cannot be replayed against a different search.
+func queryFingerprint(q Query, opts QueryOption) string {
+	parts := []string{q.GetTerms(), strings.Join(q.GetFilters(), "\x00"), q.GetQuery()}
+
+	if opts != nil {
+		parts = append(parts, opts.GetOrderBy(), fmt.Sprint(opts.GetDescending()))
+	}
+
+	sum := sha256.Sum256([]byte(strings.Join(parts, "\x01")))
+
+	return hex.EncodeToString(sum[:8])
+}
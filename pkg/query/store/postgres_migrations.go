@@ -0,0 +1,272 @@
+package store
+
+import (
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// postgresMigrationsLockID is the key of the advisory lock held while migrating,
+// so that replicas starting at the same time do not race each other.
+const postgresMigrationsLockID = 7_362_914_201
+
+// postgresMigration is a single, append-only step of the Postgres schema.
+// Once released, a migration must never be changed: add a new one instead.
+type postgresMigration struct {
+	Version int
+	Name    string
+	Migrate func(tx *gorm.DB) error
+}
+
+// schemaMigration records the migrations that have been applied to a database.
+type schemaMigration struct {
+	Version   int `gorm:"primaryKey;autoIncrement:false"`
+	Name      string
+	AppliedAt time.Time
+}
+
+func (schemaMigration) TableName() string {
+	return "schema_migrations"
+}
+
+// The migrations are plain DDL rather than AutoMigrate calls on the models,
+// so that a released migration keeps creating the same schema as the models
+// change. It is the schema AutoMigrate created for the models at the time.
+var postgresMigrations = []postgresMigration{
+	{
+		Version: 1,
+		Name:    "create explorer tables",
+		Migrate: execStatements(
+			`CREATE TABLE objects (
+				id text,
+				created_at timestamptz,
+				updated_at timestamptz,
+				deleted_at timestamptz,
+				cluster text,
+				namespace text,
+				api_group text,
+				api_version text,
+				kind text,
+				name text,
+				status text,
+				message text,
+				category text,
+				kubernetes_deleted_at timestamptz,
+				unstructured bytea,
+				tenant text,
+				PRIMARY KEY (id)
+			)`,
+			`CREATE INDEX idx_objects_deleted_at ON objects (deleted_at)`,
+			`CREATE TABLE roles (
+				id text,
+				created_at timestamptz,
+				updated_at timestamptz,
+				deleted_at timestamptz,
+				cluster text,
+				namespace text,
+				kind text,
+				name text,
+				PRIMARY KEY (id)
+			)`,
+			`CREATE INDEX idx_roles_deleted_at ON roles (deleted_at)`,
+			`CREATE TABLE policy_rules (
+				id bigserial,
+				created_at timestamptz,
+				updated_at timestamptz,
+				deleted_at timestamptz,
+				api_groups text,
+				resources text,
+				verbs text,
+				role_id text,
+				resource_names text,
+				PRIMARY KEY (id),
+				CONSTRAINT fk_roles_policy_rules FOREIGN KEY (role_id) REFERENCES roles (id)
+			)`,
+			`CREATE INDEX idx_policy_rules_deleted_at ON policy_rules (deleted_at)`,
+			`CREATE TABLE role_bindings (
+				id text,
+				created_at timestamptz,
+				updated_at timestamptz,
+				deleted_at timestamptz,
+				cluster text,
+				namespace text,
+				kind text,
+				name text,
+				role_ref_name text,
+				role_ref_kind text,
+				PRIMARY KEY (id)
+			)`,
+			`CREATE INDEX idx_role_bindings_deleted_at ON role_bindings (deleted_at)`,
+			`CREATE TABLE subjects (
+				id bigserial,
+				created_at timestamptz,
+				updated_at timestamptz,
+				deleted_at timestamptz,
+				kind text,
+				name text,
+				namespace text,
+				api_group text,
+				role_binding_id text,
+				PRIMARY KEY (id),
+				CONSTRAINT fk_role_bindings_subjects FOREIGN KEY (role_binding_id) REFERENCES role_bindings (id)
+			)`,
+			`CREATE INDEX idx_subjects_deleted_at ON subjects (deleted_at)`,
+			`CREATE TABLE tenants (
+				id text,
+				created_at timestamptz,
+				updated_at timestamptz,
+				deleted_at timestamptz,
+				cluster_name text,
+				name text,
+				namespace text,
+				PRIMARY KEY (id)
+			)`,
+			`CREATE INDEX idx_tenants_deleted_at ON tenants (deleted_at)`,
+		),
+	},
+	{
+		Version: 2,
+		Name:    "index rows by cluster",
+		// Collectors delete everything for a cluster when it goes away or is resynced.
+		Migrate: execStatements(
+			`CREATE INDEX idx_objects_cluster ON objects (cluster)`,
+			`CREATE INDEX idx_roles_cluster ON roles (cluster)`,
+			`CREATE INDEX idx_role_bindings_cluster ON role_bindings (cluster)`,
+			`CREATE INDEX idx_tenants_cluster_name ON tenants (cluster_name)`,
+		),
+	},
+	{
+		Version: 3,
+		Name:    "create object history",
+		Migrate: execStatements(
+			`CREATE TABLE object_revisions (
+				id bigserial,
+				object_id text,
+				cluster text,
+				status text,
+				message text,
+				spec bytea,
+				recorded_at timestamptz,
+				PRIMARY KEY (id)
+			)`,
+			`CREATE INDEX idx_object_revisions_object_id ON object_revisions (object_id)`,
+			`CREATE INDEX idx_object_revisions_cluster ON object_revisions (cluster)`,
+			`CREATE INDEX idx_object_revisions_recorded_at ON object_revisions (recorded_at)`,
+		),
+	},
+	{
+		Version: 4,
+		Name:    "create saved queries",
+		Migrate: execStatements(
+			`CREATE TABLE saved_queries (
+				id text,
+				name text,
+				owner text,
+				shared_with text,
+				terms text,
+				filters text,
+				query text,
+				order_by text,
+				descending boolean,
+				columns text,
+				created_at timestamptz,
+				PRIMARY KEY (id)
+			)`,
+			`CREATE INDEX idx_saved_queries_owner ON saved_queries (owner)`,
+		),
+	},
+	{
+		Version: 5,
+		Name:    "add role labels and aggregation selectors",
+		Migrate: execStatements(
+			`ALTER TABLE roles ADD COLUMN labels text`,
+			`ALTER TABLE roles ADD COLUMN aggregation_selectors text`,
+		),
+	},
+	{
+		Version: 6,
+		Name:    "add object search fields",
+		Migrate: execStatements(
+			`ALTER TABLE objects ADD COLUMN fields text`,
+		),
+	},
+	{
+		Version: 7,
+		Name:    "create object edges",
+		Migrate: execStatements(
+			`CREATE TABLE object_edges (
+				id bigserial,
+				cluster text,
+				owner text,
+				upstream text,
+				downstream text,
+				relation text,
+				PRIMARY KEY (id)
+			)`,
+			`CREATE INDEX idx_object_edges_cluster ON object_edges (cluster)`,
+			`CREATE INDEX idx_object_edges_owner ON object_edges (owner)`,
+			`CREATE INDEX idx_object_edges_upstream ON object_edges (upstream)`,
+			`CREATE INDEX idx_object_edges_downstream ON object_edges (downstream)`,
+		),
+	},
+}
+
+// execStatements returns a migration that executes the statements in order.
+func execStatements(statements ...string) func(tx *gorm.DB) error {
+	return func(tx *gorm.DB) error {
+		for _, stmt := range statements {
+			if err := tx.Exec(stmt).Error; err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+}
+
+// migratePostgres applies every migration that has not been applied yet, in order.
+// All pending migrations run in a single transaction, so a failure leaves the
+// schema as it was.
+func migratePostgres(db *gorm.DB) error {
+	return applyPostgresMigrations(db, postgresMigrations)
+}
+
+// applyPostgresMigrations applies the migrations given that have not been applied yet, in order.
+func applyPostgresMigrations(db *gorm.DB, migrations []postgresMigration) error {
+	return db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec("SELECT pg_advisory_xact_lock(?)", postgresMigrationsLockID).Error; err != nil {
+			return fmt.Errorf("failed to acquire migrations lock: %w", err)
+		}
+
+		if err := tx.AutoMigrate(&schemaMigration{}); err != nil {
+			return fmt.Errorf("failed to create migrations table: %w", err)
+		}
+
+		applied := []schemaMigration{}
+		if err := tx.Find(&applied).Error; err != nil {
+			return fmt.Errorf("failed to list applied migrations: %w", err)
+		}
+
+		done := map[int]bool{}
+		for _, m := range applied {
+			done[m.Version] = true
+		}
+
+		for _, m := range migrations {
+			if done[m.Version] {
+				continue
+			}
+
+			if err := m.Migrate(tx); err != nil {
+				return fmt.Errorf("migration %d (%s) failed: %w", m.Version, m.Name, err)
+			}
+
+			if err := tx.Create(&schemaMigration{Version: m.Version, Name: m.Name, AppliedAt: time.Now()}).Error; err != nil {
+				return fmt.Errorf("failed to record migration %d: %w", m.Version, err)
+			}
+		}
+
+		return nil
+	})
+}
@@ -0,0 +1,340 @@
+package store
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	embeddedpostgres "github.com/fergusstrange/embedded-postgres"
+	"github.com/go-logr/logr"
+	. "github.com/onsi/gomega"
+	"github.com/weaveworks/weave-gitops-enterprise/pkg/query/configuration"
+	"github.com/weaveworks/weave-gitops-enterprise/pkg/query/internal/models"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	gormlog "gorm.io/gorm/logger"
+)
+
+// The postgres tests run against an embedded server that is started on first use
+// and shared by the whole package, or against the server of QUERY_POSTGRES_DSN, a
+// connection string in the key=value form. Each test gets its own database.
+//
+// The tests are skipped when the embedded server can't be started, unless
+// QUERY_POSTGRES_TESTS=1, as in CI, where they fail instead.
+var (
+	postgresOnce    sync.Once
+	postgresServer  *embeddedpostgres.EmbeddedPostgres
+	postgresPort    uint32
+	postgresErr     error
+	postgresDBCount int32
+)
+
+func TestMain(m *testing.M) {
+	code := m.Run()
+
+	if postgresServer != nil {
+		if err := postgresServer.Stop(); err != nil {
+			fmt.Fprintf(os.Stderr, "failed to stop embedded postgres: %v\n", err)
+		}
+	}
+
+	os.Exit(code)
+}
+
+func TestCreatePostgresDB(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	dsn := createPostgresDatabase(t)
+
+	db, err := CreatePostgresDB(dsn)
+	g.Expect(err).To(BeNil())
+	closeDB(t, db)
+
+	// Migrating an up to date database is a no-op.
+	db, err = CreatePostgresDB(dsn)
+	g.Expect(err).To(BeNil())
+	defer closeDB(t, db)
+
+	applied := []schemaMigration{}
+	g.Expect(db.Order("version").Find(&applied).Error).To(Succeed())
+	g.Expect(applied).To(HaveLen(len(postgresMigrations)))
+
+	for i, m := range postgresMigrations {
+		g.Expect(applied[i].Version).To(Equal(m.Version))
+	}
+
+	for _, table := range []string{"objects", "roles", "role_bindings", "policy_rules", "subjects", "tenants", "object_revisions", "saved_queries"} {
+		g.Expect(db.Migrator().HasTable(table)).To(BeTrue(), table)
+	}
+}
+
+func TestPostgresMigrations(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// Applying a migration to a database migrated by the previous ones creates the
+	// same schema as applying them all at once.
+	for n := 1; n <= len(postgresMigrations); n++ {
+		upgraded := openPostgresDatabase(t)
+		g.Expect(applyPostgresMigrations(upgraded, postgresMigrations[:n-1])).To(Succeed())
+		g.Expect(applyPostgresMigrations(upgraded, postgresMigrations[:n])).To(Succeed())
+
+		fresh := openPostgresDatabase(t)
+		g.Expect(applyPostgresMigrations(fresh, postgresMigrations[:n])).To(Succeed())
+
+		g.Expect(postgresSchema(t, upgraded, false)).To(Equal(postgresSchema(t, fresh, false)), "migration %d", postgresMigrations[n-1].Version)
+	}
+
+	// The migrations create the tables the models are stored in. The indexes that aren't
+	// declared by the models are left out.
+	migrated := openPostgresDatabase(t)
+	g.Expect(migratePostgres(migrated)).To(Succeed())
+
+	automigrated := openPostgresDatabase(t)
+	g.Expect(automigrated.AutoMigrate(&models.Object{}, &models.Role{}, &models.Subject{}, &models.RoleBinding{}, &models.PolicyRule{}, &models.Tenant{}, &models.ObjectRevision{}, &models.SavedQuery{}, &models.ObjectEdge{})).To(Succeed())
+
+	g.Expect(postgresSchema(t, migrated, true)).To(Equal(postgresSchema(t, automigrated, true)))
+}
+
+// postgresSchema returns the columns, constraints and indexes of the tables of a database.
+// The migrations table, and the indexes, can be left out to compare with the tables of the models.
+func postgresSchema(t *testing.T, db *gorm.DB, modelsOnly bool) []string {
+	g := NewGomegaWithT(t)
+
+	queries := []string{
+		`SELECT table_name || '.' || column_name || ' ' || data_type AS def
+		FROM information_schema.columns WHERE table_schema = 'public' AND table_name <> 'schema_migrations'
+		ORDER BY table_name, column_name`,
+		`SELECT conrelid::regclass::text || ' ' || conname || ' ' || pg_get_constraintdef(oid) AS def
+		FROM pg_constraint WHERE connamespace = 'public'::regnamespace AND conrelid::regclass::text <> 'schema_migrations'
+		ORDER BY conrelid::regclass::text, conname`,
+	}
+	if !modelsOnly {
+		queries = append(queries, `SELECT indexdef AS def FROM pg_indexes WHERE schemaname = 'public' ORDER BY indexname`)
+	}
+
+	schema := []string{}
+	for _, query := range queries {
+		defs := []string{}
+		g.Expect(db.Raw(query).Scan(&defs).Error).To(Succeed())
+		schema = append(schema, defs...)
+	}
+
+	return schema
+}
+
+func openPostgresDatabase(t *testing.T) *gorm.DB {
+	db, err := gorm.Open(postgres.Open(createPostgresDatabase(t)), &gorm.Config{Logger: gormlog.Discard})
+	if err != nil {
+		t.Fatalf("failed to open database: %v", err)
+	}
+	t.Cleanup(func() { closeDB(t, db) })
+
+	return db
+}
+
+func TestPostgresStore_DeleteAllRoles(t *testing.T) {
+	g := NewGomegaWithT(t)
+	ctx := context.Background()
+
+	store, db := createPostgresStore(t)
+
+	roles := []models.Role{
+		{
+			Cluster:   "cluster-a",
+			Namespace: "namespace",
+			Name:      "role",
+			Kind:      "Role",
+			PolicyRules: []models.PolicyRule{{
+				APIGroups: "example.com",
+				Resources: "somekinds",
+				Verbs:     "list",
+			}},
+		},
+		{
+			Cluster:   "cluster-b",
+			Namespace: "namespace",
+			Name:      "role",
+			Kind:      "Role",
+			PolicyRules: []models.PolicyRule{{
+				APIGroups: "example.com",
+				Resources: "somekinds",
+				Verbs:     "list",
+			}},
+		},
+	}
+
+	g.Expect(store.StoreRoles(ctx, roles)).To(Succeed())
+	g.Expect(store.DeleteAllRoles(ctx, []string{"cluster-a"})).To(Succeed())
+
+	stored, err := store.GetRoles(ctx)
+	g.Expect(err).To(BeNil())
+	g.Expect(stored).To(HaveLen(1))
+	g.Expect(stored[0].Cluster).To(Equal("cluster-b"))
+
+	// The policy rules of deleted roles go with them.
+	var count int64
+	g.Expect(db.Model(&models.PolicyRule{}).Count(&count).Error).To(Succeed())
+	g.Expect(count).To(Equal(int64(1)))
+}
+
+func TestPostgresStore_StoreObjectsWithDuplicates(t *testing.T) {
+	g := NewGomegaWithT(t)
+	ctx := context.Background()
+
+	store, _ := createPostgresStore(t)
+
+	obj := models.Object{
+		Cluster:    "test-cluster",
+		Name:       "someName",
+		Namespace:  "namespace",
+		Kind:       "ValidKind",
+		APIGroup:   "example.com",
+		APIVersion: "v1",
+		Category:   configuration.CategoryAutomation,
+		Status:     "Progressing",
+	}
+
+	updated := obj
+	updated.Status = "Success"
+
+	g.Expect(store.StoreObjects(ctx, []models.Object{obj, updated})).To(Succeed())
+
+	stored, err := store.GetObjectByID(ctx, obj.GetID())
+	g.Expect(err).To(BeNil())
+	g.Expect(stored.Status).To(Equal("Success"))
+}
+
+func createPostgresStore(t *testing.T) (Store, *gorm.DB) {
+	g := NewGomegaWithT(t)
+
+	db, err := CreatePostgresDB(createPostgresDatabase(t))
+	g.Expect(err).To(BeNil())
+	t.Cleanup(func() { closeDB(t, db) })
+
+	store, err := NewPostgresStore(db, logr.Discard())
+	g.Expect(err).To(BeNil())
+
+	return store, db
+}
+
+// createPostgresDatabase creates an empty database on the postgres server, which
+// is dropped at the end of the test, and returns its connection string. The test
+// is skipped if the embedded server cannot be started, for example when its
+// binaries cannot be downloaded, unless the postgres tests are required.
+func createPostgresDatabase(t *testing.T) string {
+	postgresOnce.Do(startPostgres)
+	if postgresErr != nil {
+		if os.Getenv("QUERY_POSTGRES_TESTS") == "1" {
+			t.Fatalf("postgres is not available: %v", postgresErr)
+		}
+		t.Skipf("embedded postgres is not available: %v", postgresErr)
+	}
+
+	// The process ID tells apart the databases of test runs sharing a server.
+	name := fmt.Sprintf("explorer_test_%d_%d", os.Getpid(), atomic.AddInt32(&postgresDBCount, 1))
+
+	admin, err := gorm.Open(postgres.Open(postgresDSN("")), &gorm.Config{Logger: gormlog.Discard})
+	if err != nil {
+		t.Fatalf("failed to connect to postgres: %v", err)
+	}
+	defer closeDB(t, admin)
+
+	if err := admin.Exec(fmt.Sprintf("CREATE DATABASE %s", name)).Error; err != nil {
+		t.Fatalf("failed to create database %s: %v", name, err)
+	}
+
+	t.Cleanup(func() {
+		admin, err := gorm.Open(postgres.Open(postgresDSN("")), &gorm.Config{Logger: gormlog.Discard})
+		if err != nil {
+			t.Errorf("failed to connect to postgres: %v", err)
+			return
+		}
+		defer closeDB(t, admin)
+
+		if err := admin.Exec(fmt.Sprintf("DROP DATABASE IF EXISTS %s WITH (FORCE)", name)).Error; err != nil {
+			t.Errorf("failed to drop database %s: %v", name, err)
+		}
+	})
+
+	return postgresDSN(name)
+}
+
+// startPostgres starts the embedded server, unless the tests use the server of QUERY_POSTGRES_DSN.
+func startPostgres() {
+	if os.Getenv("QUERY_POSTGRES_DSN") != "" {
+		return
+	}
+
+	startEmbeddedPostgres()
+}
+
+func startEmbeddedPostgres() {
+	runtimeDir, err := os.MkdirTemp("", "postgres")
+	if err != nil {
+		postgresErr = err
+		return
+	}
+
+	postgresPort, err = freePort()
+	if err != nil {
+		postgresErr = err
+		return
+	}
+
+	server := embeddedpostgres.NewDatabase(embeddedpostgres.DefaultConfig().
+		Port(postgresPort).
+		RuntimePath(runtimeDir).
+		Logger(io.Discard))
+
+	if err := server.Start(); err != nil {
+		postgresErr = err
+		return
+	}
+
+	postgresServer = server
+}
+
+// postgresDSN returns the connection string of a database of the postgres server,
+// or of its default database if none is given.
+func postgresDSN(database string) string {
+	if dsn := os.Getenv("QUERY_POSTGRES_DSN"); dsn != "" {
+		if database == "" {
+			return dsn
+		}
+		// Later settings override earlier ones.
+		return fmt.Sprintf("%s dbname=%s", dsn, database)
+	}
+
+	if database == "" {
+		database = "postgres"
+	}
+
+	return fmt.Sprintf("host=localhost port=%d user=postgres password=postgres dbname=%s sslmode=disable", postgresPort, database)
+}
+
+func freePort() (uint32, error) {
+	l, err := net.Listen("tcp", "localhost:0")
+	if err != nil {
+		return 0, err
+	}
+	defer l.Close()
+
+	return uint32(l.Addr().(*net.TCPAddr).Port), nil
+}
+
+func closeDB(t *testing.T, db *gorm.DB) {
+	sqlDB, err := db.DB()
+	if err != nil {
+		t.Errorf("failed to get sql database: %v", err)
+		return
+	}
+
+	if err := sqlDB.Close(); err != nil {
+		t.Errorf("failed to close database: %v", err)
+	}
+}
@@ -0,0 +1,536 @@
+package store
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	bleve "github.com/blevesearch/bleve/v2"
+	blevequery "github.com/blevesearch/bleve/v2/search/query"
+)
+
+// ParseQuery parses a structured query expression and compiles it to a bleve query.
+//
+// The grammar supports boolean groups and field comparisons:
+//
+//	expr       = or
+//	or         = and { "OR" and }
+//	and        = unary { [ "AND" ] unary }
+//	unary      = "NOT" unary | primary
+//	primary    = "(" expr ")" | comparison | term
+//	comparison = field ( ":" | "=" | "!=" | ">" | ">=" | "<" | "<=" | "~" ) value
+//	           | field ( "in" | "notin" ) "(" value { "," value } ")"
+//	value      = word | quoted-string | /regex/
+//
+// For example:
+//
+//	kind:HelmRelease AND (status:Failed OR status:Stalled) AND NOT namespace:flux-system
+//	name:podinfo* labels.team in (a,b) kubernetesDeletedAt>=2023-01-01T00:00:00Z
+//
+// A value ending in `*` is a prefix match, `~` compares against a regular expression and
+// range operators compare numbers, RFC3339 timestamps or dates, falling back to strings.
+// Terms without a field are matched against any field, like DoQuery terms.
+// Syntax errors are returned as a *ParseError.
+func ParseQuery(expr string) (blevequery.Query, error) {
+	p := &queryParser{input: []rune(expr)}
+
+	q, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+
+	p.skipSpace()
+	if !p.eof() {
+		if p.peek() == ')' {
+			return nil, p.errorf("unexpected ')' without matching '('")
+		}
+		return nil, p.errorf("unexpected %q", string(p.peek()))
+	}
+
+	return q, nil
+}
+
+// ParseError is a syntax error in a structured query.
+type ParseError struct {
+	// Query is the query that failed to parse.
+	Query string
+	// Position is the zero-based offset, in characters, where the error was found.
+	Position int
+	// Message describes the error.
+	Message string
+}
+
+func (e *ParseError) Error() string {
+	return fmt.Sprintf("invalid query at column %d: %s", e.Position+1, e.Message)
+}
+
+const (
+	opEqual        = ":"
+	opEqualAlt     = "="
+	opNotEqual     = "!="
+	opGreater      = ">"
+	opGreaterEqual = ">="
+	opLess         = "<"
+	opLessEqual    = "<="
+	opRegex        = "~"
+	opIn           = "in"
+	opNotIn        = "notin"
+)
+
+// reserved holds the characters that end a word.
+const reserved = `()",:=!<>~`
+
+type queryParser struct {
+	input []rune
+	pos   int
+}
+
+func (p *queryParser) eof() bool {
+	return p.pos >= len(p.input)
+}
+
+func (p *queryParser) peek() rune {
+	if p.eof() {
+		return 0
+	}
+	return p.input[p.pos]
+}
+
+func (p *queryParser) skipSpace() {
+	for !p.eof() && unicode.IsSpace(p.peek()) {
+		p.pos++
+	}
+}
+
+func (p *queryParser) errorf(format string, args ...interface{}) error {
+	return p.errorAt(p.pos, format, args...)
+}
+
+func (p *queryParser) errorAt(pos int, format string, args ...interface{}) error {
+	return &ParseError{
+		Query:    string(p.input),
+		Position: pos,
+		Message:  fmt.Sprintf(format, args...),
+	}
+}
+
+// peekKeyword reports whether the next word is the given keyword, ignoring case.
+func (p *queryParser) peekKeyword(keyword string) bool {
+	p.skipSpace()
+
+	end := p.pos + len(keyword)
+	if end > len(p.input) || !strings.EqualFold(string(p.input[p.pos:end]), keyword) {
+		return false
+	}
+
+	// The keyword has to be a whole word
+	return end == len(p.input) || unicode.IsSpace(p.input[end]) || p.input[end] == '('
+}
+
+func (p *queryParser) parseOr() (blevequery.Query, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+
+	disjuncts := []blevequery.Query{left}
+
+	for p.peekKeyword("OR") {
+		p.pos += len("OR")
+
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		disjuncts = append(disjuncts, right)
+	}
+
+	if len(disjuncts) == 1 {
+		return left, nil
+	}
+
+	return bleve.NewDisjunctionQuery(disjuncts...), nil
+}
+
+func (p *queryParser) parseAnd() (blevequery.Query, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+
+	conjuncts := []blevequery.Query{left}
+
+	for {
+		p.skipSpace()
+		if p.eof() || p.peek() == ')' || p.peekKeyword("OR") {
+			break
+		}
+
+		if p.peekKeyword("AND") {
+			p.pos += len("AND")
+		}
+
+		right, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		conjuncts = append(conjuncts, right)
+	}
+
+	if len(conjuncts) == 1 {
+		return left, nil
+	}
+
+	return bleve.NewConjunctionQuery(conjuncts...), nil
+}
+
+func (p *queryParser) parseUnary() (blevequery.Query, error) {
+	if p.peekKeyword("NOT") {
+		p.pos += len("NOT")
+
+		q, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+
+		return negate(q), nil
+	}
+
+	return p.parsePrimary()
+}
+
+func (p *queryParser) parsePrimary() (blevequery.Query, error) {
+	p.skipSpace()
+
+	if p.eof() {
+		return nil, p.errorf("unexpected end of query, expected a term or a comparison")
+	}
+
+	if p.peek() == '(' {
+		open := p.pos
+		p.pos++
+
+		q, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+
+		p.skipSpace()
+		if p.peek() != ')' {
+			return nil, p.errorAt(open, "missing ')' for this '('")
+		}
+		p.pos++
+
+		return q, nil
+	}
+
+	if p.peek() == '"' {
+		start := p.pos
+		term, err := p.parseQuoted()
+		if err != nil {
+			return nil, err
+		}
+		if term == "" {
+			return nil, p.errorAt(start, "empty term")
+		}
+
+		return bleve.NewMatchPhraseQuery(term), nil
+	}
+
+	word := p.parseWord()
+	if word == "" {
+		return nil, p.errorf("unexpected %q, expected a term or a comparison", string(p.peek()))
+	}
+
+	op, ok := p.parseOperator()
+	if !ok {
+		return termQuery(word), nil
+	}
+
+	return p.parseComparison(word, op)
+}
+
+// parseWord reads a field name or a bare term.
+func (p *queryParser) parseWord() string {
+	start := p.pos
+	for !p.eof() && !unicode.IsSpace(p.peek()) && !strings.ContainsRune(reserved, p.peek()) {
+		p.pos++
+	}
+
+	return string(p.input[start:p.pos])
+}
+
+// parseOperator reads the comparison operator following a field name, if any.
+func (p *queryParser) parseOperator() (string, bool) {
+	for _, op := range []string{opNotEqual, opGreaterEqual, opLessEqual, opEqual, opEqualAlt, opGreater, opLess, opRegex} {
+		end := p.pos + len(op)
+		if end <= len(p.input) && string(p.input[p.pos:end]) == op {
+			p.pos = end
+			return op, true
+		}
+	}
+
+	// Set based operators are words, so they need to be followed by a list.
+	saved := p.pos
+	for _, op := range []string{opNotIn, opIn} {
+		if p.peekKeyword(op) {
+			p.pos += len(op)
+			p.skipSpace()
+			if p.peek() == '(' {
+				return op, true
+			}
+		}
+		p.pos = saved
+	}
+
+	return "", false
+}
+
+func (p *queryParser) parseComparison(field string, op string) (blevequery.Query, error) {
+	if op == opIn || op == opNotIn {
+		values, err := p.parseList()
+		if err != nil {
+			return nil, err
+		}
+
+		matches := []blevequery.Query{}
+		for _, v := range values {
+			matches = append(matches, matchQuery(field, v))
+		}
+
+		q := blevequery.Query(bleve.NewDisjunctionQuery(matches...))
+		if op == opNotIn {
+			q = negate(q)
+		}
+
+		return q, nil
+	}
+
+	// The value has to follow the operator directly, so that `status: AND x`
+	// is reported as a missing value rather than a comparison with "AND".
+	valueStart := p.pos
+
+	if op == opRegex && p.peek() == '/' {
+		pattern, err := p.parseRegex()
+		if err != nil {
+			return nil, err
+		}
+
+		return regexQuery(field, pattern), nil
+	}
+
+	value, quoted, err := p.parseValue()
+	if err != nil {
+		return nil, err
+	}
+	if value == "" {
+		return nil, p.errorAt(valueStart, "missing value after %q", field+op)
+	}
+
+	switch op {
+	case opEqual, opEqualAlt:
+		if !quoted && strings.HasSuffix(value, "*") {
+			return prefixQuery(field, strings.TrimSuffix(value, "*")), nil
+		}
+		return matchQuery(field, value), nil
+	case opNotEqual:
+		return negate(matchQuery(field, value)), nil
+	case opRegex:
+		return regexQuery(field, value), nil
+	default:
+		return rangeQuery(field, op, value), nil
+	}
+}
+
+// parseValue reads a quoted string or a bare value. Bare values may contain
+// characters that are otherwise reserved, like the colons in a timestamp.
+func (p *queryParser) parseValue() (string, bool, error) {
+	if p.peek() == '"' {
+		v, err := p.parseQuoted()
+		return v, true, err
+	}
+
+	start := p.pos
+	for !p.eof() && !unicode.IsSpace(p.peek()) && p.peek() != ')' && p.peek() != '(' && p.peek() != ',' {
+		p.pos++
+	}
+
+	return string(p.input[start:p.pos]), false, nil
+}
+
+func (p *queryParser) parseQuoted() (string, error) {
+	start := p.pos
+	p.pos++
+
+	var sb strings.Builder
+	for !p.eof() {
+		r := p.peek()
+		p.pos++
+
+		switch r {
+		case '\\':
+			if p.eof() {
+				return "", p.errorAt(start, "unterminated string")
+			}
+			sb.WriteRune(p.peek())
+			p.pos++
+		case '"':
+			return sb.String(), nil
+		default:
+			sb.WriteRune(r)
+		}
+	}
+
+	return "", p.errorAt(start, "unterminated string")
+}
+
+func (p *queryParser) parseRegex() (string, error) {
+	start := p.pos
+	p.pos++
+
+	var sb strings.Builder
+	for !p.eof() {
+		r := p.peek()
+		p.pos++
+
+		switch r {
+		case '\\':
+			if !p.eof() && p.peek() == '/' {
+				sb.WriteRune('/')
+				p.pos++
+				continue
+			}
+			sb.WriteRune(r)
+		case '/':
+			if sb.Len() == 0 {
+				return "", p.errorAt(start, "empty regular expression")
+			}
+			return sb.String(), nil
+		default:
+			sb.WriteRune(r)
+		}
+	}
+
+	return "", p.errorAt(start, "unterminated regular expression")
+}
+
+func (p *queryParser) parseList() ([]string, error) {
+	open := p.pos
+	// parseOperator leaves us on the opening parenthesis
+	p.pos++
+
+	values := []string{}
+	for {
+		p.skipSpace()
+		if p.eof() {
+			return nil, p.errorAt(open, "missing ')' for this '('")
+		}
+
+		valueStart := p.pos
+		v, _, err := p.parseValue()
+		if err != nil {
+			return nil, err
+		}
+		if v == "" {
+			return nil, p.errorAt(valueStart, "expected a value")
+		}
+		values = append(values, v)
+
+		p.skipSpace()
+		switch p.peek() {
+		case ',':
+			p.pos++
+		case ')':
+			p.pos++
+			return values, nil
+		default:
+			if p.eof() {
+				return nil, p.errorAt(open, "missing ')' for this '('")
+			}
+			return nil, p.errorf("expected ',' or ')' in list")
+		}
+	}
+}
+
+func termQuery(term string) blevequery.Query {
+	if strings.HasSuffix(term, "*") && len(term) > 1 {
+		return bleve.NewPrefixQuery(strings.TrimSuffix(term, "*"))
+	}
+
+	return bleve.NewTermQuery(term)
+}
+
+func matchQuery(field, value string) blevequery.Query {
+	q := bleve.NewMatchPhraseQuery(value)
+	q.SetField(field)
+	return q
+}
+
+func prefixQuery(field, prefix string) blevequery.Query {
+	q := bleve.NewPrefixQuery(prefix)
+	q.SetField(field)
+	return q
+}
+
+func regexQuery(field, pattern string) blevequery.Query {
+	q := bleve.NewRegexpQuery(pattern)
+	q.SetField(field)
+	return q
+}
+
+// rangeQuery compares the field with a number, a time or, failing both, a string.
+func rangeQuery(field, op, value string) blevequery.Query {
+	inclusive := op == opGreaterEqual || op == opLessEqual
+	lower := op == opGreater || op == opGreaterEqual
+
+	if n, err := strconv.ParseFloat(value, 64); err == nil {
+		var q *blevequery.NumericRangeQuery
+		if lower {
+			q = bleve.NewNumericRangeInclusiveQuery(&n, nil, &inclusive, nil)
+		} else {
+			q = bleve.NewNumericRangeInclusiveQuery(nil, &n, nil, &inclusive)
+		}
+		q.SetField(field)
+		return q
+	}
+
+	if t, ok := parseTime(value); ok {
+		var q *blevequery.DateRangeQuery
+		if lower {
+			q = bleve.NewDateRangeInclusiveQuery(t, time.Time{}, &inclusive, nil)
+		} else {
+			q = bleve.NewDateRangeInclusiveQuery(time.Time{}, t, nil, &inclusive)
+		}
+		q.SetField(field)
+		return q
+	}
+
+	var q *blevequery.TermRangeQuery
+	if lower {
+		q = bleve.NewTermRangeInclusiveQuery(value, "", &inclusive, nil)
+	} else {
+		q = bleve.NewTermRangeInclusiveQuery("", value, nil, &inclusive)
+	}
+	q.SetField(field)
+	return q
+}
+
+func parseTime(value string) (time.Time, bool) {
+	for _, layout := range []string{time.RFC3339Nano, "2006-01-02"} {
+		if t, err := time.Parse(layout, value); err == nil {
+			return t, true
+		}
+	}
+
+	return time.Time{}, false
+}
+
+// negate matches everything the given query does not match.
+func negate(q blevequery.Query) blevequery.Query {
+	bq := bleve.NewBooleanQuery()
+	bq.AddMust(bleve.NewMatchAllQuery())
+	bq.AddMustNot(q)
+	return bq
+}
@@ -0,0 +1,176 @@
+package store
+
+import (
+	"errors"
+	"testing"
+
+	blevequery "github.com/blevesearch/bleve/v2/search/query"
+	. "github.com/onsi/gomega"
+)
+
+func TestParseQuery(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  func(g *WithT, q blevequery.Query)
+	}{
+		{
+			name:  "single comparison",
+			input: "kind:HelmRelease",
+			want: func(g *WithT, q blevequery.Query) {
+				g.Expect(q).To(BeAssignableToTypeOf(&blevequery.MatchPhraseQuery{}))
+				g.Expect(q.(*blevequery.MatchPhraseQuery).FieldVal).To(Equal("kind"))
+				g.Expect(q.(*blevequery.MatchPhraseQuery).MatchPhrase).To(Equal("HelmRelease"))
+			},
+		},
+		{
+			name:  "implicit and",
+			input: "kind:HelmRelease cluster:management",
+			want: func(g *WithT, q blevequery.Query) {
+				g.Expect(q).To(BeAssignableToTypeOf(&blevequery.ConjunctionQuery{}))
+				g.Expect(q.(*blevequery.ConjunctionQuery).Conjuncts).To(HaveLen(2))
+			},
+		},
+		{
+			name:  "or binds weaker than and",
+			input: "kind:HelmRelease AND status:Failed OR kind:Kustomization",
+			want: func(g *WithT, q blevequery.Query) {
+				g.Expect(q).To(BeAssignableToTypeOf(&blevequery.DisjunctionQuery{}))
+				disjuncts := q.(*blevequery.DisjunctionQuery).Disjuncts
+				g.Expect(disjuncts).To(HaveLen(2))
+				g.Expect(disjuncts[0]).To(BeAssignableToTypeOf(&blevequery.ConjunctionQuery{}))
+			},
+		},
+		{
+			name:  "not",
+			input: "NOT namespace:flux-system",
+			want: func(g *WithT, q blevequery.Query) {
+				g.Expect(q).To(BeAssignableToTypeOf(&blevequery.BooleanQuery{}))
+			},
+		},
+		{
+			name:  "prefix",
+			input: "name:podinfo*",
+			want: func(g *WithT, q blevequery.Query) {
+				g.Expect(q).To(BeAssignableToTypeOf(&blevequery.PrefixQuery{}))
+				g.Expect(q.(*blevequery.PrefixQuery).Prefix).To(Equal("podinfo"))
+			},
+		},
+		{
+			name:  "quoted values are not prefixes",
+			input: `message:"install retries*"`,
+			want: func(g *WithT, q blevequery.Query) {
+				g.Expect(q).To(BeAssignableToTypeOf(&blevequery.MatchPhraseQuery{}))
+				g.Expect(q.(*blevequery.MatchPhraseQuery).MatchPhrase).To(Equal("install retries*"))
+			},
+		},
+		{
+			name:  "regex",
+			input: `name~/pod.*\/info/`,
+			want: func(g *WithT, q blevequery.Query) {
+				g.Expect(q).To(BeAssignableToTypeOf(&blevequery.RegexpQuery{}))
+				g.Expect(q.(*blevequery.RegexpQuery).Regexp).To(Equal("pod.*/info"))
+			},
+		},
+		{
+			name:  "time range keeps colons in the value",
+			input: "kubernetesDeletedAt>2023-01-01T10:00:00Z",
+			want: func(g *WithT, q blevequery.Query) {
+				g.Expect(q).To(BeAssignableToTypeOf(&blevequery.DateRangeQuery{}))
+				g.Expect(q.(*blevequery.DateRangeQuery).Start.Time.Hour()).To(Equal(10))
+			},
+		},
+		{
+			name:  "numeric range",
+			input: "replicas<=3",
+			want: func(g *WithT, q blevequery.Query) {
+				g.Expect(q).To(BeAssignableToTypeOf(&blevequery.NumericRangeQuery{}))
+				g.Expect(*q.(*blevequery.NumericRangeQuery).Max).To(Equal(float64(3)))
+			},
+		},
+		{
+			name:  "label selector",
+			input: "labels.team in (a,b)",
+			want: func(g *WithT, q blevequery.Query) {
+				g.Expect(q).To(BeAssignableToTypeOf(&blevequery.DisjunctionQuery{}))
+				g.Expect(q.(*blevequery.DisjunctionQuery).Disjuncts).To(HaveLen(2))
+			},
+		},
+		{
+			name:  "terms without a field",
+			input: "podinfo",
+			want: func(g *WithT, q blevequery.Query) {
+				g.Expect(q).To(BeAssignableToTypeOf(&blevequery.TermQuery{}))
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewGomegaWithT(t)
+
+			q, err := ParseQuery(tt.input)
+			g.Expect(err).NotTo(HaveOccurred())
+			tt.want(g, q)
+		})
+	}
+}
+
+func TestParseQuery_Errors(t *testing.T) {
+	tests := []struct {
+		input    string
+		position int
+		message  string
+	}{
+		{
+			input:    "kind:HelmRelease AND (status:Failed",
+			position: 21,
+			message:  "missing ')' for this '('",
+		},
+		{
+			input:    "kind:HelmRelease)",
+			position: 16,
+			message:  "unexpected ')' without matching '('",
+		},
+		{
+			input:    "status: AND kind:HelmRelease",
+			position: 7,
+			message:  `missing value after "status:"`,
+		},
+		{
+			input:    `message:"unterminated`,
+			position: 8,
+			message:  "unterminated string",
+		},
+		{
+			input:    "labels.team in (a b)",
+			position: 18,
+			message:  "expected ',' or ')' in list",
+		},
+		{
+			input:    "kind:HelmRelease AND",
+			position: 20,
+			message:  "unexpected end of query, expected a term or a comparison",
+		},
+		{
+			input:    "name~/unterminated",
+			position: 5,
+			message:  "unterminated regular expression",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			g := NewGomegaWithT(t)
+
+			_, err := ParseQuery(tt.input)
+			g.Expect(err).To(HaveOccurred())
+
+			var parseErr *ParseError
+			g.Expect(errors.As(err, &parseErr)).To(BeTrue())
+			g.Expect(parseErr.Position).To(Equal(tt.position))
+			g.Expect(parseErr.Message).To(Equal(tt.message))
+			g.Expect(parseErr.Query).To(Equal(tt.input))
+		})
+	}
+}
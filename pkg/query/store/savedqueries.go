@@ -0,0 +1,40 @@
+package store
+
+import (
+	"fmt"
+
+	"github.com/weaveworks/weave-gitops-enterprise/pkg/query/internal/models"
+	"gorm.io/gorm"
+)
+
+// Saved queries are plain SQL that both backends share.
+
+func storeSavedQuery(db *gorm.DB, query models.SavedQuery) error {
+	if err := query.Validate(); err != nil {
+		return fmt.Errorf("invalid saved query: %w", err)
+	}
+
+	if err := db.Create(&query).Error; err != nil {
+		return fmt.Errorf("failed to store saved query: %w", err)
+	}
+
+	return nil
+}
+
+func deleteSavedQuery(db *gorm.DB, id string) error {
+	if err := db.Where("id = ?", id).Delete(&models.SavedQuery{}).Error; err != nil {
+		return fmt.Errorf("failed to delete saved query: %w", err)
+	}
+
+	return nil
+}
+
+func getSavedQueries(db *gorm.DB) ([]models.SavedQuery, error) {
+	queries := []models.SavedQuery{}
+
+	if err := db.Order("name, id").Find(&queries).Error; err != nil {
+		return nil, fmt.Errorf("failed to get saved queries: %w", err)
+	}
+
+	return queries, nil
+}
@@ -0,0 +1,36 @@
+package store
+
+import (
+	"gorm.io/gorm"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/weaveworks/weave-gitops-enterprise/pkg/query/internal/models"
+)
+
+// ObjectSelector selects the stored objects of some clusters and kinds.
+// The objects of every cluster are selected when no cluster is given, and likewise for kinds.
+type ObjectSelector struct {
+	Clusters []string
+	Kinds    []schema.GroupVersionKind
+}
+
+// selectObjects narrows a query of objects down to the ones the selector selects.
+func selectObjects(db *gorm.DB, selector ObjectSelector) *gorm.DB {
+	query := db.Model(&models.Object{})
+
+	if len(selector.Clusters) > 0 {
+		query = query.Where("cluster IN ?", selector.Clusters)
+	}
+
+	if len(selector.Kinds) > 0 {
+		kinds := db.Where("api_group = ? AND api_version = ? AND kind = ?",
+			selector.Kinds[0].Group, selector.Kinds[0].Version, selector.Kinds[0].Kind)
+		for _, gvk := range selector.Kinds[1:] {
+			kinds = kinds.Or("api_group = ? AND api_version = ? AND kind = ?", gvk.Group, gvk.Version, gvk.Kind)
+		}
+
+		query = query.Where(kinds)
+	}
+
+	return query
+}
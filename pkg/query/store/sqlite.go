@@ -81,6 +81,16 @@ func (i *SQLiteStore) DeleteAllObjects(ctx context.Context, clusters []string) (
 		if result.Error != nil {
 			return fmt.Errorf("failed to delete all objects: %w", result.Error)
 		}
+
+		result = i.db.Where("cluster = ?", cluster).Delete(&models.ObjectRevision{})
+		if result.Error != nil {
+			return fmt.Errorf("failed to delete all object history: %w", result.Error)
+		}
+
+		result = i.db.Where("cluster = ?", cluster).Delete(&models.ObjectEdge{})
+		if result.Error != nil {
+			return fmt.Errorf("failed to delete all object edges: %w", result.Error)
+		}
 	}
 
 	return nil
@@ -317,6 +327,14 @@ func (i *SQLiteStore) GetAllObjects(ctx context.Context) (Iterator, error) {
 	return sqliterator.New(result)
 }
 
+func (i *SQLiteStore) GetObjectsBySelector(ctx context.Context, selector ObjectSelector) (it Iterator, err error) {
+	// metrics
+	metrics.DataStoreInflightRequests(metrics.GetObjectsBySelectorAction, 1)
+	defer recordMetrics(metrics.GetObjectsBySelectorAction, time.Now(), err)
+
+	return sqliterator.New(selectObjects(i.db, selector))
+}
+
 func (i *SQLiteStore) GetRoles(ctx context.Context) (roles []models.Role, err error) {
 	// metrics
 	metrics.DataStoreInflightRequests(metrics.GetRolesAction, 1)
@@ -378,6 +396,78 @@ func (i *SQLiteStore) GetTenants(ctx context.Context) (tenants []models.Tenant,
 	return tenants, nil
 }
 
+func (i *SQLiteStore) StoreObjectRevisions(ctx context.Context, revisions []models.ObjectRevision) (err error) {
+	// metrics
+	metrics.DataStoreInflightRequests(metrics.StoreObjectRevisionsAction, 1)
+	defer recordMetrics(metrics.StoreObjectRevisionsAction, time.Now(), err)
+
+	return storeObjectRevisions(i.db, revisions)
+}
+
+func (i *SQLiteStore) DeleteObjectRevisions(ctx context.Context, objectIDs []string, before time.Time) (err error) {
+	// metrics
+	metrics.DataStoreInflightRequests(metrics.DeleteObjectRevisionsAction, 1)
+	defer recordMetrics(metrics.DeleteObjectRevisionsAction, time.Now(), err)
+
+	return deleteObjectRevisions(i.db, objectIDs, before)
+}
+
+func (i *SQLiteStore) GetObjectHistory(ctx context.Context, objectID string) (revisions []models.ObjectRevision, err error) {
+	// metrics
+	metrics.DataStoreInflightRequests(metrics.GetObjectHistoryAction, 1)
+	defer recordMetrics(metrics.GetObjectHistoryAction, time.Now(), err)
+
+	return getObjectHistory(i.db, objectID)
+}
+
+func (i *SQLiteStore) StoreSavedQuery(ctx context.Context, query models.SavedQuery) (err error) {
+	// metrics
+	metrics.DataStoreInflightRequests(metrics.StoreSavedQueryAction, 1)
+	defer recordMetrics(metrics.StoreSavedQueryAction, time.Now(), err)
+
+	return storeSavedQuery(i.db, query)
+}
+
+func (i *SQLiteStore) DeleteSavedQuery(ctx context.Context, id string) (err error) {
+	// metrics
+	metrics.DataStoreInflightRequests(metrics.DeleteSavedQueryAction, 1)
+	defer recordMetrics(metrics.DeleteSavedQueryAction, time.Now(), err)
+
+	return deleteSavedQuery(i.db, id)
+}
+
+func (i *SQLiteStore) GetSavedQueries(ctx context.Context) (queries []models.SavedQuery, err error) {
+	// metrics
+	metrics.DataStoreInflightRequests(metrics.GetSavedQueriesAction, 1)
+	defer recordMetrics(metrics.GetSavedQueriesAction, time.Now(), err)
+
+	return getSavedQueries(i.db)
+}
+
+func (i *SQLiteStore) StoreObjectEdges(ctx context.Context, owners []string, edges []models.ObjectEdge) (err error) {
+	// metrics
+	metrics.DataStoreInflightRequests(metrics.StoreObjectEdgesAction, 1)
+	defer recordMetrics(metrics.StoreObjectEdgesAction, time.Now(), err)
+
+	return storeObjectEdges(i.db, owners, edges)
+}
+
+func (i *SQLiteStore) GetObjectEdges(ctx context.Context, references []string) (edges []models.ObjectEdge, err error) {
+	// metrics
+	metrics.DataStoreInflightRequests(metrics.GetObjectEdgesAction, 1)
+	defer recordMetrics(metrics.GetObjectEdgesAction, time.Now(), err)
+
+	return getObjectEdges(i.db, references)
+}
+
+func (i *SQLiteStore) GetObjectsByReference(ctx context.Context, references []models.ObjectReference) (objects []models.Object, err error) {
+	// metrics
+	metrics.DataStoreInflightRequests(metrics.GetObjectsByReferenceAction, 1)
+	defer recordMetrics(metrics.GetObjectsByReferenceAction, time.Now(), err)
+
+	return getObjectsByReference(i.db, references)
+}
+
 func (i *SQLiteStore) DeleteObjects(ctx context.Context, objects []models.Object) (err error) {
 	// metrics
 	metrics.DataStoreInflightRequests(metrics.DeleteObjectsAction, 1)
@@ -396,6 +486,16 @@ func (i *SQLiteStore) DeleteObjects(ctx context.Context, objects []models.Object
 		if result.Error != nil {
 			return fmt.Errorf("failed to delete object: %w", result.Error)
 		}
+
+		result = i.db.Where("object_id = ?", object.GetID()).Delete(&models.ObjectRevision{})
+		if result.Error != nil {
+			return fmt.Errorf("failed to delete object history: %w", result.Error)
+		}
+
+		result = i.db.Where("owner = ?", object.GetID()).Delete(&models.ObjectEdge{})
+		if result.Error != nil {
+			return fmt.Errorf("failed to delete object edges: %w", result.Error)
+		}
 	}
 
 	return nil
@@ -491,7 +591,7 @@ func CreateSQLiteDB(path string) (*gorm.DB, error) {
 	// From the readme: https://github.com/mattn/go-sqlite3
 	goDB.SetMaxOpenConns(1)
 
-	if err := db.AutoMigrate(&models.Object{}, &models.Role{}, &models.Subject{}, &models.RoleBinding{}, &models.PolicyRule{}, &models.Tenant{}); err != nil {
+	if err := db.AutoMigrate(&models.Object{}, &models.Role{}, &models.Subject{}, &models.RoleBinding{}, &models.PolicyRule{}, &models.Tenant{}, &models.ObjectRevision{}, &models.SavedQuery{}, &models.ObjectEdge{}); err != nil {
 		return nil, fmt.Errorf("failed to migrate database: %w", err)
 	}
 
@@ -20,6 +20,7 @@ import (
 	"github.com/weaveworks/weave-gitops-enterprise/pkg/query/internal/models"
 	"github.com/weaveworks/weave-gitops-enterprise/pkg/query/rbac"
 	"github.com/weaveworks/weave-gitops-enterprise/pkg/query/utils/testutils"
+	"github.com/weaveworks/weave-gitops/pkg/kube"
 	"github.com/weaveworks/weave-gitops/pkg/server/auth"
 )
 
@@ -796,7 +797,7 @@ func TestUpsertRoleWithPolicyRules(t *testing.T) {
 	rolebindings1, err := store.GetRoleBindings(ctx)
 	g.Expect(err).NotTo(HaveOccurred())
 
-	authz := rbac.NewAuthorizer(resourcesMap)
+	authz := rbac.NewAuthorizer(resourcesMap, kube.UserPrefixes{})
 	allow := authz.ObjectAuthorizer(roles1, rolebindings1, user, obj.Cluster)
 	g.Expect(allow(obj)).To(BeTrue())
 
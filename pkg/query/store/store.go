@@ -3,6 +3,7 @@ package store
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/go-logr/logr"
 	"github.com/weaveworks/weave-gitops-enterprise/pkg/query/internal/models"
@@ -33,8 +34,23 @@ type StoreWriter interface {
 	DeleteRoleBindings(ctx context.Context, roleBindings []models.RoleBinding) error
 	DeleteAllRoleBindings(ctx context.Context, clusters []string) error
 	DeleteTenants(ctx context.Context, tenants []models.Tenant) error
+	// StoreObjectRevisions appends revisions to the history of their objects.
+	// Only the latest MaxObjectRevisions of each object are kept.
+	StoreObjectRevisions(ctx context.Context, revisions []models.ObjectRevision) error
+	// DeleteObjectRevisions removes the revisions of the given objects recorded before a point in time.
+	DeleteObjectRevisions(ctx context.Context, objectIDs []string, before time.Time) error
+	// StoreSavedQuery adds a saved query. It fails if there is one with the same ID.
+	StoreSavedQuery(ctx context.Context, query models.SavedQuery) error
+	// DeleteSavedQuery removes the saved query with the given ID, if there is one.
+	DeleteSavedQuery(ctx context.Context, id string) error
+	// StoreObjectEdges replaces the edges read from the objects with the given IDs.
+	// The edges of an object are removed along with it.
+	StoreObjectEdges(ctx context.Context, owners []string, edges []models.ObjectEdge) error
 }
 
+// MaxObjectRevisions is the number of revisions kept in the history of an object.
+const MaxObjectRevisions = 50
+
 type QueryOperand string
 
 const (
@@ -53,9 +69,11 @@ const (
 // The filters are applied, and then terms are (logically) ANDed together.
 // Only results that match on both filters and terms are returned.
 // https://blevesearch.com/docs/Query/
+// The structured query, if set, is parsed with ParseQuery and ANDed with the rest.
 type Query interface {
 	GetTerms() string
 	GetFilters() []string
+	GetQuery() string
 }
 
 type QueryOption interface {
@@ -63,6 +81,9 @@ type QueryOption interface {
 	GetOffset() int32
 	GetOrderBy() string
 	GetDescending() bool
+	// GetPageToken returns the token of a previous page to resume the query from.
+	// The offset is ignored when it is set.
+	GetPageToken() string
 }
 
 // StoreReader is an interface for querying objects
@@ -72,12 +93,25 @@ type StoreReader interface {
 	GetObjectByID(ctx context.Context, id string) (models.Object, error)
 	GetObjects(ctx context.Context, ids []string, opts QueryOption) (Iterator, error)
 	GetAllObjects(ctx context.Context) (Iterator, error)
+	// GetObjectsBySelector returns the stored objects of the clusters and kinds the selector selects.
+	GetObjectsBySelector(ctx context.Context, selector ObjectSelector) (Iterator, error)
 	GetRoles(ctx context.Context) ([]models.Role, error)
 	GetRoleBindings(ctx context.Context) ([]models.RoleBinding, error)
 	GetAccessRules(ctx context.Context) ([]models.AccessRule, error)
 	GetTenants(ctx context.Context) ([]models.Tenant, error)
+	// GetObjectHistory returns the revisions of an object, oldest first.
+	GetObjectHistory(ctx context.Context, objectID string) ([]models.ObjectRevision, error)
+	// GetSavedQueries returns the queries saved by every user, sorted by name.
+	GetSavedQueries(ctx context.Context) ([]models.SavedQuery, error)
+	// GetObjectEdges returns the edges from or to any of the given object references.
+	GetObjectEdges(ctx context.Context, references []string) ([]models.ObjectEdge, error)
+	// GetObjectsByReference returns the stored objects that match the given references, whatever their API version.
+	GetObjectsByReference(ctx context.Context, references []models.ObjectReference) ([]models.Object, error)
 }
 
+// RowFilter decides whether a row read by an iterator is handed to the caller.
+type RowFilter = func(models.Object) (bool, error)
+
 // Iterator provides an iterable interface for requesting the next row of an object.
 // Since we are doing the access filtering outside of the database, we need to
 // ensure we are "filling" up the limit of the query.
@@ -90,8 +124,13 @@ type Iterator interface {
 	Row() (models.Object, error)
 	// All returns all rows of the iterator
 	All() ([]models.Object, error)
-	// Page returns a specified number of rows of the iterator with a specified offset
-	Page(count int, offset int) ([]models.Object, error)
+	// Page reads rows from the current position of the iterator until count rows
+	// were accepted by the filter, or there are no rows left. A count of zero reads
+	// all the remaining rows, and a nil filter accepts every row.
+	// Rows are read once: calling Page again carries on where the last page stopped.
+	// Iterators that can be resumed also return an opaque token for the rows after
+	// the page, which is empty once there are no rows left.
+	Page(count int, filter RowFilter) ([]models.Object, string, error)
 
 	// Close closes the iterator
 	Close() error
@@ -100,10 +139,12 @@ type Iterator interface {
 type StorageBackend string
 
 const (
-	StorageBackendSQLite StorageBackend = "sqlite"
+	StorageBackendSQLite   StorageBackend = "sqlite"
+	StorageBackendPostgres StorageBackend = "postgres"
 )
 
-// factory method that by default creates a in memory store
+// factory method that by default creates a in memory store.
+// For sqlite the uri is the directory holding the database file, for postgres it is the connection string.
 func NewStore(backend StorageBackend, uri string, log logr.Logger) (Store, error) {
 	switch backend {
 	case StorageBackendSQLite:
@@ -112,6 +153,12 @@ func NewStore(backend StorageBackend, uri string, log logr.Logger) (Store, error
 			return nil, fmt.Errorf("error creating sqlite db: %w", err)
 		}
 		return NewSQLiteStore(db, log)
+	case StorageBackendPostgres:
+		db, err := CreatePostgresDB(uri)
+		if err != nil {
+			return nil, fmt.Errorf("error creating postgres db: %w", err)
+		}
+		return NewPostgresStore(db, log)
 	default:
 		return nil, fmt.Errorf("unknown storage backend: %s", backend)
 	}
@@ -127,6 +174,13 @@ func DeriveAccessRules(roles []models.Role, bindings []models.RoleBinding) []mod
 
 	// Figure out the binding/role pairs
 	for _, role := range roles {
+		// Aggregated cluster roles grant the rules of the cluster roles they select.
+		rules, err := role.AggregatedRules(roles)
+		if err != nil {
+			continue
+		}
+		role.PolicyRules = rules
+
 		for _, binding := range bindings {
 			if bindingRoleMatch(binding, role) {
 				rule := convertToAccessRule(role.Cluster, role, binding, DefaultVerbsRequiredForAccess)
@@ -143,7 +197,8 @@ func bindingRoleMatch(binding models.RoleBinding, role models.Role) bool {
 		return false
 	}
 
-	if binding.Namespace != role.Namespace {
+	// Role bindings can grant a cluster role within their namespace.
+	if role.Kind == "Role" && binding.Namespace != role.Namespace {
 		return false
 	}
 
@@ -205,7 +260,7 @@ func convertToAccessRule(clusterName string, role models.Role, binding models.Ro
 
 	return models.AccessRule{
 		Cluster:                 clusterName,
-		Namespace:               role.Namespace,
+		Namespace:               binding.Namespace,
 		AccessibleKinds:         accessibleKinds,
 		Subjects:                binding.Subjects,
 		ProvidedByRole:          fmt.Sprintf("%s/%s", role.Kind, role.Name),
@@ -7,6 +7,7 @@ import (
 	"os"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/go-logr/logr"
 	"github.com/google/go-cmp/cmp"
@@ -14,154 +15,186 @@ import (
 	. "github.com/onsi/gomega"
 	"github.com/weaveworks/weave-gitops-enterprise/pkg/query/configuration"
 	"github.com/weaveworks/weave-gitops-enterprise/pkg/query/internal/models"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 
 	"gorm.io/gorm"
 )
 
 func TestGetObjects(t *testing.T) {
-	g := NewGomegaWithT(t)
+	forEachBackend(t, func(t *testing.T, backend StorageBackend) {
+		g := NewGomegaWithT(t)
 
-	store, db := createStore(t)
+		store, db := createStoreForBackend(t, backend)
 
-	obj := models.Object{
-		Cluster:   "test-cluster",
-		Name:      "someName",
-		Namespace: "namespace",
-		Kind:      "ValidKind",
-	}
+		obj := models.Object{
+			Cluster:   "test-cluster",
+			Name:      "someName",
+			Namespace: "namespace",
+			Kind:      "ValidKind",
+		}
 
-	g.Expect(SeedObjects(db, []models.Object{obj})).To(Succeed())
+		g.Expect(SeedObjects(db, []models.Object{obj})).To(Succeed())
 
-	iter, err := store.GetObjects(context.Background(), nil, nil)
-	g.Expect(err).To(BeNil())
+		iter, err := store.GetObjects(context.Background(), nil, nil)
+		g.Expect(err).To(BeNil())
 
-	objects, err := iter.All()
-	g.Expect(err).To(BeNil())
+		objects, err := iter.All()
+		g.Expect(err).To(BeNil())
 
-	g.Expect(len(objects) > 0).To(BeTrue())
-	g.Expect(objects[0].Name).To(Equal(obj.Name))
+		g.Expect(len(objects) > 0).To(BeTrue())
+		g.Expect(objects[0].Name).To(Equal(obj.Name))
+	})
 }
 
 func TestGetObjectsWithPagination(t *testing.T) {
-	g := NewGomegaWithT(t)
+	forEachBackend(t, func(t *testing.T, backend StorageBackend) {
+		g := NewGomegaWithT(t)
 
-	store, db := createStore(t)
+		store, db := createStoreForBackend(t, backend)
 
-	testObjects := []models.Object{
-		{
-			Cluster:   "test-cluster",
-			Name:      "someName-1",
-			Namespace: "namespace",
-			Kind:      "ValidKind",
-		},
-		{
-			Cluster:   "test-cluster",
-			Name:      "someName-2",
-			Namespace: "namespace",
-			Kind:      "ValidKind",
-		},
-		{
-			Cluster:   "test-cluster",
-			Name:      "someName-3",
-			Namespace: "namespace",
-			Kind:      "ValidKind",
-		},
-		{
-			Cluster:   "test-cluster",
-			Name:      "someName-4",
-			Namespace: "namespace",
-			Kind:      "ValidKind",
-		},
-		{
-			Cluster:   "test-cluster",
-			Name:      "someName-5",
-			Namespace: "namespace",
-			Kind:      "ValidKind",
-		},
-		{
-			Cluster:   "test-cluster",
-			Name:      "someName-6",
-			Namespace: "namespace",
-			Kind:      "ValidKind",
-		},
-		{
-			Cluster:   "test-cluster",
-			Name:      "someName-7",
-			Namespace: "namespace",
-			Kind:      "ValidKind",
-		},
-	}
+		testObjects := []models.Object{
+			{
+				Cluster:   "test-cluster",
+				Name:      "someName-1",
+				Namespace: "namespace",
+				Kind:      "ValidKind",
+			},
+			{
+				Cluster:   "test-cluster",
+				Name:      "someName-2",
+				Namespace: "namespace",
+				Kind:      "ValidKind",
+			},
+			{
+				Cluster:   "test-cluster",
+				Name:      "someName-3",
+				Namespace: "namespace",
+				Kind:      "ValidKind",
+			},
+			{
+				Cluster:   "test-cluster",
+				Name:      "someName-4",
+				Namespace: "namespace",
+				Kind:      "ValidKind",
+			},
+			{
+				Cluster:   "test-cluster",
+				Name:      "someName-5",
+				Namespace: "namespace",
+				Kind:      "ValidKind",
+			},
+			{
+				Cluster:   "test-cluster",
+				Name:      "someName-6",
+				Namespace: "namespace",
+				Kind:      "ValidKind",
+			},
+			{
+				Cluster:   "test-cluster",
+				Name:      "someName-7",
+				Namespace: "namespace",
+				Kind:      "ValidKind",
+			},
+		}
 
-	g.Expect(SeedObjects(db, testObjects)).To(Succeed())
+		g.Expect(SeedObjects(db, testObjects)).To(Succeed())
 
-	iter, err := store.GetObjects(context.Background(), nil, nil)
-	g.Expect(err).To(BeNil())
+		iter, err := store.GetObjects(context.Background(), nil, nil)
+		g.Expect(err).To(BeNil())
 
-	objects, err := iter.All()
-	g.Expect(err).To(BeNil())
-	g.Expect(len(objects)).To(Equal(len(testObjects)))
-	g.Expect(objects[0].Name).To(Equal(testObjects[0].Name))
-	g.Expect(objects[3].Name).To(Equal(testObjects[3].Name))
+		objects, err := iter.All()
+		g.Expect(err).To(BeNil())
+		g.Expect(len(objects)).To(Equal(len(testObjects)))
+		g.Expect(objects[0].Name).To(Equal(testObjects[0].Name))
+		g.Expect(objects[3].Name).To(Equal(testObjects[3].Name))
 
-	// With pagination and without an offset.
-	iter, err = store.GetObjects(context.Background(), nil, nil)
-	g.Expect(err).To(BeNil())
+		// With pagination, each page carries on where the previous one stopped.
+		iter, err = store.GetObjects(context.Background(), nil, nil)
+		g.Expect(err).To(BeNil())
 
-	objects, err = iter.Page(3, 0)
-	g.Expect(err).To(BeNil())
-	g.Expect(len(objects)).To(Equal(3))
-	g.Expect(objects[0].Name).To(Equal(testObjects[0].Name))
+		objects, _, err = iter.Page(3, nil)
+		g.Expect(err).To(BeNil())
+		g.Expect(len(objects)).To(Equal(3))
+		g.Expect(objects[0].Name).To(Equal(testObjects[0].Name))
 
-	objects, err = iter.Page(3, 0)
-	g.Expect(err).To(BeNil())
-	g.Expect(len(objects)).To(Equal(3))
-	g.Expect(objects[0].Name).To(Equal(testObjects[3].Name))
+		objects, _, err = iter.Page(3, nil)
+		g.Expect(err).To(BeNil())
+		g.Expect(len(objects)).To(Equal(3))
+		g.Expect(objects[0].Name).To(Equal(testObjects[3].Name))
 
-	objects, err = iter.Page(3, 0)
-	g.Expect(err).To(BeNil())
-	g.Expect(len(objects)).To(Equal(1))
-	g.Expect(objects[0].Name).To(Equal(testObjects[6].Name))
+		objects, _, err = iter.Page(3, nil)
+		g.Expect(err).To(BeNil())
+		g.Expect(len(objects)).To(Equal(1))
+		g.Expect(objects[0].Name).To(Equal(testObjects[6].Name))
 
-	// With pagination and with an initial offset.
-	iter, err = store.GetObjects(context.Background(), nil, nil)
-	g.Expect(err).To(BeNil())
+		// With pagination and a filter, rejected rows do not count towards the page.
+		iter, err = store.GetObjects(context.Background(), nil, nil)
+		g.Expect(err).To(BeNil())
 
-	objects, err = iter.Page(2, 2)
-	g.Expect(err).To(BeNil())
-	g.Expect(len(objects)).To(Equal(2))
-	g.Expect(objects[0].Name).To(Equal(testObjects[2].Name))
+		skipSecond := func(obj models.Object) (bool, error) {
+			return obj.Name != testObjects[1].Name, nil
+		}
 
-	objects, err = iter.Page(2, 0)
-	g.Expect(err).To(BeNil())
-	g.Expect(len(objects)).To(Equal(2))
-	g.Expect(objects[0].Name).To(Equal(testObjects[4].Name))
+		objects, _, err = iter.Page(2, skipSecond)
+		g.Expect(err).To(BeNil())
+		g.Expect(len(objects)).To(Equal(2))
+		g.Expect(objects[1].Name).To(Equal(testObjects[2].Name))
 
-	objects, err = iter.Page(2, 0)
-	g.Expect(err).To(BeNil())
-	g.Expect(len(objects)).To(Equal(1))
-	g.Expect(objects[0].Name).To(Equal(testObjects[6].Name))
+		objects, _, err = iter.Page(2, skipSecond)
+		g.Expect(err).To(BeNil())
+		g.Expect(len(objects)).To(Equal(2))
+		g.Expect(objects[0].Name).To(Equal(testObjects[3].Name))
+
+		objects, _, err = iter.Page(0, skipSecond)
+		g.Expect(err).To(BeNil())
+		g.Expect(len(objects)).To(Equal(2))
+		g.Expect(objects[1].Name).To(Equal(testObjects[6].Name))
+	})
 }
 
 func TestDeleteObjects(t *testing.T) {
-	tests := []struct {
-		name     string
-		seed     []models.Object
-		toRemove []models.Object
-		want     []models.Object
-	}{
-		{
-			name: "remove one object",
-			seed: []models.Object{
-				{
-					Cluster:    "test-cluster",
-					Name:       "someName",
-					Namespace:  "namespace",
-					Kind:       "ValidKind",
-					APIGroup:   "example.com",
-					APIVersion: "v1",
-					Category:   configuration.CategoryAutomation,
+	forEachBackend(t, func(t *testing.T, backend StorageBackend) {
+		tests := []struct {
+			name     string
+			seed     []models.Object
+			toRemove []models.Object
+			want     []models.Object
+		}{
+			{
+				name: "remove one object",
+				seed: []models.Object{
+					{
+						Cluster:    "test-cluster",
+						Name:       "someName",
+						Namespace:  "namespace",
+						Kind:       "ValidKind",
+						APIGroup:   "example.com",
+						APIVersion: "v1",
+						Category:   configuration.CategoryAutomation,
+					},
+					{
+						Cluster:    "test-cluster",
+						Name:       "otherName",
+						Namespace:  "namespace",
+						Kind:       "ValidKind",
+						APIGroup:   "example.com",
+						APIVersion: "v1",
+						Category:   configuration.CategoryAutomation,
+					},
 				},
-				{
+				toRemove: []models.Object{
+					{
+						Cluster:    "test-cluster",
+						Name:       "someName",
+						Namespace:  "namespace",
+						Kind:       "ValidKind",
+						APIGroup:   "example.com",
+						APIVersion: "v1",
+						Category:   configuration.CategoryAutomation,
+					},
+				},
+				want: []models.Object{{
 					Cluster:    "test-cluster",
 					Name:       "otherName",
 					Namespace:  "namespace",
@@ -169,114 +202,284 @@ func TestDeleteObjects(t *testing.T) {
 					APIGroup:   "example.com",
 					APIVersion: "v1",
 					Category:   configuration.CategoryAutomation,
-				},
+				}},
 			},
-			toRemove: []models.Object{
-				{
-					Cluster:    "test-cluster",
-					Name:       "someName",
-					Namespace:  "namespace",
-					Kind:       "ValidKind",
-					APIGroup:   "example.com",
-					APIVersion: "v1",
-					Category:   configuration.CategoryAutomation,
+			{
+				name: "remove multiple objects",
+				seed: []models.Object{
+					{
+						Cluster:    "test-cluster",
+						Name:       "someName",
+						Namespace:  "namespace",
+						Kind:       "ValidKind",
+						APIGroup:   "example.com",
+						APIVersion: "v1",
+						Category:   configuration.CategoryAutomation,
+					},
+					{
+						Cluster:    "test-cluster",
+						Name:       "otherName",
+						Namespace:  "namespace",
+						Kind:       "ValidKind",
+						APIGroup:   "example.com",
+						APIVersion: "v1",
+						Category:   configuration.CategoryAutomation,
+					},
+				},
+				toRemove: []models.Object{
+					{
+						Cluster:    "test-cluster",
+						Name:       "someName",
+						Namespace:  "namespace",
+						Kind:       "ValidKind",
+						APIGroup:   "example.com",
+						APIVersion: "v1",
+						Category:   configuration.CategoryAutomation,
+					},
+					{
+						Cluster:    "test-cluster",
+						Name:       "otherName",
+						Namespace:  "namespace",
+						Kind:       "ValidKind",
+						APIGroup:   "example.com",
+						APIVersion: "v1",
+						Category:   configuration.CategoryAutomation,
+					},
 				},
+				want: []models.Object{},
 			},
-			want: []models.Object{{
+		}
+		g := NewGomegaWithT(t)
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				store, db := createStoreForBackend(t, backend)
+
+				g.Expect(SeedObjects(db, tt.seed)).To(Succeed())
+
+				sqlDB, err := db.DB()
+				g.Expect(err).To(BeNil())
+
+				var count1 int64
+				g.Expect(sqlDB.QueryRow("SELECT COUNT(*) FROM objects").Scan(&count1)).To(Succeed())
+				g.Expect(count1).To(Equal(int64(len(tt.seed))))
+
+				g.Expect(store.DeleteObjects(context.Background(), tt.toRemove)).To(Succeed())
+
+				var count2 int64
+
+				g.Expect(sqlDB.QueryRow("SELECT COUNT(*) FROM objects").Scan(&count2)).To(Succeed())
+
+				g.Expect(count2).To(Equal(int64(len(tt.want))))
+			})
+		}
+	})
+}
+
+func TestStoreObjects(t *testing.T) {
+	forEachBackend(t, func(t *testing.T, backend StorageBackend) {
+		g := NewGomegaWithT(t)
+
+		t.Run("stores objects", func(t *testing.T) {
+			store, db := createStoreForBackend(t, backend)
+
+			obj := models.Object{
 				Cluster:    "test-cluster",
-				Name:       "otherName",
+				Name:       "someName",
 				Namespace:  "namespace",
 				Kind:       "ValidKind",
 				APIGroup:   "example.com",
 				APIVersion: "v1",
 				Category:   configuration.CategoryAutomation,
-			}},
-		},
-		{
-			name: "remove multiple objects",
-			seed: []models.Object{
-				{
-					Cluster:    "test-cluster",
-					Name:       "someName",
-					Namespace:  "namespace",
-					Kind:       "ValidKind",
-					APIGroup:   "example.com",
-					APIVersion: "v1",
-					Category:   configuration.CategoryAutomation,
-				},
+			}
+
+			g.Expect(store.StoreObjects(context.Background(), []models.Object{obj})).To(Succeed())
+
+			sqlDB, err := db.DB()
+			g.Expect(err).To(BeNil())
+
+			var storedObj models.Object
+			g.Expect(sqlDB.QueryRow("SELECT id FROM objects").Scan(&storedObj.ID)).To(Succeed())
+
+			g.Expect(storedObj.ID).To(Equal(obj.GetID()))
+		})
+		t.Run("upserts objects", func(t *testing.T) {
+			store, db := createStoreForBackend(t, backend)
+
+			obj := models.Object{
+				Cluster:    "test-cluster",
+				Name:       "someName",
+				Namespace:  "namespace",
+				Kind:       "ValidKind",
+				APIGroup:   "example.com",
+				APIVersion: "v1",
+				Category:   configuration.CategoryAutomation,
+			}
+
+			g.Expect(store.StoreObjects(context.Background(), []models.Object{obj})).To(Succeed())
+
+			sqlDB, err := db.DB()
+			g.Expect(err).To(BeNil())
+
+			var count int64
+			g.Expect(sqlDB.QueryRow("SELECT COUNT(*) FROM objects").Scan(&count)).To(Succeed())
+			g.Expect(count).To(Equal(int64(1)))
+
+			g.Expect(store.StoreObjects(context.Background(), []models.Object{obj})).To(Succeed())
+
+			g.Expect(sqlDB.QueryRow("SELECT COUNT(*) FROM objects").Scan(&count)).To(Succeed())
+			g.Expect(count).To(Equal(int64(1)))
+		})
+
+	})
+}
+
+func TestGetAccessRules(t *testing.T) {
+	forEachBackend(t, func(t *testing.T, backend StorageBackend) {
+		g := NewGomegaWithT(t)
+		ctx := context.Background()
+
+		store, _ := createStoreForBackend(t, backend)
+
+		role := models.Role{
+			Cluster:   "test-cluster",
+			Namespace: "namespace",
+			Name:      "someName",
+			Kind:      "Role",
+			PolicyRules: []models.PolicyRule{
 				{
-					Cluster:    "test-cluster",
-					Name:       "otherName",
-					Namespace:  "namespace",
-					Kind:       "ValidKind",
-					APIGroup:   "example.com",
-					APIVersion: "v1",
-					Category:   configuration.CategoryAutomation,
+					APIGroups: strings.Join([]string{"example.com"}, ","),
+					Resources: strings.Join([]string{"SomeKind"}, ","),
+					Verbs:     strings.Join([]string{"get", "list"}, ","),
 				},
 			},
-			toRemove: []models.Object{
-				{
-					Cluster:    "test-cluster",
-					Name:       "someName",
-					Namespace:  "namespace",
-					Kind:       "ValidKind",
-					APIGroup:   "example.com",
-					APIVersion: "v1",
-					Category:   configuration.CategoryAutomation,
-				},
+		}
+
+		rb := models.RoleBinding{
+			Cluster:   "test-cluster",
+			Namespace: "namespace",
+			Name:      "someName",
+			Kind:      "RoleBinding",
+			Subjects: []models.Subject{
 				{
-					Cluster:    "test-cluster",
-					Name:       "otherName",
-					Namespace:  "namespace",
-					Kind:       "ValidKind",
-					APIGroup:   "example.com",
-					APIVersion: "v1",
-					Category:   configuration.CategoryAutomation,
+					APIGroup: "rbac.authorization.k8s.io",
+					Kind:     role.Kind,
+					Name:     role.Name,
 				},
 			},
-			want: []models.Object{},
-		},
-	}
-	g := NewGomegaWithT(t)
+			RoleRefName: role.Name,
+			RoleRefKind: role.Kind,
+		}
 
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			store, db := createStore(t)
+		g.Expect(store.StoreRoles(ctx, []models.Role{role})).To(Succeed())
+		g.Expect(store.StoreRoleBindings(ctx, []models.RoleBinding{rb})).To(Succeed())
 
-			g.Expect(SeedObjects(db, tt.seed)).To(Succeed())
+		r, err := store.GetAccessRules(ctx)
+		g.Expect(err).To(BeNil())
 
-			sqlDB, err := db.DB()
-			g.Expect(err).To(BeNil())
+		g.Expect(r).To(HaveLen(1))
+
+		expected := models.AccessRule{
+			Cluster:   "test-cluster",
+			Namespace: "namespace",
+			Subjects: []models.Subject{{
+				APIGroup:      "rbac.authorization.k8s.io",
+				Kind:          "Role",
+				Name:          "someName",
+				RoleBindingID: rb.GetID(),
+			}},
+			AccessibleKinds:         []string{"example.com/SomeKind"},
+			ProvidedByRole:          fmt.Sprintf("%s/%s", role.Kind, role.Name),
+			ProvidedByBinding:       fmt.Sprintf("%s/%s", rb.Kind, rb.Name),
+			AccessibleResourceNames: []string{},
+		}
 
-			var count1 int64
-			g.Expect(sqlDB.QueryRow("SELECT COUNT(*) FROM objects").Scan(&count1)).To(Succeed())
-			g.Expect(count1).To(Equal(int64(len(tt.seed))))
+		diff := cmp.Diff(expected, r[0], cmpopts.IgnoreFields(models.Subject{}, "ID", "CreatedAt", "UpdatedAt"))
 
-			g.Expect(store.DeleteObjects(context.Background(), tt.toRemove)).To(Succeed())
+		if diff != "" {
+			t.Errorf("GetAccessRules() mismatch (-want +got):\n%s", diff)
+		}
+	})
+}
 
-			var count2 int64
+func TestGetAccessRules_AggregatedClusterRoles(t *testing.T) {
+	forEachBackend(t, func(t *testing.T, backend StorageBackend) {
+		g := NewGomegaWithT(t)
+		ctx := context.Background()
 
-			g.Expect(sqlDB.QueryRow("SELECT COUNT(*) FROM objects").Scan(&count2)).To(Succeed())
+		store, _ := createStoreForBackend(t, backend)
 
-			g.Expect(count2).To(Equal(int64(len(tt.want))))
-		})
-	}
-}
+		aggregateToView := map[string]string{"rbac.authorization.k8s.io/aggregate-to-view": "true"}
+		roles := []models.Role{
+			{
+				Cluster:              "test-cluster",
+				Kind:                 "ClusterRole",
+				Name:                 "view",
+				AggregationSelectors: []metav1.LabelSelector{{MatchLabels: aggregateToView}},
+			},
+			{
+				Cluster: "test-cluster",
+				Kind:    "ClusterRole",
+				Name:    "view-helm",
+				Labels:  aggregateToView,
+				PolicyRules: []models.PolicyRule{{
+					APIGroups: "helm.toolkit.fluxcd.io",
+					Resources: "helmreleases",
+					Verbs:     "list",
+				}},
+			},
+		}
 
-func TestStoreObjects(t *testing.T) {
-	g := NewGomegaWithT(t)
+		rb := models.RoleBinding{
+			Cluster:     "test-cluster",
+			Namespace:   "namespace",
+			Name:        "devs-view",
+			Kind:        "RoleBinding",
+			Subjects:    []models.Subject{{Kind: "Group", Name: "devs"}},
+			RoleRefName: "view",
+			RoleRefKind: "ClusterRole",
+		}
 
-	t.Run("stores objects", func(t *testing.T) {
-		store, db := createStore(t)
+		g.Expect(store.StoreRoles(ctx, roles)).To(Succeed())
+		g.Expect(store.StoreRoleBindings(ctx, []models.RoleBinding{rb})).To(Succeed())
+
+		stored, err := store.GetRoles(ctx)
+		g.Expect(err).NotTo(HaveOccurred())
+		g.Expect(stored).To(HaveLen(2))
+		for _, role := range stored {
+			if role.Name == "view" {
+				g.Expect(role.AggregationSelectors).To(Equal(roles[0].AggregationSelectors))
+			} else {
+				g.Expect(role.Labels).To(Equal(aggregateToView))
+			}
+		}
+
+		// The role binding grants the aggregated cluster role in its namespace.
+		r, err := store.GetAccessRules(ctx)
+		g.Expect(err).NotTo(HaveOccurred())
+		g.Expect(r).To(HaveLen(1))
+		g.Expect(r[0].Namespace).To(Equal("namespace"))
+		g.Expect(r[0].ProvidedByRole).To(Equal("ClusterRole/view"))
+		g.Expect(r[0].AccessibleKinds).To(Equal([]string{"helm.toolkit.fluxcd.io/helmreleases"}))
+	})
+}
+
+func TestStoreUnstructured(t *testing.T) {
+	forEachBackend(t, func(t *testing.T, backend StorageBackend) {
+		g := NewGomegaWithT(t)
+		input := []byte(`{"myKey": "someValue"}`)
+		rawMsg := json.RawMessage(input)
+		store, db := createStoreForBackend(t, backend)
 
 		obj := models.Object{
-			Cluster:    "test-cluster",
-			Name:       "someName",
-			Namespace:  "namespace",
-			Kind:       "ValidKind",
-			APIGroup:   "example.com",
-			APIVersion: "v1",
-			Category:   configuration.CategoryAutomation,
+			Cluster:      "test-cluster",
+			Name:         "someName",
+			Namespace:    "namespace",
+			Kind:         "ValidKind",
+			APIGroup:     "example.com",
+			APIVersion:   "v1",
+			Category:     configuration.CategoryAutomation,
+			Unstructured: rawMsg,
 		}
 
 		g.Expect(store.StoreObjects(context.Background(), []models.Object{obj})).To(Succeed())
@@ -284,13 +487,19 @@ func TestStoreObjects(t *testing.T) {
 		sqlDB, err := db.DB()
 		g.Expect(err).To(BeNil())
 
-		var storedObj models.Object
-		g.Expect(sqlDB.QueryRow("SELECT id FROM objects").Scan(&storedObj.ID)).To(Succeed())
+		result := []byte{}
+
+		g.Expect(sqlDB.QueryRow("SELECT unstructured FROM objects").Scan(&result)).To(Succeed())
 
-		g.Expect(storedObj.ID).To(Equal(obj.GetID()))
+		g.Expect(result).To(Equal(input))
 	})
-	t.Run("upserts objects", func(t *testing.T) {
-		store, db := createStore(t)
+}
+
+func TestObjectHistory(t *testing.T) {
+	forEachBackend(t, func(t *testing.T, backend StorageBackend) {
+		g := NewGomegaWithT(t)
+		ctx := context.Background()
+		store, _ := createStoreForBackend(t, backend)
 
 		obj := models.Object{
 			Cluster:    "test-cluster",
@@ -301,129 +510,278 @@ func TestStoreObjects(t *testing.T) {
 			APIVersion: "v1",
 			Category:   configuration.CategoryAutomation,
 		}
+		other := obj
+		other.Name = "otherName"
+
+		g.Expect(store.StoreObjects(ctx, []models.Object{obj, other})).To(Succeed())
+
+		start := time.Now().Add(-time.Hour)
+		for i := 0; i < MaxObjectRevisions+5; i++ {
+			g.Expect(store.StoreObjectRevisions(ctx, []models.ObjectRevision{{
+				ObjectID:   obj.GetID(),
+				Cluster:    obj.Cluster,
+				Status:     fmt.Sprintf("status-%d", i),
+				RecordedAt: start.Add(time.Duration(i) * time.Minute),
+			}})).To(Succeed())
+		}
+		g.Expect(store.StoreObjectRevisions(ctx, []models.ObjectRevision{{
+			ObjectID:   other.GetID(),
+			Cluster:    other.Cluster,
+			RecordedAt: start,
+		}})).To(Succeed())
+
+		// Only the latest revisions are kept, oldest first.
+		history, err := store.GetObjectHistory(ctx, obj.GetID())
+		g.Expect(err).NotTo(HaveOccurred())
+		g.Expect(history).To(HaveLen(MaxObjectRevisions))
+		g.Expect(history[0].Status).To(Equal("status-5"))
+		g.Expect(history[MaxObjectRevisions-1].Status).To(Equal(fmt.Sprintf("status-%d", MaxObjectRevisions+4)))
+
+		// Expire the revisions recorded in the first half hour.
+		g.Expect(store.DeleteObjectRevisions(ctx, []string{obj.GetID(), other.GetID()}, start.Add(30*time.Minute))).To(Succeed())
+
+		history, err = store.GetObjectHistory(ctx, obj.GetID())
+		g.Expect(err).NotTo(HaveOccurred())
+		g.Expect(history).To(HaveLen(MaxObjectRevisions + 5 - 30))
+		g.Expect(history[0].Status).To(Equal("status-30"))
+
+		history, err = store.GetObjectHistory(ctx, other.GetID())
+		g.Expect(err).NotTo(HaveOccurred())
+		g.Expect(history).To(BeEmpty())
+
+		// The history is removed along with its object.
+		g.Expect(store.DeleteObjects(ctx, []models.Object{obj})).To(Succeed())
+
+		history, err = store.GetObjectHistory(ctx, obj.GetID())
+		g.Expect(err).NotTo(HaveOccurred())
+		g.Expect(history).To(BeEmpty())
+	})
+}
 
-		g.Expect(store.StoreObjects(context.Background(), []models.Object{obj})).To(Succeed())
-
-		sqlDB, err := db.DB()
-		g.Expect(err).To(BeNil())
-
-		var count int64
-		g.Expect(sqlDB.QueryRow("SELECT COUNT(*) FROM objects").Scan(&count)).To(Succeed())
-		g.Expect(count).To(Equal(int64(1)))
-
-		g.Expect(store.StoreObjects(context.Background(), []models.Object{obj})).To(Succeed())
+func TestSavedQueries(t *testing.T) {
+	forEachBackend(t, func(t *testing.T, backend StorageBackend) {
+		g := NewGomegaWithT(t)
+		ctx := context.Background()
+		store, _ := createStoreForBackend(t, backend)
+
+		failing := models.SavedQuery{
+			ID:         "1",
+			Name:       "failing releases",
+			Owner:      "alice",
+			SharedWith: "team-a",
+			Filters:    []string{"kind:HelmRelease"},
+			Query:      "status:Failed",
+			OrderBy:    "name",
+			Columns:    []string{"cluster", "name"},
+			CreatedAt:  time.Now().UTC().Truncate(time.Second),
+		}
+		all := models.SavedQuery{
+			ID:        "2",
+			Name:      "all",
+			Owner:     "bob",
+			CreatedAt: time.Now().UTC().Truncate(time.Second),
+		}
 
-		g.Expect(sqlDB.QueryRow("SELECT COUNT(*) FROM objects").Scan(&count)).To(Succeed())
-		g.Expect(count).To(Equal(int64(1)))
+		g.Expect(store.StoreSavedQuery(ctx, failing)).To(Succeed())
+		g.Expect(store.StoreSavedQuery(ctx, all)).To(Succeed())
+		g.Expect(store.StoreSavedQuery(ctx, models.SavedQuery{ID: "3"})).To(MatchError(ContainSubstring("invalid saved query")))
+
+		saved, err := store.GetSavedQueries(ctx)
+		g.Expect(err).NotTo(HaveOccurred())
+		g.Expect(saved).To(HaveLen(2))
+		// Ordered by name.
+		g.Expect(saved[0].ID).To(Equal(all.ID))
+		g.Expect(saved[1].Filters).To(Equal(failing.Filters))
+		g.Expect(saved[1].Columns).To(Equal(failing.Columns))
+		g.Expect(saved[1].CreatedAt.Equal(failing.CreatedAt)).To(BeTrue())
+
+		g.Expect(store.DeleteSavedQuery(ctx, all.ID)).To(Succeed())
+
+		saved, err = store.GetSavedQueries(ctx)
+		g.Expect(err).NotTo(HaveOccurred())
+		g.Expect(saved).To(HaveLen(1))
+		g.Expect(saved[0].ID).To(Equal(failing.ID))
 	})
-
 }
 
-func TestGetAccessRules(t *testing.T) {
+func createStore(t *testing.T) (Store, *gorm.DB) {
 	g := NewGomegaWithT(t)
-	ctx := context.Background()
+	dbDir, err := os.MkdirTemp("", "db")
+	g.Expect(err).To(BeNil())
 
-	store, _ := createStore(t)
+	db, err := CreateSQLiteDB(dbDir)
+	g.Expect(err).To(BeNil())
 
-	role := models.Role{
-		Cluster:   "test-cluster",
-		Namespace: "namespace",
-		Name:      "someName",
-		Kind:      "Role",
-		PolicyRules: []models.PolicyRule{
-			{
-				APIGroups: strings.Join([]string{"example.com"}, ","),
-				Resources: strings.Join([]string{"SomeKind"}, ","),
-				Verbs:     strings.Join([]string{"get", "list"}, ","),
-			},
-		},
+	store, err := NewSQLiteStore(db, logr.Discard())
+	g.Expect(err).To(BeNil())
+
+	return store, db
+}
+
+// forEachBackend runs the given test once per storage backend.
+func forEachBackend(t *testing.T, f func(t *testing.T, backend StorageBackend)) {
+	for _, backend := range []StorageBackend{StorageBackendSQLite, StorageBackendPostgres} {
+		t.Run(string(backend), func(t *testing.T) {
+			f(t, backend)
+		})
 	}
+}
 
-	rb := models.RoleBinding{
-		Cluster:   "test-cluster",
-		Namespace: "namespace",
-		Name:      "someName",
-		Kind:      "RoleBinding",
-		Subjects: []models.Subject{
-			{
-				APIGroup: "rbac.authorization.k8s.io",
-				Kind:     role.Kind,
-				Name:     role.Name,
-			},
-		},
-		RoleRefName: role.Name,
-		RoleRefKind: role.Kind,
+func createStoreForBackend(t *testing.T, backend StorageBackend) (Store, *gorm.DB) {
+	switch backend {
+	case StorageBackendPostgres:
+		return createPostgresStore(t)
+	default:
+		return createStore(t)
 	}
+}
 
-	g.Expect(store.StoreRoles(ctx, []models.Role{role})).To(Succeed())
-	g.Expect(store.StoreRoleBindings(ctx, []models.RoleBinding{rb})).To(Succeed())
+func TestObjectEdges(t *testing.T) {
+	forEachBackend(t, func(t *testing.T, backend StorageBackend) {
+		g := NewGomegaWithT(t)
+		ctx := context.Background()
+		store, _ := createStoreForBackend(t, backend)
 
-	r, err := store.GetAccessRules(ctx)
-	g.Expect(err).To(BeNil())
+		ks := models.Object{
+			Cluster:    "cluster-a",
+			Namespace:  "flux-system",
+			APIGroup:   "kustomize.toolkit.fluxcd.io",
+			APIVersion: "v1",
+			Kind:       "Kustomization",
+			Name:       "apps",
+			Category:   "automation",
+		}
+		repo := models.Object{
+			Cluster:    "cluster-a",
+			Namespace:  "flux-system",
+			APIGroup:   "source.toolkit.fluxcd.io",
+			APIVersion: "v1",
+			Kind:       "GitRepository",
+			Name:       "flux-system",
+			Category:   "source",
+		}
+		g.Expect(store.StoreObjects(ctx, []models.Object{ks, repo})).To(Succeed())
 
-	g.Expect(r).To(HaveLen(1))
-
-	expected := models.AccessRule{
-		Cluster:   "test-cluster",
-		Namespace: "namespace",
-		Subjects: []models.Subject{{
-			APIGroup:      "rbac.authorization.k8s.io",
-			Kind:          "Role",
-			Name:          "someName",
-			RoleBindingID: rb.GetID(),
-		}},
-		AccessibleKinds:         []string{"example.com/SomeKind"},
-		ProvidedByRole:          fmt.Sprintf("%s/%s", role.Kind, role.Name),
-		ProvidedByBinding:       fmt.Sprintf("%s/%s", rb.Kind, rb.Name),
-		AccessibleResourceNames: []string{},
-	}
+		deployment := models.ObjectReference{Cluster: "cluster-a", Namespace: "apps", APIGroup: "apps", Kind: "Deployment", Name: "podinfo"}
+		edges := []models.ObjectEdge{
+			{Cluster: "cluster-a", Owner: ks.GetID(), Upstream: repo.Reference().String(), Downstream: ks.Reference().String(), Relation: models.EdgeRelationSource},
+			{Cluster: "cluster-a", Owner: ks.GetID(), Upstream: ks.Reference().String(), Downstream: deployment.String(), Relation: models.EdgeRelationInventory},
+		}
+		g.Expect(store.StoreObjectEdges(ctx, []string{ks.GetID()}, edges)).To(Succeed())
 
-	diff := cmp.Diff(expected, r[0], cmpopts.IgnoreFields(models.Subject{}, "ID", "CreatedAt", "UpdatedAt"))
+		found, err := store.GetObjectEdges(ctx, []string{repo.Reference().String()})
+		g.Expect(err).NotTo(HaveOccurred())
+		g.Expect(found).To(HaveLen(1))
+		g.Expect(found[0].Downstream).To(Equal(ks.Reference().String()))
 
-	if diff != "" {
-		t.Errorf("GetAccessRules() mismatch (-want +got):\n%s", diff)
-	}
-}
+		found, err = store.GetObjectEdges(ctx, []string{ks.Reference().String()})
+		g.Expect(err).NotTo(HaveOccurred())
+		g.Expect(found).To(HaveLen(2))
 
-func TestStoreUnstructured(t *testing.T) {
-	g := NewGomegaWithT(t)
-	input := []byte(`{"myKey": "someValue"}`)
-	rawMsg := json.RawMessage(input)
-	store, db := createStore(t)
-
-	obj := models.Object{
-		Cluster:      "test-cluster",
-		Name:         "someName",
-		Namespace:    "namespace",
-		Kind:         "ValidKind",
-		APIGroup:     "example.com",
-		APIVersion:   "v1",
-		Category:     configuration.CategoryAutomation,
-		Unstructured: rawMsg,
-	}
+		t.Run("objects are found by reference whatever their version", func(t *testing.T) {
+			g := NewWithT(t)
 
-	g.Expect(store.StoreObjects(context.Background(), []models.Object{obj})).To(Succeed())
+			objects, err := store.GetObjectsByReference(ctx, []models.ObjectReference{repo.Reference(), deployment})
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(objects).To(HaveLen(1))
+			g.Expect(objects[0].GetID()).To(Equal(repo.GetID()))
+		})
 
-	sqlDB, err := db.DB()
-	g.Expect(err).To(BeNil())
+		t.Run("edges are replaced by those read again from their owner", func(t *testing.T) {
+			g := NewWithT(t)
 
-	result := []byte{}
+			g.Expect(store.StoreObjectEdges(ctx, []string{ks.GetID()}, edges[:1])).To(Succeed())
 
-	g.Expect(sqlDB.QueryRow("SELECT unstructured FROM objects").Scan(&result)).To(Succeed())
+			found, err := store.GetObjectEdges(ctx, []string{ks.Reference().String()})
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(found).To(HaveLen(1))
+			g.Expect(found[0].Relation).To(Equal(models.EdgeRelationSource))
+		})
 
-	g.Expect(result).To(Equal(input))
+		t.Run("edges are removed with their owner", func(t *testing.T) {
+			g := NewWithT(t)
+
+			g.Expect(store.DeleteObjects(ctx, []models.Object{ks})).To(Succeed())
+
+			found, err := store.GetObjectEdges(ctx, []string{repo.Reference().String()})
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(found).To(BeEmpty())
+		})
+	})
 }
 
-func createStore(t *testing.T) (Store, *gorm.DB) {
-	g := NewGomegaWithT(t)
-	dbDir, err := os.MkdirTemp("", "db")
-	g.Expect(err).To(BeNil())
+func TestGetObjectsBySelector(t *testing.T) {
+	forEachBackend(t, func(t *testing.T, backend StorageBackend) {
+		g := NewGomegaWithT(t)
+		ctx := context.Background()
+		store, _ := createStoreForBackend(t, backend)
+
+		newObject := func(cluster, kind, name string) models.Object {
+			return models.Object{
+				Cluster:    cluster,
+				Namespace:  "flux-system",
+				APIGroup:   "kustomize.toolkit.fluxcd.io",
+				APIVersion: "v1",
+				Kind:       kind,
+				Name:       name,
+				Category:   "automation",
+			}
+		}
 
-	db, err := CreateSQLiteDB(dbDir)
-	g.Expect(err).To(BeNil())
+		objects := []models.Object{
+			newObject("cluster-a", "Kustomization", "a-apps"),
+			newObject("cluster-a", "Other", "a-other"),
+			newObject("cluster-b", "Kustomization", "b-apps"),
+			newObject("cluster-c", "Kustomization", "c-apps"),
+		}
+		g.Expect(store.StoreObjects(ctx, objects)).To(Succeed())
 
-	store, err := NewSQLiteStore(db, logr.Discard())
-	g.Expect(err).To(BeNil())
+		ks := schema.GroupVersionKind{Group: "kustomize.toolkit.fluxcd.io", Version: "v1", Kind: "Kustomization"}
+		other := schema.GroupVersionKind{Group: "kustomize.toolkit.fluxcd.io", Version: "v1", Kind: "Other"}
 
-	return store, db
+		tests := []struct {
+			name     string
+			selector ObjectSelector
+			expected []string
+		}{
+			{
+				name:     "every object",
+				selector: ObjectSelector{},
+				expected: []string{"a-apps", "a-other", "b-apps", "c-apps"},
+			},
+			{
+				name:     "objects of clusters",
+				selector: ObjectSelector{Clusters: []string{"cluster-a", "cluster-b"}},
+				expected: []string{"a-apps", "a-other", "b-apps"},
+			},
+			{
+				name:     "objects of kinds",
+				selector: ObjectSelector{Kinds: []schema.GroupVersionKind{other}},
+				expected: []string{"a-other"},
+			},
+			{
+				name:     "objects of kinds in clusters",
+				selector: ObjectSelector{Clusters: []string{"cluster-a", "cluster-c"}, Kinds: []schema.GroupVersionKind{ks, other}},
+				expected: []string{"a-apps", "a-other", "c-apps"},
+			},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				g := NewWithT(t)
+
+				iter, err := store.GetObjectsBySelector(ctx, tt.selector)
+				g.Expect(err).NotTo(HaveOccurred())
+
+				found, err := iter.All()
+				g.Expect(err).NotTo(HaveOccurred())
+
+				names := []string{}
+				for _, obj := range found {
+					names = append(names, obj.Name)
+				}
+				g.Expect(names).To(ConsistOf(tt.expected))
+			})
+		}
+	})
 }
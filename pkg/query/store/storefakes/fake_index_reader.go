@@ -10,11 +10,12 @@ import (
 )
 
 type FakeIndexReader struct {
-	ListFacetsStub        func(context.Context, configuration.ObjectCategory) (store.Facets, error)
+	ListFacetsStub        func(context.Context, configuration.ObjectCategory, []configuration.ObjectKind) (store.Facets, error)
 	listFacetsMutex       sync.RWMutex
 	listFacetsArgsForCall []struct {
 		arg1 context.Context
 		arg2 configuration.ObjectCategory
+		arg3 []configuration.ObjectKind
 	}
 	listFacetsReturns struct {
 		result1 store.Facets
@@ -43,19 +44,25 @@ type FakeIndexReader struct {
 	invocationsMutex sync.RWMutex
 }
 
-func (fake *FakeIndexReader) ListFacets(arg1 context.Context, arg2 configuration.ObjectCategory) (store.Facets, error) {
+func (fake *FakeIndexReader) ListFacets(arg1 context.Context, arg2 configuration.ObjectCategory, arg3 []configuration.ObjectKind) (store.Facets, error) {
+	var arg3Copy []configuration.ObjectKind
+	if arg3 != nil {
+		arg3Copy = make([]configuration.ObjectKind, len(arg3))
+		copy(arg3Copy, arg3)
+	}
 	fake.listFacetsMutex.Lock()
 	ret, specificReturn := fake.listFacetsReturnsOnCall[len(fake.listFacetsArgsForCall)]
 	fake.listFacetsArgsForCall = append(fake.listFacetsArgsForCall, struct {
 		arg1 context.Context
 		arg2 configuration.ObjectCategory
-	}{arg1, arg2})
+		arg3 []configuration.ObjectKind
+	}{arg1, arg2, arg3Copy})
 	stub := fake.ListFacetsStub
 	fakeReturns := fake.listFacetsReturns
-	fake.recordInvocation("ListFacets", []interface{}{arg1, arg2})
+	fake.recordInvocation("ListFacets", []interface{}{arg1, arg2, arg3Copy})
 	fake.listFacetsMutex.Unlock()
 	if stub != nil {
-		return stub(arg1, arg2)
+		return stub(arg1, arg2, arg3)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
@@ -69,17 +76,17 @@ func (fake *FakeIndexReader) ListFacetsCallCount() int {
 	return len(fake.listFacetsArgsForCall)
 }
 
-func (fake *FakeIndexReader) ListFacetsCalls(stub func(context.Context, configuration.ObjectCategory) (store.Facets, error)) {
+func (fake *FakeIndexReader) ListFacetsCalls(stub func(context.Context, configuration.ObjectCategory, []configuration.ObjectKind) (store.Facets, error)) {
 	fake.listFacetsMutex.Lock()
 	defer fake.listFacetsMutex.Unlock()
 	fake.ListFacetsStub = stub
 }
 
-func (fake *FakeIndexReader) ListFacetsArgsForCall(i int) (context.Context, configuration.ObjectCategory) {
+func (fake *FakeIndexReader) ListFacetsArgsForCall(i int) (context.Context, configuration.ObjectCategory, []configuration.ObjectKind) {
 	fake.listFacetsMutex.RLock()
 	defer fake.listFacetsMutex.RUnlock()
 	argsForCall := fake.listFacetsArgsForCall[i]
-	return argsForCall.arg1, argsForCall.arg2
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
 }
 
 func (fake *FakeIndexReader) ListFacetsReturns(result1 store.Facets, result2 error) {
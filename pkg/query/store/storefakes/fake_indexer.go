@@ -23,11 +23,12 @@ type FakeIndexer struct {
 	addReturnsOnCall map[int]struct {
 		result1 error
 	}
-	ListFacetsStub        func(context.Context, configuration.ObjectCategory) (store.Facets, error)
+	ListFacetsStub        func(context.Context, configuration.ObjectCategory, []configuration.ObjectKind) (store.Facets, error)
 	listFacetsMutex       sync.RWMutex
 	listFacetsArgsForCall []struct {
 		arg1 context.Context
 		arg2 configuration.ObjectCategory
+		arg3 []configuration.ObjectKind
 	}
 	listFacetsReturns struct {
 		result1 store.Facets
@@ -147,19 +148,25 @@ func (fake *FakeIndexer) AddReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
-func (fake *FakeIndexer) ListFacets(arg1 context.Context, arg2 configuration.ObjectCategory) (store.Facets, error) {
+func (fake *FakeIndexer) ListFacets(arg1 context.Context, arg2 configuration.ObjectCategory, arg3 []configuration.ObjectKind) (store.Facets, error) {
+	var arg3Copy []configuration.ObjectKind
+	if arg3 != nil {
+		arg3Copy = make([]configuration.ObjectKind, len(arg3))
+		copy(arg3Copy, arg3)
+	}
 	fake.listFacetsMutex.Lock()
 	ret, specificReturn := fake.listFacetsReturnsOnCall[len(fake.listFacetsArgsForCall)]
 	fake.listFacetsArgsForCall = append(fake.listFacetsArgsForCall, struct {
 		arg1 context.Context
 		arg2 configuration.ObjectCategory
-	}{arg1, arg2})
+		arg3 []configuration.ObjectKind
+	}{arg1, arg2, arg3Copy})
 	stub := fake.ListFacetsStub
 	fakeReturns := fake.listFacetsReturns
-	fake.recordInvocation("ListFacets", []interface{}{arg1, arg2})
+	fake.recordInvocation("ListFacets", []interface{}{arg1, arg2, arg3Copy})
 	fake.listFacetsMutex.Unlock()
 	if stub != nil {
-		return stub(arg1, arg2)
+		return stub(arg1, arg2, arg3)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
@@ -173,17 +180,17 @@ func (fake *FakeIndexer) ListFacetsCallCount() int {
 	return len(fake.listFacetsArgsForCall)
 }
 
-func (fake *FakeIndexer) ListFacetsCalls(stub func(context.Context, configuration.ObjectCategory) (store.Facets, error)) {
+func (fake *FakeIndexer) ListFacetsCalls(stub func(context.Context, configuration.ObjectCategory, []configuration.ObjectKind) (store.Facets, error)) {
 	fake.listFacetsMutex.Lock()
 	defer fake.listFacetsMutex.Unlock()
 	fake.ListFacetsStub = stub
 }
 
-func (fake *FakeIndexer) ListFacetsArgsForCall(i int) (context.Context, configuration.ObjectCategory) {
+func (fake *FakeIndexer) ListFacetsArgsForCall(i int) (context.Context, configuration.ObjectCategory, []configuration.ObjectKind) {
 	fake.listFacetsMutex.RLock()
 	defer fake.listFacetsMutex.RUnlock()
 	argsForCall := fake.listFacetsArgsForCall[i]
-	return argsForCall.arg1, argsForCall.arg2
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
 }
 
 func (fake *FakeIndexer) ListFacetsReturns(result1 store.Facets, result2 error) {
@@ -41,19 +41,21 @@ type FakeIterator struct {
 	nextReturnsOnCall map[int]struct {
 		result1 bool
 	}
-	PageStub        func(int, int) ([]models.Object, error)
+	PageStub        func(int, store.RowFilter) ([]models.Object, string, error)
 	pageMutex       sync.RWMutex
 	pageArgsForCall []struct {
 		arg1 int
-		arg2 int
+		arg2 store.RowFilter
 	}
 	pageReturns struct {
 		result1 []models.Object
-		result2 error
+		result2 string
+		result3 error
 	}
 	pageReturnsOnCall map[int]struct {
 		result1 []models.Object
-		result2 error
+		result2 string
+		result3 error
 	}
 	RowStub        func() (models.Object, error)
 	rowMutex       sync.RWMutex
@@ -233,12 +235,12 @@ func (fake *FakeIterator) NextReturnsOnCall(i int, result1 bool) {
 	}{result1}
 }
 
-func (fake *FakeIterator) Page(arg1 int, arg2 int) ([]models.Object, error) {
+func (fake *FakeIterator) Page(arg1 int, arg2 store.RowFilter) ([]models.Object, string, error) {
 	fake.pageMutex.Lock()
 	ret, specificReturn := fake.pageReturnsOnCall[len(fake.pageArgsForCall)]
 	fake.pageArgsForCall = append(fake.pageArgsForCall, struct {
 		arg1 int
-		arg2 int
+		arg2 store.RowFilter
 	}{arg1, arg2})
 	stub := fake.PageStub
 	fakeReturns := fake.pageReturns
@@ -248,9 +250,9 @@ func (fake *FakeIterator) Page(arg1 int, arg2 int) ([]models.Object, error) {
 		return stub(arg1, arg2)
 	}
 	if specificReturn {
-		return ret.result1, ret.result2
+		return ret.result1, ret.result2, ret.result3
 	}
-	return fakeReturns.result1, fakeReturns.result2
+	return fakeReturns.result1, fakeReturns.result2, fakeReturns.result3
 }
 
 func (fake *FakeIterator) PageCallCount() int {
@@ -259,43 +261,46 @@ func (fake *FakeIterator) PageCallCount() int {
 	return len(fake.pageArgsForCall)
 }
 
-func (fake *FakeIterator) PageCalls(stub func(int, int) ([]models.Object, error)) {
+func (fake *FakeIterator) PageCalls(stub func(int, store.RowFilter) ([]models.Object, string, error)) {
 	fake.pageMutex.Lock()
 	defer fake.pageMutex.Unlock()
 	fake.PageStub = stub
 }
 
-func (fake *FakeIterator) PageArgsForCall(i int) (int, int) {
+func (fake *FakeIterator) PageArgsForCall(i int) (int, store.RowFilter) {
 	fake.pageMutex.RLock()
 	defer fake.pageMutex.RUnlock()
 	argsForCall := fake.pageArgsForCall[i]
 	return argsForCall.arg1, argsForCall.arg2
 }
 
-func (fake *FakeIterator) PageReturns(result1 []models.Object, result2 error) {
+func (fake *FakeIterator) PageReturns(result1 []models.Object, result2 string, result3 error) {
 	fake.pageMutex.Lock()
 	defer fake.pageMutex.Unlock()
 	fake.PageStub = nil
 	fake.pageReturns = struct {
 		result1 []models.Object
-		result2 error
-	}{result1, result2}
+		result2 string
+		result3 error
+	}{result1, result2, result3}
 }
 
-func (fake *FakeIterator) PageReturnsOnCall(i int, result1 []models.Object, result2 error) {
+func (fake *FakeIterator) PageReturnsOnCall(i int, result1 []models.Object, result2 string, result3 error) {
 	fake.pageMutex.Lock()
 	defer fake.pageMutex.Unlock()
 	fake.PageStub = nil
 	if fake.pageReturnsOnCall == nil {
 		fake.pageReturnsOnCall = make(map[int]struct {
 			result1 []models.Object
-			result2 error
+			result2 string
+			result3 error
 		})
 	}
 	fake.pageReturnsOnCall[i] = struct {
 		result1 []models.Object
-		result2 error
-	}{result1, result2}
+		result2 string
+		result3 error
+	}{result1, result2, result3}
 }
 
 func (fake *FakeIterator) Row() (models.Object, error) {
@@ -4,6 +4,7 @@ package storefakes
 import (
 	"context"
 	"sync"
+	"time"
 
 	"github.com/weaveworks/weave-gitops-enterprise/pkg/query/internal/models"
 	"github.com/weaveworks/weave-gitops-enterprise/pkg/query/store"
@@ -46,6 +47,19 @@ type FakeStore struct {
 	deleteAllRolesReturnsOnCall map[int]struct {
 		result1 error
 	}
+	DeleteObjectRevisionsStub        func(context.Context, []string, time.Time) error
+	deleteObjectRevisionsMutex       sync.RWMutex
+	deleteObjectRevisionsArgsForCall []struct {
+		arg1 context.Context
+		arg2 []string
+		arg3 time.Time
+	}
+	deleteObjectRevisionsReturns struct {
+		result1 error
+	}
+	deleteObjectRevisionsReturnsOnCall map[int]struct {
+		result1 error
+	}
 	DeleteObjectsStub        func(context.Context, []models.Object) error
 	deleteObjectsMutex       sync.RWMutex
 	deleteObjectsArgsForCall []struct {
@@ -82,6 +96,18 @@ type FakeStore struct {
 	deleteRolesReturnsOnCall map[int]struct {
 		result1 error
 	}
+	DeleteSavedQueryStub        func(context.Context, string) error
+	deleteSavedQueryMutex       sync.RWMutex
+	deleteSavedQueryArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+	}
+	deleteSavedQueryReturns struct {
+		result1 error
+	}
+	deleteSavedQueryReturnsOnCall map[int]struct {
+		result1 error
+	}
 	DeleteTenantsStub        func(context.Context, []models.Tenant) error
 	deleteTenantsMutex       sync.RWMutex
 	deleteTenantsArgsForCall []struct {
@@ -134,6 +160,34 @@ type FakeStore struct {
 		result1 models.Object
 		result2 error
 	}
+	GetObjectEdgesStub        func(context.Context, []string) ([]models.ObjectEdge, error)
+	getObjectEdgesMutex       sync.RWMutex
+	getObjectEdgesArgsForCall []struct {
+		arg1 context.Context
+		arg2 []string
+	}
+	getObjectEdgesReturns struct {
+		result1 []models.ObjectEdge
+		result2 error
+	}
+	getObjectEdgesReturnsOnCall map[int]struct {
+		result1 []models.ObjectEdge
+		result2 error
+	}
+	GetObjectHistoryStub        func(context.Context, string) ([]models.ObjectRevision, error)
+	getObjectHistoryMutex       sync.RWMutex
+	getObjectHistoryArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+	}
+	getObjectHistoryReturns struct {
+		result1 []models.ObjectRevision
+		result2 error
+	}
+	getObjectHistoryReturnsOnCall map[int]struct {
+		result1 []models.ObjectRevision
+		result2 error
+	}
 	GetObjectsStub        func(context.Context, []string, store.QueryOption) (store.Iterator, error)
 	getObjectsMutex       sync.RWMutex
 	getObjectsArgsForCall []struct {
@@ -149,6 +203,34 @@ type FakeStore struct {
 		result1 store.Iterator
 		result2 error
 	}
+	GetObjectsByReferenceStub        func(context.Context, []models.ObjectReference) ([]models.Object, error)
+	getObjectsByReferenceMutex       sync.RWMutex
+	getObjectsByReferenceArgsForCall []struct {
+		arg1 context.Context
+		arg2 []models.ObjectReference
+	}
+	getObjectsByReferenceReturns struct {
+		result1 []models.Object
+		result2 error
+	}
+	getObjectsByReferenceReturnsOnCall map[int]struct {
+		result1 []models.Object
+		result2 error
+	}
+	GetObjectsBySelectorStub        func(context.Context, store.ObjectSelector) (store.Iterator, error)
+	getObjectsBySelectorMutex       sync.RWMutex
+	getObjectsBySelectorArgsForCall []struct {
+		arg1 context.Context
+		arg2 store.ObjectSelector
+	}
+	getObjectsBySelectorReturns struct {
+		result1 store.Iterator
+		result2 error
+	}
+	getObjectsBySelectorReturnsOnCall map[int]struct {
+		result1 store.Iterator
+		result2 error
+	}
 	GetRoleBindingsStub        func(context.Context) ([]models.RoleBinding, error)
 	getRoleBindingsMutex       sync.RWMutex
 	getRoleBindingsArgsForCall []struct {
@@ -175,6 +257,19 @@ type FakeStore struct {
 		result1 []models.Role
 		result2 error
 	}
+	GetSavedQueriesStub        func(context.Context) ([]models.SavedQuery, error)
+	getSavedQueriesMutex       sync.RWMutex
+	getSavedQueriesArgsForCall []struct {
+		arg1 context.Context
+	}
+	getSavedQueriesReturns struct {
+		result1 []models.SavedQuery
+		result2 error
+	}
+	getSavedQueriesReturnsOnCall map[int]struct {
+		result1 []models.SavedQuery
+		result2 error
+	}
 	GetTenantsStub        func(context.Context) ([]models.Tenant, error)
 	getTenantsMutex       sync.RWMutex
 	getTenantsArgsForCall []struct {
@@ -188,6 +283,31 @@ type FakeStore struct {
 		result1 []models.Tenant
 		result2 error
 	}
+	StoreObjectEdgesStub        func(context.Context, []string, []models.ObjectEdge) error
+	storeObjectEdgesMutex       sync.RWMutex
+	storeObjectEdgesArgsForCall []struct {
+		arg1 context.Context
+		arg2 []string
+		arg3 []models.ObjectEdge
+	}
+	storeObjectEdgesReturns struct {
+		result1 error
+	}
+	storeObjectEdgesReturnsOnCall map[int]struct {
+		result1 error
+	}
+	StoreObjectRevisionsStub        func(context.Context, []models.ObjectRevision) error
+	storeObjectRevisionsMutex       sync.RWMutex
+	storeObjectRevisionsArgsForCall []struct {
+		arg1 context.Context
+		arg2 []models.ObjectRevision
+	}
+	storeObjectRevisionsReturns struct {
+		result1 error
+	}
+	storeObjectRevisionsReturnsOnCall map[int]struct {
+		result1 error
+	}
 	StoreObjectsStub        func(context.Context, []models.Object) error
 	storeObjectsMutex       sync.RWMutex
 	storeObjectsArgsForCall []struct {
@@ -224,6 +344,18 @@ type FakeStore struct {
 	storeRolesReturnsOnCall map[int]struct {
 		result1 error
 	}
+	StoreSavedQueryStub        func(context.Context, models.SavedQuery) error
+	storeSavedQueryMutex       sync.RWMutex
+	storeSavedQueryArgsForCall []struct {
+		arg1 context.Context
+		arg2 models.SavedQuery
+	}
+	storeSavedQueryReturns struct {
+		result1 error
+	}
+	storeSavedQueryReturnsOnCall map[int]struct {
+		result1 error
+	}
 	StoreTenantsStub        func(context.Context, []models.Tenant) error
 	storeTenantsMutex       sync.RWMutex
 	storeTenantsArgsForCall []struct {
@@ -441,6 +573,74 @@ func (fake *FakeStore) DeleteAllRolesReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
+func (fake *FakeStore) DeleteObjectRevisions(arg1 context.Context, arg2 []string, arg3 time.Time) error {
+	var arg2Copy []string
+	if arg2 != nil {
+		arg2Copy = make([]string, len(arg2))
+		copy(arg2Copy, arg2)
+	}
+	fake.deleteObjectRevisionsMutex.Lock()
+	ret, specificReturn := fake.deleteObjectRevisionsReturnsOnCall[len(fake.deleteObjectRevisionsArgsForCall)]
+	fake.deleteObjectRevisionsArgsForCall = append(fake.deleteObjectRevisionsArgsForCall, struct {
+		arg1 context.Context
+		arg2 []string
+		arg3 time.Time
+	}{arg1, arg2Copy, arg3})
+	stub := fake.DeleteObjectRevisionsStub
+	fakeReturns := fake.deleteObjectRevisionsReturns
+	fake.recordInvocation("DeleteObjectRevisions", []interface{}{arg1, arg2Copy, arg3})
+	fake.deleteObjectRevisionsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeStore) DeleteObjectRevisionsCallCount() int {
+	fake.deleteObjectRevisionsMutex.RLock()
+	defer fake.deleteObjectRevisionsMutex.RUnlock()
+	return len(fake.deleteObjectRevisionsArgsForCall)
+}
+
+func (fake *FakeStore) DeleteObjectRevisionsCalls(stub func(context.Context, []string, time.Time) error) {
+	fake.deleteObjectRevisionsMutex.Lock()
+	defer fake.deleteObjectRevisionsMutex.Unlock()
+	fake.DeleteObjectRevisionsStub = stub
+}
+
+func (fake *FakeStore) DeleteObjectRevisionsArgsForCall(i int) (context.Context, []string, time.Time) {
+	fake.deleteObjectRevisionsMutex.RLock()
+	defer fake.deleteObjectRevisionsMutex.RUnlock()
+	argsForCall := fake.deleteObjectRevisionsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeStore) DeleteObjectRevisionsReturns(result1 error) {
+	fake.deleteObjectRevisionsMutex.Lock()
+	defer fake.deleteObjectRevisionsMutex.Unlock()
+	fake.DeleteObjectRevisionsStub = nil
+	fake.deleteObjectRevisionsReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeStore) DeleteObjectRevisionsReturnsOnCall(i int, result1 error) {
+	fake.deleteObjectRevisionsMutex.Lock()
+	defer fake.deleteObjectRevisionsMutex.Unlock()
+	fake.DeleteObjectRevisionsStub = nil
+	if fake.deleteObjectRevisionsReturnsOnCall == nil {
+		fake.deleteObjectRevisionsReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.deleteObjectRevisionsReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeStore) DeleteObjects(arg1 context.Context, arg2 []models.Object) error {
 	var arg2Copy []models.Object
 	if arg2 != nil {
@@ -642,6 +842,68 @@ func (fake *FakeStore) DeleteRolesReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
+func (fake *FakeStore) DeleteSavedQuery(arg1 context.Context, arg2 string) error {
+	fake.deleteSavedQueryMutex.Lock()
+	ret, specificReturn := fake.deleteSavedQueryReturnsOnCall[len(fake.deleteSavedQueryArgsForCall)]
+	fake.deleteSavedQueryArgsForCall = append(fake.deleteSavedQueryArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.DeleteSavedQueryStub
+	fakeReturns := fake.deleteSavedQueryReturns
+	fake.recordInvocation("DeleteSavedQuery", []interface{}{arg1, arg2})
+	fake.deleteSavedQueryMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeStore) DeleteSavedQueryCallCount() int {
+	fake.deleteSavedQueryMutex.RLock()
+	defer fake.deleteSavedQueryMutex.RUnlock()
+	return len(fake.deleteSavedQueryArgsForCall)
+}
+
+func (fake *FakeStore) DeleteSavedQueryCalls(stub func(context.Context, string) error) {
+	fake.deleteSavedQueryMutex.Lock()
+	defer fake.deleteSavedQueryMutex.Unlock()
+	fake.DeleteSavedQueryStub = stub
+}
+
+func (fake *FakeStore) DeleteSavedQueryArgsForCall(i int) (context.Context, string) {
+	fake.deleteSavedQueryMutex.RLock()
+	defer fake.deleteSavedQueryMutex.RUnlock()
+	argsForCall := fake.deleteSavedQueryArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeStore) DeleteSavedQueryReturns(result1 error) {
+	fake.deleteSavedQueryMutex.Lock()
+	defer fake.deleteSavedQueryMutex.Unlock()
+	fake.DeleteSavedQueryStub = nil
+	fake.deleteSavedQueryReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeStore) DeleteSavedQueryReturnsOnCall(i int, result1 error) {
+	fake.deleteSavedQueryMutex.Lock()
+	defer fake.deleteSavedQueryMutex.Unlock()
+	fake.DeleteSavedQueryStub = nil
+	if fake.deleteSavedQueryReturnsOnCall == nil {
+		fake.deleteSavedQueryReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.deleteSavedQueryReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeStore) DeleteTenants(arg1 context.Context, arg2 []models.Tenant) error {
 	var arg2Copy []models.Tenant
 	if arg2 != nil {
@@ -902,6 +1164,141 @@ func (fake *FakeStore) GetObjectByIDReturnsOnCall(i int, result1 models.Object,
 	}{result1, result2}
 }
 
+func (fake *FakeStore) GetObjectEdges(arg1 context.Context, arg2 []string) ([]models.ObjectEdge, error) {
+	var arg2Copy []string
+	if arg2 != nil {
+		arg2Copy = make([]string, len(arg2))
+		copy(arg2Copy, arg2)
+	}
+	fake.getObjectEdgesMutex.Lock()
+	ret, specificReturn := fake.getObjectEdgesReturnsOnCall[len(fake.getObjectEdgesArgsForCall)]
+	fake.getObjectEdgesArgsForCall = append(fake.getObjectEdgesArgsForCall, struct {
+		arg1 context.Context
+		arg2 []string
+	}{arg1, arg2Copy})
+	stub := fake.GetObjectEdgesStub
+	fakeReturns := fake.getObjectEdgesReturns
+	fake.recordInvocation("GetObjectEdges", []interface{}{arg1, arg2Copy})
+	fake.getObjectEdgesMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeStore) GetObjectEdgesCallCount() int {
+	fake.getObjectEdgesMutex.RLock()
+	defer fake.getObjectEdgesMutex.RUnlock()
+	return len(fake.getObjectEdgesArgsForCall)
+}
+
+func (fake *FakeStore) GetObjectEdgesCalls(stub func(context.Context, []string) ([]models.ObjectEdge, error)) {
+	fake.getObjectEdgesMutex.Lock()
+	defer fake.getObjectEdgesMutex.Unlock()
+	fake.GetObjectEdgesStub = stub
+}
+
+func (fake *FakeStore) GetObjectEdgesArgsForCall(i int) (context.Context, []string) {
+	fake.getObjectEdgesMutex.RLock()
+	defer fake.getObjectEdgesMutex.RUnlock()
+	argsForCall := fake.getObjectEdgesArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeStore) GetObjectEdgesReturns(result1 []models.ObjectEdge, result2 error) {
+	fake.getObjectEdgesMutex.Lock()
+	defer fake.getObjectEdgesMutex.Unlock()
+	fake.GetObjectEdgesStub = nil
+	fake.getObjectEdgesReturns = struct {
+		result1 []models.ObjectEdge
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeStore) GetObjectEdgesReturnsOnCall(i int, result1 []models.ObjectEdge, result2 error) {
+	fake.getObjectEdgesMutex.Lock()
+	defer fake.getObjectEdgesMutex.Unlock()
+	fake.GetObjectEdgesStub = nil
+	if fake.getObjectEdgesReturnsOnCall == nil {
+		fake.getObjectEdgesReturnsOnCall = make(map[int]struct {
+			result1 []models.ObjectEdge
+			result2 error
+		})
+	}
+	fake.getObjectEdgesReturnsOnCall[i] = struct {
+		result1 []models.ObjectEdge
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeStore) GetObjectHistory(arg1 context.Context, arg2 string) ([]models.ObjectRevision, error) {
+	fake.getObjectHistoryMutex.Lock()
+	ret, specificReturn := fake.getObjectHistoryReturnsOnCall[len(fake.getObjectHistoryArgsForCall)]
+	fake.getObjectHistoryArgsForCall = append(fake.getObjectHistoryArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.GetObjectHistoryStub
+	fakeReturns := fake.getObjectHistoryReturns
+	fake.recordInvocation("GetObjectHistory", []interface{}{arg1, arg2})
+	fake.getObjectHistoryMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeStore) GetObjectHistoryCallCount() int {
+	fake.getObjectHistoryMutex.RLock()
+	defer fake.getObjectHistoryMutex.RUnlock()
+	return len(fake.getObjectHistoryArgsForCall)
+}
+
+func (fake *FakeStore) GetObjectHistoryCalls(stub func(context.Context, string) ([]models.ObjectRevision, error)) {
+	fake.getObjectHistoryMutex.Lock()
+	defer fake.getObjectHistoryMutex.Unlock()
+	fake.GetObjectHistoryStub = stub
+}
+
+func (fake *FakeStore) GetObjectHistoryArgsForCall(i int) (context.Context, string) {
+	fake.getObjectHistoryMutex.RLock()
+	defer fake.getObjectHistoryMutex.RUnlock()
+	argsForCall := fake.getObjectHistoryArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeStore) GetObjectHistoryReturns(result1 []models.ObjectRevision, result2 error) {
+	fake.getObjectHistoryMutex.Lock()
+	defer fake.getObjectHistoryMutex.Unlock()
+	fake.GetObjectHistoryStub = nil
+	fake.getObjectHistoryReturns = struct {
+		result1 []models.ObjectRevision
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeStore) GetObjectHistoryReturnsOnCall(i int, result1 []models.ObjectRevision, result2 error) {
+	fake.getObjectHistoryMutex.Lock()
+	defer fake.getObjectHistoryMutex.Unlock()
+	fake.GetObjectHistoryStub = nil
+	if fake.getObjectHistoryReturnsOnCall == nil {
+		fake.getObjectHistoryReturnsOnCall = make(map[int]struct {
+			result1 []models.ObjectRevision
+			result2 error
+		})
+	}
+	fake.getObjectHistoryReturnsOnCall[i] = struct {
+		result1 []models.ObjectRevision
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeStore) GetObjects(arg1 context.Context, arg2 []string, arg3 store.QueryOption) (store.Iterator, error) {
 	var arg2Copy []string
 	if arg2 != nil {
@@ -973,6 +1370,141 @@ func (fake *FakeStore) GetObjectsReturnsOnCall(i int, result1 store.Iterator, re
 	}{result1, result2}
 }
 
+func (fake *FakeStore) GetObjectsByReference(arg1 context.Context, arg2 []models.ObjectReference) ([]models.Object, error) {
+	var arg2Copy []models.ObjectReference
+	if arg2 != nil {
+		arg2Copy = make([]models.ObjectReference, len(arg2))
+		copy(arg2Copy, arg2)
+	}
+	fake.getObjectsByReferenceMutex.Lock()
+	ret, specificReturn := fake.getObjectsByReferenceReturnsOnCall[len(fake.getObjectsByReferenceArgsForCall)]
+	fake.getObjectsByReferenceArgsForCall = append(fake.getObjectsByReferenceArgsForCall, struct {
+		arg1 context.Context
+		arg2 []models.ObjectReference
+	}{arg1, arg2Copy})
+	stub := fake.GetObjectsByReferenceStub
+	fakeReturns := fake.getObjectsByReferenceReturns
+	fake.recordInvocation("GetObjectsByReference", []interface{}{arg1, arg2Copy})
+	fake.getObjectsByReferenceMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeStore) GetObjectsByReferenceCallCount() int {
+	fake.getObjectsByReferenceMutex.RLock()
+	defer fake.getObjectsByReferenceMutex.RUnlock()
+	return len(fake.getObjectsByReferenceArgsForCall)
+}
+
+func (fake *FakeStore) GetObjectsByReferenceCalls(stub func(context.Context, []models.ObjectReference) ([]models.Object, error)) {
+	fake.getObjectsByReferenceMutex.Lock()
+	defer fake.getObjectsByReferenceMutex.Unlock()
+	fake.GetObjectsByReferenceStub = stub
+}
+
+func (fake *FakeStore) GetObjectsByReferenceArgsForCall(i int) (context.Context, []models.ObjectReference) {
+	fake.getObjectsByReferenceMutex.RLock()
+	defer fake.getObjectsByReferenceMutex.RUnlock()
+	argsForCall := fake.getObjectsByReferenceArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeStore) GetObjectsByReferenceReturns(result1 []models.Object, result2 error) {
+	fake.getObjectsByReferenceMutex.Lock()
+	defer fake.getObjectsByReferenceMutex.Unlock()
+	fake.GetObjectsByReferenceStub = nil
+	fake.getObjectsByReferenceReturns = struct {
+		result1 []models.Object
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeStore) GetObjectsByReferenceReturnsOnCall(i int, result1 []models.Object, result2 error) {
+	fake.getObjectsByReferenceMutex.Lock()
+	defer fake.getObjectsByReferenceMutex.Unlock()
+	fake.GetObjectsByReferenceStub = nil
+	if fake.getObjectsByReferenceReturnsOnCall == nil {
+		fake.getObjectsByReferenceReturnsOnCall = make(map[int]struct {
+			result1 []models.Object
+			result2 error
+		})
+	}
+	fake.getObjectsByReferenceReturnsOnCall[i] = struct {
+		result1 []models.Object
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeStore) GetObjectsBySelector(arg1 context.Context, arg2 store.ObjectSelector) (store.Iterator, error) {
+	fake.getObjectsBySelectorMutex.Lock()
+	ret, specificReturn := fake.getObjectsBySelectorReturnsOnCall[len(fake.getObjectsBySelectorArgsForCall)]
+	fake.getObjectsBySelectorArgsForCall = append(fake.getObjectsBySelectorArgsForCall, struct {
+		arg1 context.Context
+		arg2 store.ObjectSelector
+	}{arg1, arg2})
+	stub := fake.GetObjectsBySelectorStub
+	fakeReturns := fake.getObjectsBySelectorReturns
+	fake.recordInvocation("GetObjectsBySelector", []interface{}{arg1, arg2})
+	fake.getObjectsBySelectorMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeStore) GetObjectsBySelectorCallCount() int {
+	fake.getObjectsBySelectorMutex.RLock()
+	defer fake.getObjectsBySelectorMutex.RUnlock()
+	return len(fake.getObjectsBySelectorArgsForCall)
+}
+
+func (fake *FakeStore) GetObjectsBySelectorCalls(stub func(context.Context, store.ObjectSelector) (store.Iterator, error)) {
+	fake.getObjectsBySelectorMutex.Lock()
+	defer fake.getObjectsBySelectorMutex.Unlock()
+	fake.GetObjectsBySelectorStub = stub
+}
+
+func (fake *FakeStore) GetObjectsBySelectorArgsForCall(i int) (context.Context, store.ObjectSelector) {
+	fake.getObjectsBySelectorMutex.RLock()
+	defer fake.getObjectsBySelectorMutex.RUnlock()
+	argsForCall := fake.getObjectsBySelectorArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeStore) GetObjectsBySelectorReturns(result1 store.Iterator, result2 error) {
+	fake.getObjectsBySelectorMutex.Lock()
+	defer fake.getObjectsBySelectorMutex.Unlock()
+	fake.GetObjectsBySelectorStub = nil
+	fake.getObjectsBySelectorReturns = struct {
+		result1 store.Iterator
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeStore) GetObjectsBySelectorReturnsOnCall(i int, result1 store.Iterator, result2 error) {
+	fake.getObjectsBySelectorMutex.Lock()
+	defer fake.getObjectsBySelectorMutex.Unlock()
+	fake.GetObjectsBySelectorStub = nil
+	if fake.getObjectsBySelectorReturnsOnCall == nil {
+		fake.getObjectsBySelectorReturnsOnCall = make(map[int]struct {
+			result1 store.Iterator
+			result2 error
+		})
+	}
+	fake.getObjectsBySelectorReturnsOnCall[i] = struct {
+		result1 store.Iterator
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeStore) GetRoleBindings(arg1 context.Context) ([]models.RoleBinding, error) {
 	fake.getRoleBindingsMutex.Lock()
 	ret, specificReturn := fake.getRoleBindingsReturnsOnCall[len(fake.getRoleBindingsArgsForCall)]
@@ -1101,6 +1633,70 @@ func (fake *FakeStore) GetRolesReturnsOnCall(i int, result1 []models.Role, resul
 	}{result1, result2}
 }
 
+func (fake *FakeStore) GetSavedQueries(arg1 context.Context) ([]models.SavedQuery, error) {
+	fake.getSavedQueriesMutex.Lock()
+	ret, specificReturn := fake.getSavedQueriesReturnsOnCall[len(fake.getSavedQueriesArgsForCall)]
+	fake.getSavedQueriesArgsForCall = append(fake.getSavedQueriesArgsForCall, struct {
+		arg1 context.Context
+	}{arg1})
+	stub := fake.GetSavedQueriesStub
+	fakeReturns := fake.getSavedQueriesReturns
+	fake.recordInvocation("GetSavedQueries", []interface{}{arg1})
+	fake.getSavedQueriesMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeStore) GetSavedQueriesCallCount() int {
+	fake.getSavedQueriesMutex.RLock()
+	defer fake.getSavedQueriesMutex.RUnlock()
+	return len(fake.getSavedQueriesArgsForCall)
+}
+
+func (fake *FakeStore) GetSavedQueriesCalls(stub func(context.Context) ([]models.SavedQuery, error)) {
+	fake.getSavedQueriesMutex.Lock()
+	defer fake.getSavedQueriesMutex.Unlock()
+	fake.GetSavedQueriesStub = stub
+}
+
+func (fake *FakeStore) GetSavedQueriesArgsForCall(i int) context.Context {
+	fake.getSavedQueriesMutex.RLock()
+	defer fake.getSavedQueriesMutex.RUnlock()
+	argsForCall := fake.getSavedQueriesArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeStore) GetSavedQueriesReturns(result1 []models.SavedQuery, result2 error) {
+	fake.getSavedQueriesMutex.Lock()
+	defer fake.getSavedQueriesMutex.Unlock()
+	fake.GetSavedQueriesStub = nil
+	fake.getSavedQueriesReturns = struct {
+		result1 []models.SavedQuery
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeStore) GetSavedQueriesReturnsOnCall(i int, result1 []models.SavedQuery, result2 error) {
+	fake.getSavedQueriesMutex.Lock()
+	defer fake.getSavedQueriesMutex.Unlock()
+	fake.GetSavedQueriesStub = nil
+	if fake.getSavedQueriesReturnsOnCall == nil {
+		fake.getSavedQueriesReturnsOnCall = make(map[int]struct {
+			result1 []models.SavedQuery
+			result2 error
+		})
+	}
+	fake.getSavedQueriesReturnsOnCall[i] = struct {
+		result1 []models.SavedQuery
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeStore) GetTenants(arg1 context.Context) ([]models.Tenant, error) {
 	fake.getTenantsMutex.Lock()
 	ret, specificReturn := fake.getTenantsReturnsOnCall[len(fake.getTenantsArgsForCall)]
@@ -1165,6 +1761,146 @@ func (fake *FakeStore) GetTenantsReturnsOnCall(i int, result1 []models.Tenant, r
 	}{result1, result2}
 }
 
+func (fake *FakeStore) StoreObjectEdges(arg1 context.Context, arg2 []string, arg3 []models.ObjectEdge) error {
+	var arg2Copy []string
+	if arg2 != nil {
+		arg2Copy = make([]string, len(arg2))
+		copy(arg2Copy, arg2)
+	}
+	var arg3Copy []models.ObjectEdge
+	if arg3 != nil {
+		arg3Copy = make([]models.ObjectEdge, len(arg3))
+		copy(arg3Copy, arg3)
+	}
+	fake.storeObjectEdgesMutex.Lock()
+	ret, specificReturn := fake.storeObjectEdgesReturnsOnCall[len(fake.storeObjectEdgesArgsForCall)]
+	fake.storeObjectEdgesArgsForCall = append(fake.storeObjectEdgesArgsForCall, struct {
+		arg1 context.Context
+		arg2 []string
+		arg3 []models.ObjectEdge
+	}{arg1, arg2Copy, arg3Copy})
+	stub := fake.StoreObjectEdgesStub
+	fakeReturns := fake.storeObjectEdgesReturns
+	fake.recordInvocation("StoreObjectEdges", []interface{}{arg1, arg2Copy, arg3Copy})
+	fake.storeObjectEdgesMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeStore) StoreObjectEdgesCallCount() int {
+	fake.storeObjectEdgesMutex.RLock()
+	defer fake.storeObjectEdgesMutex.RUnlock()
+	return len(fake.storeObjectEdgesArgsForCall)
+}
+
+func (fake *FakeStore) StoreObjectEdgesCalls(stub func(context.Context, []string, []models.ObjectEdge) error) {
+	fake.storeObjectEdgesMutex.Lock()
+	defer fake.storeObjectEdgesMutex.Unlock()
+	fake.StoreObjectEdgesStub = stub
+}
+
+func (fake *FakeStore) StoreObjectEdgesArgsForCall(i int) (context.Context, []string, []models.ObjectEdge) {
+	fake.storeObjectEdgesMutex.RLock()
+	defer fake.storeObjectEdgesMutex.RUnlock()
+	argsForCall := fake.storeObjectEdgesArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeStore) StoreObjectEdgesReturns(result1 error) {
+	fake.storeObjectEdgesMutex.Lock()
+	defer fake.storeObjectEdgesMutex.Unlock()
+	fake.StoreObjectEdgesStub = nil
+	fake.storeObjectEdgesReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeStore) StoreObjectEdgesReturnsOnCall(i int, result1 error) {
+	fake.storeObjectEdgesMutex.Lock()
+	defer fake.storeObjectEdgesMutex.Unlock()
+	fake.StoreObjectEdgesStub = nil
+	if fake.storeObjectEdgesReturnsOnCall == nil {
+		fake.storeObjectEdgesReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.storeObjectEdgesReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeStore) StoreObjectRevisions(arg1 context.Context, arg2 []models.ObjectRevision) error {
+	var arg2Copy []models.ObjectRevision
+	if arg2 != nil {
+		arg2Copy = make([]models.ObjectRevision, len(arg2))
+		copy(arg2Copy, arg2)
+	}
+	fake.storeObjectRevisionsMutex.Lock()
+	ret, specificReturn := fake.storeObjectRevisionsReturnsOnCall[len(fake.storeObjectRevisionsArgsForCall)]
+	fake.storeObjectRevisionsArgsForCall = append(fake.storeObjectRevisionsArgsForCall, struct {
+		arg1 context.Context
+		arg2 []models.ObjectRevision
+	}{arg1, arg2Copy})
+	stub := fake.StoreObjectRevisionsStub
+	fakeReturns := fake.storeObjectRevisionsReturns
+	fake.recordInvocation("StoreObjectRevisions", []interface{}{arg1, arg2Copy})
+	fake.storeObjectRevisionsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeStore) StoreObjectRevisionsCallCount() int {
+	fake.storeObjectRevisionsMutex.RLock()
+	defer fake.storeObjectRevisionsMutex.RUnlock()
+	return len(fake.storeObjectRevisionsArgsForCall)
+}
+
+func (fake *FakeStore) StoreObjectRevisionsCalls(stub func(context.Context, []models.ObjectRevision) error) {
+	fake.storeObjectRevisionsMutex.Lock()
+	defer fake.storeObjectRevisionsMutex.Unlock()
+	fake.StoreObjectRevisionsStub = stub
+}
+
+func (fake *FakeStore) StoreObjectRevisionsArgsForCall(i int) (context.Context, []models.ObjectRevision) {
+	fake.storeObjectRevisionsMutex.RLock()
+	defer fake.storeObjectRevisionsMutex.RUnlock()
+	argsForCall := fake.storeObjectRevisionsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeStore) StoreObjectRevisionsReturns(result1 error) {
+	fake.storeObjectRevisionsMutex.Lock()
+	defer fake.storeObjectRevisionsMutex.Unlock()
+	fake.StoreObjectRevisionsStub = nil
+	fake.storeObjectRevisionsReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeStore) StoreObjectRevisionsReturnsOnCall(i int, result1 error) {
+	fake.storeObjectRevisionsMutex.Lock()
+	defer fake.storeObjectRevisionsMutex.Unlock()
+	fake.StoreObjectRevisionsStub = nil
+	if fake.storeObjectRevisionsReturnsOnCall == nil {
+		fake.storeObjectRevisionsReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.storeObjectRevisionsReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeStore) StoreObjects(arg1 context.Context, arg2 []models.Object) error {
 	var arg2Copy []models.Object
 	if arg2 != nil {
@@ -1366,6 +2102,68 @@ func (fake *FakeStore) StoreRolesReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
+func (fake *FakeStore) StoreSavedQuery(arg1 context.Context, arg2 models.SavedQuery) error {
+	fake.storeSavedQueryMutex.Lock()
+	ret, specificReturn := fake.storeSavedQueryReturnsOnCall[len(fake.storeSavedQueryArgsForCall)]
+	fake.storeSavedQueryArgsForCall = append(fake.storeSavedQueryArgsForCall, struct {
+		arg1 context.Context
+		arg2 models.SavedQuery
+	}{arg1, arg2})
+	stub := fake.StoreSavedQueryStub
+	fakeReturns := fake.storeSavedQueryReturns
+	fake.recordInvocation("StoreSavedQuery", []interface{}{arg1, arg2})
+	fake.storeSavedQueryMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeStore) StoreSavedQueryCallCount() int {
+	fake.storeSavedQueryMutex.RLock()
+	defer fake.storeSavedQueryMutex.RUnlock()
+	return len(fake.storeSavedQueryArgsForCall)
+}
+
+func (fake *FakeStore) StoreSavedQueryCalls(stub func(context.Context, models.SavedQuery) error) {
+	fake.storeSavedQueryMutex.Lock()
+	defer fake.storeSavedQueryMutex.Unlock()
+	fake.StoreSavedQueryStub = stub
+}
+
+func (fake *FakeStore) StoreSavedQueryArgsForCall(i int) (context.Context, models.SavedQuery) {
+	fake.storeSavedQueryMutex.RLock()
+	defer fake.storeSavedQueryMutex.RUnlock()
+	argsForCall := fake.storeSavedQueryArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeStore) StoreSavedQueryReturns(result1 error) {
+	fake.storeSavedQueryMutex.Lock()
+	defer fake.storeSavedQueryMutex.Unlock()
+	fake.StoreSavedQueryStub = nil
+	fake.storeSavedQueryReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeStore) StoreSavedQueryReturnsOnCall(i int, result1 error) {
+	fake.storeSavedQueryMutex.Lock()
+	defer fake.storeSavedQueryMutex.Unlock()
+	fake.StoreSavedQueryStub = nil
+	if fake.storeSavedQueryReturnsOnCall == nil {
+		fake.storeSavedQueryReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.storeSavedQueryReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeStore) StoreTenants(arg1 context.Context, arg2 []models.Tenant) error {
 	var arg2Copy []models.Tenant
 	if arg2 != nil {
@@ -1442,12 +2240,16 @@ func (fake *FakeStore) Invocations() map[string][][]interface{} {
 	defer fake.deleteAllRoleBindingsMutex.RUnlock()
 	fake.deleteAllRolesMutex.RLock()
 	defer fake.deleteAllRolesMutex.RUnlock()
+	fake.deleteObjectRevisionsMutex.RLock()
+	defer fake.deleteObjectRevisionsMutex.RUnlock()
 	fake.deleteObjectsMutex.RLock()
 	defer fake.deleteObjectsMutex.RUnlock()
 	fake.deleteRoleBindingsMutex.RLock()
 	defer fake.deleteRoleBindingsMutex.RUnlock()
 	fake.deleteRolesMutex.RLock()
 	defer fake.deleteRolesMutex.RUnlock()
+	fake.deleteSavedQueryMutex.RLock()
+	defer fake.deleteSavedQueryMutex.RUnlock()
 	fake.deleteTenantsMutex.RLock()
 	defer fake.deleteTenantsMutex.RUnlock()
 	fake.getAccessRulesMutex.RLock()
@@ -1456,20 +2258,36 @@ func (fake *FakeStore) Invocations() map[string][][]interface{} {
 	defer fake.getAllObjectsMutex.RUnlock()
 	fake.getObjectByIDMutex.RLock()
 	defer fake.getObjectByIDMutex.RUnlock()
+	fake.getObjectEdgesMutex.RLock()
+	defer fake.getObjectEdgesMutex.RUnlock()
+	fake.getObjectHistoryMutex.RLock()
+	defer fake.getObjectHistoryMutex.RUnlock()
 	fake.getObjectsMutex.RLock()
 	defer fake.getObjectsMutex.RUnlock()
+	fake.getObjectsByReferenceMutex.RLock()
+	defer fake.getObjectsByReferenceMutex.RUnlock()
+	fake.getObjectsBySelectorMutex.RLock()
+	defer fake.getObjectsBySelectorMutex.RUnlock()
 	fake.getRoleBindingsMutex.RLock()
 	defer fake.getRoleBindingsMutex.RUnlock()
 	fake.getRolesMutex.RLock()
 	defer fake.getRolesMutex.RUnlock()
+	fake.getSavedQueriesMutex.RLock()
+	defer fake.getSavedQueriesMutex.RUnlock()
 	fake.getTenantsMutex.RLock()
 	defer fake.getTenantsMutex.RUnlock()
+	fake.storeObjectEdgesMutex.RLock()
+	defer fake.storeObjectEdgesMutex.RUnlock()
+	fake.storeObjectRevisionsMutex.RLock()
+	defer fake.storeObjectRevisionsMutex.RUnlock()
 	fake.storeObjectsMutex.RLock()
 	defer fake.storeObjectsMutex.RUnlock()
 	fake.storeRoleBindingsMutex.RLock()
 	defer fake.storeRoleBindingsMutex.RUnlock()
 	fake.storeRolesMutex.RLock()
 	defer fake.storeRolesMutex.RUnlock()
+	fake.storeSavedQueryMutex.RLock()
+	defer fake.storeSavedQueryMutex.RUnlock()
 	fake.storeTenantsMutex.RLock()
 	defer fake.storeTenantsMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
@@ -50,6 +50,34 @@ type FakeStoreReader struct {
 		result1 models.Object
 		result2 error
 	}
+	GetObjectEdgesStub        func(context.Context, []string) ([]models.ObjectEdge, error)
+	getObjectEdgesMutex       sync.RWMutex
+	getObjectEdgesArgsForCall []struct {
+		arg1 context.Context
+		arg2 []string
+	}
+	getObjectEdgesReturns struct {
+		result1 []models.ObjectEdge
+		result2 error
+	}
+	getObjectEdgesReturnsOnCall map[int]struct {
+		result1 []models.ObjectEdge
+		result2 error
+	}
+	GetObjectHistoryStub        func(context.Context, string) ([]models.ObjectRevision, error)
+	getObjectHistoryMutex       sync.RWMutex
+	getObjectHistoryArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+	}
+	getObjectHistoryReturns struct {
+		result1 []models.ObjectRevision
+		result2 error
+	}
+	getObjectHistoryReturnsOnCall map[int]struct {
+		result1 []models.ObjectRevision
+		result2 error
+	}
 	GetObjectsStub        func(context.Context, []string, store.QueryOption) (store.Iterator, error)
 	getObjectsMutex       sync.RWMutex
 	getObjectsArgsForCall []struct {
@@ -65,6 +93,34 @@ type FakeStoreReader struct {
 		result1 store.Iterator
 		result2 error
 	}
+	GetObjectsByReferenceStub        func(context.Context, []models.ObjectReference) ([]models.Object, error)
+	getObjectsByReferenceMutex       sync.RWMutex
+	getObjectsByReferenceArgsForCall []struct {
+		arg1 context.Context
+		arg2 []models.ObjectReference
+	}
+	getObjectsByReferenceReturns struct {
+		result1 []models.Object
+		result2 error
+	}
+	getObjectsByReferenceReturnsOnCall map[int]struct {
+		result1 []models.Object
+		result2 error
+	}
+	GetObjectsBySelectorStub        func(context.Context, store.ObjectSelector) (store.Iterator, error)
+	getObjectsBySelectorMutex       sync.RWMutex
+	getObjectsBySelectorArgsForCall []struct {
+		arg1 context.Context
+		arg2 store.ObjectSelector
+	}
+	getObjectsBySelectorReturns struct {
+		result1 store.Iterator
+		result2 error
+	}
+	getObjectsBySelectorReturnsOnCall map[int]struct {
+		result1 store.Iterator
+		result2 error
+	}
 	GetRoleBindingsStub        func(context.Context) ([]models.RoleBinding, error)
 	getRoleBindingsMutex       sync.RWMutex
 	getRoleBindingsArgsForCall []struct {
@@ -91,6 +147,19 @@ type FakeStoreReader struct {
 		result1 []models.Role
 		result2 error
 	}
+	GetSavedQueriesStub        func(context.Context) ([]models.SavedQuery, error)
+	getSavedQueriesMutex       sync.RWMutex
+	getSavedQueriesArgsForCall []struct {
+		arg1 context.Context
+	}
+	getSavedQueriesReturns struct {
+		result1 []models.SavedQuery
+		result2 error
+	}
+	getSavedQueriesReturnsOnCall map[int]struct {
+		result1 []models.SavedQuery
+		result2 error
+	}
 	GetTenantsStub        func(context.Context) ([]models.Tenant, error)
 	getTenantsMutex       sync.RWMutex
 	getTenantsArgsForCall []struct {
@@ -301,6 +370,141 @@ func (fake *FakeStoreReader) GetObjectByIDReturnsOnCall(i int, result1 models.Ob
 	}{result1, result2}
 }
 
+func (fake *FakeStoreReader) GetObjectEdges(arg1 context.Context, arg2 []string) ([]models.ObjectEdge, error) {
+	var arg2Copy []string
+	if arg2 != nil {
+		arg2Copy = make([]string, len(arg2))
+		copy(arg2Copy, arg2)
+	}
+	fake.getObjectEdgesMutex.Lock()
+	ret, specificReturn := fake.getObjectEdgesReturnsOnCall[len(fake.getObjectEdgesArgsForCall)]
+	fake.getObjectEdgesArgsForCall = append(fake.getObjectEdgesArgsForCall, struct {
+		arg1 context.Context
+		arg2 []string
+	}{arg1, arg2Copy})
+	stub := fake.GetObjectEdgesStub
+	fakeReturns := fake.getObjectEdgesReturns
+	fake.recordInvocation("GetObjectEdges", []interface{}{arg1, arg2Copy})
+	fake.getObjectEdgesMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeStoreReader) GetObjectEdgesCallCount() int {
+	fake.getObjectEdgesMutex.RLock()
+	defer fake.getObjectEdgesMutex.RUnlock()
+	return len(fake.getObjectEdgesArgsForCall)
+}
+
+func (fake *FakeStoreReader) GetObjectEdgesCalls(stub func(context.Context, []string) ([]models.ObjectEdge, error)) {
+	fake.getObjectEdgesMutex.Lock()
+	defer fake.getObjectEdgesMutex.Unlock()
+	fake.GetObjectEdgesStub = stub
+}
+
+func (fake *FakeStoreReader) GetObjectEdgesArgsForCall(i int) (context.Context, []string) {
+	fake.getObjectEdgesMutex.RLock()
+	defer fake.getObjectEdgesMutex.RUnlock()
+	argsForCall := fake.getObjectEdgesArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeStoreReader) GetObjectEdgesReturns(result1 []models.ObjectEdge, result2 error) {
+	fake.getObjectEdgesMutex.Lock()
+	defer fake.getObjectEdgesMutex.Unlock()
+	fake.GetObjectEdgesStub = nil
+	fake.getObjectEdgesReturns = struct {
+		result1 []models.ObjectEdge
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeStoreReader) GetObjectEdgesReturnsOnCall(i int, result1 []models.ObjectEdge, result2 error) {
+	fake.getObjectEdgesMutex.Lock()
+	defer fake.getObjectEdgesMutex.Unlock()
+	fake.GetObjectEdgesStub = nil
+	if fake.getObjectEdgesReturnsOnCall == nil {
+		fake.getObjectEdgesReturnsOnCall = make(map[int]struct {
+			result1 []models.ObjectEdge
+			result2 error
+		})
+	}
+	fake.getObjectEdgesReturnsOnCall[i] = struct {
+		result1 []models.ObjectEdge
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeStoreReader) GetObjectHistory(arg1 context.Context, arg2 string) ([]models.ObjectRevision, error) {
+	fake.getObjectHistoryMutex.Lock()
+	ret, specificReturn := fake.getObjectHistoryReturnsOnCall[len(fake.getObjectHistoryArgsForCall)]
+	fake.getObjectHistoryArgsForCall = append(fake.getObjectHistoryArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.GetObjectHistoryStub
+	fakeReturns := fake.getObjectHistoryReturns
+	fake.recordInvocation("GetObjectHistory", []interface{}{arg1, arg2})
+	fake.getObjectHistoryMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeStoreReader) GetObjectHistoryCallCount() int {
+	fake.getObjectHistoryMutex.RLock()
+	defer fake.getObjectHistoryMutex.RUnlock()
+	return len(fake.getObjectHistoryArgsForCall)
+}
+
+func (fake *FakeStoreReader) GetObjectHistoryCalls(stub func(context.Context, string) ([]models.ObjectRevision, error)) {
+	fake.getObjectHistoryMutex.Lock()
+	defer fake.getObjectHistoryMutex.Unlock()
+	fake.GetObjectHistoryStub = stub
+}
+
+func (fake *FakeStoreReader) GetObjectHistoryArgsForCall(i int) (context.Context, string) {
+	fake.getObjectHistoryMutex.RLock()
+	defer fake.getObjectHistoryMutex.RUnlock()
+	argsForCall := fake.getObjectHistoryArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeStoreReader) GetObjectHistoryReturns(result1 []models.ObjectRevision, result2 error) {
+	fake.getObjectHistoryMutex.Lock()
+	defer fake.getObjectHistoryMutex.Unlock()
+	fake.GetObjectHistoryStub = nil
+	fake.getObjectHistoryReturns = struct {
+		result1 []models.ObjectRevision
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeStoreReader) GetObjectHistoryReturnsOnCall(i int, result1 []models.ObjectRevision, result2 error) {
+	fake.getObjectHistoryMutex.Lock()
+	defer fake.getObjectHistoryMutex.Unlock()
+	fake.GetObjectHistoryStub = nil
+	if fake.getObjectHistoryReturnsOnCall == nil {
+		fake.getObjectHistoryReturnsOnCall = make(map[int]struct {
+			result1 []models.ObjectRevision
+			result2 error
+		})
+	}
+	fake.getObjectHistoryReturnsOnCall[i] = struct {
+		result1 []models.ObjectRevision
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeStoreReader) GetObjects(arg1 context.Context, arg2 []string, arg3 store.QueryOption) (store.Iterator, error) {
 	var arg2Copy []string
 	if arg2 != nil {
@@ -372,6 +576,141 @@ func (fake *FakeStoreReader) GetObjectsReturnsOnCall(i int, result1 store.Iterat
 	}{result1, result2}
 }
 
+func (fake *FakeStoreReader) GetObjectsByReference(arg1 context.Context, arg2 []models.ObjectReference) ([]models.Object, error) {
+	var arg2Copy []models.ObjectReference
+	if arg2 != nil {
+		arg2Copy = make([]models.ObjectReference, len(arg2))
+		copy(arg2Copy, arg2)
+	}
+	fake.getObjectsByReferenceMutex.Lock()
+	ret, specificReturn := fake.getObjectsByReferenceReturnsOnCall[len(fake.getObjectsByReferenceArgsForCall)]
+	fake.getObjectsByReferenceArgsForCall = append(fake.getObjectsByReferenceArgsForCall, struct {
+		arg1 context.Context
+		arg2 []models.ObjectReference
+	}{arg1, arg2Copy})
+	stub := fake.GetObjectsByReferenceStub
+	fakeReturns := fake.getObjectsByReferenceReturns
+	fake.recordInvocation("GetObjectsByReference", []interface{}{arg1, arg2Copy})
+	fake.getObjectsByReferenceMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeStoreReader) GetObjectsByReferenceCallCount() int {
+	fake.getObjectsByReferenceMutex.RLock()
+	defer fake.getObjectsByReferenceMutex.RUnlock()
+	return len(fake.getObjectsByReferenceArgsForCall)
+}
+
+func (fake *FakeStoreReader) GetObjectsByReferenceCalls(stub func(context.Context, []models.ObjectReference) ([]models.Object, error)) {
+	fake.getObjectsByReferenceMutex.Lock()
+	defer fake.getObjectsByReferenceMutex.Unlock()
+	fake.GetObjectsByReferenceStub = stub
+}
+
+func (fake *FakeStoreReader) GetObjectsByReferenceArgsForCall(i int) (context.Context, []models.ObjectReference) {
+	fake.getObjectsByReferenceMutex.RLock()
+	defer fake.getObjectsByReferenceMutex.RUnlock()
+	argsForCall := fake.getObjectsByReferenceArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeStoreReader) GetObjectsByReferenceReturns(result1 []models.Object, result2 error) {
+	fake.getObjectsByReferenceMutex.Lock()
+	defer fake.getObjectsByReferenceMutex.Unlock()
+	fake.GetObjectsByReferenceStub = nil
+	fake.getObjectsByReferenceReturns = struct {
+		result1 []models.Object
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeStoreReader) GetObjectsByReferenceReturnsOnCall(i int, result1 []models.Object, result2 error) {
+	fake.getObjectsByReferenceMutex.Lock()
+	defer fake.getObjectsByReferenceMutex.Unlock()
+	fake.GetObjectsByReferenceStub = nil
+	if fake.getObjectsByReferenceReturnsOnCall == nil {
+		fake.getObjectsByReferenceReturnsOnCall = make(map[int]struct {
+			result1 []models.Object
+			result2 error
+		})
+	}
+	fake.getObjectsByReferenceReturnsOnCall[i] = struct {
+		result1 []models.Object
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeStoreReader) GetObjectsBySelector(arg1 context.Context, arg2 store.ObjectSelector) (store.Iterator, error) {
+	fake.getObjectsBySelectorMutex.Lock()
+	ret, specificReturn := fake.getObjectsBySelectorReturnsOnCall[len(fake.getObjectsBySelectorArgsForCall)]
+	fake.getObjectsBySelectorArgsForCall = append(fake.getObjectsBySelectorArgsForCall, struct {
+		arg1 context.Context
+		arg2 store.ObjectSelector
+	}{arg1, arg2})
+	stub := fake.GetObjectsBySelectorStub
+	fakeReturns := fake.getObjectsBySelectorReturns
+	fake.recordInvocation("GetObjectsBySelector", []interface{}{arg1, arg2})
+	fake.getObjectsBySelectorMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeStoreReader) GetObjectsBySelectorCallCount() int {
+	fake.getObjectsBySelectorMutex.RLock()
+	defer fake.getObjectsBySelectorMutex.RUnlock()
+	return len(fake.getObjectsBySelectorArgsForCall)
+}
+
+func (fake *FakeStoreReader) GetObjectsBySelectorCalls(stub func(context.Context, store.ObjectSelector) (store.Iterator, error)) {
+	fake.getObjectsBySelectorMutex.Lock()
+	defer fake.getObjectsBySelectorMutex.Unlock()
+	fake.GetObjectsBySelectorStub = stub
+}
+
+func (fake *FakeStoreReader) GetObjectsBySelectorArgsForCall(i int) (context.Context, store.ObjectSelector) {
+	fake.getObjectsBySelectorMutex.RLock()
+	defer fake.getObjectsBySelectorMutex.RUnlock()
+	argsForCall := fake.getObjectsBySelectorArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeStoreReader) GetObjectsBySelectorReturns(result1 store.Iterator, result2 error) {
+	fake.getObjectsBySelectorMutex.Lock()
+	defer fake.getObjectsBySelectorMutex.Unlock()
+	fake.GetObjectsBySelectorStub = nil
+	fake.getObjectsBySelectorReturns = struct {
+		result1 store.Iterator
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeStoreReader) GetObjectsBySelectorReturnsOnCall(i int, result1 store.Iterator, result2 error) {
+	fake.getObjectsBySelectorMutex.Lock()
+	defer fake.getObjectsBySelectorMutex.Unlock()
+	fake.GetObjectsBySelectorStub = nil
+	if fake.getObjectsBySelectorReturnsOnCall == nil {
+		fake.getObjectsBySelectorReturnsOnCall = make(map[int]struct {
+			result1 store.Iterator
+			result2 error
+		})
+	}
+	fake.getObjectsBySelectorReturnsOnCall[i] = struct {
+		result1 store.Iterator
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeStoreReader) GetRoleBindings(arg1 context.Context) ([]models.RoleBinding, error) {
 	fake.getRoleBindingsMutex.Lock()
 	ret, specificReturn := fake.getRoleBindingsReturnsOnCall[len(fake.getRoleBindingsArgsForCall)]
@@ -500,6 +839,70 @@ func (fake *FakeStoreReader) GetRolesReturnsOnCall(i int, result1 []models.Role,
 	}{result1, result2}
 }
 
+func (fake *FakeStoreReader) GetSavedQueries(arg1 context.Context) ([]models.SavedQuery, error) {
+	fake.getSavedQueriesMutex.Lock()
+	ret, specificReturn := fake.getSavedQueriesReturnsOnCall[len(fake.getSavedQueriesArgsForCall)]
+	fake.getSavedQueriesArgsForCall = append(fake.getSavedQueriesArgsForCall, struct {
+		arg1 context.Context
+	}{arg1})
+	stub := fake.GetSavedQueriesStub
+	fakeReturns := fake.getSavedQueriesReturns
+	fake.recordInvocation("GetSavedQueries", []interface{}{arg1})
+	fake.getSavedQueriesMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeStoreReader) GetSavedQueriesCallCount() int {
+	fake.getSavedQueriesMutex.RLock()
+	defer fake.getSavedQueriesMutex.RUnlock()
+	return len(fake.getSavedQueriesArgsForCall)
+}
+
+func (fake *FakeStoreReader) GetSavedQueriesCalls(stub func(context.Context) ([]models.SavedQuery, error)) {
+	fake.getSavedQueriesMutex.Lock()
+	defer fake.getSavedQueriesMutex.Unlock()
+	fake.GetSavedQueriesStub = stub
+}
+
+func (fake *FakeStoreReader) GetSavedQueriesArgsForCall(i int) context.Context {
+	fake.getSavedQueriesMutex.RLock()
+	defer fake.getSavedQueriesMutex.RUnlock()
+	argsForCall := fake.getSavedQueriesArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeStoreReader) GetSavedQueriesReturns(result1 []models.SavedQuery, result2 error) {
+	fake.getSavedQueriesMutex.Lock()
+	defer fake.getSavedQueriesMutex.Unlock()
+	fake.GetSavedQueriesStub = nil
+	fake.getSavedQueriesReturns = struct {
+		result1 []models.SavedQuery
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeStoreReader) GetSavedQueriesReturnsOnCall(i int, result1 []models.SavedQuery, result2 error) {
+	fake.getSavedQueriesMutex.Lock()
+	defer fake.getSavedQueriesMutex.Unlock()
+	fake.GetSavedQueriesStub = nil
+	if fake.getSavedQueriesReturnsOnCall == nil {
+		fake.getSavedQueriesReturnsOnCall = make(map[int]struct {
+			result1 []models.SavedQuery
+			result2 error
+		})
+	}
+	fake.getSavedQueriesReturnsOnCall[i] = struct {
+		result1 []models.SavedQuery
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeStoreReader) GetTenants(arg1 context.Context) ([]models.Tenant, error) {
 	fake.getTenantsMutex.Lock()
 	ret, specificReturn := fake.getTenantsReturnsOnCall[len(fake.getTenantsArgsForCall)]
@@ -573,12 +976,22 @@ func (fake *FakeStoreReader) Invocations() map[string][][]interface{} {
 	defer fake.getAllObjectsMutex.RUnlock()
 	fake.getObjectByIDMutex.RLock()
 	defer fake.getObjectByIDMutex.RUnlock()
+	fake.getObjectEdgesMutex.RLock()
+	defer fake.getObjectEdgesMutex.RUnlock()
+	fake.getObjectHistoryMutex.RLock()
+	defer fake.getObjectHistoryMutex.RUnlock()
 	fake.getObjectsMutex.RLock()
 	defer fake.getObjectsMutex.RUnlock()
+	fake.getObjectsByReferenceMutex.RLock()
+	defer fake.getObjectsByReferenceMutex.RUnlock()
+	fake.getObjectsBySelectorMutex.RLock()
+	defer fake.getObjectsBySelectorMutex.RUnlock()
 	fake.getRoleBindingsMutex.RLock()
 	defer fake.getRoleBindingsMutex.RUnlock()
 	fake.getRolesMutex.RLock()
 	defer fake.getRolesMutex.RUnlock()
+	fake.getSavedQueriesMutex.RLock()
+	defer fake.getSavedQueriesMutex.RUnlock()
 	fake.getTenantsMutex.RLock()
 	defer fake.getTenantsMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
@@ -4,6 +4,7 @@ package storefakes
 import (
 	"context"
 	"sync"
+	"time"
 
 	"github.com/weaveworks/weave-gitops-enterprise/pkg/query/internal/models"
 	"github.com/weaveworks/weave-gitops-enterprise/pkg/query/store"
@@ -46,6 +47,19 @@ type FakeStoreWriter struct {
 	deleteAllRolesReturnsOnCall map[int]struct {
 		result1 error
 	}
+	DeleteObjectRevisionsStub        func(context.Context, []string, time.Time) error
+	deleteObjectRevisionsMutex       sync.RWMutex
+	deleteObjectRevisionsArgsForCall []struct {
+		arg1 context.Context
+		arg2 []string
+		arg3 time.Time
+	}
+	deleteObjectRevisionsReturns struct {
+		result1 error
+	}
+	deleteObjectRevisionsReturnsOnCall map[int]struct {
+		result1 error
+	}
 	DeleteObjectsStub        func(context.Context, []models.Object) error
 	deleteObjectsMutex       sync.RWMutex
 	deleteObjectsArgsForCall []struct {
@@ -82,6 +96,18 @@ type FakeStoreWriter struct {
 	deleteRolesReturnsOnCall map[int]struct {
 		result1 error
 	}
+	DeleteSavedQueryStub        func(context.Context, string) error
+	deleteSavedQueryMutex       sync.RWMutex
+	deleteSavedQueryArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+	}
+	deleteSavedQueryReturns struct {
+		result1 error
+	}
+	deleteSavedQueryReturnsOnCall map[int]struct {
+		result1 error
+	}
 	DeleteTenantsStub        func(context.Context, []models.Tenant) error
 	deleteTenantsMutex       sync.RWMutex
 	deleteTenantsArgsForCall []struct {
@@ -94,6 +120,31 @@ type FakeStoreWriter struct {
 	deleteTenantsReturnsOnCall map[int]struct {
 		result1 error
 	}
+	StoreObjectEdgesStub        func(context.Context, []string, []models.ObjectEdge) error
+	storeObjectEdgesMutex       sync.RWMutex
+	storeObjectEdgesArgsForCall []struct {
+		arg1 context.Context
+		arg2 []string
+		arg3 []models.ObjectEdge
+	}
+	storeObjectEdgesReturns struct {
+		result1 error
+	}
+	storeObjectEdgesReturnsOnCall map[int]struct {
+		result1 error
+	}
+	StoreObjectRevisionsStub        func(context.Context, []models.ObjectRevision) error
+	storeObjectRevisionsMutex       sync.RWMutex
+	storeObjectRevisionsArgsForCall []struct {
+		arg1 context.Context
+		arg2 []models.ObjectRevision
+	}
+	storeObjectRevisionsReturns struct {
+		result1 error
+	}
+	storeObjectRevisionsReturnsOnCall map[int]struct {
+		result1 error
+	}
 	StoreObjectsStub        func(context.Context, []models.Object) error
 	storeObjectsMutex       sync.RWMutex
 	storeObjectsArgsForCall []struct {
@@ -130,6 +181,18 @@ type FakeStoreWriter struct {
 	storeRolesReturnsOnCall map[int]struct {
 		result1 error
 	}
+	StoreSavedQueryStub        func(context.Context, models.SavedQuery) error
+	storeSavedQueryMutex       sync.RWMutex
+	storeSavedQueryArgsForCall []struct {
+		arg1 context.Context
+		arg2 models.SavedQuery
+	}
+	storeSavedQueryReturns struct {
+		result1 error
+	}
+	storeSavedQueryReturnsOnCall map[int]struct {
+		result1 error
+	}
 	StoreTenantsStub        func(context.Context, []models.Tenant) error
 	storeTenantsMutex       sync.RWMutex
 	storeTenantsArgsForCall []struct {
@@ -347,6 +410,74 @@ func (fake *FakeStoreWriter) DeleteAllRolesReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
+func (fake *FakeStoreWriter) DeleteObjectRevisions(arg1 context.Context, arg2 []string, arg3 time.Time) error {
+	var arg2Copy []string
+	if arg2 != nil {
+		arg2Copy = make([]string, len(arg2))
+		copy(arg2Copy, arg2)
+	}
+	fake.deleteObjectRevisionsMutex.Lock()
+	ret, specificReturn := fake.deleteObjectRevisionsReturnsOnCall[len(fake.deleteObjectRevisionsArgsForCall)]
+	fake.deleteObjectRevisionsArgsForCall = append(fake.deleteObjectRevisionsArgsForCall, struct {
+		arg1 context.Context
+		arg2 []string
+		arg3 time.Time
+	}{arg1, arg2Copy, arg3})
+	stub := fake.DeleteObjectRevisionsStub
+	fakeReturns := fake.deleteObjectRevisionsReturns
+	fake.recordInvocation("DeleteObjectRevisions", []interface{}{arg1, arg2Copy, arg3})
+	fake.deleteObjectRevisionsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeStoreWriter) DeleteObjectRevisionsCallCount() int {
+	fake.deleteObjectRevisionsMutex.RLock()
+	defer fake.deleteObjectRevisionsMutex.RUnlock()
+	return len(fake.deleteObjectRevisionsArgsForCall)
+}
+
+func (fake *FakeStoreWriter) DeleteObjectRevisionsCalls(stub func(context.Context, []string, time.Time) error) {
+	fake.deleteObjectRevisionsMutex.Lock()
+	defer fake.deleteObjectRevisionsMutex.Unlock()
+	fake.DeleteObjectRevisionsStub = stub
+}
+
+func (fake *FakeStoreWriter) DeleteObjectRevisionsArgsForCall(i int) (context.Context, []string, time.Time) {
+	fake.deleteObjectRevisionsMutex.RLock()
+	defer fake.deleteObjectRevisionsMutex.RUnlock()
+	argsForCall := fake.deleteObjectRevisionsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeStoreWriter) DeleteObjectRevisionsReturns(result1 error) {
+	fake.deleteObjectRevisionsMutex.Lock()
+	defer fake.deleteObjectRevisionsMutex.Unlock()
+	fake.DeleteObjectRevisionsStub = nil
+	fake.deleteObjectRevisionsReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeStoreWriter) DeleteObjectRevisionsReturnsOnCall(i int, result1 error) {
+	fake.deleteObjectRevisionsMutex.Lock()
+	defer fake.deleteObjectRevisionsMutex.Unlock()
+	fake.DeleteObjectRevisionsStub = nil
+	if fake.deleteObjectRevisionsReturnsOnCall == nil {
+		fake.deleteObjectRevisionsReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.deleteObjectRevisionsReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeStoreWriter) DeleteObjects(arg1 context.Context, arg2 []models.Object) error {
 	var arg2Copy []models.Object
 	if arg2 != nil {
@@ -548,6 +679,68 @@ func (fake *FakeStoreWriter) DeleteRolesReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
+func (fake *FakeStoreWriter) DeleteSavedQuery(arg1 context.Context, arg2 string) error {
+	fake.deleteSavedQueryMutex.Lock()
+	ret, specificReturn := fake.deleteSavedQueryReturnsOnCall[len(fake.deleteSavedQueryArgsForCall)]
+	fake.deleteSavedQueryArgsForCall = append(fake.deleteSavedQueryArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.DeleteSavedQueryStub
+	fakeReturns := fake.deleteSavedQueryReturns
+	fake.recordInvocation("DeleteSavedQuery", []interface{}{arg1, arg2})
+	fake.deleteSavedQueryMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeStoreWriter) DeleteSavedQueryCallCount() int {
+	fake.deleteSavedQueryMutex.RLock()
+	defer fake.deleteSavedQueryMutex.RUnlock()
+	return len(fake.deleteSavedQueryArgsForCall)
+}
+
+func (fake *FakeStoreWriter) DeleteSavedQueryCalls(stub func(context.Context, string) error) {
+	fake.deleteSavedQueryMutex.Lock()
+	defer fake.deleteSavedQueryMutex.Unlock()
+	fake.DeleteSavedQueryStub = stub
+}
+
+func (fake *FakeStoreWriter) DeleteSavedQueryArgsForCall(i int) (context.Context, string) {
+	fake.deleteSavedQueryMutex.RLock()
+	defer fake.deleteSavedQueryMutex.RUnlock()
+	argsForCall := fake.deleteSavedQueryArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeStoreWriter) DeleteSavedQueryReturns(result1 error) {
+	fake.deleteSavedQueryMutex.Lock()
+	defer fake.deleteSavedQueryMutex.Unlock()
+	fake.DeleteSavedQueryStub = nil
+	fake.deleteSavedQueryReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeStoreWriter) DeleteSavedQueryReturnsOnCall(i int, result1 error) {
+	fake.deleteSavedQueryMutex.Lock()
+	defer fake.deleteSavedQueryMutex.Unlock()
+	fake.DeleteSavedQueryStub = nil
+	if fake.deleteSavedQueryReturnsOnCall == nil {
+		fake.deleteSavedQueryReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.deleteSavedQueryReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeStoreWriter) DeleteTenants(arg1 context.Context, arg2 []models.Tenant) error {
 	var arg2Copy []models.Tenant
 	if arg2 != nil {
@@ -615,6 +808,146 @@ func (fake *FakeStoreWriter) DeleteTenantsReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
+func (fake *FakeStoreWriter) StoreObjectEdges(arg1 context.Context, arg2 []string, arg3 []models.ObjectEdge) error {
+	var arg2Copy []string
+	if arg2 != nil {
+		arg2Copy = make([]string, len(arg2))
+		copy(arg2Copy, arg2)
+	}
+	var arg3Copy []models.ObjectEdge
+	if arg3 != nil {
+		arg3Copy = make([]models.ObjectEdge, len(arg3))
+		copy(arg3Copy, arg3)
+	}
+	fake.storeObjectEdgesMutex.Lock()
+	ret, specificReturn := fake.storeObjectEdgesReturnsOnCall[len(fake.storeObjectEdgesArgsForCall)]
+	fake.storeObjectEdgesArgsForCall = append(fake.storeObjectEdgesArgsForCall, struct {
+		arg1 context.Context
+		arg2 []string
+		arg3 []models.ObjectEdge
+	}{arg1, arg2Copy, arg3Copy})
+	stub := fake.StoreObjectEdgesStub
+	fakeReturns := fake.storeObjectEdgesReturns
+	fake.recordInvocation("StoreObjectEdges", []interface{}{arg1, arg2Copy, arg3Copy})
+	fake.storeObjectEdgesMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeStoreWriter) StoreObjectEdgesCallCount() int {
+	fake.storeObjectEdgesMutex.RLock()
+	defer fake.storeObjectEdgesMutex.RUnlock()
+	return len(fake.storeObjectEdgesArgsForCall)
+}
+
+func (fake *FakeStoreWriter) StoreObjectEdgesCalls(stub func(context.Context, []string, []models.ObjectEdge) error) {
+	fake.storeObjectEdgesMutex.Lock()
+	defer fake.storeObjectEdgesMutex.Unlock()
+	fake.StoreObjectEdgesStub = stub
+}
+
+func (fake *FakeStoreWriter) StoreObjectEdgesArgsForCall(i int) (context.Context, []string, []models.ObjectEdge) {
+	fake.storeObjectEdgesMutex.RLock()
+	defer fake.storeObjectEdgesMutex.RUnlock()
+	argsForCall := fake.storeObjectEdgesArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeStoreWriter) StoreObjectEdgesReturns(result1 error) {
+	fake.storeObjectEdgesMutex.Lock()
+	defer fake.storeObjectEdgesMutex.Unlock()
+	fake.StoreObjectEdgesStub = nil
+	fake.storeObjectEdgesReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeStoreWriter) StoreObjectEdgesReturnsOnCall(i int, result1 error) {
+	fake.storeObjectEdgesMutex.Lock()
+	defer fake.storeObjectEdgesMutex.Unlock()
+	fake.StoreObjectEdgesStub = nil
+	if fake.storeObjectEdgesReturnsOnCall == nil {
+		fake.storeObjectEdgesReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.storeObjectEdgesReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeStoreWriter) StoreObjectRevisions(arg1 context.Context, arg2 []models.ObjectRevision) error {
+	var arg2Copy []models.ObjectRevision
+	if arg2 != nil {
+		arg2Copy = make([]models.ObjectRevision, len(arg2))
+		copy(arg2Copy, arg2)
+	}
+	fake.storeObjectRevisionsMutex.Lock()
+	ret, specificReturn := fake.storeObjectRevisionsReturnsOnCall[len(fake.storeObjectRevisionsArgsForCall)]
+	fake.storeObjectRevisionsArgsForCall = append(fake.storeObjectRevisionsArgsForCall, struct {
+		arg1 context.Context
+		arg2 []models.ObjectRevision
+	}{arg1, arg2Copy})
+	stub := fake.StoreObjectRevisionsStub
+	fakeReturns := fake.storeObjectRevisionsReturns
+	fake.recordInvocation("StoreObjectRevisions", []interface{}{arg1, arg2Copy})
+	fake.storeObjectRevisionsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeStoreWriter) StoreObjectRevisionsCallCount() int {
+	fake.storeObjectRevisionsMutex.RLock()
+	defer fake.storeObjectRevisionsMutex.RUnlock()
+	return len(fake.storeObjectRevisionsArgsForCall)
+}
+
+func (fake *FakeStoreWriter) StoreObjectRevisionsCalls(stub func(context.Context, []models.ObjectRevision) error) {
+	fake.storeObjectRevisionsMutex.Lock()
+	defer fake.storeObjectRevisionsMutex.Unlock()
+	fake.StoreObjectRevisionsStub = stub
+}
+
+func (fake *FakeStoreWriter) StoreObjectRevisionsArgsForCall(i int) (context.Context, []models.ObjectRevision) {
+	fake.storeObjectRevisionsMutex.RLock()
+	defer fake.storeObjectRevisionsMutex.RUnlock()
+	argsForCall := fake.storeObjectRevisionsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeStoreWriter) StoreObjectRevisionsReturns(result1 error) {
+	fake.storeObjectRevisionsMutex.Lock()
+	defer fake.storeObjectRevisionsMutex.Unlock()
+	fake.StoreObjectRevisionsStub = nil
+	fake.storeObjectRevisionsReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeStoreWriter) StoreObjectRevisionsReturnsOnCall(i int, result1 error) {
+	fake.storeObjectRevisionsMutex.Lock()
+	defer fake.storeObjectRevisionsMutex.Unlock()
+	fake.StoreObjectRevisionsStub = nil
+	if fake.storeObjectRevisionsReturnsOnCall == nil {
+		fake.storeObjectRevisionsReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.storeObjectRevisionsReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeStoreWriter) StoreObjects(arg1 context.Context, arg2 []models.Object) error {
 	var arg2Copy []models.Object
 	if arg2 != nil {
@@ -816,6 +1149,68 @@ func (fake *FakeStoreWriter) StoreRolesReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
+func (fake *FakeStoreWriter) StoreSavedQuery(arg1 context.Context, arg2 models.SavedQuery) error {
+	fake.storeSavedQueryMutex.Lock()
+	ret, specificReturn := fake.storeSavedQueryReturnsOnCall[len(fake.storeSavedQueryArgsForCall)]
+	fake.storeSavedQueryArgsForCall = append(fake.storeSavedQueryArgsForCall, struct {
+		arg1 context.Context
+		arg2 models.SavedQuery
+	}{arg1, arg2})
+	stub := fake.StoreSavedQueryStub
+	fakeReturns := fake.storeSavedQueryReturns
+	fake.recordInvocation("StoreSavedQuery", []interface{}{arg1, arg2})
+	fake.storeSavedQueryMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeStoreWriter) StoreSavedQueryCallCount() int {
+	fake.storeSavedQueryMutex.RLock()
+	defer fake.storeSavedQueryMutex.RUnlock()
+	return len(fake.storeSavedQueryArgsForCall)
+}
+
+func (fake *FakeStoreWriter) StoreSavedQueryCalls(stub func(context.Context, models.SavedQuery) error) {
+	fake.storeSavedQueryMutex.Lock()
+	defer fake.storeSavedQueryMutex.Unlock()
+	fake.StoreSavedQueryStub = stub
+}
+
+func (fake *FakeStoreWriter) StoreSavedQueryArgsForCall(i int) (context.Context, models.SavedQuery) {
+	fake.storeSavedQueryMutex.RLock()
+	defer fake.storeSavedQueryMutex.RUnlock()
+	argsForCall := fake.storeSavedQueryArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeStoreWriter) StoreSavedQueryReturns(result1 error) {
+	fake.storeSavedQueryMutex.Lock()
+	defer fake.storeSavedQueryMutex.Unlock()
+	fake.StoreSavedQueryStub = nil
+	fake.storeSavedQueryReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeStoreWriter) StoreSavedQueryReturnsOnCall(i int, result1 error) {
+	fake.storeSavedQueryMutex.Lock()
+	defer fake.storeSavedQueryMutex.Unlock()
+	fake.StoreSavedQueryStub = nil
+	if fake.storeSavedQueryReturnsOnCall == nil {
+		fake.storeSavedQueryReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.storeSavedQueryReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeStoreWriter) StoreTenants(arg1 context.Context, arg2 []models.Tenant) error {
 	var arg2Copy []models.Tenant
 	if arg2 != nil {
@@ -892,20 +1287,30 @@ func (fake *FakeStoreWriter) Invocations() map[string][][]interface{} {
 	defer fake.deleteAllRoleBindingsMutex.RUnlock()
 	fake.deleteAllRolesMutex.RLock()
 	defer fake.deleteAllRolesMutex.RUnlock()
+	fake.deleteObjectRevisionsMutex.RLock()
+	defer fake.deleteObjectRevisionsMutex.RUnlock()
 	fake.deleteObjectsMutex.RLock()
 	defer fake.deleteObjectsMutex.RUnlock()
 	fake.deleteRoleBindingsMutex.RLock()
 	defer fake.deleteRoleBindingsMutex.RUnlock()
 	fake.deleteRolesMutex.RLock()
 	defer fake.deleteRolesMutex.RUnlock()
+	fake.deleteSavedQueryMutex.RLock()
+	defer fake.deleteSavedQueryMutex.RUnlock()
 	fake.deleteTenantsMutex.RLock()
 	defer fake.deleteTenantsMutex.RUnlock()
+	fake.storeObjectEdgesMutex.RLock()
+	defer fake.storeObjectEdgesMutex.RUnlock()
+	fake.storeObjectRevisionsMutex.RLock()
+	defer fake.storeObjectRevisionsMutex.RUnlock()
 	fake.storeObjectsMutex.RLock()
 	defer fake.storeObjectsMutex.RUnlock()
 	fake.storeRoleBindingsMutex.RLock()
 	defer fake.storeRoleBindingsMutex.RUnlock()
 	fake.storeRolesMutex.RLock()
 	defer fake.storeRolesMutex.RUnlock()
+	fake.storeSavedQueryMutex.RLock()
+	defer fake.storeSavedQueryMutex.RUnlock()
 	fake.storeTenantsMutex.RLock()
 	defer fake.storeTenantsMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
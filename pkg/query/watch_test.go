@@ -0,0 +1,116 @@
+package query
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/go-logr/logr"
+	. "github.com/onsi/gomega"
+	"github.com/weaveworks/weave-gitops-enterprise/pkg/query/events"
+	"github.com/weaveworks/weave-gitops-enterprise/pkg/query/internal/models"
+	"github.com/weaveworks/weave-gitops-enterprise/pkg/query/store"
+	"github.com/weaveworks/weave-gitops/pkg/server/auth"
+)
+
+// notifyingSubscriber lets the test know when the watch has subscribed,
+// so that no events are published before it is listening.
+type notifyingSubscriber struct {
+	events.Subscriber
+	subscribed chan struct{}
+}
+
+func (s notifyingSubscriber) Subscribe() *events.Subscription {
+	defer close(s.subscribed)
+	return s.Subscriber.Subscribe()
+}
+
+func TestWatchQuery(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	db, err := store.CreateSQLiteDB(t.TempDir())
+	g.Expect(err).NotTo(HaveOccurred())
+
+	s, err := store.NewSQLiteStore(db, logr.Discard())
+	g.Expect(err).NotTo(HaveOccurred())
+
+	broadcaster := events.NewBroadcaster(logr.Discard())
+	subscriber := notifyingSubscriber{Subscriber: broadcaster, subscribed: make(chan struct{})}
+
+	q := &qs{
+		log:   logr.Discard(),
+		debug: logr.Discard(),
+		r:     s,
+		authorizer: predicateAuthz{
+			predicate: func(obj models.Object) (bool, error) {
+				return obj.Namespace != "namespace-b", nil
+			},
+		},
+		events: subscriber,
+	}
+
+	ctx, cancel := context.WithCancel(auth.WithPrincipal(context.Background(), &auth.UserPrincipal{ID: "test"}))
+	defer cancel()
+
+	sent := make(chan string, 10)
+	done := make(chan error, 1)
+	go func() {
+		done <- q.WatchQuery(ctx, &query{query: "kind:HelmRelease AND status:Failed"}, func(e models.ObjectEvent) error {
+			sent <- fmt.Sprintf("%s %s", e.Type, e.Object.Name)
+			return nil
+		})
+	}()
+
+	<-subscriber.subscribed
+
+	failed := models.Object{Cluster: "management", Namespace: "namespace-a", Name: "podinfo", Kind: "HelmRelease", Status: "Failed"}
+	healthy := failed
+	healthy.Status = "Success"
+	hidden := failed
+	hidden.Namespace = "namespace-b"
+	hidden.Name = "hidden"
+	kustomization := failed
+	kustomization.Kind = "Kustomization"
+	kustomization.Name = "flux-system"
+
+	broadcaster.Publish([]models.ObjectEvent{
+		{Type: models.ObjectEventAdded, Object: failed},
+		{Type: models.ObjectEventAdded, Object: hidden},
+		{Type: models.ObjectEventAdded, Object: kustomization},
+	})
+	g.Eventually(sent).Should(Receive(Equal("added podinfo")))
+
+	// Recovering takes the release out of the results.
+	broadcaster.Publish([]models.ObjectEvent{{Type: models.ObjectEventModified, Object: healthy}})
+	g.Eventually(sent).Should(Receive(Equal("deleted podinfo")))
+
+	// Changes to objects outside of the results are not sent.
+	broadcaster.Publish([]models.ObjectEvent{{Type: models.ObjectEventModified, Object: healthy}})
+
+	broadcaster.Publish([]models.ObjectEvent{{Type: models.ObjectEventModified, Object: failed}})
+	g.Eventually(sent).Should(Receive(Equal("modified podinfo")))
+
+	broadcaster.Publish([]models.ObjectEvent{{Type: models.ObjectEventDeleted, Object: failed}})
+	g.Eventually(sent).Should(Receive(Equal("deleted podinfo")))
+
+	cancel()
+	g.Eventually(done).Should(Receive(BeNil()))
+	g.Expect(sent).NotTo(Receive())
+}
+
+func TestWatchQuery_InvalidQuery(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	q := &qs{
+		log:    logr.Discard(),
+		debug:  logr.Discard(),
+		events: events.NewBroadcaster(logr.Discard()),
+	}
+
+	ctx := auth.WithPrincipal(context.Background(), &auth.UserPrincipal{ID: "test"})
+
+	err := q.WatchQuery(ctx, &query{query: "kind:("}, func(models.ObjectEvent) error { return nil })
+
+	var parseErr *store.ParseError
+	g.Expect(err).To(BeAssignableToTypeOf(parseErr))
+}
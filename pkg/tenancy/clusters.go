@@ -0,0 +1,233 @@
+package tenancy
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	gitopsv1alpha1 "github.com/weaveworks/cluster-controller/api/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// exportFileName is the file the resources of the tenants are exported to, in the directory of each cluster.
+const exportFileName = "tenants.yaml"
+
+// ClusterTargets selects the GitopsClusters of the management cluster that the tenants are applied to.
+// A cluster is selected if it is named, or if its labels match the selector.
+type ClusterTargets struct {
+	// Names are the names of the clusters, as `<namespace>/<name>`.
+	Names    []string              `json:"names,omitempty"`
+	Selector *metav1.LabelSelector `json:"selector,omitempty"`
+}
+
+// Validate returns an error if any of the fields isn't valid
+func (t ClusterTargets) Validate() error {
+	if len(t.Names) == 0 && t.Selector == nil {
+		return errors.New("must provide cluster names or a cluster selector")
+	}
+
+	for _, name := range t.Names {
+		if _, err := parseClusterName(name); err != nil {
+			return err
+		}
+	}
+
+	if t.Selector != nil {
+		if _, err := metav1.LabelSelectorAsSelector(t.Selector); err != nil {
+			return fmt.Errorf("invalid cluster selector: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func parseClusterName(name string) (types.NamespacedName, error) {
+	namespace, clusterName, ok := strings.Cut(name, "/")
+	if !ok || namespace == "" || clusterName == "" || strings.Contains(clusterName, "/") {
+		return types.NamespacedName{}, fmt.Errorf("invalid cluster name %q, must be <namespace>/<name>", name)
+	}
+
+	return types.NamespacedName{Namespace: namespace, Name: clusterName}, nil
+}
+
+// SelectClusters returns the GitopsClusters selected by the targets, sorted by name.
+// It is an error for a named cluster not to exist.
+func SelectClusters(ctx context.Context, kubeClient client.Client, targets ClusterTargets) ([]types.NamespacedName, error) {
+	if err := targets.Validate(); err != nil {
+		return nil, err
+	}
+
+	clusters := gitopsv1alpha1.GitopsClusterList{}
+	if err := kubeClient.List(ctx, &clusters); err != nil {
+		return nil, fmt.Errorf("failed to list clusters: %w", err)
+	}
+
+	existing := map[types.NamespacedName]bool{}
+	selected := map[types.NamespacedName]bool{}
+
+	var selector labels.Selector
+	if targets.Selector != nil {
+		s, err := metav1.LabelSelectorAsSelector(targets.Selector)
+		if err != nil {
+			return nil, fmt.Errorf("invalid cluster selector: %w", err)
+		}
+		selector = s
+	}
+
+	for _, cluster := range clusters.Items {
+		key := client.ObjectKeyFromObject(&cluster)
+		existing[key] = true
+
+		if selector != nil && selector.Matches(labels.Set(cluster.GetLabels())) {
+			selected[key] = true
+		}
+	}
+
+	for _, name := range targets.Names {
+		key, err := parseClusterName(name)
+		if err != nil {
+			return nil, err
+		}
+
+		if !existing[key] {
+			return nil, fmt.Errorf("cluster %s not found", name)
+		}
+		selected[key] = true
+	}
+
+	result := []types.NamespacedName{}
+	for key := range selected {
+		result = append(result, key)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].String() < result[j].String() })
+
+	return result, nil
+}
+
+// ClusterResult is the outcome of applying the tenants to a cluster.
+type ClusterResult struct {
+	Cluster types.NamespacedName
+	Err     error
+}
+
+// ApplyTenantsToClusters applies the tenants to each cluster in turn, with the client that clientFor returns
+// for it. A cluster that fails does not stop the tenants from being applied to the others.
+func ApplyTenantsToClusters(ctx context.Context, config *Config, clusters []types.NamespacedName, clientFor func(context.Context, types.NamespacedName) (client.Client, error), prune bool, out io.Writer) []ClusterResult {
+	results := []ClusterResult{}
+
+	for _, cluster := range clusters {
+		fmt.Fprintf(out, "applying tenants to cluster %s\n", cluster)
+
+		err := func() error {
+			kubeClient, err := clientFor(ctx, cluster)
+			if err != nil {
+				return err
+			}
+
+			return ApplyTenants(ctx, config, kubeClient, prune, out)
+		}()
+
+		results = append(results, ClusterResult{Cluster: cluster, Err: err})
+	}
+
+	return results
+}
+
+// WriteClusterResults writes a summary of the results, and returns an error if any cluster failed.
+func WriteClusterResults(out io.Writer, results []ClusterResult) error {
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "CLUSTER\tRESULT")
+
+	failed := 0
+	for _, result := range results {
+		if result.Err != nil {
+			failed++
+			fmt.Fprintf(w, "%s\tfailed: %s\n", result.Cluster, result.Err)
+			continue
+		}
+		fmt.Fprintf(w, "%s\tsucceeded\n", result.Cluster)
+	}
+
+	if err := w.Flush(); err != nil {
+		return err
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("failed to apply tenants to %d of %d clusters", failed, len(results))
+	}
+
+	return nil
+}
+
+// ExportTenantsToClusters exports the tenants to a directory for each cluster, `<dir>/<namespace>/<name>`,
+// the way the management repository lays out the files of clusters.
+func ExportTenantsToClusters(config *Config, clusters []types.NamespacedName, dir string) error {
+	resources, err := GenerateTenantResources(config)
+	if err != nil {
+		return fmt.Errorf("failed to generate tenant output: %w", err)
+	}
+
+	for _, cluster := range clusters {
+		clusterDir := filepath.Join(dir, cluster.Namespace, cluster.Name)
+		if err := os.MkdirAll(clusterDir, 0o755); err != nil {
+			return fmt.Errorf("failed to create directory for cluster %s: %w", cluster, err)
+		}
+
+		f, err := os.Create(filepath.Join(clusterDir, exportFileName))
+		if err != nil {
+			return fmt.Errorf("failed to create file for cluster %s: %w", cluster, err)
+		}
+
+		if err := outputResources(f, resources); err != nil {
+			f.Close()
+			return fmt.Errorf("failed to export tenants for cluster %s: %w", cluster, err)
+		}
+
+		if err := f.Close(); err != nil {
+			return fmt.Errorf("failed to write file for cluster %s: %w", cluster, err)
+		}
+	}
+
+	return nil
+}
+
+// TenantFiles renders the resources of each tenant to YAML, by tenant name, for each tenant to be
+// kept in a file of its own. The file of a tenant only changes when the declaration of the tenant does.
+func TenantFiles(config *Config) (map[string]string, error) {
+	resources, err := GenerateTenantResources(config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate tenant output: %w", err)
+	}
+
+	buffers := map[string]*bytes.Buffer{}
+	for _, resource := range resources {
+		tenant := resource.GetLabels()[tenantLabel]
+
+		buf, ok := buffers[tenant]
+		if !ok {
+			buf = &bytes.Buffer{}
+			buffers[tenant] = buf
+		}
+
+		if err := outputResources(buf, []client.Object{resource}); err != nil {
+			return nil, fmt.Errorf("failed to export tenant %s: %w", tenant, err)
+		}
+	}
+
+	files := map[string]string{}
+	for tenant, buf := range buffers {
+		files[tenant] = buf.String()
+	}
+
+	return files, nil
+}
@@ -0,0 +1,230 @@
+package tenancy
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	gitopsv1alpha1 "github.com/weaveworks/cluster-controller/api/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestParse_WithClusters(t *testing.T) {
+	config, err := Parse("testdata/with_clusters.yaml")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, &ClusterTargets{
+		Names: []string{"default/leaf-1"},
+		Selector: &metav1.LabelSelector{
+			MatchLabels: map[string]string{"environment": "production"},
+		},
+	}, config.Clusters)
+	assert.Equal(t, 1, len(config.Tenants))
+}
+
+func TestClusterTargets_Validate(t *testing.T) {
+	assert.ErrorContains(t, ClusterTargets{}.Validate(), "must provide cluster names or a cluster selector")
+	assert.ErrorContains(t, ClusterTargets{Names: []string{"leaf-1"}}.Validate(), `invalid cluster name "leaf-1", must be <namespace>/<name>`)
+	assert.ErrorContains(t, ClusterTargets{Selector: &metav1.LabelSelector{
+		MatchExpressions: []metav1.LabelSelectorRequirement{{Key: "environment", Operator: "Unknown"}},
+	}}.Validate(), "invalid cluster selector")
+	assert.NoError(t, ClusterTargets{Names: []string{"default/leaf-1"}}.Validate())
+}
+
+func TestSelectClusters(t *testing.T) {
+	kubeClient := newFakeClusterClient(t,
+		newGitopsCluster("default", "leaf-1", nil),
+		newGitopsCluster("default", "leaf-2", map[string]string{"environment": "production"}),
+		newGitopsCluster("team-a", "leaf-3", map[string]string{"environment": "production"}),
+		newGitopsCluster("team-a", "leaf-4", map[string]string{"environment": "staging"}),
+	)
+
+	tests := []struct {
+		name     string
+		targets  ClusterTargets
+		expected []types.NamespacedName
+		err      string
+	}{
+		{
+			name:    "by name",
+			targets: ClusterTargets{Names: []string{"team-a/leaf-4", "default/leaf-1"}},
+			expected: []types.NamespacedName{
+				{Namespace: "default", Name: "leaf-1"},
+				{Namespace: "team-a", Name: "leaf-4"},
+			},
+		},
+		{
+			name: "by selector",
+			targets: ClusterTargets{Selector: &metav1.LabelSelector{
+				MatchLabels: map[string]string{"environment": "production"},
+			}},
+			expected: []types.NamespacedName{
+				{Namespace: "default", Name: "leaf-2"},
+				{Namespace: "team-a", Name: "leaf-3"},
+			},
+		},
+		{
+			name: "by name and selector",
+			targets: ClusterTargets{
+				Names: []string{"default/leaf-2", "team-a/leaf-4"},
+				Selector: &metav1.LabelSelector{
+					MatchLabels: map[string]string{"environment": "production"},
+				},
+			},
+			expected: []types.NamespacedName{
+				{Namespace: "default", Name: "leaf-2"},
+				{Namespace: "team-a", Name: "leaf-3"},
+				{Namespace: "team-a", Name: "leaf-4"},
+			},
+		},
+		{
+			name:    "missing cluster",
+			targets: ClusterTargets{Names: []string{"default/leaf-5"}},
+			err:     "cluster default/leaf-5 not found",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clusters, err := SelectClusters(context.TODO(), kubeClient, tt.targets)
+			if tt.err != "" {
+				assert.ErrorContains(t, err, tt.err)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, clusters)
+		})
+	}
+}
+
+func TestApplyTenantsToClusters(t *testing.T) {
+	config := &Config{
+		Tenants: []Tenant{
+			{
+				Name:       "foo-tenant",
+				Namespaces: []string{"foo-ns"},
+			},
+		},
+	}
+
+	leaf1 := types.NamespacedName{Namespace: "default", Name: "leaf-1"}
+	leaf2 := types.NamespacedName{Namespace: "default", Name: "leaf-2"}
+	leaf3 := types.NamespacedName{Namespace: "default", Name: "leaf-3"}
+
+	clients := map[types.NamespacedName]client.Client{
+		leaf1: newFakeClient(t),
+		leaf3: newFakeClient(t),
+	}
+	clientFor := func(_ context.Context, cluster types.NamespacedName) (client.Client, error) {
+		c, ok := clients[cluster]
+		if !ok {
+			return nil, errors.New("cluster is not ready")
+		}
+		return c, nil
+	}
+
+	out := &bytes.Buffer{}
+	results := ApplyTenantsToClusters(context.TODO(), config, []types.NamespacedName{leaf1, leaf2, leaf3}, clientFor, false, out)
+
+	assert.Equal(t, []ClusterResult{
+		{Cluster: leaf1},
+		{Cluster: leaf2, Err: errors.New("cluster is not ready")},
+		{Cluster: leaf3},
+	}, results)
+
+	// The clusters after the one that failed are applied to as well.
+	for _, cluster := range []types.NamespacedName{leaf1, leaf3} {
+		verifyNamespaces(setResourceVersion(newNamespace("foo-ns", map[string]string{
+			"toolkit.fluxcd.io/tenant": "foo-tenant",
+		}), 1))(t, clients[cluster])
+	}
+
+	summary := &bytes.Buffer{}
+	err := WriteClusterResults(summary, results)
+	assert.EqualError(t, err, "failed to apply tenants to 1 of 3 clusters")
+	assert.Equal(t, `CLUSTER         RESULT
+default/leaf-1  succeeded
+default/leaf-2  failed: cluster is not ready
+default/leaf-3  succeeded
+`, summary.String())
+}
+
+func TestExportTenantsToClusters(t *testing.T) {
+	config, err := Parse("testdata/example.yaml")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dir := t.TempDir()
+	clusters := []types.NamespacedName{
+		{Namespace: "default", Name: "leaf-1"},
+		{Namespace: "team-a", Name: "leaf-2"},
+	}
+
+	err = ExportTenantsToClusters(config, clusters, dir)
+	assert.NoError(t, err)
+
+	expected := readGoldenFile(t, "testdata/example.yaml.golden")
+	for _, cluster := range clusters {
+		exported, err := os.ReadFile(filepath.Join(dir, cluster.Namespace, cluster.Name, "tenants.yaml"))
+		assert.NoError(t, err)
+		assert.Equal(t, expected, string(exported))
+	}
+}
+
+func TestTenantFiles(t *testing.T) {
+	config, err := Parse("testdata/example.yaml")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	files, err := TenantFiles(config)
+	assert.NoError(t, err)
+	assert.Len(t, files, 2)
+
+	// The files of the tenants make up the export of all of them.
+	assert.Equal(t, readGoldenFile(t, "testdata/example.yaml.golden"), files["foo-tenant"]+files["bar-tenant"])
+	assert.Contains(t, files["foo-tenant"], "name: foo-ns")
+	assert.NotContains(t, files["foo-tenant"], "bar-tenant")
+}
+
+func newFakeClusterClient(t *testing.T, objs ...runtime.Object) client.Client {
+	t.Helper()
+
+	scheme := runtime.NewScheme()
+
+	if err := corev1.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := gitopsv1alpha1.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+
+	return fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithRuntimeObjects(objs...).
+		Build()
+}
+
+func newGitopsCluster(namespace, name string, labels map[string]string) *gitopsv1alpha1.GitopsCluster {
+	return &gitopsv1alpha1.GitopsCluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+			Labels:    labels,
+		},
+	}
+}
@@ -0,0 +1,320 @@
+package tenancy
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// DriftType tells how a tenant resource in a cluster differs from the tenancy configuration.
+type DriftType string
+
+const (
+	// DriftMissing is a resource that the configuration generates, but is not in the cluster.
+	DriftMissing DriftType = "missing"
+	// DriftExtra is a tenant resource in the cluster that the configuration no longer generates.
+	DriftExtra DriftType = "extra"
+	// DriftModified is a resource whose fields in the cluster differ from those the configuration generates.
+	DriftModified DriftType = "modified"
+)
+
+// FieldDiff is a field of a resource that differs from the configuration, with its values as JSON.
+type FieldDiff struct {
+	// Path is the path to the field, e.g. `roleRef.name` or `subjects[0].kind`.
+	Path     string
+	Expected string
+	Actual   string
+}
+
+// Drift is a tenant resource that differs from the configuration.
+type Drift struct {
+	Type DriftType
+	// ID identifies the resource, as `[<namespace>/]<kind>.<group>/<name>`.
+	ID     string
+	Tenant string
+	// Fields are the fields that differ, for modified resources.
+	Fields []FieldDiff
+}
+
+// CheckTenants compares the resources generated for the tenants with the tenant resources in the
+// cluster, and returns those that differ. Only the fields that the configuration sets are compared,
+// so the fields that the cluster defaults or adds are not drift.
+func CheckTenants(ctx context.Context, config *Config, kubeClient client.Client) ([]Drift, error) {
+	resources, err := GenerateTenantResources(config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate tenant output: %w", err)
+	}
+
+	existing, err := getCurrentResources(ctx, kubeClient)
+	if err != nil {
+		return nil, err
+	}
+
+	existingByID := map[string]client.Object{}
+	for _, obj := range existing {
+		existingByID[getObjectID(obj)] = obj
+	}
+
+	drifts := []Drift{}
+	for _, generated := range resources {
+		id := getObjectID(generated)
+		drift := Drift{
+			ID:     id,
+			Tenant: generated.GetLabels()[tenantLabel],
+		}
+
+		current, ok := existingByID[id]
+		if !ok {
+			drift.Type = DriftMissing
+			drifts = append(drifts, drift)
+			continue
+		}
+
+		fields, err := diffResource(generated, current)
+		if err != nil {
+			return nil, fmt.Errorf("failed to compare %s: %w", id, err)
+		}
+
+		if len(fields) > 0 {
+			drift.Type = DriftModified
+			drift.Fields = fields
+			drifts = append(drifts, drift)
+		}
+	}
+
+	for _, obj := range getResourcesToDelete(resources, existing) {
+		drifts = append(drifts, Drift{
+			Type:   DriftExtra,
+			ID:     getObjectID(obj),
+			Tenant: obj.GetLabels()[tenantLabel],
+		})
+	}
+
+	return drifts, nil
+}
+
+// WriteDriftReport writes the drifts, and returns an error if there are any.
+func WriteDriftReport(out io.Writer, drifts []Drift) error {
+	writeDrifts(out, drifts)
+
+	if len(drifts) > 0 {
+		return fmt.Errorf("drift detected in %d tenant resources", len(drifts))
+	}
+
+	return nil
+}
+
+func writeDrifts(out io.Writer, drifts []Drift) {
+	if len(drifts) == 0 {
+		fmt.Fprintln(out, "no drift detected")
+		return
+	}
+
+	for _, drift := range drifts {
+		fmt.Fprintf(out, "%s %s (tenant %s)\n", drift.ID, drift.Type, drift.Tenant)
+		for _, field := range drift.Fields {
+			fmt.Fprintf(out, "  %s: expected %s, got %s\n", field.Path, field.Expected, field.Actual)
+		}
+	}
+}
+
+// ClusterDrifts are the drifts of the tenant resources of a cluster, or the error checking them.
+type ClusterDrifts struct {
+	Cluster types.NamespacedName
+	Drifts  []Drift
+	Err     error
+}
+
+// CheckTenantsOfClusters checks the tenant resources of each cluster in turn, with the client that
+// clientFor returns for it. A cluster that fails does not stop the others from being checked.
+func CheckTenantsOfClusters(ctx context.Context, config *Config, clusters []types.NamespacedName, clientFor func(context.Context, types.NamespacedName) (client.Client, error)) []ClusterDrifts {
+	results := []ClusterDrifts{}
+
+	for _, cluster := range clusters {
+		result := ClusterDrifts{Cluster: cluster}
+
+		kubeClient, err := clientFor(ctx, cluster)
+		if err == nil {
+			result.Drifts, err = CheckTenants(ctx, config, kubeClient)
+		}
+		result.Err = err
+
+		results = append(results, result)
+	}
+
+	return results
+}
+
+// WriteClusterDriftReport writes the drifts of each cluster, and returns an error if there are any,
+// or if any cluster could not be checked.
+func WriteClusterDriftReport(out io.Writer, results []ClusterDrifts) error {
+	var drifted, failed int
+
+	for _, result := range results {
+		fmt.Fprintf(out, "cluster %s:\n", result.Cluster)
+
+		if result.Err != nil {
+			failed++
+			fmt.Fprintf(out, "failed: %s\n", result.Err)
+			continue
+		}
+
+		if len(result.Drifts) > 0 {
+			drifted++
+		}
+		writeDrifts(out, result.Drifts)
+	}
+
+	switch {
+	case failed > 0:
+		return fmt.Errorf("failed to check tenants of %d of %d clusters", failed, len(results))
+	case drifted > 0:
+		return fmt.Errorf("drift detected in %d of %d clusters", drifted, len(results))
+	}
+
+	return nil
+}
+
+// diffResource returns the fields that the generated resource sets, and differ in the current one.
+func diffResource(generated, current client.Object) ([]FieldDiff, error) {
+	expected, err := runtime.DefaultUnstructuredConverter.ToUnstructured(generated)
+	if err != nil {
+		return nil, err
+	}
+
+	var actual map[string]interface{}
+	if u, ok := current.(*unstructured.Unstructured); ok {
+		actual = u.Object
+	} else {
+		actual, err = runtime.DefaultUnstructuredConverter.ToUnstructured(current)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// The identity of the resources is the same, and of their metadata only the labels and annotations are declared.
+	delete(expected, "apiVersion")
+	delete(expected, "kind")
+	delete(expected, "status")
+	if metadata, ok := expected["metadata"].(map[string]interface{}); ok {
+		expected["metadata"] = pick(metadata, "labels", "annotations")
+	}
+
+	fields := []FieldDiff{}
+	diffFields("", expected, actual, &fields)
+
+	return fields, nil
+}
+
+func pick(m map[string]interface{}, keys ...string) map[string]interface{} {
+	picked := map[string]interface{}{}
+	for _, key := range keys {
+		if v, ok := m[key]; ok {
+			picked[key] = v
+		}
+	}
+
+	return picked
+}
+
+// diffFields compares the expected value with the actual one, and adds the fields that differ.
+// Maps are compared by the keys of the expected one, and lists element by element when their
+// lengths match.
+func diffFields(path string, expected, actual interface{}, fields *[]FieldDiff) {
+	switch e := expected.(type) {
+	case map[string]interface{}:
+		a, ok := actual.(map[string]interface{})
+		if !ok {
+			break
+		}
+
+		keys := []string{}
+		for k := range e {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+
+		for _, k := range keys {
+			diffFields(joinPath(path, k), e[k], a[k], fields)
+		}
+
+		return
+	case []interface{}:
+		a, ok := actual.([]interface{})
+		if !ok || len(a) != len(e) {
+			break
+		}
+
+		for i := range e {
+			diffFields(fmt.Sprintf("%s[%d]", path, i), e[i], a[i], fields)
+		}
+
+		return
+	default:
+		if equalValues(e, actual) {
+			return
+		}
+	}
+
+	*fields = append(*fields, FieldDiff{
+		Path:     path,
+		Expected: toJSON(expected),
+		Actual:   toJSON(actual),
+	})
+}
+
+// equalValues tells whether two scalar values are equal. Quantities, like the limits of resource
+// quotas, are compared by value, as the API server may write them differently.
+func equalValues(expected, actual interface{}) bool {
+	if toJSON(expected) == toJSON(actual) {
+		return true
+	}
+
+	e, eok := expected.(string)
+	a, aok := actual.(string)
+	if !eok || !aok {
+		return false
+	}
+
+	eq, err := resource.ParseQuantity(e)
+	if err != nil {
+		return false
+	}
+	aq, err := resource.ParseQuantity(a)
+	if err != nil {
+		return false
+	}
+
+	return eq.Cmp(aq) == 0
+}
+
+func joinPath(path, key string) string {
+	if strings.ContainsAny(key, "./") {
+		key = fmt.Sprintf("[%q]", key)
+		return path + key
+	}
+
+	if path == "" {
+		return key
+	}
+
+	return path + "." + key
+}
+
+func toJSON(v interface{}) string {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprintf("%v", v)
+	}
+
+	return string(b)
+}
@@ -0,0 +1,221 @@
+package tenancy
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	pacv2beta2 "github.com/weaveworks/policy-agent/api/v2beta2"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestCheckTenants(t *testing.T) {
+	config := &Config{
+		Tenants: []Tenant{
+			{
+				Name:       "foo-tenant",
+				Namespaces: []string{"foo-ns"},
+				TeamRBAC: &TenantTeamRBAC{
+					GroupNames: []string{"foo-team"},
+					Rules: []rbacv1.PolicyRule{
+						{APIGroups: []string{""}, Resources: []string{"pods"}, Verbs: []string{"get", "list"}},
+					},
+				},
+				ResourceQuotas: []TenantResourceQuota{
+					{
+						Name: "compute",
+						Hard: corev1.ResourceList{corev1.ResourceRequestsCPU: resource.MustParse("500m")},
+					},
+				},
+			},
+		},
+	}
+
+	fc := newFakeClient(t)
+	ctx := context.TODO()
+
+	if err := ApplyTenants(ctx, config, fc, false, &bytes.Buffer{}); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("no drift", func(t *testing.T) {
+		drifts, err := CheckTenants(ctx, config, fc)
+		assert.NoError(t, err)
+		assert.Empty(t, drifts)
+
+		out := &bytes.Buffer{}
+		assert.NoError(t, WriteDriftReport(out, drifts))
+		assert.Equal(t, "no drift detected\n", out.String())
+	})
+
+	t.Run("quantities written differently are not drift", func(t *testing.T) {
+		quota := &corev1.ResourceQuota{}
+		if err := fc.Get(ctx, client.ObjectKey{Namespace: "foo-ns", Name: "foo-tenant-compute"}, quota); err != nil {
+			t.Fatal(err)
+		}
+		quota.Spec.Hard[corev1.ResourceRequestsCPU] = resource.MustParse("0.5")
+		if err := fc.Update(ctx, quota); err != nil {
+			t.Fatal(err)
+		}
+
+		drifts, err := CheckTenants(ctx, config, fc)
+		assert.NoError(t, err)
+		assert.Empty(t, drifts)
+	})
+
+	t.Run("drift", func(t *testing.T) {
+		roleBinding := &rbacv1.RoleBinding{}
+		if err := fc.Get(ctx, client.ObjectKey{Namespace: "foo-ns", Name: "foo-tenant-team"}, roleBinding); err != nil {
+			t.Fatal(err)
+		}
+		roleBinding.Subjects[0].Name = "bar-team"
+		roleBinding.Labels["extra"] = "label"
+		if err := fc.Update(ctx, roleBinding); err != nil {
+			t.Fatal(err)
+		}
+
+		policy := &pacv2beta2.Policy{}
+		if err := fc.Get(ctx, client.ObjectKey{Name: "weave.policies.tenancy.foo-tenant-allowed-application-deploy"}, policy); err != nil {
+			t.Fatal(err)
+		}
+		if err := fc.Delete(ctx, policy); err != nil {
+			t.Fatal(err)
+		}
+
+		extra := newNamespace("old-ns", map[string]string{tenantLabel: "foo-tenant"})
+		if err := fc.Create(ctx, extra); err != nil {
+			t.Fatal(err)
+		}
+
+		drifts, err := CheckTenants(ctx, config, fc)
+		assert.NoError(t, err)
+		assert.Equal(t, []Drift{
+			{
+				Type:   DriftModified,
+				ID:     "foo-ns/rolebinding.rbac.authorization.k8s.io/foo-tenant-team",
+				Tenant: "foo-tenant",
+				Fields: []FieldDiff{
+					{Path: "subjects[0].name", Expected: `"foo-team"`, Actual: `"bar-team"`},
+				},
+			},
+			{
+				Type:   DriftMissing,
+				ID:     "policy.pac.weave.works/weave.policies.tenancy.foo-tenant-allowed-application-deploy",
+				Tenant: "foo-tenant",
+			},
+			{
+				Type:   DriftExtra,
+				ID:     "namespace/old-ns",
+				Tenant: "foo-tenant",
+			},
+		}, drifts)
+
+		out := &bytes.Buffer{}
+		assert.EqualError(t, WriteDriftReport(out, drifts), "drift detected in 3 tenant resources")
+		assert.Equal(t, `foo-ns/rolebinding.rbac.authorization.k8s.io/foo-tenant-team modified (tenant foo-tenant)
+  subjects[0].name: expected "foo-team", got "bar-team"
+policy.pac.weave.works/weave.policies.tenancy.foo-tenant-allowed-application-deploy missing (tenant foo-tenant)
+namespace/old-ns extra (tenant foo-tenant)
+`, out.String())
+	})
+}
+
+func TestCheckTenantsOfClusters(t *testing.T) {
+	config := &Config{
+		Tenants: []Tenant{
+			{
+				Name:       "foo-tenant",
+				Namespaces: []string{"foo-ns"},
+			},
+		},
+	}
+
+	leaf1 := types.NamespacedName{Namespace: "default", Name: "leaf-1"}
+	leaf2 := types.NamespacedName{Namespace: "default", Name: "leaf-2"}
+	leaf3 := types.NamespacedName{Namespace: "default", Name: "leaf-3"}
+
+	clients := map[types.NamespacedName]client.Client{
+		leaf1: newFakeClient(t),
+		leaf3: newFakeClient(t),
+	}
+	if err := ApplyTenants(context.TODO(), config, clients[leaf1], false, &bytes.Buffer{}); err != nil {
+		t.Fatal(err)
+	}
+	clientFor := func(_ context.Context, cluster types.NamespacedName) (client.Client, error) {
+		c, ok := clients[cluster]
+		if !ok {
+			return nil, errors.New("cluster is not ready")
+		}
+		return c, nil
+	}
+
+	results := CheckTenantsOfClusters(context.TODO(), config, []types.NamespacedName{leaf1, leaf2, leaf3}, clientFor)
+
+	assert.Equal(t, []ClusterDrifts{
+		{Cluster: leaf1, Drifts: []Drift{}},
+		{Cluster: leaf2, Err: errors.New("cluster is not ready")},
+		{Cluster: leaf3, Drifts: []Drift{
+			{Type: DriftMissing, ID: "namespace/foo-ns", Tenant: "foo-tenant"},
+			{Type: DriftMissing, ID: "foo-ns/serviceaccount/foo-tenant", Tenant: "foo-tenant"},
+			{Type: DriftMissing, ID: "foo-ns/rolebinding.rbac.authorization.k8s.io/foo-tenant-service-account-cluster-admin", Tenant: "foo-tenant"},
+			{Type: DriftMissing, ID: "policy.pac.weave.works/weave.policies.tenancy.foo-tenant-allowed-application-deploy", Tenant: "foo-tenant"},
+		}},
+	}, results)
+
+	out := &bytes.Buffer{}
+	assert.EqualError(t, WriteClusterDriftReport(out, results), "failed to check tenants of 1 of 3 clusters")
+	assert.Equal(t, `cluster default/leaf-1:
+no drift detected
+cluster default/leaf-2:
+failed: cluster is not ready
+cluster default/leaf-3:
+namespace/foo-ns missing (tenant foo-tenant)
+foo-ns/serviceaccount/foo-tenant missing (tenant foo-tenant)
+foo-ns/rolebinding.rbac.authorization.k8s.io/foo-tenant-service-account-cluster-admin missing (tenant foo-tenant)
+policy.pac.weave.works/weave.policies.tenancy.foo-tenant-allowed-application-deploy missing (tenant foo-tenant)
+`, out.String())
+}
+
+func TestDiffFields(t *testing.T) {
+	expected := map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"labels": map[string]interface{}{tenantLabel: "foo-tenant"},
+		},
+		"rules": []interface{}{"get", "list"},
+		"spec":  map[string]interface{}{"replicas": int64(1)},
+	}
+	actual := map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"labels": map[string]interface{}{tenantLabel: "bar-tenant", "other": "label"},
+		},
+		"rules": []interface{}{"get"},
+		"spec":  map[string]interface{}{"replicas": float64(1), "defaulted": true},
+	}
+
+	fields := []FieldDiff{}
+	diffFields("", expected, actual, &fields)
+
+	assert.Equal(t, []FieldDiff{
+		{Path: `metadata.labels["toolkit.fluxcd.io/tenant"]`, Expected: `"foo-tenant"`, Actual: `"bar-tenant"`},
+		{Path: "rules", Expected: `["get","list"]`, Actual: `["get"]`},
+	}, fields)
+}
+
+func TestDiffResource_IgnoresIdentity(t *testing.T) {
+	generated := newNamespace("foo-ns", map[string]string{tenantLabel: "foo-tenant"})
+	current := newNamespace("foo-ns", map[string]string{tenantLabel: "foo-tenant", "kubernetes.io/metadata.name": "foo-ns"})
+	current.ObjectMeta.ResourceVersion = "42"
+	current.ObjectMeta.CreationTimestamp = metav1.Now()
+	current.Status.Phase = corev1.NamespaceActive
+
+	fields, err := diffResource(generated, current)
+	assert.NoError(t, err)
+	assert.Empty(t, fields)
+}
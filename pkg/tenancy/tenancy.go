@@ -12,6 +12,7 @@ import (
 	"github.com/hashicorp/go-multierror"
 	pacv2beta2 "github.com/weaveworks/policy-agent/api/v2beta2"
 	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
 	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	"k8s.io/apimachinery/pkg/api/equality"
@@ -35,6 +36,9 @@ var (
 	roleBindingTypeMeta    = typeMeta("RoleBinding", "rbac.authorization.k8s.io/v1")
 	policyTypeMeta         = typeMeta(pacv2beta2.PolicyKind, pacv2beta2.GroupVersion.String())
 	roleTypeMeta           = typeMeta("Role", "rbac.authorization.k8s.io/v1")
+	resourceQuotaTypeMeta  = typeMeta("ResourceQuota", "v1")
+	limitRangeTypeMeta     = typeMeta("LimitRange", "v1")
+	networkPolicyTypeMeta  = typeMeta("NetworkPolicy", "networking.k8s.io/v1")
 )
 
 // ServiceAccountOptions is additional configuration for generating
@@ -71,10 +75,32 @@ type TenantDeploymentRBAC struct {
 	BindRoles []TenantRoleBinding `json:"bindRoles"`
 }
 
+// TenantResourceQuota defines a ResourceQuota created in each namespace of a tenant
+type TenantResourceQuota struct {
+	Name   string                      `json:"name"`
+	Hard   corev1.ResourceList         `json:"hard"`
+	Scopes []corev1.ResourceQuotaScope `json:"scopes,omitempty"`
+}
+
+// TenantLimitRange defines a LimitRange created in each namespace of a tenant
+type TenantLimitRange struct {
+	Name   string                  `json:"name"`
+	Limits []corev1.LimitRangeItem `json:"limits"`
+}
+
+// TenantNetworkPolicy isolates the namespaces of a tenant. Ingress traffic is
+// denied, except from the namespaces of the tenant and the allowed peers.
+type TenantNetworkPolicy struct {
+	AllowedPeers []networkingv1.NetworkPolicyPeer `json:"allowedPeers,omitempty"`
+}
+
 // Config represents the structure of the Tenancy file.
 type Config struct {
 	ServiceAccount *ServiceAccountOptions `json:"serviceAccount,omitempty"`
-	Tenants        []Tenant               `json:"tenants"`
+	// Clusters selects the GitopsClusters the tenants are applied to.
+	// They are applied to the current cluster when it is not set.
+	Clusters *ClusterTargets `json:"clusters,omitempty"`
+	Tenants  []Tenant        `json:"tenants"`
 }
 
 // Tenant represents a tenant that we generate resources for in the tenancy
@@ -87,6 +113,9 @@ type Tenant struct {
 	AllowedClusters     []AllowedCluster      `json:"allowedClusters"`
 	TeamRBAC            *TenantTeamRBAC       `json:"teamRBAC,omitempty"`
 	DeploymentRBAC      *TenantDeploymentRBAC `json:"deploymentRBAC,omitempty"`
+	ResourceQuotas      []TenantResourceQuota `json:"resourceQuotas,omitempty"`
+	LimitRanges         []TenantLimitRange    `json:"limitRanges,omitempty"`
+	NetworkPolicy       *TenantNetworkPolicy  `json:"networkPolicy,omitempty"`
 }
 
 // Validate returns an error if any of the fields isn't valid
@@ -125,6 +154,52 @@ func (t Tenant) Validate() error {
 		}
 	}
 
+	quotaNames := map[string]bool{}
+	for _, quota := range t.ResourceQuotas {
+		if err := validation.IsDNS1123Label(quota.Name); len(err) > 0 {
+			result = multierror.Append(result, fmt.Errorf("invalid resource quota name: %s", err))
+		}
+
+		if quotaNames[quota.Name] {
+			result = multierror.Append(result, fmt.Errorf("resource quota %q is repeated", quota.Name))
+		}
+		quotaNames[quota.Name] = true
+
+		if len(quota.Hard) == 0 {
+			result = multierror.Append(result, fmt.Errorf("must provide hard limits in resource quota %q", quota.Name))
+		}
+	}
+
+	limitRangeNames := map[string]bool{}
+	for _, limitRange := range t.LimitRanges {
+		if err := validation.IsDNS1123Label(limitRange.Name); len(err) > 0 {
+			result = multierror.Append(result, fmt.Errorf("invalid limit range name: %s", err))
+		}
+
+		if limitRangeNames[limitRange.Name] {
+			result = multierror.Append(result, fmt.Errorf("limit range %q is repeated", limitRange.Name))
+		}
+		limitRangeNames[limitRange.Name] = true
+
+		if len(limitRange.Limits) == 0 {
+			result = multierror.Append(result, fmt.Errorf("must provide limits in limit range %q", limitRange.Name))
+		}
+
+		for _, limit := range limitRange.Limits {
+			if limit.Type != corev1.LimitTypeContainer && limit.Type != corev1.LimitTypePod && limit.Type != corev1.LimitTypePersistentVolumeClaim {
+				result = multierror.Append(result, fmt.Errorf("invalid limit type %q in limit range %q", limit.Type, limitRange.Name))
+			}
+		}
+	}
+
+	if t.NetworkPolicy != nil {
+		for _, peer := range t.NetworkPolicy.AllowedPeers {
+			if peer.PodSelector == nil && peer.NamespaceSelector == nil && peer.IPBlock == nil {
+				result = multierror.Append(result, errors.New("must provide a pod selector, namespace selector or IP block in network policy peers"))
+			}
+		}
+	}
+
 	return result
 }
 
@@ -161,6 +236,9 @@ func getCurrentResources(ctx context.Context, kubeClient client.Client) ([]clien
 		serviceAccountTypeMeta,
 		roleTypeMeta,
 		policyTypeMeta,
+		resourceQuotaTypeMeta,
+		limitRangeTypeMeta,
+		networkPolicyTypeMeta,
 	}
 
 	opts := []client.ListOption{client.HasLabels{tenantLabel}}
@@ -286,6 +364,73 @@ func upsert(ctx context.Context, kubeClient client.Client, obj client.Object, ou
 				return fmt.Errorf("failed to patch existing policy: %w", err)
 			}
 
+			fmt.Fprintf(out, "%s updated\n", objectID)
+		}
+	case *corev1.ResourceQuota:
+		existingQuota := existing.(*corev1.ResourceQuota)
+
+		var changed bool
+
+		if !equality.Semantic.DeepDerivative(to.GetLabels(), existingQuota.GetLabels()) {
+			existingQuota.SetLabels(to.GetLabels())
+			changed = true
+		}
+
+		// The fields defaulted by the API server are ignored, but not the limits removed from the tenant.
+		if !equality.Semantic.DeepDerivative(to.Spec, existingQuota.Spec) || len(to.Spec.Hard) != len(existingQuota.Spec.Hard) {
+			existingQuota.Spec = to.Spec
+			changed = true
+		}
+
+		if changed {
+			if err := kubeClient.Update(ctx, existing); err != nil {
+				return fmt.Errorf("failed to update existing resource quota: %w", err)
+			}
+
+			fmt.Fprintf(out, "%s updated\n", objectID)
+		}
+	case *corev1.LimitRange:
+		existingLimitRange := existing.(*corev1.LimitRange)
+
+		var changed bool
+
+		if !equality.Semantic.DeepDerivative(to.GetLabels(), existingLimitRange.GetLabels()) {
+			existingLimitRange.SetLabels(to.GetLabels())
+			changed = true
+		}
+
+		if !equality.Semantic.DeepDerivative(to.Spec, existingLimitRange.Spec) || len(to.Spec.Limits) != len(existingLimitRange.Spec.Limits) {
+			existingLimitRange.Spec = to.Spec
+			changed = true
+		}
+
+		if changed {
+			if err := kubeClient.Update(ctx, existing); err != nil {
+				return fmt.Errorf("failed to update existing limit range: %w", err)
+			}
+
+			fmt.Fprintf(out, "%s updated\n", objectID)
+		}
+	case *networkingv1.NetworkPolicy:
+		existingNetworkPolicy := existing.(*networkingv1.NetworkPolicy)
+
+		var changed bool
+
+		if !equality.Semantic.DeepDerivative(to.GetLabels(), existingNetworkPolicy.GetLabels()) {
+			existingNetworkPolicy.SetLabels(to.GetLabels())
+			changed = true
+		}
+
+		if !equality.Semantic.DeepDerivative(to.Spec, existingNetworkPolicy.Spec) || !sameIngressPeers(to.Spec.Ingress, existingNetworkPolicy.Spec.Ingress) {
+			existingNetworkPolicy.Spec = to.Spec
+			changed = true
+		}
+
+		if changed {
+			if err := kubeClient.Update(ctx, existing); err != nil {
+				return fmt.Errorf("failed to update existing network policy: %w", err)
+			}
+
 			fmt.Fprintf(out, "%s updated\n", objectID)
 		}
 	default:
@@ -303,6 +448,22 @@ func upsert(ctx context.Context, kubeClient client.Client, obj client.Object, ou
 	return nil
 }
 
+// sameIngressPeers reports whether the ingress rules allow as many peers, for
+// the peers removed from a tenant to be removed from its network policy too.
+func sameIngressPeers(from, to []networkingv1.NetworkPolicyIngressRule) bool {
+	if len(from) != len(to) {
+		return false
+	}
+
+	for i := range from {
+		if len(from[i].From) != len(to[i].From) {
+			return false
+		}
+	}
+
+	return true
+}
+
 // ExportTenants exports all the tenants to a file.
 func ExportTenants(config *Config, out io.Writer) error {
 	resources, err := GenerateTenantResources(config)
@@ -369,6 +530,18 @@ func generateTenantResource(tenant Tenant, serviceAccount *ServiceAccountOptions
 			generated = append(generated, newTeamRole(tenant.Name, namespace, tenantLabels, tenant.TeamRBAC.Rules))
 			generated = append(generated, newTeamRoleBinding(tenant.Name, namespace, tenant.TeamRBAC.GroupNames, tenantLabels))
 		}
+
+		for _, quota := range tenant.ResourceQuotas {
+			generated = append(generated, newResourceQuota(tenant.Name, namespace, tenantLabels, quota))
+		}
+
+		for _, limitRange := range tenant.LimitRanges {
+			generated = append(generated, newLimitRange(tenant.Name, namespace, tenantLabels, limitRange))
+		}
+
+		if tenant.NetworkPolicy != nil {
+			generated = append(generated, newNetworkPolicy(tenant.Name, namespace, tenantLabels, tenant.NetworkPolicy.AllowedPeers))
+		}
 	}
 
 	policy, err := newAllowedApplicationDeployPolicy(tenant.Name, serviceAccountName, tenant.Namespaces, tenantLabels)
@@ -534,6 +707,68 @@ func newRole(name, namespace string, labels map[string]string, rules []rbacv1.Po
 	}
 }
 
+func newResourceQuota(tenantName, namespace string, labels map[string]string, quota TenantResourceQuota) *corev1.ResourceQuota {
+	return &corev1.ResourceQuota{
+		TypeMeta: resourceQuotaTypeMeta,
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      fmt.Sprintf("%s-%s", tenantName, quota.Name),
+			Namespace: namespace,
+			Labels:    labels,
+		},
+		Spec: corev1.ResourceQuotaSpec{
+			Hard:   quota.Hard,
+			Scopes: quota.Scopes,
+		},
+	}
+}
+
+func newLimitRange(tenantName, namespace string, labels map[string]string, limitRange TenantLimitRange) *corev1.LimitRange {
+	return &corev1.LimitRange{
+		TypeMeta: limitRangeTypeMeta,
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      fmt.Sprintf("%s-%s", tenantName, limitRange.Name),
+			Namespace: namespace,
+			Labels:    labels,
+		},
+		Spec: corev1.LimitRangeSpec{
+			Limits: limitRange.Limits,
+		},
+	}
+}
+
+// newNetworkPolicy creates a policy that selects every pod of the namespace, so that their ingress
+// traffic is denied unless it comes from a namespace of the tenant or one of the allowed peers.
+func newNetworkPolicy(tenantName, namespace string, labels map[string]string, allowedPeers []networkingv1.NetworkPolicyPeer) *networkingv1.NetworkPolicy {
+	peers := []networkingv1.NetworkPolicyPeer{
+		{
+			NamespaceSelector: &metav1.LabelSelector{
+				MatchLabels: map[string]string{
+					tenantLabel: tenantName,
+				},
+			},
+		},
+	}
+	peers = append(peers, allowedPeers...)
+
+	return &networkingv1.NetworkPolicy{
+		TypeMeta: networkPolicyTypeMeta,
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      fmt.Sprintf("%s-default-deny", tenantName),
+			Namespace: namespace,
+			Labels:    labels,
+		},
+		Spec: networkingv1.NetworkPolicySpec{
+			PodSelector: metav1.LabelSelector{},
+			PolicyTypes: []networkingv1.PolicyType{networkingv1.PolicyTypeIngress},
+			Ingress: []networkingv1.NetworkPolicyIngressRule{
+				{
+					From: peers,
+				},
+			},
+		},
+	}
+}
+
 func newAllowedRepositoriesPolicy(tenantName string, namespaces []string, allowedRepositories []AllowedRepository, labels map[string]string) (*pacv2beta2.Policy, error) {
 	policyName := fmt.Sprintf("weave.policies.tenancy.%s-allowed-repositories", tenantName)
 	policy := &pacv2beta2.Policy{
@@ -699,15 +934,27 @@ func Parse(filename string) (*Config, error) {
 		return nil, fmt.Errorf("failed to read tenants file for export: %w", err)
 	}
 
+	return ParseConfig(tenantsYAML)
+}
+
+// ParseConfig parses a tenant declaration from YAML, and returns the
+// extracted Tenants.
+func ParseConfig(tenantsYAML []byte) (*Config, error) {
 	var tenancy struct {
 		ServiceAccount *ServiceAccountOptions `json:"serviceAccount,omitempty"`
+		Clusters       *ClusterTargets        `json:"clusters,omitempty"`
 		Tenants        []Tenant               `json:"tenants"`
 	}
 
-	err = yaml.Unmarshal(tenantsYAML, &tenancy)
-	if err != nil {
+	if err := yaml.Unmarshal(tenantsYAML, &tenancy); err != nil {
 		return nil, err
 	}
 
-	return &Config{Tenants: tenancy.Tenants, ServiceAccount: tenancy.ServiceAccount}, nil
+	if tenancy.Clusters != nil {
+		if err := tenancy.Clusters.Validate(); err != nil {
+			return nil, fmt.Errorf("invalid clusters: %w", err)
+		}
+	}
+
+	return &Config{Tenants: tenancy.Tenants, ServiceAccount: tenancy.ServiceAccount, Clusters: tenancy.Clusters}, nil
 }
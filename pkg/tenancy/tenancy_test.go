@@ -13,8 +13,11 @@ import (
 	"github.com/stretchr/testify/assert"
 	pacv2beta2 "github.com/weaveworks/policy-agent/api/v2beta2"
 	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
 	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/resource"
 	"k8s.io/apimachinery/pkg/runtime"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -336,6 +339,101 @@ func Test_ApplyTenants(t *testing.T) {
 	}
 }
 
+func Test_ApplyTenants_ResourceGovernance(t *testing.T) {
+	labels := map[string]string{
+		"toolkit.fluxcd.io/tenant": "foo-tenant",
+	}
+	quota := TenantResourceQuota{
+		Name: "compute",
+		Hard: corev1.ResourceList{
+			corev1.ResourceRequestsCPU:    resource.MustParse("4"),
+			corev1.ResourceRequestsMemory: resource.MustParse("8Gi"),
+		},
+	}
+	limitRange := TenantLimitRange{
+		Name: "defaults",
+		Limits: []corev1.LimitRangeItem{
+			{
+				Type: corev1.LimitTypeContainer,
+				Default: corev1.ResourceList{
+					corev1.ResourceCPU: resource.MustParse("500m"),
+				},
+			},
+		},
+	}
+	allowedPeers := []networkingv1.NetworkPolicyPeer{
+		{
+			IPBlock: &networkingv1.IPBlock{CIDR: "10.0.0.0/16"},
+		},
+	}
+	tenant := Tenant{
+		Name:           "foo-tenant",
+		Namespaces:     []string{"foo-ns"},
+		ResourceQuotas: []TenantResourceQuota{quota},
+		LimitRanges:    []TenantLimitRange{limitRange},
+		NetworkPolicy:  &TenantNetworkPolicy{AllowedPeers: allowedPeers},
+	}
+
+	fc := newFakeClient(t)
+	out := &bytes.Buffer{}
+
+	t.Run("create", func(t *testing.T) {
+		err := ApplyTenants(context.TODO(), &Config{Tenants: []Tenant{tenant}}, fc, false, out)
+		assert.NoError(t, err)
+
+		verifyResourceQuotas(setResourceVersion(newResourceQuota("foo-tenant", "foo-ns", labels, quota), 1))(t, fc)
+		verifyLimitRanges(setResourceVersion(newLimitRange("foo-tenant", "foo-ns", labels, limitRange), 1))(t, fc)
+		verifyNetworkPolicies(setResourceVersion(newNetworkPolicy("foo-tenant", "foo-ns", labels, allowedPeers), 1))(t, fc)
+	})
+
+	t.Run("update", func(t *testing.T) {
+		// The limit removed from the quota is removed from the cluster too.
+		updated := TenantResourceQuota{
+			Name: "compute",
+			Hard: corev1.ResourceList{
+				corev1.ResourceRequestsCPU: resource.MustParse("4"),
+			},
+		}
+		updatedTenant := tenant
+		updatedTenant.ResourceQuotas = []TenantResourceQuota{updated}
+
+		err := ApplyTenants(context.TODO(), &Config{Tenants: []Tenant{updatedTenant}}, fc, false, out)
+		assert.NoError(t, err)
+
+		verifyResourceQuotas(setResourceVersion(newResourceQuota("foo-tenant", "foo-ns", labels, updated), 2))(t, fc)
+		verifyLimitRanges(setResourceVersion(newLimitRange("foo-tenant", "foo-ns", labels, limitRange), 1))(t, fc)
+		verifyNetworkPolicies(setResourceVersion(newNetworkPolicy("foo-tenant", "foo-ns", labels, allowedPeers), 1))(t, fc)
+	})
+
+	t.Run("fields defaulted by the API server", func(t *testing.T) {
+		limitRange := &corev1.LimitRange{}
+		assert.NoError(t, fc.Get(context.TODO(), client.ObjectKey{Namespace: "foo-ns", Name: "foo-tenant-defaults"}, limitRange))
+		limitRange.Spec.Limits[0].DefaultRequest = limitRange.Spec.Limits[0].Default
+		assert.NoError(t, fc.Update(context.TODO(), limitRange))
+
+		out := &bytes.Buffer{}
+		err := ApplyTenants(context.TODO(), &Config{Tenants: []Tenant{tenant}}, fc, false, out)
+		assert.NoError(t, err)
+
+		assert.NotContains(t, out.String(), "LimitRange")
+		verifyLimitRanges(setResourceVersion(limitRange, 2))(t, fc)
+	})
+
+	t.Run("prune", func(t *testing.T) {
+		prunedTenant := Tenant{
+			Name:       "foo-tenant",
+			Namespaces: []string{"foo-ns"},
+		}
+
+		err := ApplyTenants(context.TODO(), &Config{Tenants: []Tenant{prunedTenant}}, fc, true, out)
+		assert.NoError(t, err)
+
+		verifyResourceQuotas()(t, fc)
+		verifyLimitRanges()(t, fc)
+		verifyNetworkPolicies()(t, fc)
+	})
+}
+
 func Test_ExportTenants(t *testing.T) {
 	testFiles := []struct {
 		filename string
@@ -344,6 +442,7 @@ func Test_ExportTenants(t *testing.T) {
 		{"testdata/with_service_account.yaml"},
 		{"testdata/with_custom_labels.yaml"},
 		{"testdata/with_bind_roles.yaml"},
+		{"testdata/with_resource_governance.yaml"},
 	}
 
 	for _, tt := range testFiles {
@@ -734,6 +833,58 @@ func TestGenerateTenantResources_WithErrors(t *testing.T) {
 			}),
 			errorMessages: []string{"invalid kind for deployment RBAC rule binds"},
 		},
+		{
+			name: "tenant with invalid resource quotas",
+			tenant: makeTestTenant(t, func(tenant *Tenant) {
+				tenant.ResourceQuotas = []TenantResourceQuota{
+					{
+						Name: "Compute",
+						Hard: corev1.ResourceList{corev1.ResourcePods: resource.MustParse("10")},
+					},
+					{
+						Name: "objects",
+					},
+					{
+						Name: "objects",
+						Hard: corev1.ResourceList{corev1.ResourcePods: resource.MustParse("10")},
+					},
+				}
+			}),
+			errorMessages: []string{
+				"invalid resource quota name",
+				`must provide hard limits in resource quota "objects"`,
+				`resource quota "objects" is repeated`,
+			},
+		},
+		{
+			name: "tenant with invalid limit ranges",
+			tenant: makeTestTenant(t, func(tenant *Tenant) {
+				tenant.LimitRanges = []TenantLimitRange{
+					{
+						Name: "empty",
+					},
+					{
+						Name: "defaults",
+						Limits: []corev1.LimitRangeItem{
+							{Type: "Node"},
+						},
+					},
+				}
+			}),
+			errorMessages: []string{
+				`must provide limits in limit range "empty"`,
+				`invalid limit type "Node" in limit range "defaults"`,
+			},
+		},
+		{
+			name: "tenant with an empty network policy peer",
+			tenant: makeTestTenant(t, func(tenant *Tenant) {
+				tenant.NetworkPolicy = &TenantNetworkPolicy{
+					AllowedPeers: []networkingv1.NetworkPolicyPeer{{}},
+				}
+			}),
+			errorMessages: []string{"must provide a pod selector, namespace selector or IP block in network policy peers"},
+		},
 	}
 
 	for _, tt := range generationTests {
@@ -1169,6 +1320,65 @@ func verifyPolicies(expected ...*pacv2beta2.Policy) func(t *testing.T, cl client
 	}
 }
 
+func verifyResourceQuotas(expected ...*corev1.ResourceQuota) func(t *testing.T, cl client.Client) {
+	return func(t *testing.T, cl client.Client) {
+		sort.Slice(expected, func(i, j int) bool { return expected[i].GetName() < expected[j].GetName() })
+
+		quotas := corev1.ResourceQuotaList{}
+
+		if err := cl.List(context.TODO(), &quotas, client.InNamespace("foo-ns")); err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, len(expected), len(quotas.Items))
+		sort.Slice(quotas.Items, func(i, j int) bool { return quotas.Items[i].GetName() < quotas.Items[j].GetName() })
+
+		for i := range expected {
+			assert.Equal(t, expected[i].ObjectMeta, quotas.Items[i].ObjectMeta)
+			assert.True(t, equality.Semantic.DeepEqual(expected[i].Spec, quotas.Items[i].Spec), "resource quota specs don't match")
+		}
+	}
+}
+
+func verifyLimitRanges(expected ...*corev1.LimitRange) func(t *testing.T, cl client.Client) {
+	return func(t *testing.T, cl client.Client) {
+		sort.Slice(expected, func(i, j int) bool { return expected[i].GetName() < expected[j].GetName() })
+
+		limitRanges := corev1.LimitRangeList{}
+
+		if err := cl.List(context.TODO(), &limitRanges, client.InNamespace("foo-ns")); err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, len(expected), len(limitRanges.Items))
+		sort.Slice(limitRanges.Items, func(i, j int) bool { return limitRanges.Items[i].GetName() < limitRanges.Items[j].GetName() })
+
+		for i := range expected {
+			assert.Equal(t, expected[i].ObjectMeta, limitRanges.Items[i].ObjectMeta)
+			assert.True(t, equality.Semantic.DeepEqual(expected[i].Spec, limitRanges.Items[i].Spec), "limit range specs don't match")
+		}
+	}
+}
+
+func verifyNetworkPolicies(expected ...*networkingv1.NetworkPolicy) func(t *testing.T, cl client.Client) {
+	return func(t *testing.T, cl client.Client) {
+		sort.Slice(expected, func(i, j int) bool { return expected[i].GetName() < expected[j].GetName() })
+
+		policies := networkingv1.NetworkPolicyList{}
+
+		if err := cl.List(context.TODO(), &policies, client.InNamespace("foo-ns")); err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, len(expected), len(policies.Items))
+		sort.Slice(policies.Items, func(i, j int) bool { return policies.Items[i].GetName() < policies.Items[j].GetName() })
+
+		for i := range expected {
+			assert.Equal(t, expected[i], &policies.Items[i])
+		}
+	}
+}
+
 type verifyFunc func(t *testing.T, cl client.Client)
 
 func makeTestTenant(t *testing.T, options ...func(*Tenant)) Tenant {
@@ -5,6 +5,7 @@
 */
 
 import * as fm from "../../fetch.pb"
+import * as GoogleApiHttpbody from "../../google/api/httpbody.pb"
 
 export enum EnabledComponent {
   unknown = "unknown",
@@ -22,10 +23,136 @@ export type DoQueryRequest = {
   limit?: number
   orderBy?: string
   descending?: boolean
+  query?: string
+  pageToken?: string
+  savedQueryId?: string
 }
 
 export type DoQueryResponse = {
   objects?: Object[]
+  nextPageToken?: string
+}
+
+export type WatchQueryRequest = {
+  terms?: string
+  filters?: string[]
+  query?: string
+}
+
+export type WatchQueryResponse = {
+  type?: string
+  object?: Object
+}
+
+export type ExportQueryRequest = {
+  terms?: string
+  filters?: string[]
+  query?: string
+  format?: string
+  columns?: string[]
+  savedQueryId?: string
+}
+
+export type AggregateQueryRequest = {
+  terms?: string
+  filters?: string[]
+  query?: string
+  groupBy?: string[]
+}
+
+export type AggregateQueryResponse = {
+  buckets?: AggregationBucket[]
+}
+
+export type AggregationBucket = {
+  group?: {[key: string]: string}
+  count?: string
+}
+
+export type SavedQuery = {
+  id?: string
+  name?: string
+  owner?: string
+  sharedWith?: string
+  terms?: string
+  filters?: string[]
+  query?: string
+  orderBy?: string
+  descending?: boolean
+  columns?: string[]
+  createdAt?: string
+}
+
+export type ListSavedQueriesRequest = {
+}
+
+export type ListSavedQueriesResponse = {
+  savedQueries?: SavedQuery[]
+}
+
+export type CreateSavedQueryRequest = {
+  name?: string
+  sharedWith?: string
+  terms?: string
+  filters?: string[]
+  query?: string
+  orderBy?: string
+  descending?: boolean
+  columns?: string[]
+}
+
+export type CreateSavedQueryResponse = {
+  savedQuery?: SavedQuery
+}
+
+export type DeleteSavedQueryRequest = {
+  id?: string
+}
+
+export type DeleteSavedQueryResponse = {
+}
+
+export type GetObjectHistoryRequest = {
+  id?: string
+}
+
+export type GetObjectHistoryResponse = {
+  revisions?: ObjectRevision[]
+}
+
+export type ObjectRevision = {
+  status?: string
+  message?: string
+  spec?: string
+  recordedAt?: string
+}
+
+export type GetObjectGraphRequest = {
+  id?: string
+  depth?: number
+  direction?: string
+}
+
+export type GetObjectGraphResponse = {
+  nodes?: GraphNode[]
+  edges?: GraphEdge[]
+}
+
+export type GraphNode = {
+  ref?: string
+  cluster?: string
+  namespace?: string
+  apiGroup?: string
+  kind?: string
+  name?: string
+  depth?: number
+  object?: Object
+}
+
+export type GraphEdge = {
+  upstream?: string
+  downstream?: string
+  relation?: string
 }
 
 export type Object = {
@@ -66,6 +193,33 @@ export type Subject = {
   name?: string
 }
 
+export type ExplainAccessRequest = {
+  id?: string
+  user?: string
+  groups?: string[]
+}
+
+export type ExplainAccessResponse = {
+  allowed?: boolean
+  matches?: RuleMatch[]
+  nearMisses?: RuleMatch[]
+}
+
+export type RuleMatch = {
+  bindingKind?: string
+  bindingNamespace?: string
+  bindingName?: string
+  roleKind?: string
+  roleNamespace?: string
+  roleName?: string
+  apiGroups?: string[]
+  resources?: string[]
+  verbs?: string[]
+  resourceNames?: string[]
+  reason?: string
+  message?: string
+}
+
 export type ListFacetsRequest = {
   category?: string
 }
@@ -91,12 +245,39 @@ export class Query {
   static DoQuery(req: DoQueryRequest, initReq?: fm.InitReq): Promise<DoQueryResponse> {
     return fm.fetchReq<DoQueryRequest, DoQueryResponse>(`/v1/query`, {...initReq, method: "POST", body: JSON.stringify(req, fm.replacer)})
   }
+  static WatchQuery(req: WatchQueryRequest, entityNotifier?: fm.NotifyStreamEntityArrival<WatchQueryResponse>, initReq?: fm.InitReq): Promise<void> {
+    return fm.fetchStreamingRequest<WatchQueryRequest, WatchQueryResponse>(`/v1/query/watch`, entityNotifier, {...initReq, method: "POST", body: JSON.stringify(req, fm.replacer)})
+  }
+  static AggregateQuery(req: AggregateQueryRequest, initReq?: fm.InitReq): Promise<AggregateQueryResponse> {
+    return fm.fetchReq<AggregateQueryRequest, AggregateQueryResponse>(`/v1/query/aggregate`, {...initReq, method: "POST", body: JSON.stringify(req, fm.replacer)})
+  }
+  static ExportQuery(req: ExportQueryRequest, entityNotifier?: fm.NotifyStreamEntityArrival<GoogleApiHttpbody.HttpBody>, initReq?: fm.InitReq): Promise<void> {
+    return fm.fetchStreamingRequest<ExportQueryRequest, GoogleApiHttpbody.HttpBody>(`/v1/query/export`, entityNotifier, {...initReq, method: "POST", body: JSON.stringify(req, fm.replacer)})
+  }
+  static ListSavedQueries(req: ListSavedQueriesRequest, initReq?: fm.InitReq): Promise<ListSavedQueriesResponse> {
+    return fm.fetchReq<ListSavedQueriesRequest, ListSavedQueriesResponse>(`/v1/saved-queries?${fm.renderURLSearchParams(req, [])}`, {...initReq, method: "GET"})
+  }
+  static CreateSavedQuery(req: CreateSavedQueryRequest, initReq?: fm.InitReq): Promise<CreateSavedQueryResponse> {
+    return fm.fetchReq<CreateSavedQueryRequest, CreateSavedQueryResponse>(`/v1/saved-queries`, {...initReq, method: "POST", body: JSON.stringify(req, fm.replacer)})
+  }
+  static DeleteSavedQuery(req: DeleteSavedQueryRequest, initReq?: fm.InitReq): Promise<DeleteSavedQueryResponse> {
+    return fm.fetchReq<DeleteSavedQueryRequest, DeleteSavedQueryResponse>(`/v1/saved-queries/${req["id"]}`, {...initReq, method: "DELETE"})
+  }
+  static GetObjectHistory(req: GetObjectHistoryRequest, initReq?: fm.InitReq): Promise<GetObjectHistoryResponse> {
+    return fm.fetchReq<GetObjectHistoryRequest, GetObjectHistoryResponse>(`/v1/object-history?${fm.renderURLSearchParams(req, [])}`, {...initReq, method: "GET"})
+  }
+  static GetObjectGraph(req: GetObjectGraphRequest, initReq?: fm.InitReq): Promise<GetObjectGraphResponse> {
+    return fm.fetchReq<GetObjectGraphRequest, GetObjectGraphResponse>(`/v1/object-graph?${fm.renderURLSearchParams(req, [])}`, {...initReq, method: "GET"})
+  }
   static ListFacets(req: ListFacetsRequest, initReq?: fm.InitReq): Promise<ListFacetsResponse> {
     return fm.fetchReq<ListFacetsRequest, ListFacetsResponse>(`/v1/facets?${fm.renderURLSearchParams(req, [])}`, {...initReq, method: "GET"})
   }
   static DebugGetAccessRules(req: DebugGetAccessRulesRequest, initReq?: fm.InitReq): Promise<DebugGetAccessRulesResponse> {
     return fm.fetchReq<DebugGetAccessRulesRequest, DebugGetAccessRulesResponse>(`/v1/debug/access-rules?${fm.renderURLSearchParams(req, [])}`, {...initReq, method: "GET"})
   }
+  static ExplainAccess(req: ExplainAccessRequest, initReq?: fm.InitReq): Promise<ExplainAccessResponse> {
+    return fm.fetchReq<ExplainAccessRequest, ExplainAccessResponse>(`/v1/explain-access?${fm.renderURLSearchParams(req, [])}`, {...initReq, method: "GET"})
+  }
   static ListEnabledComponents(req: ListEnabledComponentsRequest, initReq?: fm.InitReq): Promise<ListEnabledComponentsResponse> {
     return fm.fetchReq<ListEnabledComponentsRequest, ListEnabledComponentsResponse>(`/v1/enabled-components?${fm.renderURLSearchParams(req, [])}`, {...initReq, method: "GET"})
   }
@@ -159,6 +159,12 @@ export type PreviousValues = {
 
 export type CreatePullRequestResponse = {
   webUrl?: string
+  conflicts?: FileConflict[]
+}
+
+export type FileConflict = {
+  path?: string
+  content?: string
 }
 
 export type CreateTfControllerPullRequestRequest = {
@@ -200,6 +206,34 @@ export type CreateDeletionPullRequestResponse = {
   webUrl?: string
 }
 
+export type CreateUpgradePullRequestRequest = {
+  repositoryUrl?: string
+  headBranch?: string
+  baseBranch?: string
+  title?: string
+  description?: string
+  commitMessage?: string
+  name?: string
+  namespace?: string
+  templateKind?: string
+  path?: string
+  pullRequestPerResource?: boolean
+  dryRun?: boolean
+}
+
+export type CreateUpgradePullRequestResponse = {
+  version?: string
+  upgrades?: TemplateResourceUpgrade[]
+}
+
+export type TemplateResourceUpgrade = {
+  path?: string
+  version?: string
+  files?: CommitFile[]
+  webUrl?: string
+  editedFiles?: string[]
+}
+
 export type ListCredentialsRequest = {
 }
 
@@ -296,6 +330,8 @@ export type Parameter = {
   options?: string[]
   default?: string
   editable?: boolean
+  type?: string
+  schema?: string
 }
 
 export type TemplateProfile = {
@@ -851,6 +887,45 @@ export type SopsSecret = {
   sops?: GoogleProtobufStruct.Value
 }
 
+export type CreateTenantsPullRequestRequest = {
+  repositoryUrl?: string
+  headBranch?: string
+  baseBranch?: string
+  title?: string
+  description?: string
+  commitMessage?: string
+  tenants?: string
+  clusters?: ClusterNamespacedName[]
+  prune?: boolean
+}
+
+export type CreateTenantsPullRequestResponse = {
+  webUrl?: string
+}
+
+export type CheckTenantsRequest = {
+  tenants?: string
+}
+
+export type CheckTenantsResponse = {
+  drifts?: TenantDrift[]
+  errors?: ListError[]
+}
+
+export type TenantDrift = {
+  type?: string
+  id?: string
+  tenant?: string
+  fields?: TenantFieldDiff[]
+  cluster?: string
+}
+
+export type TenantFieldDiff = {
+  path?: string
+  expected?: string
+  actual?: string
+}
+
 export class ClustersService {
   static ListTemplates(req: ListTemplatesRequest, initReq?: fm.InitReq): Promise<ListTemplatesResponse> {
     return fm.fetchReq<ListTemplatesRequest, ListTemplatesResponse>(`/v1/templates?${fm.renderURLSearchParams(req, [])}`, {...initReq, method: "GET"})
@@ -873,6 +948,9 @@ export class ClustersService {
   static CreateDeletionPullRequest(req: CreateDeletionPullRequestRequest, initReq?: fm.InitReq): Promise<CreateDeletionPullRequestResponse> {
     return fm.fetchReq<CreateDeletionPullRequestRequest, CreateDeletionPullRequestResponse>(`/v1/templates/deletion-pull-request`, {...initReq, method: "POST", body: JSON.stringify(req)})
   }
+  static CreateUpgradePullRequest(req: CreateUpgradePullRequestRequest, initReq?: fm.InitReq): Promise<CreateUpgradePullRequestResponse> {
+    return fm.fetchReq<CreateUpgradePullRequestRequest, CreateUpgradePullRequestResponse>(`/v1/namespaces/${req["namespace"]}/templates/${req["name"]}/upgrade-pull-request`, {...initReq, method: "POST", body: JSON.stringify(req)})
+  }
   static RenderAutomation(req: RenderAutomationRequest, initReq?: fm.InitReq): Promise<RenderAutomationResponse> {
     return fm.fetchReq<RenderAutomationRequest, RenderAutomationResponse>(`/v1/automations/render`, {...initReq, method: "POST", body: JSON.stringify(req)})
   }
@@ -951,4 +1029,10 @@ export class ClustersService {
   static GetPolicyConfig(req: GetPolicyConfigRequest, initReq?: fm.InitReq): Promise<GetPolicyConfigResponse> {
     return fm.fetchReq<GetPolicyConfigRequest, GetPolicyConfigResponse>(`/v1/policy-configs/${req["name"]}?${fm.renderURLSearchParams(req, ["name"])}`, {...initReq, method: "GET"})
   }
+  static CreateTenantsPullRequest(req: CreateTenantsPullRequestRequest, initReq?: fm.InitReq): Promise<CreateTenantsPullRequestResponse> {
+    return fm.fetchReq<CreateTenantsPullRequestRequest, CreateTenantsPullRequestResponse>(`/v1/tenants/pull-request`, {...initReq, method: "POST", body: JSON.stringify(req)})
+  }
+  static CheckTenants(req: CheckTenantsRequest, initReq?: fm.InitReq): Promise<CheckTenantsResponse> {
+    return fm.fetchReq<CheckTenantsRequest, CheckTenantsResponse>(`/v1/tenants/check`, {...initReq, method: "POST", body: JSON.stringify(req)})
+  }
 }
\ No newline at end of file